@@ -0,0 +1,1515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: api/proto/simulation.proto
+
+package simulation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Shared simulation messages
+type ComponentSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentSpec) Reset() {
+	*x = ComponentSpec{}
+	mi := &file_api_proto_simulation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentSpec) ProtoMessage() {}
+
+func (x *ComponentSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentSpec.ProtoReflect.Descriptor instead.
+func (*ComponentSpec) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ComponentSpec) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ComponentSpec) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Simulation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Components    []*ComponentSpec       `protobuf:"bytes,5,rep,name=components,proto3" json:"components,omitempty"`
+	ScalingFactor float64                `protobuf:"fixed64,6,opt,name=scaling_factor,json=scalingFactor,proto3" json:"scaling_factor,omitempty"`
+	MaxRuntimeMs  int64                  `protobuf:"varint,7,opt,name=max_runtime_ms,json=maxRuntimeMs,proto3" json:"max_runtime_ms,omitempty"`
+	LearningMode  bool                   `protobuf:"varint,8,opt,name=learning_mode,json=learningMode,proto3" json:"learning_mode,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,11,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	StoppedAt     int64                  `protobuf:"varint,12,opt,name=stopped_at,json=stoppedAt,proto3" json:"stopped_at,omitempty"`
+	LastError     string                 `protobuf:"bytes,13,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Seed          int64                  `protobuf:"varint,14,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Simulation) Reset() {
+	*x = Simulation{}
+	mi := &file_api_proto_simulation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Simulation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Simulation) ProtoMessage() {}
+
+func (x *Simulation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Simulation.ProtoReflect.Descriptor instead.
+func (*Simulation) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Simulation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Simulation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Simulation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Simulation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Simulation) GetComponents() []*ComponentSpec {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Simulation) GetScalingFactor() float64 {
+	if x != nil {
+		return x.ScalingFactor
+	}
+	return 0
+}
+
+func (x *Simulation) GetMaxRuntimeMs() int64 {
+	if x != nil {
+		return x.MaxRuntimeMs
+	}
+	return 0
+}
+
+func (x *Simulation) GetLearningMode() bool {
+	if x != nil {
+		return x.LearningMode
+	}
+	return false
+}
+
+func (x *Simulation) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Simulation) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Simulation) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Simulation) GetStoppedAt() int64 {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return 0
+}
+
+func (x *Simulation) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Simulation) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type ComponentMetrics struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ComponentId         string                 `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	ComponentType       string                 `protobuf:"bytes,2,opt,name=component_type,json=componentType,proto3" json:"component_type,omitempty"`
+	State               string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	TotalOperations     int64                  `protobuf:"varint,4,opt,name=total_operations,json=totalOperations,proto3" json:"total_operations,omitempty"`
+	CompletedOperations int64                  `protobuf:"varint,5,opt,name=completed_operations,json=completedOperations,proto3" json:"completed_operations,omitempty"`
+	FailedOperations    int64                  `protobuf:"varint,6,opt,name=failed_operations,json=failedOperations,proto3" json:"failed_operations,omitempty"`
+	AverageLatencyMs    float64                `protobuf:"fixed64,7,opt,name=average_latency_ms,json=averageLatencyMs,proto3" json:"average_latency_ms,omitempty"`
+	CurrentUtilization  float64                `protobuf:"fixed64,8,opt,name=current_utilization,json=currentUtilization,proto3" json:"current_utilization,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ComponentMetrics) Reset() {
+	*x = ComponentMetrics{}
+	mi := &file_api_proto_simulation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentMetrics) ProtoMessage() {}
+
+func (x *ComponentMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentMetrics.ProtoReflect.Descriptor instead.
+func (*ComponentMetrics) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ComponentMetrics) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *ComponentMetrics) GetComponentType() string {
+	if x != nil {
+		return x.ComponentType
+	}
+	return ""
+}
+
+func (x *ComponentMetrics) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ComponentMetrics) GetTotalOperations() int64 {
+	if x != nil {
+		return x.TotalOperations
+	}
+	return 0
+}
+
+func (x *ComponentMetrics) GetCompletedOperations() int64 {
+	if x != nil {
+		return x.CompletedOperations
+	}
+	return 0
+}
+
+func (x *ComponentMetrics) GetFailedOperations() int64 {
+	if x != nil {
+		return x.FailedOperations
+	}
+	return 0
+}
+
+func (x *ComponentMetrics) GetAverageLatencyMs() float64 {
+	if x != nil {
+		return x.AverageLatencyMs
+	}
+	return 0
+}
+
+func (x *ComponentMetrics) GetCurrentUtilization() float64 {
+	if x != nil {
+		return x.CurrentUtilization
+	}
+	return 0
+}
+
+type SimulationMetrics struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId        string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	CurrentTick         int64                  `protobuf:"varint,2,opt,name=current_tick,json=currentTick,proto3" json:"current_tick,omitempty"`
+	TicksPerSecond      float64                `protobuf:"fixed64,3,opt,name=ticks_per_second,json=ticksPerSecond,proto3" json:"ticks_per_second,omitempty"`
+	AverageTickTimeUs   float64                `protobuf:"fixed64,4,opt,name=average_tick_time_us,json=averageTickTimeUs,proto3" json:"average_tick_time_us,omitempty"`
+	MaxTickTimeUs       float64                `protobuf:"fixed64,5,opt,name=max_tick_time_us,json=maxTickTimeUs,proto3" json:"max_tick_time_us,omitempty"`
+	TotalOperations     int64                  `protobuf:"varint,6,opt,name=total_operations,json=totalOperations,proto3" json:"total_operations,omitempty"`
+	CompletedOperations int64                  `protobuf:"varint,7,opt,name=completed_operations,json=completedOperations,proto3" json:"completed_operations,omitempty"`
+	FailedOperations    int64                  `protobuf:"varint,8,opt,name=failed_operations,json=failedOperations,proto3" json:"failed_operations,omitempty"`
+	Components          []*ComponentMetrics    `protobuf:"bytes,9,rep,name=components,proto3" json:"components,omitempty"`
+	Timestamp           int64                  `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SimulationMetrics) Reset() {
+	*x = SimulationMetrics{}
+	mi := &file_api_proto_simulation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationMetrics) ProtoMessage() {}
+
+func (x *SimulationMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationMetrics.ProtoReflect.Descriptor instead.
+func (*SimulationMetrics) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SimulationMetrics) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *SimulationMetrics) GetCurrentTick() int64 {
+	if x != nil {
+		return x.CurrentTick
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetTicksPerSecond() float64 {
+	if x != nil {
+		return x.TicksPerSecond
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetAverageTickTimeUs() float64 {
+	if x != nil {
+		return x.AverageTickTimeUs
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetMaxTickTimeUs() float64 {
+	if x != nil {
+		return x.MaxTickTimeUs
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetTotalOperations() int64 {
+	if x != nil {
+		return x.TotalOperations
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetCompletedOperations() int64 {
+	if x != nil {
+		return x.CompletedOperations
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetFailedOperations() int64 {
+	if x != nil {
+		return x.FailedOperations
+	}
+	return 0
+}
+
+func (x *SimulationMetrics) GetComponents() []*ComponentMetrics {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *SimulationMetrics) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// CreateSimulation - Simulation creation messages
+type CreateSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Components    []*ComponentSpec       `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"`
+	ScalingFactor float64                `protobuf:"fixed64,4,opt,name=scaling_factor,json=scalingFactor,proto3" json:"scaling_factor,omitempty"`
+	MaxRuntimeMs  int64                  `protobuf:"varint,5,opt,name=max_runtime_ms,json=maxRuntimeMs,proto3" json:"max_runtime_ms,omitempty"`
+	LearningMode  bool                   `protobuf:"varint,6,opt,name=learning_mode,json=learningMode,proto3" json:"learning_mode,omitempty"`
+	UserId        string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Seed          int64                  `protobuf:"varint,9,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSimulationRequest) Reset() {
+	*x = CreateSimulationRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSimulationRequest) ProtoMessage() {}
+
+func (x *CreateSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSimulationRequest.ProtoReflect.Descriptor instead.
+func (*CreateSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateSimulationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSimulationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateSimulationRequest) GetComponents() []*ComponentSpec {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *CreateSimulationRequest) GetScalingFactor() float64 {
+	if x != nil {
+		return x.ScalingFactor
+	}
+	return 0
+}
+
+func (x *CreateSimulationRequest) GetMaxRuntimeMs() int64 {
+	if x != nil {
+		return x.MaxRuntimeMs
+	}
+	return 0
+}
+
+func (x *CreateSimulationRequest) GetLearningMode() bool {
+	if x != nil {
+		return x.LearningMode
+	}
+	return false
+}
+
+func (x *CreateSimulationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSimulationRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CreateSimulationRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type CreateSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSimulationResponse) Reset() {
+	*x = CreateSimulationResponse{}
+	mi := &file_api_proto_simulation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSimulationResponse) ProtoMessage() {}
+
+func (x *CreateSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSimulationResponse.ProtoReflect.Descriptor instead.
+func (*CreateSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+func (x *CreateSimulationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// ListSimulations - Simulation listing messages
+type ListSimulationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSimulationsRequest) Reset() {
+	*x = ListSimulationsRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSimulationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSimulationsRequest) ProtoMessage() {}
+
+func (x *ListSimulationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSimulationsRequest.ProtoReflect.Descriptor instead.
+func (*ListSimulationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSimulationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSimulationsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type ListSimulationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulations   []*Simulation          `protobuf:"bytes,1,rep,name=simulations,proto3" json:"simulations,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSimulationsResponse) Reset() {
+	*x = ListSimulationsResponse{}
+	mi := &file_api_proto_simulation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSimulationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSimulationsResponse) ProtoMessage() {}
+
+func (x *ListSimulationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSimulationsResponse.ProtoReflect.Descriptor instead.
+func (*ListSimulationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSimulationsResponse) GetSimulations() []*Simulation {
+	if x != nil {
+		return x.Simulations
+	}
+	return nil
+}
+
+func (x *ListSimulationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// StartSimulation - Simulation start messages
+type StartSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSimulationRequest) Reset() {
+	*x = StartSimulationRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSimulationRequest) ProtoMessage() {}
+
+func (x *StartSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSimulationRequest.ProtoReflect.Descriptor instead.
+func (*StartSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StartSimulationRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *StartSimulationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StartSimulationRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type StartSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSimulationResponse) Reset() {
+	*x = StartSimulationResponse{}
+	mi := &file_api_proto_simulation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSimulationResponse) ProtoMessage() {}
+
+func (x *StartSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StartSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StartSimulationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartSimulationResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StartSimulationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// StopSimulation - Simulation stop messages
+type StopSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopSimulationRequest) Reset() {
+	*x = StopSimulationRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopSimulationRequest) ProtoMessage() {}
+
+func (x *StopSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopSimulationRequest.ProtoReflect.Descriptor instead.
+func (*StopSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StopSimulationRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *StopSimulationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StopSimulationRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type StopSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopSimulationResponse) Reset() {
+	*x = StopSimulationResponse{}
+	mi := &file_api_proto_simulation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopSimulationResponse) ProtoMessage() {}
+
+func (x *StopSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StopSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StopSimulationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StopSimulationResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StopSimulationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// GetStatus - Simulation status messages
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetStatusRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *GetStatusRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *GetStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetStatusResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId      string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CurrentTick       int64                  `protobuf:"varint,3,opt,name=current_tick,json=currentTick,proto3" json:"current_tick,omitempty"`
+	SimulationTimeMs  float64                `protobuf:"fixed64,4,opt,name=simulation_time_ms,json=simulationTimeMs,proto3" json:"simulation_time_ms,omitempty"`
+	RealTimeElapsedMs float64                `protobuf:"fixed64,5,opt,name=real_time_elapsed_ms,json=realTimeElapsedMs,proto3" json:"real_time_elapsed_ms,omitempty"`
+	ComponentCount    int32                  `protobuf:"varint,6,opt,name=component_count,json=componentCount,proto3" json:"component_count,omitempty"`
+	HealthyComponents int32                  `protobuf:"varint,7,opt,name=healthy_components,json=healthyComponents,proto3" json:"healthy_components,omitempty"`
+	ControllerStatus  string                 `protobuf:"bytes,8,opt,name=controller_status,json=controllerStatus,proto3" json:"controller_status,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_api_proto_simulation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetStatusResponse) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetCurrentTick() int64 {
+	if x != nil {
+		return x.CurrentTick
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetSimulationTimeMs() float64 {
+	if x != nil {
+		return x.SimulationTimeMs
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetRealTimeElapsedMs() float64 {
+	if x != nil {
+		return x.RealTimeElapsedMs
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetComponentCount() int32 {
+	if x != nil {
+		return x.ComponentCount
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetHealthyComponents() int32 {
+	if x != nil {
+		return x.HealthyComponents
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetControllerStatus() string {
+	if x != nil {
+		return x.ControllerStatus
+	}
+	return ""
+}
+
+// GetResults - Simulation results messages
+type GetResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResultsRequest) Reset() {
+	*x = GetResultsRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultsRequest) ProtoMessage() {}
+
+func (x *GetResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetResultsRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *GetResultsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *GetResultsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	Metrics       *SimulationMetrics     `protobuf:"bytes,2,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResultsResponse) Reset() {
+	*x = GetResultsResponse{}
+	mi := &file_api_proto_simulation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultsResponse) ProtoMessage() {}
+
+func (x *GetResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultsResponse.ProtoReflect.Descriptor instead.
+func (*GetResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetResultsResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+func (x *GetResultsResponse) GetMetrics() *SimulationMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+// StreamMetrics - Live metrics streaming messages
+type StreamMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	IntervalMs    int64                  `protobuf:"varint,2,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	RequestId     string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMetricsRequest) Reset() {
+	*x = StreamMetricsRequest{}
+	mi := &file_api_proto_simulation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMetricsRequest) ProtoMessage() {}
+
+func (x *StreamMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_simulation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMetricsRequest.ProtoReflect.Descriptor instead.
+func (*StreamMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_simulation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StreamMetricsRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *StreamMetricsRequest) GetIntervalMs() int64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *StreamMetricsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *StreamMetricsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+var File_api_proto_simulation_proto protoreflect.FileDescriptor
+
+const file_api_proto_simulation_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/proto/simulation.proto\x12\n" +
+	"simulation\"3\n" +
+	"\rComponentSpec\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\xc6\x03\n" +
+	"\n" +
+	"Simulation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"components\x18\x05 \x03(\v2\x19.simulation.ComponentSpecR\n" +
+	"components\x12%\n" +
+	"\x0escaling_factor\x18\x06 \x01(\x01R\rscalingFactor\x12$\n" +
+	"\x0emax_runtime_ms\x18\a \x01(\x03R\fmaxRuntimeMs\x12#\n" +
+	"\rlearning_mode\x18\b \x01(\bR\flearningMode\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\v \x01(\x03R\tstartedAt\x12\x1d\n" +
+	"\n" +
+	"stopped_at\x18\f \x01(\x03R\tstoppedAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\r \x01(\tR\tlastError\x12\x12\n" +
+	"\x04seed\x18\x0e \x01(\x03R\x04seed\"\xdc\x02\n" +
+	"\x10ComponentMetrics\x12!\n" +
+	"\fcomponent_id\x18\x01 \x01(\tR\vcomponentId\x12%\n" +
+	"\x0ecomponent_type\x18\x02 \x01(\tR\rcomponentType\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12)\n" +
+	"\x10total_operations\x18\x04 \x01(\x03R\x0ftotalOperations\x121\n" +
+	"\x14completed_operations\x18\x05 \x01(\x03R\x13completedOperations\x12+\n" +
+	"\x11failed_operations\x18\x06 \x01(\x03R\x10failedOperations\x12,\n" +
+	"\x12average_latency_ms\x18\a \x01(\x01R\x10averageLatencyMs\x12/\n" +
+	"\x13current_utilization\x18\b \x01(\x01R\x12currentUtilization\"\xc6\x03\n" +
+	"\x11SimulationMetrics\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12!\n" +
+	"\fcurrent_tick\x18\x02 \x01(\x03R\vcurrentTick\x12(\n" +
+	"\x10ticks_per_second\x18\x03 \x01(\x01R\x0eticksPerSecond\x12/\n" +
+	"\x14average_tick_time_us\x18\x04 \x01(\x01R\x11averageTickTimeUs\x12'\n" +
+	"\x10max_tick_time_us\x18\x05 \x01(\x01R\rmaxTickTimeUs\x12)\n" +
+	"\x10total_operations\x18\x06 \x01(\x03R\x0ftotalOperations\x121\n" +
+	"\x14completed_operations\x18\a \x01(\x03R\x13completedOperations\x12+\n" +
+	"\x11failed_operations\x18\b \x01(\x03R\x10failedOperations\x12<\n" +
+	"\n" +
+	"components\x18\t \x03(\v2\x1c.simulation.ComponentMetricsR\n" +
+	"components\x12\x1c\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\x03R\ttimestamp\"\xc8\x02\n" +
+	"\x17CreateSimulationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"components\x18\x03 \x03(\v2\x19.simulation.ComponentSpecR\n" +
+	"components\x12%\n" +
+	"\x0escaling_factor\x18\x04 \x01(\x01R\rscalingFactor\x12$\n" +
+	"\x0emax_runtime_ms\x18\x05 \x01(\x03R\fmaxRuntimeMs\x12#\n" +
+	"\rlearning_mode\x18\x06 \x01(\bR\flearningMode\x12\x17\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tR\trequestId\x12\x12\n" +
+	"\x04seed\x18\t \x01(\x03R\x04seed\"w\n" +
+	"\x18CreateSimulationResponse\x126\n" +
+	"\n" +
+	"simulation\x18\x01 \x01(\v2\x16.simulation.SimulationR\n" +
+	"simulation\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"P\n" +
+	"\x16ListSimulationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"i\n" +
+	"\x17ListSimulationsResponse\x128\n" +
+	"\vsimulations\x18\x01 \x03(\v2\x16.simulation.SimulationR\vsimulations\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"u\n" +
+	"\x16StartSimulationRequest\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\"p\n" +
+	"\x17StartSimulationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"t\n" +
+	"\x15StopSimulationRequest\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\"o\n" +
+	"\x16StopSimulationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"o\n" +
+	"\x10GetStatusRequest\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xd7\x02\n" +
+	"\x11GetStatusResponse\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\fcurrent_tick\x18\x03 \x01(\x03R\vcurrentTick\x12,\n" +
+	"\x12simulation_time_ms\x18\x04 \x01(\x01R\x10simulationTimeMs\x12/\n" +
+	"\x14real_time_elapsed_ms\x18\x05 \x01(\x01R\x11realTimeElapsedMs\x12'\n" +
+	"\x0fcomponent_count\x18\x06 \x01(\x05R\x0ecomponentCount\x12-\n" +
+	"\x12healthy_components\x18\a \x01(\x05R\x11healthyComponents\x12+\n" +
+	"\x11controller_status\x18\b \x01(\tR\x10controllerStatus\"p\n" +
+	"\x11GetResultsRequest\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\x85\x01\n" +
+	"\x12GetResultsResponse\x126\n" +
+	"\n" +
+	"simulation\x18\x01 \x01(\v2\x16.simulation.SimulationR\n" +
+	"simulation\x127\n" +
+	"\ametrics\x18\x02 \x01(\v2\x1d.simulation.SimulationMetricsR\ametrics\"\x94\x01\n" +
+	"\x14StreamMetricsRequest\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x1f\n" +
+	"\vinterval_ms\x18\x02 \x01(\x03R\n" +
+	"intervalMs\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId2\xee\x04\n" +
+	"\x11SimulationService\x12]\n" +
+	"\x10CreateSimulation\x12#.simulation.CreateSimulationRequest\x1a$.simulation.CreateSimulationResponse\x12Z\n" +
+	"\x0fListSimulations\x12\".simulation.ListSimulationsRequest\x1a#.simulation.ListSimulationsResponse\x12Z\n" +
+	"\x0fStartSimulation\x12\".simulation.StartSimulationRequest\x1a#.simulation.StartSimulationResponse\x12W\n" +
+	"\x0eStopSimulation\x12!.simulation.StopSimulationRequest\x1a\".simulation.StopSimulationResponse\x12H\n" +
+	"\tGetStatus\x12\x1c.simulation.GetStatusRequest\x1a\x1d.simulation.GetStatusResponse\x12K\n" +
+	"\n" +
+	"GetResults\x12\x1d.simulation.GetResultsRequest\x1a\x1e.simulation.GetResultsResponse\x12R\n" +
+	"\rStreamMetrics\x12 .simulation.StreamMetricsRequest\x1a\x1d.simulation.SimulationMetrics0\x01B>Z<github.com/systemsim/simulation-service/api/proto/simulationb\x06proto3"
+
+var (
+	file_api_proto_simulation_proto_rawDescOnce sync.Once
+	file_api_proto_simulation_proto_rawDescData []byte
+)
+
+func file_api_proto_simulation_proto_rawDescGZIP() []byte {
+	file_api_proto_simulation_proto_rawDescOnce.Do(func() {
+		file_api_proto_simulation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_simulation_proto_rawDesc), len(file_api_proto_simulation_proto_rawDesc)))
+	})
+	return file_api_proto_simulation_proto_rawDescData
+}
+
+var file_api_proto_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_api_proto_simulation_proto_goTypes = []any{
+	(*ComponentSpec)(nil),            // 0: simulation.ComponentSpec
+	(*Simulation)(nil),               // 1: simulation.Simulation
+	(*ComponentMetrics)(nil),         // 2: simulation.ComponentMetrics
+	(*SimulationMetrics)(nil),        // 3: simulation.SimulationMetrics
+	(*CreateSimulationRequest)(nil),  // 4: simulation.CreateSimulationRequest
+	(*CreateSimulationResponse)(nil), // 5: simulation.CreateSimulationResponse
+	(*ListSimulationsRequest)(nil),   // 6: simulation.ListSimulationsRequest
+	(*ListSimulationsResponse)(nil),  // 7: simulation.ListSimulationsResponse
+	(*StartSimulationRequest)(nil),   // 8: simulation.StartSimulationRequest
+	(*StartSimulationResponse)(nil),  // 9: simulation.StartSimulationResponse
+	(*StopSimulationRequest)(nil),    // 10: simulation.StopSimulationRequest
+	(*StopSimulationResponse)(nil),   // 11: simulation.StopSimulationResponse
+	(*GetStatusRequest)(nil),         // 12: simulation.GetStatusRequest
+	(*GetStatusResponse)(nil),        // 13: simulation.GetStatusResponse
+	(*GetResultsRequest)(nil),        // 14: simulation.GetResultsRequest
+	(*GetResultsResponse)(nil),       // 15: simulation.GetResultsResponse
+	(*StreamMetricsRequest)(nil),     // 16: simulation.StreamMetricsRequest
+}
+var file_api_proto_simulation_proto_depIdxs = []int32{
+	0,  // 0: simulation.Simulation.components:type_name -> simulation.ComponentSpec
+	2,  // 1: simulation.SimulationMetrics.components:type_name -> simulation.ComponentMetrics
+	0,  // 2: simulation.CreateSimulationRequest.components:type_name -> simulation.ComponentSpec
+	1,  // 3: simulation.CreateSimulationResponse.simulation:type_name -> simulation.Simulation
+	1,  // 4: simulation.ListSimulationsResponse.simulations:type_name -> simulation.Simulation
+	1,  // 5: simulation.GetResultsResponse.simulation:type_name -> simulation.Simulation
+	3,  // 6: simulation.GetResultsResponse.metrics:type_name -> simulation.SimulationMetrics
+	4,  // 7: simulation.SimulationService.CreateSimulation:input_type -> simulation.CreateSimulationRequest
+	6,  // 8: simulation.SimulationService.ListSimulations:input_type -> simulation.ListSimulationsRequest
+	8,  // 9: simulation.SimulationService.StartSimulation:input_type -> simulation.StartSimulationRequest
+	10, // 10: simulation.SimulationService.StopSimulation:input_type -> simulation.StopSimulationRequest
+	12, // 11: simulation.SimulationService.GetStatus:input_type -> simulation.GetStatusRequest
+	14, // 12: simulation.SimulationService.GetResults:input_type -> simulation.GetResultsRequest
+	16, // 13: simulation.SimulationService.StreamMetrics:input_type -> simulation.StreamMetricsRequest
+	5,  // 14: simulation.SimulationService.CreateSimulation:output_type -> simulation.CreateSimulationResponse
+	7,  // 15: simulation.SimulationService.ListSimulations:output_type -> simulation.ListSimulationsResponse
+	9,  // 16: simulation.SimulationService.StartSimulation:output_type -> simulation.StartSimulationResponse
+	11, // 17: simulation.SimulationService.StopSimulation:output_type -> simulation.StopSimulationResponse
+	13, // 18: simulation.SimulationService.GetStatus:output_type -> simulation.GetStatusResponse
+	15, // 19: simulation.SimulationService.GetResults:output_type -> simulation.GetResultsResponse
+	3,  // 20: simulation.SimulationService.StreamMetrics:output_type -> simulation.SimulationMetrics
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_simulation_proto_init() }
+func file_api_proto_simulation_proto_init() {
+	if File_api_proto_simulation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_simulation_proto_rawDesc), len(file_api_proto_simulation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_simulation_proto_goTypes,
+		DependencyIndexes: file_api_proto_simulation_proto_depIdxs,
+		MessageInfos:      file_api_proto_simulation_proto_msgTypes,
+	}.Build()
+	File_api_proto_simulation_proto = out.File
+	file_api_proto_simulation_proto_goTypes = nil
+	file_api_proto_simulation_proto_depIdxs = nil
+}
@@ -35,7 +35,8 @@ type Gateway struct {
 	redisClient *redis_client.Client
 
 	// Service clients
-	authService *services.AuthService
+	authService       *services.AuthService
+	simulationService *services.SimulationService
 
 	// Middleware
 	authMiddleware *middleware.AuthMiddleware
@@ -73,9 +74,11 @@ func New(cfg *Config) *Gateway {
 	// Initialize service clients
 	if cfg.GRPCClients != nil {
 		gateway.authService = services.NewAuthService(cfg.GRPCClients.GetAuthPool())
+		gateway.simulationService = services.NewSimulationService(cfg.GRPCClients.GetSimulationPool())
 	} else {
 		// Use mock auth service when gRPC clients are not available
 		gateway.authService = services.NewAuthService(nil)
+		gateway.simulationService = services.NewSimulationService(nil)
 	}
 
 	// Always initialize auth middleware
@@ -595,6 +598,12 @@ func (gw *Gateway) handleSSENotifications(w http.ResponseWriter, flusher http.Fl
 
 // handleSSESimulation handles simulation data events via SSE
 func (gw *Gateway) handleSSESimulation(w http.ResponseWriter, flusher http.Flusher, ctx context.Context, userID, simulationID string) {
+	// Relay live metrics from the simulation service when it is reachable
+	if gw.simulationService != nil && gw.simulationService.IsAvailable() {
+		gw.streamSimulationMetrics(w, flusher, ctx, userID, simulationID)
+		return
+	}
+
 	ticker := time.NewTicker(1 * time.Second) // High frequency for simulation data
 	defer ticker.Stop()
 
@@ -1133,72 +1142,6 @@ func (gw *Gateway) handleProjectRequestMock(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// handleSimulationRequest handles simulation service requests
-func (gw *Gateway) handleSimulationRequest(w http.ResponseWriter, r *http.Request, method, path string) {
-	// Provide mock responses when gRPC clients are not available
-	if gw.grpcClients == nil {
-		gw.handleSimulationRequestMock(w, r, method, path)
-		return
-	}
-
-	// TODO: Implement actual gRPC calls when services are available
-	response := map[string]interface{}{
-		"service": "simulation",
-		"method":  method,
-		"path":    path,
-		"status":  "not_implemented",
-		"message": "Simulation service integration pending",
-	}
-
-	gw.sendJSONResponse(w, http.StatusOK, response)
-}
-
-// handleSimulationRequestMock provides mock responses for simulation service
-func (gw *Gateway) handleSimulationRequestMock(w http.ResponseWriter, r *http.Request, method, path string) {
-	switch {
-	case path == "/api/simulations" && method == "GET":
-		// Mock simulation list
-		response := map[string]interface{}{
-			"simulations": []map[string]interface{}{
-				{
-					"id":         "sim-123",
-					"project_id": "project-123",
-					"name":       "Mock Load Test Simulation",
-					"status":     "completed",
-					"created_at": "2023-12-01T14:00:00Z",
-					"started_at": "2023-12-01T14:05:00Z",
-					"progress":   100,
-				},
-			},
-			"total": 1,
-		}
-		gw.sendJSONResponse(w, http.StatusOK, response)
-
-	case path == "/api/simulations" && method == "POST":
-		// Mock simulation creation
-		response := map[string]interface{}{
-			"id":         "sim-456",
-			"project_id": "project-123",
-			"name":       "New Mock Simulation",
-			"status":     "created",
-			"created_at": time.Now().Format(time.RFC3339),
-		}
-		gw.sendJSONResponse(w, http.StatusCreated, response)
-
-	default:
-		// Default mock response
-		response := map[string]interface{}{
-			"service": "simulation",
-			"method":  method,
-			"path":    path,
-			"status":  "mock_response",
-			"message": "Mock simulation service response",
-			"data":    map[string]interface{}{"mock": true},
-		}
-		gw.sendJSONResponse(w, http.StatusOK, response)
-	}
-}
-
 // handleHealthCheck handles comprehensive health check requests with service aggregation
 func (gw *Gateway) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
@@ -0,0 +1,198 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "server-service/api/proto/simulation"
+	"server-service/internal/middleware"
+	"server-service/internal/services"
+)
+
+// simulationMetricsInterval is how often live metrics are pushed to SSE clients
+const simulationMetricsInterval = 1 * time.Second
+
+// protoMarshaler renders gRPC responses with the proto field names (snake_case)
+var protoMarshaler = protojson.MarshalOptions{
+	UseProtoNames:   true,
+	EmitUnpopulated: true,
+}
+
+// handleSimulationRequest handles simulation service requests via gRPC
+func (gw *Gateway) handleSimulationRequest(w http.ResponseWriter, r *http.Request, method, path string) {
+	// Check if simulation service is available
+	if gw.simulationService == nil || !gw.simulationService.IsAvailable() {
+		gw.handleServiceUnavailable(w, r, "simulation")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	requestID := middleware.GetRequestID(r)
+	if requestID == "" {
+		requestID = services.GenerateRequestID()
+	}
+
+	// /api/simulations[/{id}[/{action}]]
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/simulations"), "/"), "/")
+	simulationID, action := "", ""
+	if len(parts) > 0 {
+		simulationID = parts[0]
+	}
+	if len(parts) > 1 {
+		action = parts[1]
+	}
+
+	switch {
+	case simulationID == "" && method == "GET":
+		resp, err := gw.simulationService.ListSimulations(userID, requestID)
+		gw.sendSimulationResponse(w, http.StatusOK, resp, err)
+
+	case simulationID == "" && method == "POST":
+		gw.handleCreateSimulation(w, r, userID, requestID)
+
+	case action == "" && method == "GET", action == "results" && method == "GET":
+		resp, err := gw.simulationService.GetResults(simulationID, userID, requestID)
+		gw.sendSimulationResponse(w, http.StatusOK, resp, err)
+
+	case action == "status" && method == "GET":
+		resp, err := gw.simulationService.GetStatus(simulationID, userID, requestID)
+		gw.sendSimulationResponse(w, http.StatusOK, resp, err)
+
+	case action == "start" && method == "POST":
+		resp, err := gw.simulationService.StartSimulation(simulationID, userID, requestID)
+		if err == nil && !resp.Success {
+			gw.sendJSONResponse(w, http.StatusConflict, map[string]interface{}{
+				"error":   "start_failed",
+				"status":  resp.Status,
+				"message": resp.ErrorMessage,
+			})
+			return
+		}
+		gw.sendSimulationResponse(w, http.StatusOK, resp, err)
+
+	case action == "stop" && method == "POST":
+		resp, err := gw.simulationService.StopSimulation(simulationID, userID, requestID)
+		if err == nil && !resp.Success {
+			gw.sendJSONResponse(w, http.StatusConflict, map[string]interface{}{
+				"error":   "stop_failed",
+				"status":  resp.Status,
+				"message": resp.ErrorMessage,
+			})
+			return
+		}
+		gw.sendSimulationResponse(w, http.StatusOK, resp, err)
+
+	default:
+		gw.sendJSONResponse(w, http.StatusMethodNotAllowed, map[string]interface{}{
+			"error":   "method_not_allowed",
+			"method":  method,
+			"path":    path,
+			"message": "Unsupported simulation operation",
+		})
+	}
+}
+
+// handleCreateSimulation decodes a create request body and forwards it to the simulation service
+func (gw *Gateway) handleCreateSimulation(w http.ResponseWriter, r *http.Request, userID, requestID string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		gw.sendJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid_request",
+			"message": "Failed to read request body",
+		})
+		return
+	}
+
+	req := &simulationpb.CreateSimulationRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, req); err != nil {
+		gw.sendJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid_request",
+			"message": fmt.Sprintf("Invalid simulation request: %v", err),
+		})
+		return
+	}
+
+	// Identity always comes from the authenticated request, never the body
+	req.UserId = userID
+	req.RequestId = requestID
+
+	resp, err := gw.simulationService.CreateSimulation(req)
+	if err != nil {
+		gw.sendSimulationResponse(w, http.StatusCreated, nil, err)
+		return
+	}
+	gw.sendSimulationResponse(w, http.StatusCreated, resp.Simulation, nil)
+}
+
+// sendSimulationResponse writes a gRPC response as JSON, mapping gRPC errors to HTTP status codes
+func (gw *Gateway) sendSimulationResponse(w http.ResponseWriter, statusCode int, msg proto.Message, err error) {
+	if err != nil {
+		httpStatus := http.StatusBadGateway
+		switch status.Code(err) {
+		case codes.NotFound:
+			httpStatus = http.StatusNotFound
+		case codes.InvalidArgument:
+			httpStatus = http.StatusBadRequest
+		case codes.FailedPrecondition:
+			httpStatus = http.StatusConflict
+		case codes.Unavailable, codes.DeadlineExceeded:
+			httpStatus = http.StatusServiceUnavailable
+		}
+
+		message := err.Error()
+		if st, ok := status.FromError(err); ok {
+			message = st.Message()
+		}
+
+		gw.sendJSONResponse(w, httpStatus, map[string]interface{}{
+			"error":   "simulation_error",
+			"service": "simulation",
+			"message": message,
+		})
+		return
+	}
+
+	data, err := protoMarshaler.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal simulation response: %v", err)
+		http.Error(w, `{"error":"internal_error","message":"Failed to encode response"}`, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(data)
+}
+
+// streamSimulationMetrics relays the simulation service metrics stream as SSE events
+func (gw *Gateway) streamSimulationMetrics(w http.ResponseWriter, flusher http.Flusher, ctx context.Context, userID, simulationID string) {
+	err := gw.simulationService.StreamMetrics(ctx, simulationID, userID, simulationMetricsInterval, func(snapshot *simulationpb.SimulationMetrics) error {
+		data, err := protoMarshaler.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metrics: %w", err)
+		}
+
+		fmt.Fprintf(w, "data: {\"type\":\"simulation_data\",\"simulation_id\":%q,\"metrics\":%s}\n\n", simulationID, data)
+		flusher.Flush()
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("SSE simulation stream ended for user: %s, simulation: %s: %v", userID, simulationID, err)
+		fmt.Fprintf(w, "data: {\"type\":\"error\",\"message\":%q}\n\n", err.Error())
+		flusher.Flush()
+		return
+	}
+
+	log.Printf("SSE simulation connection closed for user: %s, simulation: %s", userID, simulationID)
+}
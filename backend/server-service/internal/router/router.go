@@ -269,11 +269,11 @@ func NewServiceRouter() *ServiceRouter {
 		},
 		simulationRoutes: []string{
 			"/api/simulations",
-			"/api/simulations/[0-9]+",
-			"/api/simulations/[0-9]+/start",
-			"/api/simulations/[0-9]+/stop",
-			"/api/simulations/[0-9]+/status",
-			"/api/simulations/[0-9]+/results",
+			"/api/simulations/[0-9a-fA-F-]+",
+			"/api/simulations/[0-9a-fA-F-]+/start",
+			"/api/simulations/[0-9a-fA-F-]+/stop",
+			"/api/simulations/[0-9a-fA-F-]+/status",
+			"/api/simulations/[0-9a-fA-F-]+/results",
 		},
 	}
 }
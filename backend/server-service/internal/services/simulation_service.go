@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	simulationpb "server-service/api/proto/simulation"
+	"server-service/internal/grpc_clients"
+)
+
+// simulationCallTimeout bounds unary calls to the simulation service
+const simulationCallTimeout = 10 * time.Second
+
+// SimulationService handles simulation-related operations via gRPC
+type SimulationService struct {
+	grpcPool *grpc_clients.ServicePool
+}
+
+// NewSimulationService creates a new simulation service client
+func NewSimulationService(grpcPool *grpc_clients.ServicePool) *SimulationService {
+	return &SimulationService{
+		grpcPool: grpcPool,
+	}
+}
+
+// IsAvailable reports whether a gRPC pool is configured for the simulation service
+func (ss *SimulationService) IsAvailable() bool {
+	return ss.grpcPool != nil
+}
+
+// CreateSimulation creates a new simulation
+func (ss *SimulationService) CreateSimulation(req *simulationpb.CreateSimulationRequest) (*simulationpb.CreateSimulationResponse, error) {
+	var resp *simulationpb.CreateSimulationResponse
+	err := ss.call("CreateSimulation", func(ctx context.Context, client simulationpb.SimulationServiceClient) error {
+		var err error
+		resp, err = client.CreateSimulation(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// ListSimulations lists all simulations
+func (ss *SimulationService) ListSimulations(userID, requestID string) (*simulationpb.ListSimulationsResponse, error) {
+	var resp *simulationpb.ListSimulationsResponse
+	err := ss.call("ListSimulations", func(ctx context.Context, client simulationpb.SimulationServiceClient) error {
+		var err error
+		resp, err = client.ListSimulations(ctx, &simulationpb.ListSimulationsRequest{
+			UserId:    userID,
+			RequestId: requestID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// StartSimulation starts a simulation
+func (ss *SimulationService) StartSimulation(simulationID, userID, requestID string) (*simulationpb.StartSimulationResponse, error) {
+	var resp *simulationpb.StartSimulationResponse
+	err := ss.call("StartSimulation", func(ctx context.Context, client simulationpb.SimulationServiceClient) error {
+		var err error
+		resp, err = client.StartSimulation(ctx, &simulationpb.StartSimulationRequest{
+			SimulationId: simulationID,
+			UserId:       userID,
+			RequestId:    requestID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// StopSimulation stops a simulation
+func (ss *SimulationService) StopSimulation(simulationID, userID, requestID string) (*simulationpb.StopSimulationResponse, error) {
+	var resp *simulationpb.StopSimulationResponse
+	err := ss.call("StopSimulation", func(ctx context.Context, client simulationpb.SimulationServiceClient) error {
+		var err error
+		resp, err = client.StopSimulation(ctx, &simulationpb.StopSimulationRequest{
+			SimulationId: simulationID,
+			UserId:       userID,
+			RequestId:    requestID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// GetStatus gets the live status of a simulation
+func (ss *SimulationService) GetStatus(simulationID, userID, requestID string) (*simulationpb.GetStatusResponse, error) {
+	var resp *simulationpb.GetStatusResponse
+	err := ss.call("GetStatus", func(ctx context.Context, client simulationpb.SimulationServiceClient) error {
+		var err error
+		resp, err = client.GetStatus(ctx, &simulationpb.GetStatusRequest{
+			SimulationId: simulationID,
+			UserId:       userID,
+			RequestId:    requestID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// GetResults gets the simulation record and its aggregated metrics
+func (ss *SimulationService) GetResults(simulationID, userID, requestID string) (*simulationpb.GetResultsResponse, error) {
+	var resp *simulationpb.GetResultsResponse
+	err := ss.call("GetResults", func(ctx context.Context, client simulationpb.SimulationServiceClient) error {
+		var err error
+		resp, err = client.GetResults(ctx, &simulationpb.GetResultsRequest{
+			SimulationId: simulationID,
+			UserId:       userID,
+			RequestId:    requestID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// StreamMetrics streams metrics snapshots to handler until ctx is cancelled,
+// the stream ends or handler returns an error
+func (ss *SimulationService) StreamMetrics(ctx context.Context, simulationID, userID string, interval time.Duration, handler func(*simulationpb.SimulationMetrics) error) error {
+	if ss.grpcPool == nil {
+		return fmt.Errorf("simulation service not available")
+	}
+
+	conn := ss.grpcPool.GetConnection()
+	if conn == nil {
+		return fmt.Errorf("no gRPC connection available")
+	}
+	defer ss.grpcPool.ReleaseConnection()
+
+	client := simulationpb.NewSimulationServiceClient(conn)
+	stream, err := client.StreamMetrics(ctx, &simulationpb.StreamMetricsRequest{
+		SimulationId: simulationID,
+		UserId:       userID,
+		IntervalMs:   interval.Milliseconds(),
+		RequestId:    GenerateRequestID(),
+	})
+	if err != nil {
+		return fmt.Errorf("gRPC call failed: %w", err)
+	}
+
+	for {
+		snapshot, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil // Client went away
+			}
+			return fmt.Errorf("metrics stream failed: %w", err)
+		}
+
+		if err := handler(snapshot); err != nil {
+			return err
+		}
+	}
+}
+
+// call runs a unary gRPC call against a pooled simulation service connection
+func (ss *SimulationService) call(method string, fn func(context.Context, simulationpb.SimulationServiceClient) error) error {
+	if ss.grpcPool == nil {
+		return fmt.Errorf("simulation service not available")
+	}
+
+	// Get connection from pool
+	conn := ss.grpcPool.GetConnection()
+	if conn == nil {
+		return fmt.Errorf("no gRPC connection available")
+	}
+
+	// Ensure connection is released after use
+	defer ss.grpcPool.ReleaseConnection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), simulationCallTimeout)
+	defer cancel()
+
+	if err := fn(ctx, simulationpb.NewSimulationServiceClient(conn)); err != nil {
+		log.Printf("gRPC %s failed: %v", method, err)
+		return fmt.Errorf("gRPC call failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: api/proto/simulation.proto
+
+package simulation
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SimulationService_CreateSimulation_FullMethodName = "/simulation.SimulationService/CreateSimulation"
+	SimulationService_ListSimulations_FullMethodName  = "/simulation.SimulationService/ListSimulations"
+	SimulationService_StartSimulation_FullMethodName  = "/simulation.SimulationService/StartSimulation"
+	SimulationService_StopSimulation_FullMethodName   = "/simulation.SimulationService/StopSimulation"
+	SimulationService_GetStatus_FullMethodName        = "/simulation.SimulationService/GetStatus"
+	SimulationService_GetResults_FullMethodName       = "/simulation.SimulationService/GetResults"
+	SimulationService_StreamMetrics_FullMethodName    = "/simulation.SimulationService/StreamMetrics"
+)
+
+// SimulationServiceClient is the client API for SimulationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SimulationService exposes the simulation lifecycle to the API gateway and the mesh
+type SimulationServiceClient interface {
+	// Simulation management
+	CreateSimulation(ctx context.Context, in *CreateSimulationRequest, opts ...grpc.CallOption) (*CreateSimulationResponse, error)
+	ListSimulations(ctx context.Context, in *ListSimulationsRequest, opts ...grpc.CallOption) (*ListSimulationsResponse, error)
+	// Lifecycle control
+	StartSimulation(ctx context.Context, in *StartSimulationRequest, opts ...grpc.CallOption) (*StartSimulationResponse, error)
+	StopSimulation(ctx context.Context, in *StopSimulationRequest, opts ...grpc.CallOption) (*StopSimulationResponse, error)
+	// Status and results
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*GetResultsResponse, error)
+	// Live metrics stream (one snapshot per interval until the client cancels)
+	StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SimulationMetrics], error)
+}
+
+type simulationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulationServiceClient(cc grpc.ClientConnInterface) SimulationServiceClient {
+	return &simulationServiceClient{cc}
+}
+
+func (c *simulationServiceClient) CreateSimulation(ctx context.Context, in *CreateSimulationRequest, opts ...grpc.CallOption) (*CreateSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_CreateSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListSimulations(ctx context.Context, in *ListSimulationsRequest, opts ...grpc.CallOption) (*ListSimulationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSimulationsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListSimulations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) StartSimulation(ctx context.Context, in *StartSimulationRequest, opts ...grpc.CallOption) (*StartSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_StartSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) StopSimulation(ctx context.Context, in *StopSimulationRequest, opts ...grpc.CallOption) (*StopSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_StopSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, SimulationService_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*GetResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResultsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_GetResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SimulationMetrics], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[0], SimulationService_StreamMetrics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamMetricsRequest, SimulationMetrics]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamMetricsClient = grpc.ServerStreamingClient[SimulationMetrics]
+
+// SimulationServiceServer is the server API for SimulationService service.
+// All implementations must embed UnimplementedSimulationServiceServer
+// for forward compatibility.
+//
+// SimulationService exposes the simulation lifecycle to the API gateway and the mesh
+type SimulationServiceServer interface {
+	// Simulation management
+	CreateSimulation(context.Context, *CreateSimulationRequest) (*CreateSimulationResponse, error)
+	ListSimulations(context.Context, *ListSimulationsRequest) (*ListSimulationsResponse, error)
+	// Lifecycle control
+	StartSimulation(context.Context, *StartSimulationRequest) (*StartSimulationResponse, error)
+	StopSimulation(context.Context, *StopSimulationRequest) (*StopSimulationResponse, error)
+	// Status and results
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	GetResults(context.Context, *GetResultsRequest) (*GetResultsResponse, error)
+	// Live metrics stream (one snapshot per interval until the client cancels)
+	StreamMetrics(*StreamMetricsRequest, grpc.ServerStreamingServer[SimulationMetrics]) error
+	mustEmbedUnimplementedSimulationServiceServer()
+}
+
+// UnimplementedSimulationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSimulationServiceServer struct{}
+
+func (UnimplementedSimulationServiceServer) CreateSimulation(context.Context, *CreateSimulationRequest) (*CreateSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListSimulations(context.Context, *ListSimulationsRequest) (*ListSimulationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSimulations not implemented")
+}
+func (UnimplementedSimulationServiceServer) StartSimulation(context.Context, *StartSimulationRequest) (*StartSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) StopSimulation(context.Context, *StopSimulationRequest) (*StopSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedSimulationServiceServer) GetResults(context.Context, *GetResultsRequest) (*GetResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResults not implemented")
+}
+func (UnimplementedSimulationServiceServer) StreamMetrics(*StreamMetricsRequest, grpc.ServerStreamingServer[SimulationMetrics]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMetrics not implemented")
+}
+func (UnimplementedSimulationServiceServer) mustEmbedUnimplementedSimulationServiceServer() {}
+func (UnimplementedSimulationServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeSimulationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulationServiceServer will
+// result in compilation errors.
+type UnsafeSimulationServiceServer interface {
+	mustEmbedUnimplementedSimulationServiceServer()
+}
+
+func RegisterSimulationServiceServer(s grpc.ServiceRegistrar, srv SimulationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSimulationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SimulationService_ServiceDesc, srv)
+}
+
+func _SimulationService_CreateSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).CreateSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_CreateSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).CreateSimulation(ctx, req.(*CreateSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListSimulations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSimulationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListSimulations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListSimulations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListSimulations(ctx, req.(*ListSimulationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_StartSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).StartSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_StartSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).StartSimulation(ctx, req.(*StartSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_StopSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).StopSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_StopSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).StopSimulation(ctx, req.(*StopSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_GetResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).GetResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_GetResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).GetResults(ctx, req.(*GetResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_StreamMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMetricsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).StreamMetrics(m, &grpc.GenericServerStream[StreamMetricsRequest, SimulationMetrics]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamMetricsServer = grpc.ServerStreamingServer[SimulationMetrics]
+
+// SimulationService_ServiceDesc is the grpc.ServiceDesc for SimulationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "simulation.SimulationService",
+	HandlerType: (*SimulationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSimulation",
+			Handler:    _SimulationService_CreateSimulation_Handler,
+		},
+		{
+			MethodName: "ListSimulations",
+			Handler:    _SimulationService_ListSimulations_Handler,
+		},
+		{
+			MethodName: "StartSimulation",
+			Handler:    _SimulationService_StartSimulation_Handler,
+		},
+		{
+			MethodName: "StopSimulation",
+			Handler:    _SimulationService_StopSimulation_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _SimulationService_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetResults",
+			Handler:    _SimulationService_GetResults_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMetrics",
+			Handler:       _SimulationService_StreamMetrics_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proto/simulation.proto",
+}
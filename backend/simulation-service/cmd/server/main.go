@@ -1,13 +1,22 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+
+	"github.com/systemsim/simulation-service/internal/config"
+	grpcserver "github.com/systemsim/simulation-service/internal/grpc"
+	"github.com/systemsim/simulation-service/internal/handlers"
+	"github.com/systemsim/simulation-service/internal/middleware"
+	"github.com/systemsim/simulation-service/internal/simulation"
 )
 
 func main() {
@@ -16,14 +25,77 @@ func main() {
 		log.Printf("Warning: .env file not found: %v", err)
 	}
 
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "11000"
 	}
 
-	// Setup basic HTTP server
+	// Initialize simulation manager
+	simManager := simulation.NewManager(cfg.Simulation)
+	simHandler := handlers.NewSimulationHandler(simManager)
+
+	// Setup HTTP router
+	router := setupRouter(simHandler)
+
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: router,
+	}
+
+	// Initialize gRPC server (for API Gateway and mesh communication)
+	grpcServer, err := grpcserver.NewServer(cfg, simManager)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC server: %v", err)
+	}
+
+	// Start HTTP server
+	go func() {
+		log.Printf("Starting simulation service on port %s", port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Start gRPC server
+	go func() {
+		if err := grpcServer.Start(); err != nil {
+			log.Printf("gRPC server error: %v", err)
+		}
+	}()
+
+	// Wait for interrupt signal to gracefully shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down simulation service...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+
+	// Stop all running simulations before the gRPC server, whose metric streams read them
+	simManager.Shutdown()
+
+	// Shutdown gRPC server
+	grpcServer.Stop()
+
+	log.Println("Simulation service exited")
+}
+
+func setupRouter(simHandler *handlers.SimulationHandler) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.CORS())
 
 	// Basic health endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -34,17 +106,55 @@ func main() {
 		})
 	})
 
-	// Basic API endpoint
-	router.GET("/api/v1/status", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "Simulation service is running",
-			"status":  "active",
+	// Prometheus/OpenMetrics scrape endpoint
+	router.GET("/metrics", simHandler.ExportMetrics)
+
+	// API routes
+	api := router.Group("/api/v1")
+	{
+		// Basic API endpoint
+		api.GET("/status", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{
+				"message": "Simulation service is running",
+				"status":  "active",
+			})
 		})
-	})
 
-	// Start server
-	log.Printf("Starting simulation service on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		// Simulation lifecycle
+		simulations := api.Group("/simulations")
+		{
+			simulations.GET("", simHandler.ListSimulations)
+			simulations.POST("", simHandler.CreateSimulation)
+			simulations.GET("/:id", simHandler.GetSimulation)
+			simulations.PUT("/:id", simHandler.UpdateSimulation)
+			simulations.DELETE("/:id", simHandler.DeleteSimulation)
+
+			simulations.POST("/:id/start", simHandler.StartSimulation)
+			simulations.POST("/:id/stop", simHandler.StopSimulation)
+			simulations.POST("/:id/pause", simHandler.PauseSimulation)
+			simulations.POST("/:id/resume", simHandler.ResumeSimulation)
+
+			simulations.GET("/:id/status", simHandler.GetSimulationStatus)
+			simulations.GET("/:id/metrics", simHandler.GetSimulationMetrics)
+
+			// Components within a simulation
+			simulations.POST("/:id/components", simHandler.CreateComponent)
+			simulations.GET("/:id/components/:componentId", simHandler.GetComponent)
+			simulations.PUT("/:id/components/:componentId", simHandler.UpdateComponent)
+			simulations.DELETE("/:id/components/:componentId", simHandler.DeleteComponent)
+			simulations.GET("/:id/components/:componentId/metrics", simHandler.GetComponentMetrics)
+
+			// Live updates
+			simulations.GET("/:id/ws", simHandler.HandleWebSocket)
+		}
+
+		// Engine catalog
+		engines := api.Group("/engines")
+		{
+			engines.GET("/profiles", simHandler.GetEngineProfiles)
+			engines.GET("/templates", simHandler.GetEngineTemplates)
+		}
 	}
+
+	return router
 }
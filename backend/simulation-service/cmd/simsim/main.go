@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/clock"
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/design"
+	"github.com/systemsim/simulation-service/internal/engines"
+	"github.com/systemsim/simulation-service/internal/simulation"
+)
+
+const usage = `Usage: simsim <command> [options]
+
+Commands:
+  run <design.yaml|design.json>   Run a system design headless and write a JSON report
+
+Run "simsim run -h" for run options.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "run":
+		if err := runCommand(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "simsim: %v\n", err)
+			os.Exit(1)
+		}
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "simsim: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+// runCommand implements "simsim run"
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	duration := flags.Duration("duration", 60*time.Second, "simulated time to run for")
+	seed := flags.Int64("seed", 0, "seed for all random decisions made by the run (default: the design's seed, or 1)")
+	out := flags.String("out", "", "write the JSON report to this file instead of stdout")
+	profiles := flags.String("profiles", "profiles", "directory containing engine and component profiles")
+	tick := flags.Duration("tick", clock.TICK_DURATION, "simulated duration of one tick")
+	fastForward := flags.Bool("fast-forward", false, "skip ticks in which no component has work to do")
+	verbose := flags.Bool("v", false, "keep component logging on stderr")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: simsim run [options] <design.yaml|design.json>")
+		flags.PrintDefaults()
+	}
+
+	// Allow the design path before or after the options
+	var designPath string
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		designPath, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if designPath == "" && flags.NArg() > 0 {
+		designPath = flags.Arg(0)
+	}
+	if designPath == "" {
+		flags.Usage()
+		return fmt.Errorf("missing design file")
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	// Engines print diagnostics with fmt.Printf; send them to stderr so stdout
+	// carries nothing but the report
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	registry := components.NewGlobalRegistry()
+	registry.Start()
+	defer registry.Stop()
+
+	factory := components.NewComponentFactory(*profiles, engines.NewEngineFactoryWithPaths(*profiles))
+	factory.SetRegistry(registry)
+
+	d, err := design.ReadFile(designPath)
+	if err != nil {
+		return err
+	}
+	switch {
+	case *seed != 0:
+		d.Seed = *seed
+	case d.Seed == 0:
+		d.Seed = 1 // Headless runs are always reproducible
+	}
+
+	system, err := design.NewLoader(factory, registry, *profiles).Build(d)
+	if err != nil {
+		return err
+	}
+
+	report, err := simulation.RunHeadless(ctx, system, simulation.HeadlessOptions{
+		Duration:     *duration,
+		Seed:         d.Seed,
+		TickDuration: *tick,
+		FastForward:  *fastForward,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		_, err = stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "simsim: wrote report for %s (%v simulated in %v) to %s\n",
+		report.Design, report.SimulatedTime, report.WallTime.Round(time.Millisecond), *out)
+
+	return nil
+}
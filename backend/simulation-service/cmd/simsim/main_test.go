@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/systemsim/simulation-service/internal/simulation"
+)
+
+func TestRunCommandWritesOnlyJSONToStdout(t *testing.T) {
+	stdoutPath := filepath.Join(t.TempDir(), "stdout")
+	stdout, err := os.Create(stdoutPath)
+	if err != nil {
+		t.Fatalf("Failed to create stdout file: %v", err)
+	}
+	defer stdout.Close()
+
+	saved := os.Stdout
+	os.Stdout = stdout
+	t.Cleanup(func() {
+		os.Stdout = saved
+		log.SetOutput(os.Stderr)
+	})
+
+	err = runCommand([]string{"../../designs/web_app.yaml", "-profiles", "../../profiles", "-duration", "100ms"})
+	if err != nil {
+		t.Fatalf("simsim run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(stdoutPath)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	var report simulation.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Expected stdout to be a single JSON report, got %v:\n%s", err, data)
+	}
+	if report.Design == "" {
+		t.Error("Expected the report to name the design")
+	}
+}
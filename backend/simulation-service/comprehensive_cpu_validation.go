@@ -1,3 +1,7 @@
+//go:build ignore
+
+// Standalone validation program, run with: go run comprehensive_cpu_validation.go
+
 package main
 
 import (
@@ -1,3 +1,7 @@
+//go:build ignore
+
+// Standalone validation program, run with: go run cpu_state_aware_validation.go
+
 package main
 
 import (
@@ -12,13 +12,26 @@ import (
 // 0.01ms (10 microseconds) - ultra-fine granularity for precise simulation
 const TICK_DURATION = 10 * time.Microsecond
 
+// SchedulingMode controls how a headless run advances the clock
+type SchedulingMode string
+
+const (
+	// SchedulingFixedTick processes every tick, whether or not anything happens in it
+	SchedulingFixedTick SchedulingMode = "fixed_tick"
+
+	// SchedulingNextEvent asks components for their next event and jumps the clock
+	// straight to it, skipping ticks in which no component has work to do
+	SchedulingNextEvent SchedulingMode = "next_event"
+)
+
 // GlobalTickCoordinator manages the central simulation clock
 // Based on time-synchronization-solution.md specifications
 type GlobalTickCoordinator struct {
 	// Core timing configuration
-	CurrentTick   int64         `json:"current_tick"`
-	TickDuration  time.Duration `json:"tick_duration"`  // Fixed at 0.01ms (10 microseconds)
-	ScalingFactor float64       `json:"scaling_factor"` // Real-time to simulation time ratio
+	CurrentTick    int64          `json:"current_tick"`
+	TickDuration   time.Duration  `json:"tick_duration"`   // Fixed at 0.01ms (10 microseconds)
+	ScalingFactor  float64        `json:"scaling_factor"`  // Real-time to simulation time ratio
+	SchedulingMode SchedulingMode `json:"scheduling_mode"` // How RunTicks advances the clock
 	
 	// Component management
 	Components    []Component   `json:"-"` // Registered components
@@ -34,6 +47,7 @@ type GlobalTickCoordinator struct {
 	AverageTickTime   time.Duration `json:"average_tick_time"`
 	MaxTickTime       time.Duration `json:"max_tick_time"`
 	TotalTicks        int64         `json:"total_ticks"`
+	SkippedTicks      int64         `json:"skipped_ticks"` // Ticks jumped over in next-event mode
 	
 	// Control channels
 	stopChan      chan struct{} `json:"-"`
@@ -66,15 +80,26 @@ type Component interface {
 	Stop() error
 }
 
+// EventSource is implemented by components that know when they next have work to
+// do, typically the earliest completion tick in their engines' processing heaps.
+// In next-event mode the coordinator only fast-forwards when every registered
+// component is an EventSource.
+type EventSource interface {
+	// NextEventTick returns the earliest tick after currentTick at which the
+	// component has work to do, or false if it is idle until new work arrives
+	NextEventTick(currentTick int64) (int64, bool)
+}
+
 // NewGlobalTickCoordinator creates a new central clock coordinator
 func NewGlobalTickCoordinator() *GlobalTickCoordinator {
 	return &GlobalTickCoordinator{
-		CurrentTick:   0,
-		TickDuration:  TICK_DURATION,
-		ScalingFactor: 1.0, // Default to real-time
-		Components:    make([]Component, 0),
-		Running:       false,
-		Paused:        false,
+		CurrentTick:    0,
+		TickDuration:   TICK_DURATION,
+		ScalingFactor:  1.0, // Default to real-time
+		SchedulingMode: SchedulingFixedTick,
+		Components:     make([]Component, 0),
+		Running:        false,
+		Paused:         false,
 		
 		// Performance tracking
 		TicksPerSecond:  0.0,
@@ -160,6 +185,7 @@ func (gtc *GlobalTickCoordinator) Start(ctx context.Context) error {
 	gtc.StartTime = time.Now()
 	gtc.CurrentTick = 0
 	gtc.TotalTicks = 0
+	gtc.SkippedTicks = 0
 	gtc.mutex.Unlock()
 	
 	log.Printf("Starting global tick coordinator with %d components", len(gtc.Components))
@@ -198,28 +224,37 @@ func (gtc *GlobalTickCoordinator) Stop() error {
 	// Stop the coordinator
 	close(gtc.stopChan)
 
-	log.Printf("Stopped global tick coordinator after %d ticks", gtc.TotalTicks)
+	gtc.mutex.RLock()
+	totalTicks := gtc.TotalTicks
+	gtc.mutex.RUnlock()
+	log.Printf("Stopped global tick coordinator after %d ticks", totalTicks)
 
 	return nil
 }
 
-// Pause temporarily halts the simulation
+// Pause temporarily halts the simulation. The loop is signalled without holding
+// the lock, since a tick in progress needs it to finish.
 func (gtc *GlobalTickCoordinator) Pause() error {
 	gtc.mutex.Lock()
-	defer gtc.mutex.Unlock()
-	
 	if !gtc.Running {
+		gtc.mutex.Unlock()
 		return fmt.Errorf("simulation is not running")
 	}
 	
 	if gtc.Paused {
+		gtc.mutex.Unlock()
 		return fmt.Errorf("simulation is already paused")
 	}
 	
 	gtc.Paused = true
-	gtc.pauseChan <- struct{}{}
+	gtc.mutex.Unlock()
 	
-	log.Printf("Paused simulation at tick %d", gtc.CurrentTick)
+	select {
+	case gtc.pauseChan <- struct{}{}:
+	case <-gtc.stopChan:
+	}
+	
+	log.Printf("Paused simulation at tick %d", gtc.currentTick())
 	
 	return nil
 }
@@ -227,20 +262,25 @@ func (gtc *GlobalTickCoordinator) Pause() error {
 // Resume continues a paused simulation
 func (gtc *GlobalTickCoordinator) Resume() error {
 	gtc.mutex.Lock()
-	defer gtc.mutex.Unlock()
-	
 	if !gtc.Running {
+		gtc.mutex.Unlock()
 		return fmt.Errorf("simulation is not running")
 	}
 	
 	if !gtc.Paused {
+		gtc.mutex.Unlock()
 		return fmt.Errorf("simulation is not paused")
 	}
 	
 	gtc.Paused = false
-	gtc.resumeChan <- struct{}{}
+	gtc.mutex.Unlock()
+	
+	select {
+	case gtc.resumeChan <- struct{}{}:
+	case <-gtc.stopChan:
+	}
 	
-	log.Printf("Resumed simulation at tick %d", gtc.CurrentTick)
+	log.Printf("Resumed simulation at tick %d", gtc.currentTick())
 	
 	return nil
 }
@@ -260,6 +300,25 @@ func (gtc *GlobalTickCoordinator) SetScalingFactor(factor float64) error {
 	return nil
 }
 
+// SetSchedulingMode selects how RunTicks advances the clock
+func (gtc *GlobalTickCoordinator) SetSchedulingMode(mode SchedulingMode) error {
+	if mode != SchedulingFixedTick && mode != SchedulingNextEvent {
+		return fmt.Errorf("unknown scheduling mode: %s", mode)
+	}
+
+	gtc.mutex.Lock()
+	defer gtc.mutex.Unlock()
+
+	if gtc.Running {
+		return fmt.Errorf("cannot change scheduling mode while running")
+	}
+
+	gtc.SchedulingMode = mode
+	log.Printf("Set scheduling mode to %s", mode)
+
+	return nil
+}
+
 // GetSimulationTime returns the current simulation time
 func (gtc *GlobalTickCoordinator) GetSimulationTime() time.Duration {
 	gtc.mutex.RLock()
@@ -377,10 +436,18 @@ func (gtc *GlobalTickCoordinator) runSimulationLoop(ctx context.Context) {
 			return
 
 		case <-gtc.pauseChan:
-			log.Printf("Simulation paused at tick %d", gtc.CurrentTick)
+			log.Printf("Simulation paused at tick %d", gtc.currentTick())
 			// Wait for resume signal
-			<-gtc.resumeChan
-			log.Printf("Simulation resumed at tick %d", gtc.CurrentTick)
+			select {
+			case <-gtc.resumeChan:
+			case <-gtc.stopChan:
+				log.Printf("Simulation loop stopped while paused")
+				return
+			case <-ctx.Done():
+				log.Printf("Simulation loop stopped by context cancellation")
+				return
+			}
+			log.Printf("Simulation resumed at tick %d", gtc.currentTick())
 
 		case <-ticker.C:
 			// Process one tick
@@ -388,7 +455,7 @@ func (gtc *GlobalTickCoordinator) runSimulationLoop(ctx context.Context) {
 
 			err := gtc.processTick()
 			if err != nil {
-				log.Printf("Error processing tick %d: %v", gtc.CurrentTick, err)
+				log.Printf("Error processing tick %d: %v", gtc.currentTick(), err)
 				// Continue processing - don't stop simulation for individual tick errors
 			}
 
@@ -443,6 +510,116 @@ func (gtc *GlobalTickCoordinator) processTick() error {
 	return nil
 }
 
+// RunTicks advances the clock by the given number of ticks as fast as possible.
+// Instead of pacing ticks with a wall-clock ticker and delivering them over tick
+// channels, every component's ProcessTick is called directly, in registration
+// order, before the next tick begins. Intended for headless runs; it cannot be
+// used while the coordinator is running via Start.
+//
+// In SchedulingNextEvent mode, ticks in which no component has work to do are
+// skipped: the clock jumps straight to the earliest tick reported by the
+// components' NextEventTick, so long idle stretches cost a single step.
+func (gtc *GlobalTickCoordinator) RunTicks(ctx context.Context, ticks int64) error {
+	gtc.mutex.Lock()
+	if gtc.Running {
+		gtc.mutex.Unlock()
+		return fmt.Errorf("simulation is already running")
+	}
+	gtc.Running = true
+	if gtc.StartTime.IsZero() {
+		gtc.StartTime = time.Now()
+	}
+	endTick := gtc.CurrentTick + ticks
+	nextEvent := gtc.SchedulingMode == SchedulingNextEvent
+	gtc.mutex.Unlock()
+
+	defer func() {
+		gtc.mutex.Lock()
+		gtc.Running = false
+		gtc.mutex.Unlock()
+	}()
+
+	tickTimes := make([]time.Duration, 0, 100)
+
+	for gtc.currentTick() < endTick {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if nextEvent {
+			gtc.fastForward(endTick)
+		}
+
+		tickStart := time.Now()
+		if err := gtc.stepTick(); err != nil {
+			log.Printf("Error processing tick %d: %v", gtc.currentTick(), err)
+		}
+		gtc.updatePerformanceMetrics(time.Since(tickStart), &tickTimes)
+	}
+
+	return nil
+}
+
+// fastForward moves the clock to just before the next tick in which any component
+// has work to do, never past endTick. Does nothing unless every registered
+// component is an EventSource.
+func (gtc *GlobalTickCoordinator) fastForward(endTick int64) {
+	currentTick := gtc.currentTick()
+
+	gtc.ComponentsMux.RLock()
+	target := endTick
+	for _, component := range gtc.Components {
+		source, ok := component.(EventSource)
+		if !ok {
+			gtc.ComponentsMux.RUnlock()
+			return // Can't see inside this component, so it gets every tick
+		}
+		if tick, pending := source.NextEventTick(currentTick); pending && tick < target {
+			target = tick
+		}
+	}
+	gtc.ComponentsMux.RUnlock()
+
+	if target <= currentTick+1 {
+		return
+	}
+
+	gtc.mutex.Lock()
+	gtc.SkippedTicks += target - 1 - currentTick
+	gtc.CurrentTick = target - 1
+	gtc.mutex.Unlock()
+}
+
+// currentTick reads the current tick under the coordinator lock
+func (gtc *GlobalTickCoordinator) currentTick() int64 {
+	gtc.mutex.RLock()
+	defer gtc.mutex.RUnlock()
+	return gtc.CurrentTick
+}
+
+// stepTick processes one tick synchronously on every registered component
+func (gtc *GlobalTickCoordinator) stepTick() error {
+	gtc.mutex.Lock()
+	gtc.CurrentTick++
+	gtc.TotalTicks++
+	currentTick := gtc.CurrentTick
+	gtc.mutex.Unlock()
+
+	gtc.ComponentsMux.RLock()
+	components := make([]Component, len(gtc.Components))
+	copy(components, gtc.Components)
+	gtc.ComponentsMux.RUnlock()
+
+	var firstErr error
+	for _, component := range components {
+		if err := component.ProcessTick(currentTick); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("component %s: %w", component.GetID(), err)
+		}
+	}
+
+	return firstErr
+}
+
 // updatePerformanceMetrics updates simulation performance tracking
 func (gtc *GlobalTickCoordinator) updatePerformanceMetrics(tickDuration time.Duration, tickTimes *[]time.Duration) {
 	gtc.mutex.Lock()
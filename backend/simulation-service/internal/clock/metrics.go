@@ -8,6 +8,7 @@ import (
 type PerformanceMetrics struct {
 	CurrentTick       int64         `json:"current_tick"`
 	TotalTicks        int64         `json:"total_ticks"`
+	SkippedTicks      int64         `json:"skipped_ticks"`
 	TicksPerSecond    float64       `json:"ticks_per_second"`
 	AverageTickTime   time.Duration `json:"average_tick_time"`
 	MaxTickTime       time.Duration `json:"max_tick_time"`
@@ -42,6 +43,7 @@ func (gtc *GlobalTickCoordinator) GetPerformanceMetrics() PerformanceMetrics {
 	return PerformanceMetrics{
 		CurrentTick:       gtc.CurrentTick,
 		TotalTicks:        gtc.TotalTicks,
+		SkippedTicks:      gtc.SkippedTicks,
 		TicksPerSecond:    gtc.TicksPerSecond,
 		AverageTickTime:   gtc.AverageTickTime,
 		MaxTickTime:       gtc.MaxTickTime,
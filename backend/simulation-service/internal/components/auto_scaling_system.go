@@ -306,18 +306,36 @@ func (ass *AutoScalingSystem) updateEfficiencyMetrics(component *AutoScalingComp
 	// Calculate efficiency score based on scaling frequency and resource utilization
 	totalActions := component.Metrics.TotalScaleUps + component.Metrics.TotalScaleDowns
 	
-	if totalActions > 0 {
-		// Lower score for frequent scaling (indicates instability)
-		frequencyPenalty := float64(totalActions) * 0.01
-		component.Metrics.EfficiencyScore = 1.0 - frequencyPenalty
-		
-		if component.Metrics.EfficiencyScore < 0.1 {
-			component.Metrics.EfficiencyScore = 0.1
-		}
-	}
+	component.Metrics.EfficiencyScore = scalingEfficiency(totalActions)
 	
 	// Calculate cost savings (educational metric)
 	if action == ScaleDown {
-		component.Metrics.CostSavings += 10.0 // $10 per instance hour saved
+		component.Metrics.CostSavings += scaleDownSavings
+	}
+}
+
+// scaleDownSavings is the educational cost saved per instance removed ($10 per instance hour)
+const scaleDownSavings = 10.0
+
+// scalingEfficiency scores scaling stability: every scaling action costs 1%, down to 0.1
+func scalingEfficiency(totalActions int64) float64 {
+	// Lower score for frequent scaling (indicates instability)
+	efficiency := 1.0 - float64(totalActions)*0.01
+	if efficiency < 0.1 {
+		efficiency = 0.1
+	}
+	return efficiency
+}
+
+// GetMetrics returns a copy of the auto-scaling metrics of every registered component
+func (ass *AutoScalingSystem) GetMetrics() map[string]*AutoScalingMetrics {
+	ass.mutex.RLock()
+	defer ass.mutex.RUnlock()
+
+	metrics := make(map[string]*AutoScalingMetrics, len(ass.components))
+	for componentID, component := range ass.components {
+		componentMetrics := *component.Metrics
+		metrics[componentID] = &componentMetrics
 	}
+	return metrics
 }
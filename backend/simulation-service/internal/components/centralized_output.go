@@ -2,8 +2,10 @@ package components
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
@@ -62,15 +64,23 @@ func (com *CentralizedOutputManager) handleOperationResult(result *engines.Opera
 	log.Printf("CentralizedOutputManager %s: Handling result %s", com.InstanceID, result.OperationID)
 
 	// Get request context from global registry
-	requestCtx, err := com.getRequestContext(result.OperationID)
+	requestCtx, err := com.getRequestContext(result)
 	if err != nil {
 		return fmt.Errorf("failed to get request context: %w", err)
 	}
 
-	// Get system graph from global registry
-	systemGraph, err := com.getSystemGraph(requestCtx.SystemFlowID)
-	if err != nil {
-		return fmt.Errorf("failed to get system graph: %w", err)
+	// Flows without a system graph follow routing rules and user flow steps
+	systemGraph := com.lookupSystemGraph(requestCtx.SystemFlowID)
+	if systemGraph == nil {
+		nextComponent, err := com.determineNextComponent(result)
+		if err != nil {
+			return fmt.Errorf("failed to determine next component: %w", err)
+		}
+		if nextComponent == "" {
+			com.endRequest(requestCtx)
+			return com.routeToEndNode(result)
+		}
+		return com.forwardResult(nextComponent, requestCtx, result)
 	}
 
 	// Determine next component using system graph and business logic evaluation
@@ -87,34 +97,103 @@ func (com *CentralizedOutputManager) handleOperationResult(result *engines.Opera
 	// If no next component, this is the end of the flow
 	if nextComponent == "" {
 		log.Printf("CentralizedOutputManager %s: End of flow for result %s", com.InstanceID, result.OperationID)
+		com.endRequest(requestCtx)
 		return com.routeToEndNode(result)
 	}
 
+	// Record where the request is headed before routing it there
+	requestCtx.CurrentSystemNode = nextComponent
+	if registry, ok := com.GlobalRegistry.(SystemGraphRegistry); ok {
+		registry.UpdateRequestContext(requestCtx.RequestID, requestCtx)
+	}
+
 	// Route to next component via global registry
-	return com.routeToNextComponent(nextComponent, result)
+	return com.forwardResult(nextComponent, requestCtx, result)
 }
 
-// getRequestContext gets request context from global registry
-func (com *CentralizedOutputManager) getRequestContext(requestID string) (*RequestContext, error) {
+// forwardResult routes a result to the next component. A result whose transfer
+// the network lost (retransmits exhausted, idle timeout, dropped datagram) never
+// arrives there: it counts as a failure on the target's circuit breaker, so flaky
+// links open the circuit, and the request ends here.
+func (com *CentralizedOutputManager) forwardResult(nextComponent string, requestCtx *RequestContext, result *engines.OperationResult) error {
+	if !isNetworkFailure(result) {
+		return com.routeToNextComponent(nextComponent, result)
+	}
+
+	if com.CircuitBreakerManager != nil {
+		com.CircuitBreakerManager.GetCircuitBreaker(nextComponent).recordFailure()
+	}
+	log.Printf("CentralizedOutputManager %s: Result %s lost on the way to %s: %v",
+		com.InstanceID, result.OperationID, nextComponent, result.Error)
+	com.endRequest(requestCtx)
+	return com.routeToEndNode(result)
+}
+
+// isNetworkFailure reports whether a result failed with a typed network error
+func isNetworkFailure(result *engines.OperationResult) bool {
+	var networkErr *engines.NetworkError
+	return !result.Success && errors.As(result.Error, &networkErr)
+}
+
+// getRequestContext gets the result's request context from the global registry,
+// creating it when the request enters its first component
+func (com *CentralizedOutputManager) getRequestContext(result *engines.OperationResult) (*RequestContext, error) {
 	if com.GlobalRegistry == nil {
 		return nil, fmt.Errorf("no global registry available")
 	}
 
+	requestID := result.OperationID
+	if request, ok := result.Metrics["request"].(*Request); ok && request != nil {
+		requestID = request.ID
+	}
+	flowID, _ := result.Metrics["flow"].(string)
+	if flowID == "" {
+		flowID = "default_flow"
+	}
+
 	// Try to get request context from enhanced global registry
-	if enhancedRegistry, ok := com.GlobalRegistry.(GlobalRegistryInterface); ok {
-		return enhancedRegistry.GetRequestContext(requestID), nil
+	if enhancedRegistry, ok := com.GlobalRegistry.(SystemGraphRegistry); ok {
+		if requestCtx := enhancedRegistry.GetRequestContext(requestID); requestCtx != nil {
+			return requestCtx, nil
+		}
+		requestCtx := &RequestContext{
+			RequestID:         requestID,
+			SystemFlowID:      flowID,
+			CurrentSystemNode: com.ComponentID,
+			StartTime:         time.Now().Format(time.RFC3339),
+			LastUpdate:        time.Now().Format(time.RFC3339),
+		}
+		if err := enhancedRegistry.UpdateRequestContext(requestID, requestCtx); err != nil {
+			return nil, err
+		}
+		return requestCtx, nil
 	}
 
 	// Fallback: create basic request context
 	return &RequestContext{
 		RequestID:         requestID,
-		SystemFlowID:      "default_flow",
-		CurrentSystemNode: "start",
+		SystemFlowID:      flowID,
+		CurrentSystemNode: com.ComponentID,
 		StartTime:         time.Now().Format(time.RFC3339),
 		LastUpdate:        time.Now().Format(time.RFC3339),
 	}, nil
 }
 
+// endRequest drops the context of a request that reached the end of its flow
+func (com *CentralizedOutputManager) endRequest(requestCtx *RequestContext) {
+	if registry, ok := com.GlobalRegistry.(interface{ DeleteRequestContext(string) error }); ok {
+		registry.DeleteRequestContext(requestCtx.RequestID)
+	}
+}
+
+// lookupSystemGraph returns the registered system graph for a flow, or nil
+func (com *CentralizedOutputManager) lookupSystemGraph(flowID string) *DecisionGraph {
+	if registry, ok := com.GlobalRegistry.(SystemGraphRegistry); ok {
+		return registry.GetSystemGraph(flowID)
+	}
+	return nil
+}
+
 // getSystemGraph gets system graph from global registry
 func (com *CentralizedOutputManager) getSystemGraph(flowID string) (*DecisionGraph, error) {
 	if com.GlobalRegistry == nil {
@@ -122,7 +201,7 @@ func (com *CentralizedOutputManager) getSystemGraph(flowID string) (*DecisionGra
 	}
 
 	// Try to get system graph from enhanced global registry
-	if enhancedRegistry, ok := com.GlobalRegistry.(GlobalRegistryInterface); ok {
+	if enhancedRegistry, ok := com.GlobalRegistry.(SystemGraphRegistry); ok {
 		graph := enhancedRegistry.GetSystemGraph(flowID)
 		if graph != nil {
 			return graph, nil
@@ -150,9 +229,15 @@ func (com *CentralizedOutputManager) getSystemGraph(flowID string) (*DecisionGra
 	}, nil
 }
 
-// evaluateSystemGraph evaluates system graph with business logic conditions
+// evaluateSystemGraph evaluates system graph with business logic conditions. The
+// current node is this component's node (component nodes are keyed by component ID),
+// falling back to the request's recorded node and then the start node. Decision nodes
+// on the way are evaluated in turn; reaching an end node returns no next component.
 func (com *CentralizedOutputManager) evaluateSystemGraph(graph *DecisionGraph, requestCtx *RequestContext, result *engines.OperationResult) (string, string, error) {
-	currentNode := graph.Nodes[requestCtx.CurrentSystemNode]
+	currentNode := graph.Nodes[com.ComponentID]
+	if currentNode == nil {
+		currentNode = graph.Nodes[requestCtx.CurrentSystemNode]
+	}
 	if currentNode == nil {
 		// Start from beginning if no current node
 		currentNode = graph.Nodes[graph.StartNode]
@@ -161,24 +246,67 @@ func (com *CentralizedOutputManager) evaluateSystemGraph(graph *DecisionGraph, r
 		}
 	}
 
+	for hops := 0; hops <= len(graph.Nodes); hops++ {
+		destination := com.nextSystemNode(currentNode, requestCtx, result)
+		if destination == "" {
+			// No conditions matched and no default - end of flow
+			return "", "", nil
+		}
+
+		// Check if destination is a sub-flow
+		if com.isSubFlow(destination) {
+			return "", destination, nil // Return sub-flow for execution
+		}
+
+		node := graph.Nodes[destination]
+		switch {
+		case node == nil:
+			return destination, "", nil // Component outside the graph's nodes
+		case node.Type == "end" || containsString(graph.EndNodes, destination):
+			return "", "", nil
+		case node.Type == "decision":
+			currentNode = node
+		default:
+			return destination, "", nil // Return next component
+		}
+	}
+
+	return "", "", fmt.Errorf("system graph %s has a decision cycle at node %s", graph.Name, currentNode.ID)
+}
+
+// nextSystemNode returns the destination of the first matching condition, checked in
+// name order with "default" last, then the node's default next node
+func (com *CentralizedOutputManager) nextSystemNode(node *DecisionNode, requestCtx *RequestContext, result *engines.OperationResult) string {
+	conditions := make([]string, 0, len(node.Conditions))
+	for condition := range node.Conditions {
+		if condition != "default" {
+			conditions = append(conditions, condition)
+		}
+	}
+	sort.Strings(conditions)
+	if _, exists := node.Conditions["default"]; exists {
+		conditions = append(conditions, "default")
+	}
+
 	// Evaluate business logic conditions
-	for condition, destination := range currentNode.Conditions {
+	for _, condition := range conditions {
 		if com.evaluateBusinessLogicCondition(condition, requestCtx, result) {
-			// Check if destination is a sub-flow
-			if com.isSubFlow(destination) {
-				return "", destination, nil // Return sub-flow for execution
-			}
-			return destination, "", nil // Return next component
+			return node.Conditions[condition]
 		}
 	}
 
 	// Return default next node
-	if currentNode.Next != "" {
-		return currentNode.Next, "", nil
-	}
+	return node.Next
+}
 
-	// No conditions matched and no default - end of flow
-	return "", "", nil
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // evaluateBusinessLogicCondition evaluates business logic conditions
@@ -201,13 +329,15 @@ func (com *CentralizedOutputManager) evaluateBusinessLogicCondition(condition st
 	case "low_priority":
 		return !com.isHighPriority(requestCtx, result)
 	case "cache_hit":
-		return result.Success && result.Data != nil
+		return result.Success && isCacheHit(result)
 	case "cache_miss":
-		return !result.Success || result.Data == nil
+		return !result.Success || !isCacheHit(result)
 	case "processing_success":
 		return result.Success
 	case "processing_failure":
 		return !result.Success
+	case "default":
+		return true
 	default:
 		log.Printf("CentralizedOutputManager %s: Unknown business logic condition '%s'", com.InstanceID, condition)
 		return false
@@ -216,9 +346,9 @@ func (com *CentralizedOutputManager) evaluateBusinessLogicCondition(condition st
 
 // Business logic evaluation helpers
 func (com *CentralizedOutputManager) isUserAuthenticated(requestCtx *RequestContext, result *engines.OperationResult) bool {
-	// Check if authentication result is available in result metadata
-	if result.Metadata != nil {
-		if authResult, exists := result.Metadata["auth_result"]; exists {
+	// Check if authentication result is available in result metrics
+	if result.Metrics != nil {
+		if authResult, exists := result.Metrics["auth_result"]; exists {
 			if authMap, ok := authResult.(map[string]interface{}); ok {
 				if authenticated, exists := authMap["is_authenticated"]; exists {
 					if authBool, ok := authenticated.(bool); ok {
@@ -234,9 +364,9 @@ func (com *CentralizedOutputManager) isUserAuthenticated(requestCtx *RequestCont
 }
 
 func (com *CentralizedOutputManager) isItemInStock(requestCtx *RequestContext, result *engines.OperationResult) bool {
-	// Check if inventory result is available in result metadata
-	if result.Metadata != nil {
-		if inventoryResult, exists := result.Metadata["inventory_result"]; exists {
+	// Check if inventory result is available in result metrics
+	if result.Metrics != nil {
+		if inventoryResult, exists := result.Metrics["inventory_result"]; exists {
 			if inventoryMap, ok := inventoryResult.(map[string]interface{}); ok {
 				if inStock, exists := inventoryMap["in_stock"]; exists {
 					if stockBool, ok := inStock.(bool); ok {
@@ -252,9 +382,9 @@ func (com *CentralizedOutputManager) isItemInStock(requestCtx *RequestContext, r
 }
 
 func (com *CentralizedOutputManager) isPaymentSuccessful(requestCtx *RequestContext, result *engines.OperationResult) bool {
-	// Check if payment result is available in result metadata
-	if result.Metadata != nil {
-		if paymentResult, exists := result.Metadata["payment_result"]; exists {
+	// Check if payment result is available in result metrics
+	if result.Metrics != nil {
+		if paymentResult, exists := result.Metrics["payment_result"]; exists {
 			if paymentMap, ok := paymentResult.(map[string]interface{}); ok {
 				if processed, exists := paymentMap["processed"]; exists {
 					if processedBool, ok := processed.(bool); ok {
@@ -270,9 +400,9 @@ func (com *CentralizedOutputManager) isPaymentSuccessful(requestCtx *RequestCont
 }
 
 func (com *CentralizedOutputManager) isHighPriority(requestCtx *RequestContext, result *engines.OperationResult) bool {
-	// Check priority in result metadata
-	if result.Metadata != nil {
-		if priority, exists := result.Metadata["priority"]; exists {
+	// Check priority in result metrics
+	if result.Metrics != nil {
+		if priority, exists := result.Metrics["priority"]; exists {
 			if priorityInt, ok := priority.(int); ok {
 				return priorityInt >= 8 // High priority threshold
 			}
@@ -283,6 +413,14 @@ func (com *CentralizedOutputManager) isHighPriority(requestCtx *RequestContext,
 	return false
 }
 
+// isCacheHit reports whether the engine served the operation from a cache
+func isCacheHit(result *engines.OperationResult) bool {
+	if hit, ok := result.Metrics["cache_hit"].(bool); ok {
+		return hit
+	}
+	return result.Metrics["page_cache"] == "hit"
+}
+
 // isSubFlow checks if destination is a sub-flow
 func (com *CentralizedOutputManager) isSubFlow(destination string) bool {
 	// Sub-flows typically have a specific naming pattern
@@ -328,13 +466,26 @@ func (com *CentralizedOutputManager) executeSubFlow(subFlowID string, requestCtx
 func (com *CentralizedOutputManager) routeToEndNode(result *engines.OperationResult) error {
 	log.Printf("CentralizedOutputManager %s: Routing result %s to end node", com.InstanceID, result.OperationID)
 
-	// Send to output channel for external consumption
+	// Mark the request finished so observers can measure it end to end
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["request_complete"] = true
+	if request, ok := result.Metrics["request"].(*Request); ok {
+		if result.Success {
+			request.MarkComplete()
+		} else {
+			request.MarkFailed()
+		}
+	}
+
+	// Offer to the output channel for external consumers; with none attached the
+	// completed request is dropped once the channel is full
 	select {
 	case com.OutputChannel <- result:
-		return nil
 	default:
-		return fmt.Errorf("output channel is full")
 	}
+	return nil
 }
 
 // determineNextComponent determines the next component based on routing rules and user flow
@@ -600,15 +751,18 @@ func (com *CentralizedOutputManager) resultToOperation(result *engines.Operation
 	// Create new operation based on result
 	operation := &engines.Operation{
 		ID:            result.OperationID + "-next",
-		Type:          result.OperationType,
-		DataSize:      1024, // TODO: Get from result metadata
-		Complexity:    "O(1)", // TODO: Get from result metadata
+		Type:          com.nextOperationType(result, nextComponentID),
+		DataSize:      1024,
+		Complexity:    "O(1)",
 		Language:      "go",
 		Priority:      5,
 		StartTick:     0, // Will be set by receiving component
 		NextComponent: nextComponentID,
 		Metadata:      make(map[string]interface{}),
 	}
+	if dataSize, ok := result.Metrics["data_size"].(int64); ok && dataSize > 0 {
+		operation.DataSize = dataSize
+	}
 
 	// Copy relevant metadata from result
 	if result.Metrics != nil {
@@ -625,6 +779,22 @@ func (com *CentralizedOutputManager) resultToOperation(result *engines.Operation
 	return operation
 }
 
+// nextOperationType returns the operation the request's user flow performs on the
+// next component, or the result's own operation type when the flow does not say
+func (com *CentralizedOutputManager) nextOperationType(result *engines.OperationResult, nextComponentID string) string {
+	flowID, _ := result.Metrics["flow"].(string)
+	if com.UserFlowConfig != nil && flowID != "" {
+		if flow := com.UserFlowConfig.Flows[flowID]; flow != nil {
+			for _, step := range flow.Steps {
+				if step.ComponentID == nextComponentID && step.Operation != "" {
+					return step.Operation
+				}
+			}
+		}
+	}
+	return result.OperationType
+}
+
 // attemptFallbackRouting attempts to route to fallback targets when primary target fails
 func (com *CentralizedOutputManager) attemptFallbackRouting(primaryTarget string, result *engines.OperationResult, reason string) error {
 	if com.FallbackRouting == nil || !com.FallbackRouting.Enabled {
@@ -745,8 +915,8 @@ func (com *CentralizedOutputManager) applyFallbackStrategy(targets []string) []s
 		return targets
 
 	case FallbackStrategyRoundRobin:
-		// Simple round-robin based on current time
-		offset := int(time.Now().UnixNano()) % len(targets)
+		// Simple round-robin, rotating the starting target on every call
+		offset := int(com.fallbackRotation.Add(1)-1) % len(targets)
 		result := make([]string, len(targets))
 		for i := 0; i < len(targets); i++ {
 			result[i] = targets[(i+offset)%len(targets)]
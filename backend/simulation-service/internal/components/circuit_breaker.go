@@ -291,6 +291,18 @@ func (cbm *CircuitBreakerManager) GetAllStats() map[string]interface{} {
 	return stats
 }
 
+// GetAllStates returns the current state of every circuit breaker by target component
+func (cbm *CircuitBreakerManager) GetAllStates() map[string]CircuitBreakerState {
+	cbm.mutex.RLock()
+	defer cbm.mutex.RUnlock()
+
+	states := make(map[string]CircuitBreakerState, len(cbm.circuitBreakers))
+	for componentID, cb := range cbm.circuitBreakers {
+		states[componentID] = cb.GetState()
+	}
+	return states
+}
+
 // ResetAll resets all circuit breakers
 func (cbm *CircuitBreakerManager) ResetAll() {
 	cbm.mutex.RLock()
@@ -368,3 +368,54 @@ func TestCentralizedOutputManager_CircuitBreakerIntegration(t *testing.T) {
 		t.Error("Expected circuit breaker stats for target-component")
 	}
 }
+
+// TestCentralizedOutputManager_NetworkFailuresOpenCircuit tests that results the
+// network engine lost are not forwarded and trip the target's circuit breaker
+func TestCentralizedOutputManager_NetworkFailuresOpenCircuit(t *testing.T) {
+	registry := NewGlobalRegistry()
+	targetChannel := make(chan *engines.Operation, 10)
+	registry.Register("target-component", targetChannel)
+
+	config := DefaultCircuitBreakerConfig()
+	com := &CentralizedOutputManager{
+		InstanceID:            "test-instance",
+		ComponentID:           "test-component",
+		GlobalRegistry:        registry,
+		CircuitBreakerManager: NewCircuitBreakerManager(config),
+		DefaultRouting:        "target-component",
+		OutputChannel:         make(chan *engines.OperationResult, 10),
+	}
+
+	for i := 0; i < config.FailureThreshold; i++ {
+		result := &engines.OperationResult{
+			OperationID:   fmt.Sprintf("lost-%d", i),
+			OperationType: "send",
+			Success:       false,
+			Error: &engines.NetworkError{
+				Code:        engines.NetworkErrorRetransmitsExhausted,
+				OperationID: fmt.Sprintf("lost-%d", i),
+				Protocol:    "tcp",
+				Attempts:    6,
+			},
+		}
+		if err := com.handleOperationResult(result); err != nil {
+			t.Fatalf("Expected the lost result to end its request, got error: %v", err)
+		}
+	}
+
+	if len(targetChannel) != 0 {
+		t.Errorf("Expected lost results not to reach the target, got %d operations", len(targetChannel))
+	}
+	if len(com.OutputChannel) != config.FailureThreshold {
+		t.Errorf("Expected %d failed results at the end node, got %d", config.FailureThreshold, len(com.OutputChannel))
+	}
+	if state := com.CircuitBreakerManager.GetCircuitBreaker("target-component").GetState(); state != CircuitBreakerOpen {
+		t.Errorf("Expected network failures to open the target's circuit, got %s", state)
+	}
+
+	// With the circuit open, even a healthy result is refused
+	healthy := &engines.OperationResult{OperationID: "healthy", OperationType: "send", Success: true}
+	if err := com.handleOperationResult(healthy); err == nil {
+		t.Error("Expected routing through the open circuit to fail")
+	}
+}
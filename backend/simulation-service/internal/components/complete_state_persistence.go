@@ -2,12 +2,8 @@ package components
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
@@ -75,7 +71,7 @@ type CompleteSystemState struct {
 	QueueStates     map[string]*QueueState  `json:"queue_states"`
 	
 	// Component states
-	ComponentStates map[string]*ComponentState `json:"component_states"`
+	ComponentStates map[string]*ComponentLevelState `json:"component_states"`
 	
 	// System-level state
 	SystemState     *SystemLevelState `json:"system_state"`
@@ -97,6 +93,8 @@ type EngineState struct {
 	// Engine configuration
 	Profile         interface{}            `json:"profile"`
 	Configuration   map[string]interface{} `json:"configuration"`
+	ComplexityLevel int                    `json:"complexity_level"`
+	Seed            int64                  `json:"seed"`
 	
 	// Runtime state
 	IsRunning       bool                   `json:"is_running"`
@@ -108,7 +106,7 @@ type EngineState struct {
 	OperationQueue   []*engines.Operation          `json:"operation_queue"`
 	
 	// Metrics
-	Metrics         *engines.EngineMetrics `json:"metrics"`
+	Metrics         map[string]interface{} `json:"metrics"`
 	
 	// Timestamp
 	LastUpdate      time.Time              `json:"last_update"`
@@ -137,8 +135,8 @@ type QueueState struct {
 	LastUpdate      time.Time                 `json:"last_update"`
 }
 
-// ComponentState represents the complete state of a component
-type ComponentState struct {
+// ComponentLevelState represents the complete state of a component
+type ComponentLevelState struct {
 	ComponentID     string                    `json:"component_id"`
 	ComponentType   ComponentType             `json:"component_type"`
 	
@@ -146,7 +144,7 @@ type ComponentState struct {
 	Instances       map[string]*InstanceState `json:"instances"`
 	
 	// Load balancer state
-	LoadBalancerState *LoadBalancerState      `json:"load_balancer_state"`
+	LoadBalancerState *LoadBalancerLevelState      `json:"load_balancer_state"`
 	
 	// Component configuration
 	Configuration   map[string]interface{}    `json:"configuration"`
@@ -179,8 +177,8 @@ type InstanceState struct {
 	LastUpdate      time.Time                 `json:"last_update"`
 }
 
-// LoadBalancerState represents the state of a load balancer
-type LoadBalancerState struct {
+// LoadBalancerLevelState represents the state of a load balancer
+type LoadBalancerLevelState struct {
 	ComponentID       string                  `json:"component_id"`
 	Algorithm         LoadBalancingAlgorithm  `json:"algorithm"`
 	
@@ -3,6 +3,7 @@ package components
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -427,3 +428,78 @@ func TestComponentArchitecture_ConcurrentOperations(t *testing.T) {
 		t.Error("Load balancer should be healthy after concurrent operations")
 	}
 }
+
+// TestComponentArchitecture_TLSHandshakeFollowUp tests that a duplex component's
+// TLS handshakes hand their crypto to the instance's CPU engine, and that
+// network follow-ups go to the side of the split network that handles them
+func TestComponentArchitecture_TLSHandshakeFollowUp(t *testing.T) {
+	config := &ComponentConfig{
+		ID:   "test-tls-component",
+		Type: ComponentTypeWebServer,
+		LoadBalancer: &LoadBalancingConfig{
+			Algorithm:    LoadBalancingNone,
+			MinInstances: 1,
+			MaxInstances: 1,
+		},
+		RequiredEngines:  []engines.EngineType{engines.NetworkInEngineType, engines.CPUEngineType, engines.NetworkOutEngineType},
+		MaxConcurrentOps: 5,
+		QueueCapacity:    10,
+		TickTimeout:      time.Millisecond * 10,
+		EngineProfiles:   make(map[engines.EngineType]string),
+		ComplexityLevels: make(map[engines.EngineType]int),
+	}
+
+	lb, err := NewLoadBalancer(config)
+	if err != nil {
+		t.Fatalf("Failed to create load balancer: %v", err)
+	}
+	var observed []*engines.OperationResult
+	lb.SetObserver(func(_ string, result *engines.OperationResult) {
+		observed = append(observed, result)
+	})
+	lb.SetTickDriven(10 * time.Microsecond)
+	if err := lb.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start load balancer: %v", err)
+	}
+	defer lb.Stop()
+
+	// Without keep-alive every transfer opens a new connection and handshakes
+	instance := lb.Instances[0]
+	for _, engineType := range []engines.EngineType{engines.NetworkInEngineType, engines.NetworkOutEngineType} {
+		instance.Engines[engineType].GetEngine().(*engines.NetworkEngine).ProtocolState.KeepAliveEnabled = false
+	}
+
+	operation := &engines.Operation{ID: "request", Type: "http_get", DataSize: 1024, Metadata: map[string]interface{}{"client_id": "client-1"}}
+	if err := lb.ProcessOperation(operation); err != nil {
+		t.Fatalf("Failed to process operation: %v", err)
+	}
+	for tick := int64(1); tick <= 5000; tick++ {
+		lb.ProcessTick(tick)
+	}
+
+	handshakes := 0
+	for _, result := range observed {
+		if result.OperationType == engines.OpCPUCompute && strings.HasSuffix(result.OperationID, "_tls") {
+			handshakes++
+		}
+	}
+	if handshakes != 2 {
+		t.Errorf("Expected the CPU engine to run both network sides' handshake crypto, got %d handshakes", handshakes)
+	}
+	if instance.Metrics.CompletedOps != 1 {
+		t.Errorf("Expected the request to complete, got %d completed", instance.Metrics.CompletedOps)
+	}
+
+	tests := map[string]engines.EngineType{
+		engines.OpNetworkRecv:     engines.NetworkInEngineType,
+		engines.OpNetworkConn:     engines.NetworkInEngineType,
+		engines.OpNetworkSend:     engines.NetworkOutEngineType,
+		engines.OpNetworkResponse: engines.NetworkOutEngineType,
+		engines.OpCPUCompute:      engines.CPUEngineType,
+	}
+	for operationType, expected := range tests {
+		if engineTypes := followUpEngineTypes(operationType); len(engineTypes) == 0 || engineTypes[0] != expected {
+			t.Errorf("Expected %s follow-ups to go to %s, got %v", operationType, expected, engineTypes)
+		}
+	}
+}
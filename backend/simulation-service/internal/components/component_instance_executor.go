@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,7 +12,9 @@ import (
 )
 
 // ComponentInstanceExecutor executes engine sequences provided by Load Balancer
-// without routing decisions or graph knowledge - pure execution engine
+// without routing decisions or graph knowledge - pure execution engine.
+// Sequences advance as their engines complete: each engine result hands the
+// operation to the next engine once the simulated clock reaches its completion tick.
 type ComponentInstanceExecutor struct {
 	// Identity
 	InstanceID    string `json:"instance_id"`
@@ -19,19 +22,29 @@ type ComponentInstanceExecutor struct {
 	
 	// Engine management
 	engines       map[engines.EngineType]*engines.EngineWrapper `json:"-"`
-	engineOrder   []engines.EngineType                          `json:"engine_order"`
+	engineOrder   []engines.EngineType                          `json:"-"`
 	
 	// Execution state
-	isRunning     bool                                          `json:"is_running"`
-	currentLoad   float64                                       `json:"current_load"`
-	health        float64                                       `json:"health"`
-	
-	// Communication channels
-	inputChannel  chan *EngineSequenceRequest                   `json:"-"`
+	isRunning     bool                                          `json:"-"`
+	currentLoad   float64                                       `json:"-"`
+	health        float64                                       `json:"-"`
+	currentTick   int64                                         `json:"-"`
+	
+	// In-flight sequences keyed by the ID of their current engine operation
+	inFlight      map[string]*sequenceExecution                 `json:"-"`
+	// Sequences waiting for the clock to reach the previous engine's completion tick
+	deferred      []*sequenceExecution                          `json:"-"`
+	// IDs of follow-up operations handed to other engines
+	followUps     map[string]bool                               `json:"-"`
+	
+	// Completed sequences go to the completion handler, or the output channel without one
+	completionHandler func(*EngineSequenceResult)               `json:"-"`
+	// Optional callback for completed follow-up operations (e.g. TLS handshake crypto)
+	followUpHandler func(*engines.OperationResult)              `json:"-"`
 	outputChannel chan *EngineSequenceResult                    `json:"-"`
 	
 	// Execution metrics
-	metrics       *ExecutionMetrics                             `json:"metrics"`
+	metrics       *ExecutionMetrics                             `json:"-"`
 	
 	// Lifecycle management
 	ctx           context.Context                               `json:"-"`
@@ -44,6 +57,9 @@ type EngineSequenceRequest struct {
 	// Request information
 	Request       *Request                                      `json:"request"`
 	
+	// Operation being executed; engine operations inherit its type, size and metadata
+	Operation     *engines.Operation                           `json:"operation,omitempty"`
+	
 	// Execution sequence (provided by Load Balancer)
 	EngineSequence []engines.EngineType                        `json:"engine_sequence"`
 	
@@ -53,6 +69,7 @@ type EngineSequenceRequest struct {
 	// Timing
 	Timestamp     time.Time                                    `json:"timestamp"`
 	Timeout       time.Duration                                `json:"timeout"`
+	StartTick     int64                                        `json:"start_tick"`
 	
 	// Tracking
 	SequenceID    string                                       `json:"sequence_id"`
@@ -62,6 +79,7 @@ type EngineSequenceRequest struct {
 type EngineSequenceResult struct {
 	// Request information
 	Request       *Request                                      `json:"request"`
+	Operation     *engines.Operation                           `json:"operation,omitempty"`
 	SequenceID    string                                       `json:"sequence_id"`
 	
 	// Execution results
@@ -70,10 +88,13 @@ type EngineSequenceResult struct {
 	// Overall result
 	Success       bool                                         `json:"success"`
 	ErrorMessage  string                                       `json:"error_message,omitempty"`
+	Error         error                                        `json:"-"` // Typed cause from the failing engine
 	
-	// Timing
+	// Timing (TotalLatency is simulated time from StartTick to CompletedTick)
 	StartTime     time.Time                                    `json:"start_time"`
 	EndTime       time.Time                                    `json:"end_time"`
+	StartTick     int64                                        `json:"start_tick"`
+	CompletedTick int64                                        `json:"completed_tick"`
 	TotalLatency  time.Duration                                `json:"total_latency"`
 	
 	// Metrics
@@ -88,10 +109,20 @@ type EngineExecutionResult struct {
 	Result        *engines.OperationResult                     `json:"result"`
 	StartTime     time.Time                                    `json:"start_time"`
 	EndTime       time.Time                                    `json:"end_time"`
-	Latency       time.Duration                                `json:"latency"`
+	Latency       time.Duration                                `json:"latency"` // Simulated engine processing time
 	ErrorMessage  string                                       `json:"error_message,omitempty"`
 }
 
+// sequenceExecution tracks one sequence while its engines run
+type sequenceExecution struct {
+	request   *EngineSequenceRequest
+	result    *EngineSequenceResult
+	index     int                      // Position of the current engine in the sequence
+	previous  *engines.OperationResult // Result of the previous engine
+	readyTick int64                    // Tick the next engine may start
+	started   time.Time                // Wall-clock start of the current engine
+}
+
 // ExecutionMetrics tracks execution performance
 type ExecutionMetrics struct {
 	TotalSequences        int64         `json:"total_sequences"`
@@ -116,7 +147,8 @@ func NewComponentInstanceExecutor(instanceID, componentID string) *ComponentInst
 		isRunning:     false,
 		currentLoad:   0.0,
 		health:        1.0,
-		inputChannel:  make(chan *EngineSequenceRequest, 100),
+		inFlight:      make(map[string]*sequenceExecution),
+		followUps:     make(map[string]bool),
 		outputChannel: make(chan *EngineSequenceResult, 100),
 		metrics: &ExecutionMetrics{
 			EngineSuccessRate: make(map[engines.EngineType]float64),
@@ -136,11 +168,6 @@ func (cie *ComponentInstanceExecutor) Start() error {
 		return fmt.Errorf("component instance executor %s is already running", cie.InstanceID)
 	}
 	
-	log.Printf("ComponentInstanceExecutor %s: Starting execution engine", cie.InstanceID)
-	
-	// Start main execution loop
-	go cie.run()
-	
 	cie.isRunning = true
 	log.Printf("ComponentInstanceExecutor %s: Started successfully", cie.InstanceID)
 	
@@ -156,9 +183,6 @@ func (cie *ComponentInstanceExecutor) Stop() error {
 		return nil
 	}
 	
-	log.Printf("ComponentInstanceExecutor %s: Stopping execution engine", cie.InstanceID)
-	
-	// Cancel context to stop main loop
 	cie.cancel()
 	
 	cie.isRunning = false
@@ -167,215 +191,348 @@ func (cie *ComponentInstanceExecutor) Stop() error {
 	return nil
 }
 
-// RegisterEngine registers an engine with the executor
+// RegisterEngine registers an engine with the executor and subscribes to its results
 func (cie *ComponentInstanceExecutor) RegisterEngine(engineType engines.EngineType, engine *engines.EngineWrapper) error {
 	cie.mutex.Lock()
 	defer cie.mutex.Unlock()
 	
 	cie.engines[engineType] = engine
+	engine.SetResultHandler(func(result *engines.OperationResult) {
+		cie.handleEngineResult(engineType, result)
+	})
 	
 	// Add to engine order if not already present
 	for _, existingType := range cie.engineOrder {
 		if existingType == engineType {
-			log.Printf("ComponentInstanceExecutor %s: Updated engine %s", cie.InstanceID, engineType)
 			return nil
 		}
 	}
 	
 	cie.engineOrder = append(cie.engineOrder, engineType)
-	log.Printf("ComponentInstanceExecutor %s: Registered engine %s", cie.InstanceID, engineType)
 	
 	return nil
 }
 
-// ExecuteSequence executes an engine sequence (external API)
-func (cie *ComponentInstanceExecutor) ExecuteSequence(request *EngineSequenceRequest) error {
-	select {
-	case cie.inputChannel <- request:
-		return nil
-	default:
-		return fmt.Errorf("executor %s input channel is full", cie.InstanceID)
-	}
+// SetCompletionHandler registers the callback that receives completed sequences.
+// It runs on whichever goroutine delivered the final engine result.
+func (cie *ComponentInstanceExecutor) SetCompletionHandler(handler func(*EngineSequenceResult)) {
+	cie.mutex.Lock()
+	defer cie.mutex.Unlock()
+	cie.completionHandler = handler
 }
 
-// GetOutputChannel returns the output channel for results
-func (cie *ComponentInstanceExecutor) GetOutputChannel() <-chan *EngineSequenceResult {
-	return cie.outputChannel
+// SetFollowUpHandler registers the callback that receives completed follow-up
+// operations, which belong to no sequence
+func (cie *ComponentInstanceExecutor) SetFollowUpHandler(handler func(*engines.OperationResult)) {
+	cie.mutex.Lock()
+	defer cie.mutex.Unlock()
+	cie.followUpHandler = handler
 }
 
-// run is the main execution loop
-func (cie *ComponentInstanceExecutor) run() {
-	log.Printf("ComponentInstanceExecutor %s: Starting main execution loop", cie.InstanceID)
-	
-	for {
-		select {
-		case request := <-cie.inputChannel:
-			result := cie.executeEngineSequence(request)
-			
-			// Send result to output channel
-			select {
-			case cie.outputChannel <- result:
-				// Result sent successfully
-			default:
-				log.Printf("ComponentInstanceExecutor %s: Output channel full, dropping result for sequence %s", 
-					cie.InstanceID, request.SequenceID)
-			}
-			
-		case <-cie.ctx.Done():
-			log.Printf("ComponentInstanceExecutor %s: Main execution loop stopping", cie.InstanceID)
-			return
-		}
+// ExecuteSequence submits a sequence to its first engine. An error means the
+// sequence was not started and the completion handler will not see it.
+func (cie *ComponentInstanceExecutor) ExecuteSequence(request *EngineSequenceRequest) error {
+	if len(request.EngineSequence) == 0 {
+		return fmt.Errorf("executor %s: sequence %s has no engines", cie.InstanceID, request.SequenceID)
 	}
-}
-
-// executeEngineSequence executes the provided engine sequence
-func (cie *ComponentInstanceExecutor) executeEngineSequence(request *EngineSequenceRequest) *EngineSequenceResult {
-	startTime := time.Now()
 	
-	log.Printf("ComponentInstanceExecutor %s: Executing sequence %s with %d engines", 
-		cie.InstanceID, request.SequenceID, len(request.EngineSequence))
-	
-	result := &EngineSequenceResult{
-		Request:       request.Request,
-		SequenceID:    request.SequenceID,
-		EngineResults: make([]EngineExecutionResult, 0, len(request.EngineSequence)),
-		Success:       true,
-		StartTime:     startTime,
-		EngineCount:   len(request.EngineSequence),
+	execution := &sequenceExecution{
+		request: request,
+		result: &EngineSequenceResult{
+			Request:       request.Request,
+			Operation:     request.Operation,
+			SequenceID:    request.SequenceID,
+			EngineResults: make([]EngineExecutionResult, 0, len(request.EngineSequence)),
+			Success:       true,
+			StartTime:     time.Now(),
+			StartTick:     request.StartTick,
+			EngineCount:   len(request.EngineSequence),
+		},
+		readyTick: request.StartTick,
 	}
 	
-	// Execute engines in sequence
-	var lastResult *engines.OperationResult
+	cie.mutex.Lock()
+	err := cie.submitLocked(execution)
+	cie.mutex.Unlock()
+	return err
+}
+
+// Advance moves the executor's clock to currentTick and starts engines whose
+// previous engine has completed by then
+func (cie *ComponentInstanceExecutor) Advance(currentTick int64) {
+	var finished []*EngineSequenceResult
 	
-	for i, engineType := range request.EngineSequence {
-		engineResult := cie.executeEngine(engineType, request, lastResult, i)
-		result.EngineResults = append(result.EngineResults, engineResult)
-		
-		// If engine execution failed, stop sequence
-		if !engineResult.Success {
-			result.Success = false
-			result.ErrorMessage = fmt.Sprintf("Engine %s failed: %s", engineType, engineResult.ErrorMessage)
-			break
+	cie.mutex.Lock()
+	cie.currentTick = currentTick
+	waiting := cie.deferred[:0]
+	for _, execution := range cie.deferred {
+		if execution.readyTick > currentTick {
+			waiting = append(waiting, execution)
+			continue
+		}
+		if err := cie.submitLocked(execution); err != nil {
+			finished = append(finished, cie.failLocked(execution, err))
 		}
-		
-		// Use this engine's result as input for next engine
-		lastResult = engineResult.Result
 	}
+	cie.deferred = waiting
+	cie.mutex.Unlock()
 	
-	// Complete result
-	result.EndTime = time.Now()
-	result.TotalLatency = result.EndTime.Sub(result.StartTime)
-	
-	// Update metrics
-	cie.updateMetrics(result)
+	for _, result := range finished {
+		cie.deliver(result)
+	}
+}
+
+// NextEventTick returns the earliest tick a deferred sequence becomes ready
+func (cie *ComponentInstanceExecutor) NextEventTick(currentTick int64) (int64, bool) {
+	cie.mutex.RLock()
+	defer cie.mutex.RUnlock()
 	
-	// Update request with final result
-	if result.Success {
-		request.Request.MarkComplete()
-	} else {
-		request.Request.MarkFailed()
+	next, ok := int64(0), false
+	for _, execution := range cie.deferred {
+		tick := execution.readyTick
+		if tick <= currentTick {
+			tick = currentTick + 1
+		}
+		if !ok || tick < next {
+			next, ok = tick, true
+		}
+	}
+	return next, ok
+}
+
+// InFlight returns the number of sequences that have not completed
+func (cie *ComponentInstanceExecutor) InFlight() int {
+	cie.mutex.RLock()
+	defer cie.mutex.RUnlock()
+	return len(cie.inFlight) + len(cie.deferred)
+}
+
+// GetOutputChannel returns the output channel for results when no completion handler is set
+func (cie *ComponentInstanceExecutor) GetOutputChannel() <-chan *EngineSequenceResult {
+	return cie.outputChannel
+}
+
+// submitLocked queues the sequence's current engine operation. Callers hold the mutex.
+func (cie *ComponentInstanceExecutor) submitLocked(execution *sequenceExecution) error {
+	engineType := execution.request.EngineSequence[execution.index]
+	engine, exists := cie.engines[engineType]
+	if !exists {
+		return fmt.Errorf("engine %s not found", engineType)
 	}
 	
-	log.Printf("ComponentInstanceExecutor %s: Completed sequence %s (success: %t, latency: %v)", 
-		cie.InstanceID, request.SequenceID, result.Success, result.TotalLatency)
+	operation := cie.createOperationForEngine(engineType, execution.request, execution.previous, execution.index)
+	if err := engine.QueueOperation(operation); err != nil {
+		return fmt.Errorf("failed to submit operation: %w", err)
+	}
 	
-	return result
+	execution.started = time.Now()
+	cie.inFlight[operation.ID] = execution
+	return nil
 }
 
-// executeEngine executes a single engine in the sequence
-func (cie *ComponentInstanceExecutor) executeEngine(engineType engines.EngineType, request *EngineSequenceRequest, 
-	previousResult *engines.OperationResult, sequenceIndex int) EngineExecutionResult {
-	
-	startTime := time.Now()
+// failLocked ends a sequence that could not run its next engine. Callers hold the mutex.
+func (cie *ComponentInstanceExecutor) failLocked(execution *sequenceExecution, err error) *EngineSequenceResult {
+	engineType := execution.request.EngineSequence[execution.index]
+	execution.result.Success = false
+	execution.result.ErrorMessage = fmt.Sprintf("Engine %s failed: %s", engineType, err)
+	execution.result.Error = err
+	return cie.finishLocked(execution, cie.currentTick)
+}
+
+// handleEngineResult advances the sequence that owns an engine result
+func (cie *ComponentInstanceExecutor) handleEngineResult(engineType engines.EngineType, result *engines.OperationResult) {
+	// Observers attribute engine results by the engine that produced them
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["engine_type"] = engineType.String()
+
+	cie.mutex.Lock()
+	if cie.followUps[result.OperationID] {
+		delete(cie.followUps, result.OperationID)
+		handler := cie.followUpHandler
+		cie.mutex.Unlock()
+		if handler != nil {
+			handler(result)
+		}
+		return
+	}
+	execution, exists := cie.inFlight[result.OperationID]
+	if !exists {
+		cie.mutex.Unlock()
+		return
+	}
+	delete(cie.inFlight, result.OperationID)
 	
 	engineResult := EngineExecutionResult{
 		EngineType:  engineType,
-		OperationID: fmt.Sprintf("%s_%s_%d", request.SequenceID, engineType, sequenceIndex),
-		StartTime:   startTime,
+		OperationID: result.OperationID,
+		Success:     result.Success,
+		Result:      result,
+		StartTime:   execution.started,
+		EndTime:     time.Now(),
+		Latency:     result.ProcessingTime,
+	}
+	if !result.Success {
+		engineResult.ErrorMessage = "Engine operation failed"
+		if result.ErrorMessage != "" {
+			engineResult.ErrorMessage = result.ErrorMessage
+		}
+		if result.Error != nil {
+			engineResult.ErrorMessage = result.Error.Error()
+		}
 	}
+	execution.result.EngineResults = append(execution.result.EngineResults, engineResult)
 	
-	// Get engine
-	engine, exists := cie.engines[engineType]
-	if !exists {
-		engineResult.Success = false
-		engineResult.ErrorMessage = fmt.Sprintf("Engine %s not found", engineType)
-		engineResult.EndTime = time.Now()
-		engineResult.Latency = engineResult.EndTime.Sub(engineResult.StartTime)
-		return engineResult
+	// Work handed off by this engine (e.g. TLS handshake crypto) runs on the matching engine
+	cie.executeFollowUpOperations(result)
+	
+	if request := execution.request.Request; request != nil {
+		request.SetCurrentPosition(cie.ComponentID, engineType.String(), "executing")
+		request.IncrementEngineCount()
+		request.AddToHistory(cie.ComponentID, engineType.String(), result.OperationType, engineResult.Success)
 	}
 	
-	// Create operation for engine
-	operation := cie.createOperationForEngine(engineType, request, previousResult, sequenceIndex)
+	completedTick := result.CompletedTick
+	if completedTick < cie.currentTick {
+		completedTick = cie.currentTick
+	}
 	
-	// Execute operation on engine
-	result, err := cie.executeOperationOnEngine(engine, operation)
-	if err != nil {
-		engineResult.Success = false
-		engineResult.ErrorMessage = err.Error()
-	} else {
-		engineResult.Success = result.Success
-		engineResult.Result = result
-		if !result.Success {
-			engineResult.ErrorMessage = "Engine operation failed"
+	var finished *EngineSequenceResult
+	switch {
+	case !result.Success:
+		execution.result.Success = false
+		execution.result.ErrorMessage = fmt.Sprintf("Engine %s failed: %s", engineType, engineResult.ErrorMessage)
+		execution.result.Error = result.Error
+		finished = cie.finishLocked(execution, completedTick)
+	case execution.index == len(execution.request.EngineSequence)-1:
+		finished = cie.finishLocked(execution, completedTick)
+	default:
+		// The next engine starts once the clock reaches this engine's completion tick
+		execution.index++
+		execution.previous = result
+		execution.readyTick = completedTick
+		if completedTick > cie.currentTick {
+			cie.deferred = append(cie.deferred, execution)
+		} else if err := cie.submitLocked(execution); err != nil {
+			finished = cie.failLocked(execution, err)
 		}
 	}
+	cie.mutex.Unlock()
 	
-	engineResult.EndTime = time.Now()
-	engineResult.Latency = engineResult.EndTime.Sub(engineResult.StartTime)
+	// Typed engine errors (e.g. unrecoverable packet loss) feed component error handling
+	if !result.Success && result.Error != nil && GlobalErrorHandler != nil {
+		GlobalErrorHandler.HandleError(context.Background(), result.Error, cie.ComponentID)
+	}
 	
-	// Update request position
-	request.Request.SetCurrentPosition(cie.ComponentID, string(engineType), "executing")
-	request.Request.IncrementEngineCount()
+	if finished != nil {
+		cie.deliver(finished)
+	}
+}
+
+// finishLocked completes a sequence at completedTick. Callers hold the mutex.
+func (cie *ComponentInstanceExecutor) finishLocked(execution *sequenceExecution, completedTick int64) *EngineSequenceResult {
+	result := execution.result
+	result.EndTime = time.Now()
+	result.CompletedTick = completedTick
+	if completedTick > result.StartTick {
+		result.TotalLatency = time.Duration(completedTick-result.StartTick) * cie.tickDurationLocked()
+	}
 	
-	// Add to request history if tracking enabled
-	request.Request.AddToHistory(cie.ComponentID, string(engineType), operation.Type, engineResult.Success)
+	cie.updateMetricsLocked(result)
 	
-	return engineResult
+	if request := execution.request.Request; request != nil {
+		if result.Success {
+			request.MarkComplete()
+		} else {
+			request.MarkFailed()
+		}
+	}
+	
+	return result
+}
+
+// tickDurationLocked returns the simulated duration of one tick on this instance's engines
+func (cie *ComponentInstanceExecutor) tickDurationLocked() time.Duration {
+	for _, engineType := range cie.engineOrder {
+		if engine := cie.engines[engineType]; engine != nil {
+			return engine.GetEngine().GetTickDuration()
+		}
+	}
+	return 0
+}
+
+// deliver hands a completed sequence to the completion handler or output channel
+func (cie *ComponentInstanceExecutor) deliver(result *EngineSequenceResult) {
+	cie.mutex.RLock()
+	handler := cie.completionHandler
+	cie.mutex.RUnlock()
+	
+	if handler != nil {
+		handler(result)
+		return
+	}
+	
+	select {
+	case cie.outputChannel <- result:
+	default:
+		log.Printf("ComponentInstanceExecutor %s: Output channel full, dropping result for sequence %s",
+			cie.InstanceID, result.SequenceID)
+	}
 }
 
 // createOperationForEngine creates an operation for a specific engine
 func (cie *ComponentInstanceExecutor) createOperationForEngine(engineType engines.EngineType, 
 	request *EngineSequenceRequest, previousResult *engines.OperationResult, sequenceIndex int) *engines.Operation {
 	
-	// Determine operation type based on engine type and previous result
-	operationType := cie.determineOperationType(engineType, previousResult)
+	operation := &engines.Operation{
+		ID:       fmt.Sprintf("%s_%s_%d", request.SequenceID, engineType, sequenceIndex),
+		Priority: 1, // Default priority
+	}
 	
-	// Determine operation data
-	var operationData interface{}
-	if previousResult != nil {
-		operationData = previousResult.Data
-	} else {
-		operationData = request.Request.Data.Payload
+	// Carry the request, the operation's metadata and the sequence parameters with the operation
+	metadata := make(map[string]interface{}, len(request.Parameters)+1)
+	if source := request.Operation; source != nil {
+		for key, value := range source.Metadata {
+			metadata[key] = value
+		}
+		operation.Type = source.Type
+		operation.DataSize = source.DataSize
+		operation.Complexity = source.Complexity
+		operation.Language = source.Language
+		operation.Priority = source.Priority
+		operation.Deadline = source.Deadline
+	}
+	for key, value := range request.Parameters {
+		metadata[key] = value
 	}
+	metadata["request"] = request.Request
+	operation.Metadata = metadata
 	
-	return &engines.Operation{
-		ID:          fmt.Sprintf("%s_%s_%d", request.SequenceID, engineType, sequenceIndex),
-		Type:        operationType,
-		Data:        operationData,
-		RequestID:   request.Request.ID,
-		ComponentID: cie.ComponentID,
-		Priority:    1, // Default priority
-		Timestamp:   time.Now(),
-		Metadata:    request.Parameters,
+	// Without a source operation, derive the type from the engine and previous result
+	if operation.Type == "" {
+		operation.Type = cie.determineOperationType(engineType, previousResult)
 	}
+	
+	return operation
 }
 
 // determineOperationType determines the operation type for an engine
 func (cie *ComponentInstanceExecutor) determineOperationType(engineType engines.EngineType, previousResult *engines.OperationResult) string {
 	switch engineType {
-	case engines.CPUEngine:
+	case engines.CPUEngineType:
 		if previousResult != nil {
 			return "process_data"
 		}
 		return "parse_request"
 		
-	case engines.MemoryEngine:
+	case engines.MemoryEngineType:
 		if previousResult != nil && previousResult.Success {
 			return "cache_store"
 		}
 		return "cache_lookup"
 		
-	case engines.StorageEngine:
+	case engines.StorageEngineType:
 		if previousResult != nil && previousResult.Success {
 			return "data_write"
 		}
@@ -386,35 +543,51 @@ func (cie *ComponentInstanceExecutor) determineOperationType(engineType engines.
 	}
 }
 
-// executeOperationOnEngine executes an operation on a specific engine
-func (cie *ComponentInstanceExecutor) executeOperationOnEngine(engine *engines.EngineWrapper, operation *engines.Operation) (*engines.OperationResult, error) {
-	// Submit operation to engine
-	if err := engine.SubmitOperation(operation); err != nil {
-		return nil, fmt.Errorf("failed to submit operation: %w", err)
+// executeFollowUpOperations queues operations an engine handed to other engines
+// on this instance, skipping engine types the instance does not have. Callers hold the mutex.
+func (cie *ComponentInstanceExecutor) executeFollowUpOperations(result *engines.OperationResult) {
+	for _, followUp := range result.FollowUpOperations {
+		var engine *engines.EngineWrapper
+		for _, engineType := range followUpEngineTypes(followUp.Type) {
+			if candidate, exists := cie.engines[engineType]; exists {
+				engine = candidate
+				break
+			}
+		}
+		if engine == nil {
+			continue
+		}
+		if err := engine.QueueOperation(followUp); err != nil {
+			log.Printf("ComponentInstanceExecutor %s: Follow-up operation %s failed: %v",
+				cie.InstanceID, followUp.ID, err)
+			continue
+		}
+		cie.followUps[followUp.ID] = true
 	}
-	
-	// Wait for result (simplified - in real implementation would use channels)
-	// For now, simulate engine execution
-	time.Sleep(10 * time.Millisecond) // Simulate processing time
-	
-	// Create mock result (in real implementation, would get from engine)
-	result := &engines.OperationResult{
-		OperationID:   operation.ID,
-		OperationType: operation.Type,
-		Success:       true,
-		Data:          operation.Data,
-		Timestamp:     time.Now(),
-		Metadata:      make(map[string]interface{}),
+}
+
+// followUpEngineTypes maps an operation type to the engines that can process it,
+// in order of preference. Duplex components split the network into a receive
+// and a send side; components with a single network engine use it for both.
+func followUpEngineTypes(operationType string) []engines.EngineType {
+	switch {
+	case strings.HasPrefix(operationType, "cpu_"):
+		return []engines.EngineType{engines.CPUEngineType}
+	case strings.HasPrefix(operationType, "memory_"):
+		return []engines.EngineType{engines.MemoryEngineType}
+	case strings.HasPrefix(operationType, "storage_"):
+		return []engines.EngineType{engines.StorageEngineType}
+	case operationType == engines.OpNetworkRecv || operationType == engines.OpNetworkConn:
+		return []engines.EngineType{engines.NetworkInEngineType, engines.NetworkEngineType}
+	case strings.HasPrefix(operationType, "network_"):
+		return []engines.EngineType{engines.NetworkOutEngineType, engines.NetworkEngineType}
+	default:
+		return nil
 	}
-	
-	return result, nil
 }
 
-// updateMetrics updates execution metrics
-func (cie *ComponentInstanceExecutor) updateMetrics(result *EngineSequenceResult) {
-	cie.mutex.Lock()
-	defer cie.mutex.Unlock()
-	
+// updateMetricsLocked updates execution metrics. Callers hold the mutex.
+func (cie *ComponentInstanceExecutor) updateMetricsLocked(result *EngineSequenceResult) {
 	cie.metrics.TotalSequences++
 	
 	if result.Success {
@@ -441,7 +614,7 @@ func (cie *ComponentInstanceExecutor) updateMetrics(result *EngineSequenceResult
 	}
 	
 	// Update engine-specific metrics
-	cie.metrics.TotalEngineExecutions += int64(result.EngineCount)
+	cie.metrics.TotalEngineExecutions += int64(len(result.EngineResults))
 	
 	for _, engineResult := range result.EngineResults {
 		currentRate := cie.metrics.EngineSuccessRate[engineResult.EngineType]
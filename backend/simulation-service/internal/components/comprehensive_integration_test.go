@@ -42,6 +42,7 @@ func TestComprehensiveIntegration_WeightedLoadBalancingWithFallback(t *testing.T
 		EngineProfiles: map[engines.EngineType]string{
 			engines.CPUEngineType: "Web Server CPU",
 		},
+		ProfilesPath:     "../../profiles",
 		MaxConcurrentOps: 10,
 		QueueCapacity:    20,
 	}
@@ -165,6 +166,7 @@ func TestComprehensiveIntegration_ErrorHandlingAndRecovery(t *testing.T) {
 		EngineProfiles: map[engines.EngineType]string{
 			engines.MemoryEngineType: "ddr5_6400_server",
 		},
+		ProfilesPath:     "../../profiles",
 		MaxConcurrentOps: 2, // Small capacity to trigger errors
 		QueueCapacity:    3,  // Small queue to trigger overload
 	}
@@ -271,6 +273,7 @@ func TestComprehensiveIntegration_PenaltyBasedRouting(t *testing.T) {
 		EngineProfiles: map[engines.EngineType]string{
 			engines.StorageEngineType: "Samsung 980 PRO 1TB NVMe SSD",
 		},
+		ProfilesPath:     "../../profiles",
 		MaxConcurrentOps: 5,
 		QueueCapacity:    10,
 	}
@@ -447,6 +450,7 @@ func TestComprehensiveIntegration_FullSystemWorkflow(t *testing.T) {
 			engines.NetworkEngineType: "Gigabit Ethernet LAN",
 			engines.CPUEngineType:     "Web Server CPU",
 		},
+		ProfilesPath:     "../../profiles",
 		MaxConcurrentOps: 10,
 		QueueCapacity:    20,
 	}
@@ -475,6 +479,7 @@ func TestComprehensiveIntegration_FullSystemWorkflow(t *testing.T) {
 			engines.StorageEngineType: "Samsung 980 PRO 1TB NVMe SSD",
 			engines.CPUEngineType:     "Compute Server CPU",
 		},
+		ProfilesPath:     "../../profiles",
 		MaxConcurrentOps: 8,
 		QueueCapacity:    15,
 	}
@@ -22,11 +22,12 @@ type DecisionGraph struct {
 
 	// Routing channels for dynamic decisions
 	EngineOutputChannels map[engines.EngineType]chan *engines.OperationResult `json:"-"`
-	DecisionChannel      chan *RoutingDecision                                 `json:"-"`
+	DecisionChannel      chan *EngineRoutingDecision                           `json:"-"`
 
 	// Component context
 	ComponentID   string        `json:"component_id"`
 	InstanceID    string        `json:"instance_id"`
+	Seed          int64         `json:"seed"` // Seeds probability-based routing (0 = unseeded)
 
 	// Enhanced routing features
 	ProbabilityEngine *ProbabilityEngine `json:"-"` // For probability-based routing
@@ -36,9 +37,6 @@ type DecisionGraph struct {
 	// State tracking
 	mutex         sync.RWMutex  `json:"-"`
 	isRunning     bool          `json:"-"`
-
-	// Performance tracking
-	DecisionStats map[string]*DecisionStats `json:"-"`
 }
 
 // NewDecisionGraph creates a new decision graph from configuration
@@ -175,7 +173,7 @@ func (dg *DecisionGraph) processProbabilityBasedRouting(node *DecisionNode, op *
 	}
 
 	if dg.ProbabilityEngine == nil {
-		dg.ProbabilityEngine = NewProbabilityEngine()
+		dg.ProbabilityEngine = NewSeededProbabilityEngine(dg.Seed)
 	}
 
 	// Generate routing decision based on probability configuration
@@ -197,7 +195,7 @@ func (dg *DecisionGraph) processStateBasedRouting(node *DecisionNode, op *engine
 	}
 
 	if dg.StateMonitor == nil {
-		dg.StateMonitor = NewStateMonitor(dg.ComponentID)
+		dg.StateMonitor = NewSeededStateMonitor(dg.ComponentID, engines.DeriveSeed(dg.Seed, "state_monitor"))
 	}
 
 	// Get current system state
@@ -6,8 +6,6 @@ import (
 	"log"
 	"sync"
 	"time"
-
-	"github.com/systemsim/simulation-service/internal/engines"
 )
 
 // EndNodeSystem manages end nodes for request completion, cleanup, and error handling
@@ -64,15 +62,15 @@ type EndNode struct {
 	processor       RequestProcessor  `json:"-"`
 	
 	// Metrics
-	metrics         *EndNodeMetrics   `json:"metrics"`
+	metrics         *EndNodeMetrics   `json:"-"`
 	
 	// Configuration
-	config          *EndNodeConfig    `json:"config"`
+	config          *EndNodeConfig    `json:"-"`
 	
 	// Lifecycle
 	ctx             context.Context   `json:"-"`
 	cancel          context.CancelFunc `json:"-"`
-	isRunning       bool              `json:"is_running"`
+	isRunning       bool              `json:"-"`
 	mutex           sync.RWMutex      `json:"-"`
 }
 
@@ -348,7 +346,7 @@ func (ens *EndNodeSystem) routeBasedOnStatus(request *Request) error {
 	case RequestStatusFailed:
 		return ens.errorEndNode.ProcessRequest(request)
 	default:
-		return fmt.Errorf("cannot route request %s with status %s to end node", request.ID, request.Status)
+		return fmt.Errorf("cannot route request %s with status %d to end node", request.ID, request.Status)
 	}
 }
 
@@ -37,6 +37,9 @@ type EngineOutputQueue struct {
 
 	// Metrics
 	RoutingMetrics *EngineOutputMetrics `json:"-"`
+
+	// Source for probability-based routing; only used from the run goroutine
+	random *rand.Rand
 }
 
 // EngineOutputRequest represents a request with engine result for routing
@@ -74,9 +77,15 @@ func NewEngineOutputQueue(engineType engines.EngineType, componentID, instanceID
 		ctx:            ctx,
 		cancel:         cancel,
 		RoutingMetrics: &EngineOutputMetrics{},
+		random:         engines.NewSeededRand(0),
 	}
 }
 
+// SetSeed makes probability-based routing reproducible (must be called before Start)
+func (eoq *EngineOutputQueue) SetSeed(seed int64) {
+	eoq.random = engines.NewSeededRand(seed)
+}
+
 // Start starts the engine output queue processing
 func (eoq *EngineOutputQueue) Start() {
 	log.Printf("EngineOutputQueue %s-%s: Starting engine output queue", eoq.ComponentID, eoq.EngineType)
@@ -120,15 +129,15 @@ func (eoq *EngineOutputQueue) handleAllRouting(request *EngineOutputRequest) err
 	}()
 
 	// Update request position
-	request.Request.SetCurrentPosition(eoq.ComponentID, string(eoq.EngineType), request.Request.CurrentNode)
+	request.Request.SetCurrentPosition(eoq.ComponentID, eoq.EngineType.String(), request.Request.CurrentNode)
 	request.Request.IncrementEngineCount()
 
 	// Add to history if tracking enabled
-	request.Request.AddToHistory(eoq.ComponentID, string(eoq.EngineType), 
+	request.Request.AddToHistory(eoq.ComponentID, eoq.EngineType.String(), 
 		request.EngineResult.OperationType, request.EngineResult.Success)
 
 	// Register with timeout manager
-	eoq.TimeoutManager.RegisterRequest(request.Request.ID, eoq.ComponentID, string(eoq.EngineType))
+	eoq.TimeoutManager.RegisterRequest(request.Request.ID, eoq.ComponentID, eoq.EngineType.String())
 
 	// 1. Look up component-level graph for routing decision
 	componentGraph := eoq.ComponentLB.GetComponentGraph()
@@ -208,9 +217,9 @@ func (eoq *EngineOutputQueue) evaluateCondition(condition string, request *Engin
 
 	switch condition {
 	case "cache_hit":
-		return result.Success && result.Data != nil
+		return result.Success && isCacheHit(result)
 	case "cache_miss":
-		return !result.Success || result.Data == nil
+		return !result.Success || !isCacheHit(result)
 	case "parse_success":
 		return result.Success
 	case "parse_failure":
@@ -241,7 +250,7 @@ func (eoq *EngineOutputQueue) evaluateProbabilityBasedRouting(node *DecisionNode
 	}
 
 	// Generate random number for probability decision
-	randomValue := rand.Float64()
+	randomValue := eoq.random.Float64()
 
 	switch request.EngineResult.OperationType {
 	case "cache_lookup":
@@ -301,7 +310,7 @@ func (eoq *EngineOutputQueue) evaluateCurrentStateCondition(condition string, re
 // Routing destination type checks
 func (eoq *EngineOutputQueue) isInternalEngine(destination string) bool {
 	// Check if destination is an engine type within the same component
-	engineTypes := []string{"cpu", "memory", "storage", "network"}
+	engineTypes := []string{"cpu", "memory", "storage", "network", "network_in", "network_out", "coordination"}
 	for _, engineType := range engineTypes {
 		if destination == engineType {
 			return true
@@ -355,13 +364,13 @@ func (eoq *EngineOutputQueue) routeToExternalComponent(componentID string, reque
 
 	// Convert to operation for target component
 	operation := &engines.Operation{
-		ID:            request.Request.ID,
-		Type:          request.Request.Data.Operation,
-		Data:          request.Request.Data.Payload,
-		RequestID:     request.Request.ID,
-		ComponentID:   componentID,
-		Priority:      1, // Default priority
-		Timestamp:     time.Now(),
+		ID:       request.Request.ID,
+		Type:     request.Request.Data.Operation,
+		Priority: 1, // Default priority
+		Metadata: map[string]interface{}{
+			"request": request.Request,
+			"payload": request.Request.Data.Payload,
+		},
 	}
 
 	// Route to target component
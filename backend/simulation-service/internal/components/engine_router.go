@@ -50,37 +50,56 @@ func NewEngineRouter(componentType ComponentType, availableEngines []engines.Eng
 
 // setupDefaultRouting sets up default routing rules based on component type
 func (er *EngineRouter) setupDefaultRouting() {
+	input, output := er.inputEngine(), er.outputEngine()
+
 	switch er.componentType {
 	case ComponentTypeWebServer:
 		// Web server: Network → CPU → Memory → Network (output)
-		er.defaultRouting[engines.NetworkEngineType] = engines.CPUEngineType
+		er.defaultRouting[input] = engines.CPUEngineType
 		er.defaultRouting[engines.CPUEngineType] = engines.MemoryEngineType
-		er.defaultRouting[engines.MemoryEngineType] = engines.NetworkEngineType
+		er.defaultRouting[engines.MemoryEngineType] = output
 
 	case ComponentTypeDatabase:
 		// Database: Network → CPU → Storage → Memory → Network (output)
-		er.defaultRouting[engines.NetworkEngineType] = engines.CPUEngineType
+		er.defaultRouting[input] = engines.CPUEngineType
 		er.defaultRouting[engines.CPUEngineType] = engines.StorageEngineType
 		er.defaultRouting[engines.StorageEngineType] = engines.MemoryEngineType
-		er.defaultRouting[engines.MemoryEngineType] = engines.NetworkEngineType
+		er.defaultRouting[engines.MemoryEngineType] = output
 
 	case ComponentTypeCache:
 		// Cache: Network → Memory → Network (output)
-		er.defaultRouting[engines.NetworkEngineType] = engines.MemoryEngineType
-		er.defaultRouting[engines.MemoryEngineType] = engines.NetworkEngineType
+		er.defaultRouting[input] = engines.MemoryEngineType
+		er.defaultRouting[engines.MemoryEngineType] = output
 
 	case ComponentTypeLoadBalancer:
 		// Load balancer: Network → CPU → Network (output)
-		er.defaultRouting[engines.NetworkEngineType] = engines.CPUEngineType
-		er.defaultRouting[engines.CPUEngineType] = engines.NetworkEngineType
+		er.defaultRouting[input] = engines.CPUEngineType
+		er.defaultRouting[engines.CPUEngineType] = output
 
 	default:
 		// Generic: Network → CPU → Memory → Storage → Network (output)
-		er.defaultRouting[engines.NetworkEngineType] = engines.CPUEngineType
+		er.defaultRouting[input] = engines.CPUEngineType
 		er.defaultRouting[engines.CPUEngineType] = engines.MemoryEngineType
 		er.defaultRouting[engines.MemoryEngineType] = engines.StorageEngineType
-		er.defaultRouting[engines.StorageEngineType] = engines.NetworkEngineType
+		er.defaultRouting[engines.StorageEngineType] = output
+	}
+}
+
+// inputEngine returns the engine operations arrive on: Network-In when the instance
+// models each side of the link separately, otherwise the full-duplex network engine
+func (er *EngineRouter) inputEngine() engines.EngineType {
+	if er.availableEngines[engines.NetworkInEngineType] {
+		return engines.NetworkInEngineType
+	}
+	return engines.NetworkEngineType
+}
+
+// outputEngine returns the engine responses leave through
+func (er *EngineRouter) outputEngine() engines.EngineType {
+	if er.availableEngines[engines.NetworkOutEngineType] {
+		return engines.NetworkOutEngineType
 	}
+	return engines.NetworkEngineType
 }
 
 // MakeRoutingDecision determines where an operation should go next after processing by an engine
@@ -147,7 +166,7 @@ func (er *EngineRouter) MakeRoutingDecision(
 
 	// 7. Route to output (end of processing)
 	return &EngineRoutingDecision{
-		NextEngine:    engines.NetworkEngineType, // Always use network for output
+		NextEngine:    er.outputEngine(), // Always use network for output
 		RouteToOutput: true,
 		Reason:        "end_of_processing",
 		Metadata:      map[string]interface{}{"source": "fallback"},
@@ -164,7 +183,7 @@ func (er *EngineRouter) makeOperationTypeDecision(
 	switch op.Type {
 	case "read_request":
 		// Read requests: try cache first (memory), then storage if cache miss
-		if currentEngine == engines.NetworkEngineType {
+		if currentEngine == er.inputEngine() {
 			if er.availableEngines[engines.MemoryEngineType] {
 				return &EngineRoutingDecision{
 					NextEngine:    engines.MemoryEngineType,
@@ -190,7 +209,7 @@ func (er *EngineRouter) makeOperationTypeDecision(
 
 	case "write_request":
 		// Write requests: CPU processing, then storage, then memory (cache update)
-		if currentEngine == engines.NetworkEngineType && er.availableEngines[engines.CPUEngineType] {
+		if currentEngine == er.inputEngine() && er.availableEngines[engines.CPUEngineType] {
 			return &EngineRoutingDecision{
 				NextEngine:    engines.CPUEngineType,
 				RouteToOutput: false,
@@ -201,7 +220,7 @@ func (er *EngineRouter) makeOperationTypeDecision(
 
 	case "compute_request":
 		// Compute requests: CPU intensive, may need memory
-		if currentEngine == engines.NetworkEngineType && er.availableEngines[engines.CPUEngineType] {
+		if currentEngine == er.inputEngine() && er.availableEngines[engines.CPUEngineType] {
 			return &EngineRoutingDecision{
 				NextEngine:    engines.CPUEngineType,
 				RouteToOutput: false,
@@ -257,7 +276,7 @@ func (er *EngineRouter) makePriorityDecision(
 	
 	// High priority operations (priority > 7) can skip some processing stages
 	if op.Priority > 7 {
-		if currentEngine == engines.NetworkEngineType && er.availableEngines[engines.MemoryEngineType] {
+		if currentEngine == er.inputEngine() && er.availableEngines[engines.MemoryEngineType] {
 			return &EngineRoutingDecision{
 				NextEngine:    engines.MemoryEngineType,
 				RouteToOutput: false,
@@ -281,6 +300,12 @@ func (er *EngineRouter) parseEngineType(engineStr string) (engines.EngineType, b
 		return engines.StorageEngineType, true
 	case "network", "network_engine":
 		return engines.NetworkEngineType, true
+	case "network_in", "network_in_engine":
+		return engines.NetworkInEngineType, true
+	case "network_out", "network_out_engine":
+		return engines.NetworkOutEngineType, true
+	case "coordination", "coordination_engine":
+		return engines.CoordinationEngineType, true
 	default:
 		return engines.EngineType(0), false
 	}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"log"
 	"sync"
-	"sync/atomic"
-	"time"
 
 	"github.com/systemsim/simulation-service/internal/engines"
 )
@@ -23,19 +21,19 @@ type EnhancedComponentLoadBalancer struct {
 
 	// Instance management
 	instances        map[string]*ComponentInstance `json:"-"`
-	nextInstanceID   int                           `json:"next_instance_id"`
-	instanceHealth   map[string]float64            `json:"instance_health"`
+	nextInstanceID   int                           `json:"-"`
+	instanceHealth   map[string]float64            `json:"-"`
 
 	// Load balancing configuration
-	algorithm        LoadBalancingAlgorithm `json:"algorithm"`
-	autoScalingConfig *AutoScalingConfig    `json:"auto_scaling_config"`
+	algorithm        LoadBalancingAlgorithm `json:"-"`
+	autoScalingConfig *AutoScalingConfig    `json:"-"`
 
 	// Load balancing state
-	roundRobinIndex int `json:"round_robin_index"`
+	roundRobinIndex int `json:"-"`
 
 	// Visibility management (key enhancement)
-	isVisible        bool   `json:"is_visible"`
-	visibilityReason string `json:"visibility_reason"`
+	isVisible        bool   `json:"-"`
+	visibilityReason string `json:"-"`
 
 	// Communication channels
 	inputChannel  chan *engines.Operation `json:"-"`
@@ -45,12 +43,12 @@ type EnhancedComponentLoadBalancer struct {
 	globalRegistry GlobalRegistryInterface `json:"-"`
 
 	// Metrics
-	metrics *LoadBalancerMetrics `json:"metrics"`
+	metrics *LoadBalancerMetrics `json:"-"`
 
 	// Lifecycle management
 	ctx     context.Context    `json:"-"`
 	cancel  context.CancelFunc `json:"-"`
-	running bool               `json:"running"`
+	running bool               `json:"-"`
 	mutex   sync.RWMutex       `json:"-"`
 }
 
@@ -273,14 +271,15 @@ func (eclb *EnhancedComponentLoadBalancer) createInstance() error {
 	eclb.nextInstanceID++
 
 	// Create instance (simplified for now)
-	instance := &ComponentInstance{
+	instance, err := NewComponentInstance(&ComponentConfig{
 		ID:            instanceID,
-		ComponentID:   eclb.ComponentID,
-		Health:        1.0,
-		InputChannel:  make(chan *engines.Operation, 100),
-		OutputChannel: make(chan *engines.Operation, 100),
-		Engines:       make(map[engines.EngineType]*Engine),
+		Type:          eclb.ComponentType,
+		QueueCapacity: 100,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create instance %s: %w", instanceID, err)
 	}
+	instance.ComponentID = eclb.ComponentID
 
 	// Add to instances map
 	eclb.instances[instanceID] = instance
@@ -369,8 +368,11 @@ func (eclb *EnhancedComponentLoadBalancer) hybridSelect() (*ComponentInstance, e
 	for instanceID, instance := range eclb.instances {
 		// Composite score: 50% health + 30% load + 20% connections
 		healthScore := eclb.instanceHealth[instanceID] * 0.5
-		loadScore := (1.0 - instance.CurrentLoad) * 0.3
-		connectionScore := (1.0 - float64(instance.ActiveConnections)/float64(instance.MaxConnections)) * 0.2
+		loadScore := (1.0 - instance.GetHealth().CurrentCPU) * 0.3
+		connectionScore := 0.2
+		if queueCapacity := cap(instance.InputChannel); queueCapacity > 0 {
+			connectionScore = (1.0 - float64(len(instance.InputChannel))/float64(queueCapacity)) * 0.2
+		}
 
 		totalScore := healthScore + loadScore + connectionScore
 
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"hash/fnv"
 	"math/rand"
 	"sync"
 	"time"
@@ -16,8 +17,14 @@ type ProbabilityEngine struct {
 
 // NewProbabilityEngine creates a new probability engine
 func NewProbabilityEngine() *ProbabilityEngine {
+	return NewSeededProbabilityEngine(0)
+}
+
+// NewSeededProbabilityEngine creates a probability engine whose decisions are
+// reproducible for a non-zero seed
+func NewSeededProbabilityEngine(seed int64) *ProbabilityEngine {
 	return &ProbabilityEngine{
-		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+		random: engines.NewSeededRand(seed),
 	}
 }
 
@@ -75,6 +82,7 @@ type StateMonitor struct {
 	mutex         sync.RWMutex
 	updateTicker  *time.Ticker
 	stopChan      chan struct{}
+	random        *rand.Rand // Drives simulated state changes, used under mutex
 }
 
 // SystemState represents the current state of the system
@@ -89,6 +97,12 @@ type SystemState struct {
 
 // NewStateMonitor creates a new state monitor
 func NewStateMonitor(componentID string) *StateMonitor {
+	return NewSeededStateMonitor(componentID, 0)
+}
+
+// NewSeededStateMonitor creates a state monitor whose simulated state changes
+// are reproducible for a non-zero seed
+func NewSeededStateMonitor(componentID string, seed int64) *StateMonitor {
 	sm := &StateMonitor{
 		componentID: componentID,
 		currentState: &SystemState{
@@ -101,6 +115,7 @@ func NewStateMonitor(componentID string) *StateMonitor {
 		},
 		updateTicker: time.NewTicker(5 * time.Second), // Update every 5 seconds
 		stopChan:     make(chan struct{}),
+		random:       engines.NewSeededRand(seed),
 	}
 
 	// Start monitoring goroutine
@@ -171,7 +186,7 @@ func (sm *StateMonitor) simulateSystemLoad(now time.Time) float64 {
 	}
 
 	// Add random variation (±20%)
-	variation := (rand.Float64() - 0.5) * 0.4
+	variation := (sm.random.Float64() - 0.5) * 0.4
 	load := baseLoad + variation
 
 	// Clamp to valid range
@@ -191,12 +206,12 @@ func (sm *StateMonitor) simulateMemoryUsage() float64 {
 	currentUsage := sm.currentState.MemoryUsage
 	
 	// Small random changes (±5%)
-	change := (rand.Float64() - 0.5) * 0.1
+	change := (sm.random.Float64() - 0.5) * 0.1
 	newUsage := currentUsage + change
 
 	// Occasional spikes (5% chance of significant increase)
-	if rand.Float64() < 0.05 {
-		newUsage += rand.Float64() * 0.3
+	if sm.random.Float64() < 0.05 {
+		newUsage += sm.random.Float64() * 0.3
 	}
 
 	// Clamp to valid range
@@ -214,13 +229,13 @@ func (sm *StateMonitor) simulateMemoryUsage() float64 {
 func (sm *StateMonitor) simulateStorageLatency() float64 {
 	// Base latency with random variation
 	baseLatency := 20.0 // 20ms base
-	variation := (rand.Float64() - 0.5) * 30.0 // ±15ms variation
+	variation := (sm.random.Float64() - 0.5) * 30.0 // ±15ms variation
 	
 	latency := baseLatency + variation
 
 	// Occasional slow operations (10% chance)
-	if rand.Float64() < 0.1 {
-		latency += rand.Float64() * 100.0 // Add up to 100ms
+	if sm.random.Float64() < 0.1 {
+		latency += sm.random.Float64() * 100.0 // Add up to 100ms
 	}
 
 	if latency < 1.0 {
@@ -234,13 +249,13 @@ func (sm *StateMonitor) simulateStorageLatency() float64 {
 func (sm *StateMonitor) simulateNetworkLatency() float64 {
 	// Base latency with random variation
 	baseLatency := 15.0 // 15ms base
-	variation := (rand.Float64() - 0.5) * 20.0 // ±10ms variation
+	variation := (sm.random.Float64() - 0.5) * 20.0 // ±10ms variation
 	
 	latency := baseLatency + variation
 
 	// Occasional network congestion (8% chance)
-	if rand.Float64() < 0.08 {
-		latency += rand.Float64() * 80.0 // Add up to 80ms
+	if sm.random.Float64() < 0.08 {
+		latency += sm.random.Float64() * 80.0 // Add up to 80ms
 	}
 
 	if latency < 1.0 {
@@ -271,9 +286,11 @@ type CustomRoutingFunc func(node *DecisionNode, op *engines.Operation, graph *De
 // LoadBalancedRouting routes based on current load across multiple destinations
 func LoadBalancedRouting(node *DecisionNode, op *engines.Operation, graph *DecisionGraph) (string, error) {
 	// This would implement load-balanced routing logic
-	// For now, return a simple round-robin approach
+	// For now, spread operations by ID so the choice is reproducible across runs
 	destinations := []string{"destination_1", "destination_2", "destination_3"}
-	index := int(time.Now().UnixNano()) % len(destinations)
+	hasher := fnv.New32a()
+	hasher.Write([]byte(op.ID))
+	index := int(hasher.Sum32() % uint32(len(destinations)))
 	return destinations[index], nil
 }
 
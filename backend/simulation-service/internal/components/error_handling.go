@@ -2,6 +2,7 @@ package components
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"runtime"
@@ -183,7 +184,28 @@ func (eh *ErrorHandler) categorizeError(err error, componentID string) *Componen
 	code := "unknown_error"
 	
 	// Pattern matching for categorization
+	var networkErr *engines.NetworkError
+	var memoryErr *engines.MemoryError
+	var storageErr *engines.StorageError
 	switch {
+	case errors.As(err, &networkErr):
+		// Lost packets the network engine could not recover: a flaky link should trip circuit breakers
+		category = ErrorCategoryNetwork
+		severity = ErrorSeverityHigh
+		code = string(networkErr.Code)
+
+	case errors.As(err, &memoryErr):
+		// The OOM killer ended the process: the instance is down until it restarts
+		category = ErrorCategoryResource
+		severity = ErrorSeverityCritical
+		code = string(memoryErr.Code)
+
+	case errors.As(err, &storageErr):
+		// A full device fails writes with ENOSPC, the storage_full failure
+		category = ErrorCategoryResource
+		severity = ErrorSeverityHigh
+		code = string(storageErr.Code)
+
 	case containsAny(errorMsg, []string{"timeout", "deadline exceeded", "context deadline exceeded"}):
 		category = ErrorCategoryTimeout
 		severity = ErrorSeverityMedium
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestComponentError_Creation(t *testing.T) {
@@ -362,3 +363,42 @@ func TestWrapError(t *testing.T) {
 		t.Errorf("Expected operation ID to be updated to 'test-op', got %s", wrapped.OperationID)
 	}
 }
+
+func TestSeededRecoveryAndStateSimulation(t *testing.T) {
+	recoveries := func(seed int64) []bool {
+		handler := NewRecoveryHandler(&TimeoutErrorConfig{Seed: seed}, nil)
+		outcomes := make([]bool, 0, 40)
+		for i := 0; i < 10; i++ {
+			for _, errorType := range []string{"timeout", "network_loss", "high_latency", "other"} {
+				outcomes = append(outcomes, handler.simulateRecovery(errorType))
+			}
+		}
+		return outcomes
+	}
+	first, second := recoveries(7), recoveries(7)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same recovery outcomes for the same seed, differ at %d", i)
+		}
+	}
+
+	states := func(seed int64) []SystemState {
+		monitor := NewSeededStateMonitor("test-component", seed)
+		defer monitor.Stop()
+		result := make([]SystemState, 0, 10)
+		for i := 0; i < 10; i++ {
+			monitor.updateState()
+			state := monitor.GetCurrentState()
+			state.LastUpdate = time.Time{}
+			state.SystemLoad = 0 // Follows the time of day
+			result = append(result, *state)
+		}
+		return result
+	}
+	firstStates, secondStates := states(7), states(7)
+	for i := range firstStates {
+		if firstStates[i] != secondStates[i] {
+			t.Fatalf("Expected the same simulated states for the same seed, got %+v and %+v", firstStates[i], secondStates[i])
+		}
+	}
+}
@@ -47,6 +47,11 @@ func (cf *ComponentFactory) CreateComponent(componentType ComponentType, compone
 		config.LoadBalancer = cf.createDefaultLoadBalancerConfig(componentType)
 	}
 
+	// Instances load their engine profiles from the factory's profiles directory
+	if config.ProfilesPath == "" {
+		config.ProfilesPath = cf.profilesPath
+	}
+
 	// Create load balancer with component configuration
 	loadBalancer, err := NewLoadBalancer(config)
 	if err != nil {
@@ -61,6 +66,22 @@ func (cf *ComponentFactory) CreateComponent(componentType ComponentType, compone
 	return loadBalancer, nil
 }
 
+// LoadComponentConfig returns the profile configuration for a component type without
+// creating the component, so callers can adjust it before CreateComponentFromConfig
+func (cf *ComponentFactory) LoadComponentConfig(componentType ComponentType, componentID string) (*ComponentConfig, error) {
+	config, err := cf.loadComponentProfile(componentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load component profile: %w", err)
+	}
+
+	config.ID = componentID
+	if config.LoadBalancer == nil {
+		config.LoadBalancer = cf.createDefaultLoadBalancerConfig(componentType)
+	}
+
+	return config, nil
+}
+
 // CreateComponentFromConfig creates a component from a configuration
 func (cf *ComponentFactory) CreateComponentFromConfig(config *ComponentConfig) (*LoadBalancer, error) {
 	// Add default load balancer configuration if not present
@@ -68,6 +89,11 @@ func (cf *ComponentFactory) CreateComponentFromConfig(config *ComponentConfig) (
 		config.LoadBalancer = cf.createDefaultLoadBalancerConfig(config.Type)
 	}
 
+	// Instances load their engine profiles from the factory's profiles directory
+	if config.ProfilesPath == "" {
+		config.ProfilesPath = cf.profilesPath
+	}
+
 	// Create load balancer with component configuration
 	loadBalancer, err := NewLoadBalancer(config)
 	if err != nil {
@@ -125,7 +151,7 @@ func (cf *ComponentFactory) createDefaultProfile(componentType ComponentType) *C
 		MaxConcurrentOps: 10,
 		QueueCapacity:    100,
 		TickTimeout:      time.Millisecond * 10,
-		EngineProfiles:   make(map[engines.EngineType]string),
+		EngineProfiles:   make(map[engines.EngineType]string), // Engines without one use their type's default profile
 		ComplexityLevels: make(map[engines.EngineType]int),
 		LoadBalancer:     cf.createDefaultLoadBalancerConfig(componentType),
 	}
@@ -133,59 +159,51 @@ func (cf *ComponentFactory) createDefaultProfile(componentType ComponentType) *C
 	switch componentType {
 	case ComponentTypeDatabase:
 		baseConfig.RequiredEngines = []engines.EngineType{
-			engines.NetworkEngineType, // Input
+			engines.NetworkInEngineType,
 			engines.CPUEngineType,
 			engines.MemoryEngineType,
 			engines.StorageEngineType,
-			engines.NetworkEngineType, // Output
+			engines.NetworkOutEngineType,
 		}
-		baseConfig.EngineProfiles[engines.CPUEngineType] = "database_cpu"
-		baseConfig.EngineProfiles[engines.MemoryEngineType] = "database_memory"
-		baseConfig.EngineProfiles[engines.StorageEngineType] = "database_storage"
-		baseConfig.EngineProfiles[engines.NetworkEngineType] = "database_network"
 		baseConfig.ComplexityLevels[engines.CPUEngineType] = 3
 		baseConfig.ComplexityLevels[engines.MemoryEngineType] = 2
 		baseConfig.ComplexityLevels[engines.StorageEngineType] = 4
-		baseConfig.ComplexityLevels[engines.NetworkEngineType] = 2
+		baseConfig.ComplexityLevels[engines.NetworkInEngineType] = 2
+		baseConfig.ComplexityLevels[engines.NetworkOutEngineType] = 2
 
 	case ComponentTypeWebServer:
 		baseConfig.RequiredEngines = []engines.EngineType{
-			engines.NetworkEngineType, // Input
+			engines.NetworkInEngineType,
 			engines.CPUEngineType,
 			engines.MemoryEngineType,
-			engines.NetworkEngineType, // Output
+			engines.NetworkOutEngineType,
 		}
-		baseConfig.EngineProfiles[engines.CPUEngineType] = "webserver_cpu"
-		baseConfig.EngineProfiles[engines.MemoryEngineType] = "webserver_memory"
-		baseConfig.EngineProfiles[engines.NetworkEngineType] = "webserver_network"
 		baseConfig.ComplexityLevels[engines.CPUEngineType] = 2
 		baseConfig.ComplexityLevels[engines.MemoryEngineType] = 1
-		baseConfig.ComplexityLevels[engines.NetworkEngineType] = 3
+		baseConfig.ComplexityLevels[engines.NetworkInEngineType] = 3
+		baseConfig.ComplexityLevels[engines.NetworkOutEngineType] = 3
 
 	case ComponentTypeCache:
 		baseConfig.RequiredEngines = []engines.EngineType{
-			engines.NetworkEngineType, // Input
+			engines.NetworkInEngineType,
 			engines.CPUEngineType,
 			engines.MemoryEngineType,
-			engines.NetworkEngineType, // Output
+			engines.NetworkOutEngineType,
 		}
-		baseConfig.EngineProfiles[engines.CPUEngineType] = "cache_cpu"
-		baseConfig.EngineProfiles[engines.MemoryEngineType] = "cache_memory"
-		baseConfig.EngineProfiles[engines.NetworkEngineType] = "cache_network"
 		baseConfig.ComplexityLevels[engines.CPUEngineType] = 1
 		baseConfig.ComplexityLevels[engines.MemoryEngineType] = 3
-		baseConfig.ComplexityLevels[engines.NetworkEngineType] = 2
+		baseConfig.ComplexityLevels[engines.NetworkInEngineType] = 2
+		baseConfig.ComplexityLevels[engines.NetworkOutEngineType] = 2
 
 	case ComponentTypeLoadBalancer:
 		baseConfig.RequiredEngines = []engines.EngineType{
-			engines.NetworkEngineType, // Input
+			engines.NetworkInEngineType,
 			engines.CPUEngineType,
-			engines.NetworkEngineType, // Output
+			engines.NetworkOutEngineType,
 		}
-		baseConfig.EngineProfiles[engines.CPUEngineType] = "loadbalancer_cpu"
-		baseConfig.EngineProfiles[engines.NetworkEngineType] = "loadbalancer_network"
 		baseConfig.ComplexityLevels[engines.CPUEngineType] = 1
-		baseConfig.ComplexityLevels[engines.NetworkEngineType] = 4
+		baseConfig.ComplexityLevels[engines.NetworkInEngineType] = 4
+		baseConfig.ComplexityLevels[engines.NetworkOutEngineType] = 4
 	}
 
 	// Create default decision graph
@@ -205,7 +223,7 @@ func (cf *ComponentFactory) createDefaultDecisionGraph(componentType ComponentTy
 				"network_input": {
 					ID:         "network_input",
 					Type:       "engine",
-					EngineType: engines.NetworkEngineType,
+					EngineType: engines.NetworkInEngineType,
 					Conditions: map[string]string{"default": "cpu_process"},
 				},
 				"cpu_process": {
@@ -229,7 +247,7 @@ func (cf *ComponentFactory) createDefaultDecisionGraph(componentType ComponentTy
 				"network_output": {
 					ID:   "network_output",
 					Type: "engine",
-					EngineType: engines.NetworkEngineType,
+					EngineType: engines.NetworkOutEngineType,
 					Conditions: map[string]string{"default": "end"},
 				},
 				"end": {
@@ -247,7 +265,7 @@ func (cf *ComponentFactory) createDefaultDecisionGraph(componentType ComponentTy
 				"network_input": {
 					ID:         "network_input",
 					Type:       "engine",
-					EngineType: engines.NetworkEngineType,
+					EngineType: engines.NetworkInEngineType,
 					Conditions: map[string]string{"default": "cpu_hash"},
 				},
 				"cpu_hash": {
@@ -265,7 +283,7 @@ func (cf *ComponentFactory) createDefaultDecisionGraph(componentType ComponentTy
 				"network_output": {
 					ID:         "network_output",
 					Type:       "engine",
-					EngineType: engines.NetworkEngineType,
+					EngineType: engines.NetworkOutEngineType,
 					Conditions: map[string]string{"default": "end"},
 				},
 				"end": {
@@ -354,3 +354,29 @@ func TestComponentFactory_ProfileLoading(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentFactory_UnknownEngineProfile(t *testing.T) {
+	config := &ComponentConfig{
+		ID:               "typo-component",
+		Type:             ComponentTypeWebServer,
+		RequiredEngines:  []engines.EngineType{engines.CPUEngineType},
+		MaxConcurrentOps: 10,
+		QueueCapacity:    50,
+		EngineProfiles:   map[engines.EngineType]string{engines.CPUEngineType: "web_server_cpu"},
+		ProfilesPath:     "../../profiles",
+	}
+
+	// Profiles are looked up by the name they declare, not their file name
+	if _, err := NewComponentInstance(config); err == nil {
+		t.Fatal("Expected an unknown engine profile to fail instance creation")
+	}
+
+	config.EngineProfiles[engines.CPUEngineType] = "Web Server CPU"
+	instance, err := NewComponentInstance(config)
+	if err != nil {
+		t.Fatalf("Failed to create instance with a known engine profile: %v", err)
+	}
+	if instance.Engines[engines.CPUEngineType] == nil {
+		t.Error("Expected the CPU engine to be created")
+	}
+}
@@ -173,80 +173,6 @@ func (gr *GlobalRegistry) UpdateSystemGraph(flowID string, graph *DecisionGraph)
 	return nil
 }
 
-// GetRequestContext returns the request context for a given request ID
-func (gr *GlobalRegistry) GetRequestContext(requestID string) *RequestContext {
-	gr.mutex.RLock()
-	defer gr.mutex.RUnlock()
-
-	context, exists := gr.requestContexts[requestID]
-	if !exists {
-		log.Printf("GlobalRegistry: Request context %s not found", requestID)
-		return nil
-	}
-
-	return context
-}
-
-// UpdateRequestContext updates or creates a request context
-func (gr *GlobalRegistry) UpdateRequestContext(requestID string, context *RequestContext) error {
-	gr.mutex.Lock()
-	defer gr.mutex.Unlock()
-
-	if requestID == "" {
-		return fmt.Errorf("request ID cannot be empty")
-	}
-
-	if context == nil {
-		return fmt.Errorf("context cannot be nil")
-	}
-
-	// Update last update timestamp
-	context.LastUpdate = time.Now().Format(time.RFC3339)
-
-	gr.requestContexts[requestID] = context
-	log.Printf("GlobalRegistry: Updated request context for request %s", requestID)
-
-	return nil
-}
-
-// System Graph Management Methods
-
-// GetSystemGraph returns the system graph for a given flow ID
-func (gr *GlobalRegistry) GetSystemGraph(flowID string) *DecisionGraph {
-	gr.mutex.RLock()
-	defer gr.mutex.RUnlock()
-
-	graph, exists := gr.systemGraphs[flowID]
-	if !exists {
-		log.Printf("GlobalRegistry: System graph %s not found", flowID)
-		return nil
-	}
-
-	return graph
-}
-
-// UpdateSystemGraph updates or creates a system graph for a given flow ID
-func (gr *GlobalRegistry) UpdateSystemGraph(flowID string, graph *DecisionGraph) error {
-	gr.mutex.Lock()
-	defer gr.mutex.Unlock()
-
-	if flowID == "" {
-		return fmt.Errorf("flow ID cannot be empty")
-	}
-
-	if graph == nil {
-		return fmt.Errorf("graph cannot be nil")
-	}
-
-	// Set graph level to system level
-	graph.Level = SystemLevel
-
-	gr.systemGraphs[flowID] = graph
-	log.Printf("GlobalRegistry: Updated system graph for flow %s", flowID)
-
-	return nil
-}
-
 // ListSystemGraphs returns all available system graph flow IDs
 func (gr *GlobalRegistry) ListSystemGraphs() []string {
 	gr.mutex.RLock()
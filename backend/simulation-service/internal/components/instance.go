@@ -2,8 +2,10 @@ package components
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"sync/atomic"
 	"time"
 
@@ -70,8 +72,14 @@ func NewComponentInstance(config *ComponentConfig) (*ComponentInstance, error) {
 		},
 		ErrorHandler: NewErrorHandler(),
 		StartTime:    time.Now(),
+		wake:         make(chan struct{}, 1),
 	}
 
+	// Operations run through the engines as sequences in RequiredEngines order
+	instance.Executor = NewComponentInstanceExecutor(instance.ID, instance.ComponentID)
+	instance.Executor.SetCompletionHandler(instance.completeOperation)
+	instance.Executor.SetFollowUpHandler(instance.observe)
+
 	// Initialize engines (placeholder for now)
 	if err := instance.initializeEngines(); err != nil {
 		return nil, fmt.Errorf("failed to initialize engines: %w", err)
@@ -83,6 +91,7 @@ func NewComponentInstance(config *ComponentConfig) (*ComponentInstance, error) {
 		EndNodes:  []string{"output_network"},
 		Nodes:     make(map[string]*DecisionNode),
 		Engines:   instance.Engines,
+		Seed:      engines.DeriveSeed(config.Seed, "decision_graph"),
 	}
 
 	// Create centralized output manager
@@ -92,6 +101,7 @@ func NewComponentInstance(config *ComponentConfig) (*ComponentInstance, error) {
 		InputChannel:       make(chan *engines.OperationResult, config.QueueCapacity),
 		OutputChannel:      outputChannel,
 		RoutingRules:       config.RoutingRules,
+		UserFlowConfig:     config.UserFlow,
 		BackpressureConfig: &BackpressureConfig{
 			MaxRetries:              3,
 			RetryDelay:              time.Millisecond * 10,
@@ -118,6 +128,18 @@ func (ci *ComponentInstance) Start(ctx context.Context) error {
 	// Create context for instance lifecycle
 	ci.ctx, ci.cancel = context.WithCancel(ctx)
 
+	if err := ci.Executor.Start(); err != nil {
+		return err
+	}
+
+	// Tick-driven instances do all their work inside ProcessTick
+	if ci.tickDriven {
+		ci.ReadyFlag.Store(true)
+		ci.running = true
+		log.Printf("ComponentInstance %s: Started in tick-driven mode", ci.ID)
+		return nil
+	}
+
 	// Start independent engine goroutines
 	for engineType, engine := range ci.Engines {
 		if engine != nil {
@@ -173,6 +195,7 @@ func (ci *ComponentInstance) Stop() error {
 	if ci.cancel != nil {
 		ci.cancel()
 	}
+	ci.Executor.Stop()
 
 	// Stop independent engine goroutines
 	for engineType, engine := range ci.Engines {
@@ -266,17 +289,34 @@ func (ci *ComponentInstance) GetState() ComponentState {
 
 // IsHealthy implements the Component interface for ComponentInstance
 func (ci *ComponentInstance) IsHealthy() bool {
+	ci.healthMutex.RLock()
+	defer ci.healthMutex.RUnlock()
+
 	return ci.Health != nil && ci.Health.Status == "GREEN"
 }
 
-// GetHealth implements the Component interface for ComponentInstance
+// GetHealth implements the Component interface for ComponentInstance, returning a copy
 func (ci *ComponentInstance) GetHealth() *ComponentHealth {
-	return ci.Health
+	ci.healthMutex.RLock()
+	defer ci.healthMutex.RUnlock()
+
+	if ci.Health == nil {
+		return nil
+	}
+	health := *ci.Health
+	return &health
 }
 
-// GetMetrics implements the Component interface for ComponentInstance
+// GetMetrics implements the Component interface for ComponentInstance, returning a copy
 func (ci *ComponentInstance) GetMetrics() *ComponentMetrics {
-	return ci.Metrics
+	ci.metricsMutex.Lock()
+	defer ci.metricsMutex.Unlock()
+
+	if ci.Metrics == nil {
+		return nil
+	}
+	metrics := *ci.Metrics
+	return &metrics
 }
 
 // ProcessOperation implements the Component interface for ComponentInstance
@@ -305,14 +345,72 @@ func (ci *ComponentInstance) ProcessOperation(op *engines.Operation) error {
 	}
 }
 
-// ProcessTick implements the Component interface for ComponentInstance
+// ProcessTick implements the Component interface for ComponentInstance. Tick-driven
+// instances admit queued operations and step their engines in RequiredEngines order;
+// otherwise the tick is forwarded to the engines' goroutines.
 func (ci *ComponentInstance) ProcessTick(currentTick int64) error {
-	// Instance doesn't process ticks directly - engines do
-	// This could be used for health monitoring in the future
+	ci.currentTick.Store(currentTick)
+	ci.Executor.Advance(currentTick)
+
+	if ci.tickDriven {
+		ci.admitQueuedOperations()
+		for _, engineType := range ci.Config.RequiredEngines {
+			if engine := ci.Engines[engineType]; engine != nil {
+				if err := engine.Step(currentTick); err != nil {
+					log.Printf("ComponentInstance %s: Engine %s tick failed: %v", ci.ID, engineType, err)
+				}
+			}
+		}
+	} else {
+		for engineType, engine := range ci.Engines {
+			if engine == nil {
+				continue
+			}
+			if err := engine.ProcessTick(currentTick); err != nil {
+				log.Printf("ComponentInstance %s: Engine %s tick failed: %v", ci.ID, engineType, err)
+			}
+		}
+	}
+
 	ci.updateComponentState()
 	return nil
 }
 
+// SetTickDriven makes the instance run on the caller's goroutine, driven by
+// ProcessTick, with each tick lasting tickDuration of simulated time. Call before Start.
+func (ci *ComponentInstance) SetTickDriven(tickDuration time.Duration) {
+	ci.tickDriven = true
+	if tickDuration <= 0 {
+		return
+	}
+	for _, engine := range ci.Engines {
+		if engine != nil {
+			engine.GetEngine().SetTickDuration(tickDuration)
+		}
+	}
+}
+
+// NextEventTick returns the earliest tick at which the instance has work to do:
+// the next tick while operations are waiting for a free slot or being handed off,
+// otherwise the earliest engine completion or deferred engine start
+func (ci *ComponentInstance) NextEventTick(currentTick int64) (int64, bool) {
+	if (len(ci.InputChannel) > 0 && ci.hasFreeSlot()) || ci.ProcessingFlag.Load() {
+		return currentTick + 1, true
+	}
+
+	next, pending := ci.Executor.NextEventTick(currentTick)
+	for _, engine := range ci.Engines {
+		if engine == nil {
+			continue
+		}
+		if tick, ok := engine.NextEventTick(currentTick); ok && (!pending || tick < next) {
+			next, pending = tick, true
+		}
+	}
+
+	return next, pending
+}
+
 // GetInputChannel returns the instance's input channel
 func (ci *ComponentInstance) GetInputChannel() chan *engines.Operation {
 	return ci.InputChannel
@@ -369,10 +467,14 @@ func (ci *ComponentInstance) LoadState(instanceID string) error {
 
 	// Restore health and metrics
 	if state.Health != nil {
+		ci.healthMutex.Lock()
 		ci.Health = state.Health
+		ci.healthMutex.Unlock()
 	}
 	if state.Metrics != nil {
+		ci.metricsMutex.Lock()
 		ci.Metrics = state.Metrics
+		ci.metricsMutex.Unlock()
 	}
 
 	// Restore engine states
@@ -392,153 +494,232 @@ func (ci *ComponentInstance) LoadState(instanceID string) error {
 	return nil
 }
 
-// runInstance is the main goroutine for the component instance
+// runInstance is the main goroutine for the component instance. It admits
+// operations while MaxConcurrentOps allows and otherwise waits for a slot to free.
 func (ci *ComponentInstance) runInstance() {
 	log.Printf("ComponentInstance %s: Starting instance goroutine", ci.ID)
 
 	for {
+		input := ci.InputChannel
+		if !ci.ShutdownFlag.Load() && !ci.hasFreeSlot() {
+			input = nil
+		}
+
 		select {
-		case op := <-ci.InputChannel:
+		case op := <-input:
 			// Check if we should shutdown
 			if ci.ShutdownFlag.Load() {
 				log.Printf("ComponentInstance %s: Shutdown flag set, rejecting operation %s", ci.ID, op.ID)
 				continue
 			}
 
-			// Mark as processing
+			// Mark as processing while the operation is handed to the engines
 			ci.ProcessingFlag.Store(true)
-
-			// Process operation through decision graph
-			if err := ci.processOperationThroughEngines(op); err != nil {
-				log.Printf("ComponentInstance %s: Error processing operation %s: %v", ci.ID, op.ID, err)
-				ci.Metrics.FailedOps++
-			} else {
-				ci.Metrics.CompletedOps++
-			}
-
-			ci.Metrics.TotalOperations++
-
-			// Mark as not processing
+			ci.startOperation(op)
 			ci.ProcessingFlag.Store(false)
 
+		case <-ci.wake:
+
 		case <-ci.ctx.Done():
+			// Operations still queued when the instance stops are rejected
+			for len(ci.InputChannel) > 0 {
+				op := <-ci.InputChannel
+				log.Printf("ComponentInstance %s: Stopping, rejecting operation %s", ci.ID, op.ID)
+			}
 			log.Printf("ComponentInstance %s: Instance goroutine stopping", ci.ID)
 			return
 		}
 	}
 }
 
-// processOperationThroughEngines processes an operation through the engine decision graph
-func (ci *ComponentInstance) processOperationThroughEngines(op *engines.Operation) error {
-	// Initialize queue position tracking metadata
-	if op.Metadata == nil {
-		op.Metadata = make(map[string]interface{})
+// admitQueuedOperations starts queued operations while slots are free (tick-driven mode)
+func (ci *ComponentInstance) admitQueuedOperations() {
+	for !ci.ShutdownFlag.Load() && ci.ReadyFlag.Load() && ci.hasFreeSlot() {
+		select {
+		case op := <-ci.InputChannel:
+			ci.startOperation(op)
+		default:
+			return
+		}
 	}
+}
 
-	// Set initial queue position tracking
-	totalEngines := len(ci.Config.RequiredEngines)
-	op.Metadata["total_engines"] = totalEngines
-	op.Metadata["current_engine_index"] = 0
-	op.Metadata["queue_position"] = totalEngines
-	op.Metadata["engines_remaining"] = totalEngines
-	op.Metadata["processing_path"] = make([]string, 0, totalEngines)
-
-	log.Printf("ComponentInstance %s: Processing operation %s through %d engines", ci.ID, op.ID, totalEngines)
-
-	// Process through engines using decision graph flow
-	return ci.executeDecisionGraphFlow(op)
+// hasFreeSlot reports whether another operation may start under MaxConcurrentOps (0 = unlimited)
+func (ci *ComponentInstance) hasFreeSlot() bool {
+	limit := ci.Config.MaxConcurrentOps
+	return limit <= 0 || ci.inFlight.Load() < int64(limit)
 }
 
-// executeDecisionGraphFlow executes the operation through the decision graph
-func (ci *ComponentInstance) executeDecisionGraphFlow(op *engines.Operation) error {
-	totalEngines := op.Metadata["total_engines"].(int)
+// signalShutdown stops the instance admitting operations and wakes its goroutine
+// so operations still queued are rejected
+func (ci *ComponentInstance) signalShutdown() {
+	ci.ShutdownFlag.Store(true)
+	ci.notify()
+}
 
-	// For now, simulate sequential processing through engines
-	// TODO: Replace with actual decision graph execution when decision graph is fully implemented
-	for i, engineType := range ci.Config.RequiredEngines {
-		// Update queue position tracking
-		currentIndex := i + 1
-		remainingEngines := totalEngines - i
+// notify wakes runInstance without blocking
+func (ci *ComponentInstance) notify() {
+	select {
+	case ci.wake <- struct{}{}:
+	default:
+	}
+}
 
-		op.Metadata["current_engine_index"] = currentIndex
-		op.Metadata["queue_position"] = remainingEngines
-		op.Metadata["engines_remaining"] = remainingEngines - 1
-		op.Metadata["current_engine"] = engineType.String()
+// startOperation submits an operation to the instance's engine sequence
+func (ci *ComponentInstance) startOperation(op *engines.Operation) {
+	if op.Metadata == nil {
+		op.Metadata = make(map[string]interface{})
+	}
+	request, _ := op.Metadata["request"].(*Request)
 
-		// Add to processing path
-		if path, ok := op.Metadata["processing_path"].([]string); ok {
-			op.Metadata["processing_path"] = append(path, engineType.String())
+	// The tick the request entered the system travels on with it to later hops
+	if _, ok := op.Metadata["request_start_tick"]; !ok {
+		startTick := op.StartTick
+		if startTick <= 0 {
+			startTick = ci.currentTick.Load()
 		}
+		op.Metadata["request_start_tick"] = startTick
+	}
+
+	ci.metricsMutex.Lock()
+	ci.Metrics.TotalOperations++
+	ci.metricsMutex.Unlock()
+	ci.inFlight.Add(1)
+
+	err := ci.Executor.ExecuteSequence(&EngineSequenceRequest{
+		Request:        request,
+		Operation:      op,
+		EngineSequence: ci.Config.RequiredEngines,
+		Timestamp:      time.Now(),
+		StartTick:      ci.currentTick.Load(),
+		SequenceID:     op.ID,
+	})
+	if err != nil {
+		ci.inFlight.Add(-1)
+		ci.metricsMutex.Lock()
+		ci.Metrics.FailedOps++
+		ci.metricsMutex.Unlock()
+		log.Printf("ComponentInstance %s: Error processing operation %s: %v", ci.ID, op.ID, err)
 
-		log.Printf("ComponentInstance %s: Processing operation %s through engine %s (position %d/%d, remaining: %d)",
-			ci.ID, op.ID, engineType, currentIndex, totalEngines, remainingEngines-1)
+		compErr := WrapError(err, ci.ID, op.ID)
+		compErr.Category = ErrorCategoryResource
+		compErr.Severity = ErrorSeverityHigh
+		ci.ErrorHandler.HandleError(context.Background(), compErr, ci.ID)
+	}
+}
 
-		// Process through engine (simulate for now)
-		result := ci.processOperationThroughSingleEngine(op, engineType)
-		if result == nil {
-			return fmt.Errorf("failed to process operation %s through engine %s", op.ID, engineType)
-		}
+// completeOperation receives a finished engine sequence: it counts the operation,
+// reports every engine result to the observer and hands the component's final
+// result to the centralized output manager
+func (ci *ComponentInstance) completeOperation(sequence *EngineSequenceResult) {
+	ci.inFlight.Add(-1)
+	defer ci.notify()
 
-		// Copy queue position metadata to result metrics
-		if result.Metrics == nil {
-			result.Metrics = make(map[string]interface{})
+	op := sequence.Operation
+	if op == nil {
+		op = &engines.Operation{ID: sequence.SequenceID}
+	}
+
+	result := &engines.OperationResult{
+		OperationID:    op.ID,
+		OperationType:  op.Type,
+		ProcessingTime: sequence.TotalLatency,
+		CompletedTick:  sequence.CompletedTick,
+		CompletedAt:    sequence.CompletedTick,
+		Success:        sequence.Success,
+		ErrorMessage:   sequence.ErrorMessage,
+		Error:          sequence.Error,
+		Metrics:        make(map[string]interface{}, len(op.Metadata)+4),
+	}
+
+	// The operation's metadata travels on; engine metrics (e.g. cache_hit) override it
+	for key, value := range op.Metadata {
+		result.Metrics[key] = value
+	}
+	for _, engineResult := range sequence.EngineResults {
+		if engineResult.Result == nil {
+			continue
 		}
-		for key, value := range op.Metadata {
+		for key, value := range engineResult.Result.Metrics {
 			result.Metrics[key] = value
 		}
+	}
+	delete(result.Metrics, "engine_type") // The final result is the component's, not its last engine's
+	result.Metrics["is_final_result"] = true
+	result.Metrics["data_size"] = op.DataSize
+	result.Metrics["total_engines"] = len(ci.Config.RequiredEngines)
+	if count := len(sequence.EngineResults); count > 0 {
+		result.Metrics["final_engine"] = sequence.EngineResults[count-1].EngineType.String()
+	}
 
-		// Check if this is the last engine (queue_position == 1)
-		if remainingEngines == 1 {
-			log.Printf("ComponentInstance %s: Last engine %s detected for operation %s (queue_position=1)",
-				ci.ID, engineType, op.ID)
-
-			// Mark as final result
-			result.Metrics["is_final_result"] = true
-			result.Metrics["final_engine"] = engineType.String()
+	ci.metricsMutex.Lock()
+	if result.Success {
+		ci.Metrics.CompletedOps++
+		// Running mean of simulated latency across completed operations
+		ci.Metrics.AverageLatency += (result.ProcessingTime - ci.Metrics.AverageLatency) / time.Duration(ci.Metrics.CompletedOps)
+	} else {
+		ci.Metrics.FailedOps++
+	}
+	ci.metricsMutex.Unlock()
 
-			// Send to centralized output manager
-			return ci.sendToOutputManager(result)
+	for _, engineResult := range sequence.EngineResults {
+		if engineResult.Result != nil {
+			ci.observe(engineResult.Result)
 		}
-
-		log.Printf("ComponentInstance %s: Processed operation %s through engine %s, continuing to next engine",
-			ci.ID, op.ID, engineType)
 	}
 
-	return nil
-}
+	// An OOM-kill ends the instance's process along with the operation
+	var memoryErr *engines.MemoryError
+	if errors.As(result.Error, &memoryErr) {
+		ci.observe(result)
+		ci.crash(result.Error)
+		return
+	}
 
-// processOperationThroughSingleEngine processes an operation through a single engine
-func (ci *ComponentInstance) processOperationThroughSingleEngine(op *engines.Operation, engineType engines.EngineType) *engines.OperationResult {
-	// Check if engine exists and is available
-	engine, exists := ci.Engines[engineType]
-	if !exists || engine == nil {
-		log.Printf("ComponentInstance %s: Engine %s not available, simulating processing", ci.ID, engineType)
-		// Simulate processing for missing engines
-		return &engines.OperationResult{
-			OperationID:    op.ID,
-			OperationType:  op.Type,
-			Success:        true,
-			ProcessingTime: time.Millisecond * 10, // Placeholder
-			Metrics:        make(map[string]interface{}),
+	// Tick-driven routing is synchronous, so the observer sees whether the
+	// result ended its request (request_complete)
+	if ci.tickDriven {
+		if ci.CentralizedOutput != nil {
+			if err := ci.CentralizedOutput.handleOperationResult(result); err != nil {
+				log.Printf("ComponentInstance %s: Error routing result %s: %v", ci.ID, result.OperationID, err)
+			}
 		}
+		ci.observe(result)
+		return
 	}
 
-	// TODO: Send operation to actual engine goroutine when engines are properly implemented
-	// For now, simulate engine processing
-	result := &engines.OperationResult{
-		OperationID:    op.ID,
-		OperationType:  op.Type,
-		Success:        true,
-		ProcessingTime: time.Millisecond * 10, // Placeholder
-		Metrics:        make(map[string]interface{}),
+	ci.observe(result)
+	if err := ci.sendToOutputManager(result); err != nil {
+		log.Printf("ComponentInstance %s: Error routing result %s: %v", ci.ID, result.OperationID, err)
 	}
+}
 
-	// Add engine-specific metadata
-	result.Metrics["engine_type"] = engineType.String()
-	result.Metrics["processing_time_ms"] = 10
-	result.Metrics["simulated"] = true
+// observe reports a result to the observer: the sequences' engine results, the
+// component's final results and completed follow-up operations, such as a TLS
+// handshake's CPU work
+func (ci *ComponentInstance) observe(result *engines.OperationResult) {
+	if ci.resultObserver != nil {
+		ci.resultObserver(result)
+	}
+}
 
-	return result
+// crash takes the instance out of rotation after its process was killed and
+// reports it to the instance's error handler and the load balancer
+func (ci *ComponentInstance) crash(err error) {
+	ci.crashed.Store(true)
+	ci.ReadyFlag.Store(false)
+	ci.healthMutex.Lock()
+	ci.Health.Status = "RED"
+	ci.Health.IsAcceptingLoad = false
+	ci.Health.AvailableCapacity = 0
+	ci.Health.LastHealthCheck = time.Now()
+	ci.healthMutex.Unlock()
+
+	log.Printf("ComponentInstance %s: Process killed: %v", ci.ID, err)
+	ci.ErrorHandler.HandleError(context.Background(), err, ci.ID)
+	if ci.crashObserver != nil {
+		ci.crashObserver(ci.ID, err)
+	}
 }
 
 // sendToOutputManager sends the final result to the centralized output manager
@@ -559,11 +740,23 @@ func (ci *ComponentInstance) sendToOutputManager(result *engines.OperationResult
 // initializeEngines creates and configures engines for the instance
 func (ci *ComponentInstance) initializeEngines() error {
 	// Create engine factory for proper engine initialization
-	engineFactory := engines.NewEngineFactoryWithPaths("./profiles") // TODO: Make configurable
+	profilesPath := ci.Config.ProfilesPath
+	if profilesPath == "" {
+		profilesPath = "./profiles"
+	}
+	engineFactory := engines.NewEngineFactoryWithPaths(profilesPath)
 
-	// Try to load profiles from files (ignore errors for now)
-	if err := engineFactory.LoadProfilesFromFiles(); err != nil {
-		log.Printf("ComponentInstance %s: Warning - could not load profiles from files: %v", ci.ID, err)
+	// Load profiles from files when the directory exists; the built-in profiles cover the rest
+	if _, err := os.Stat(profilesPath); err == nil {
+		if err := engineFactory.LoadProfilesFromFiles(); err != nil {
+			log.Printf("ComponentInstance %s: Warning - could not load profiles from files: %v", ci.ID, err)
+		}
+	}
+
+	// One garbage-collected heap per instance: its pauses stop every engine running the instance's code
+	var runtime *engines.RuntimeGC
+	if ci.Config.Runtime != "" {
+		runtime = engines.NewRuntimeGC(ci.Config.Runtime)
 	}
 
 	for _, engineType := range ci.Config.RequiredEngines {
@@ -573,16 +766,32 @@ func (ci *ComponentInstance) initializeEngines() error {
 		// Get complexity level for this engine type
 		complexityLevel := ci.getEngineComplexityLevel(engineType)
 
-		// Create the base engine using factory
+		// Create the base engine using factory. A profile configured for the instance must
+		// exist; the component type's suggested profile falls back to the engine type's default.
 		baseEngine, err := engineFactory.CreateEngine(engineType, profileName, ci.Config.QueueCapacity)
 		if err != nil {
-			log.Printf("ComponentInstance %s: Failed to create %s engine, using placeholder: %v", ci.ID, engineType, err)
-			// Create placeholder engine wrapper for testing - set to nil to avoid crashes
+			if _, configured := ci.Config.EngineProfiles[engineType]; configured {
+				return fmt.Errorf("failed to create %s engine: %w", engineType, err)
+			}
+			log.Printf("ComponentInstance %s: Warning - profile %s unavailable for %s engine, using default: %v",
+				ci.ID, profileName, engineType, err)
+			baseEngine, err = engineFactory.CreateEngineWithDefaultProfile(engineType, ci.Config.QueueCapacity)
+		}
+		if err != nil {
+			log.Printf("ComponentInstance %s: Failed to create %s engine: %v", ci.ID, engineType, err)
 			ci.Engines[engineType] = nil
 		} else {
+			// Seed the engine before wrapping so every decision it makes is reproducible
+			baseEngine.SetSeed(engines.DeriveSeed(ci.Config.Seed, engineType.String()))
+
+			if attacher, ok := baseEngine.(engines.RuntimeAttacher); ok && runtime != nil {
+				attacher.AttachRuntime(runtime)
+			}
+
 			// Create proper engine wrapper with the base engine
 			engineWrapper := engines.NewEngineWrapper(baseEngine, complexityLevel)
 			ci.Engines[engineType] = engineWrapper
+			ci.Executor.RegisterEngine(engineType, engineWrapper)
 
 			log.Printf("ComponentInstance %s: Created %s engine with profile %s", ci.ID, engineType, profileName)
 		}
@@ -597,6 +806,18 @@ func (ci *ComponentInstance) initializeEngines() error {
 		}
 	}
 
+	// Memory the instance cannot keep in RAM is swapped out to its storage engine,
+	// and the RAM it leaves free caches the storage engine's pages
+	memoryWrapper, storageWrapper := ci.Engines[engines.MemoryEngineType], ci.Engines[engines.StorageEngineType]
+	if memoryWrapper != nil && storageWrapper != nil {
+		memoryEngine, isMemory := memoryWrapper.GetEngine().(*engines.MemoryEngine)
+		storageEngine, isStorage := storageWrapper.GetEngine().(*engines.StorageEngine)
+		if isMemory && isStorage {
+			memoryEngine.AttachSwapDevice(storageEngine)
+			storageEngine.AttachPageCache(memoryEngine)
+		}
+	}
+
 	log.Printf("ComponentInstance %s: Initialized %d engines", ci.ID, len(ci.Engines))
 	return nil
 }
@@ -613,7 +834,7 @@ func (ci *ComponentInstance) getEngineProfileName(engineType engines.EngineType)
 	switch ci.ComponentType {
 	case ComponentTypeWebServer:
 		switch engineType {
-		case engines.NetworkEngineType:
+		case engines.NetworkEngineType, engines.NetworkInEngineType, engines.NetworkOutEngineType:
 			return "web_server_network"
 		case engines.CPUEngineType:
 			return "web_server_cpu"
@@ -624,7 +845,7 @@ func (ci *ComponentInstance) getEngineProfileName(engineType engines.EngineType)
 		}
 	case ComponentTypeDatabase:
 		switch engineType {
-		case engines.NetworkEngineType:
+		case engines.NetworkEngineType, engines.NetworkInEngineType, engines.NetworkOutEngineType:
 			return "database_network"
 		case engines.CPUEngineType:
 			return "database_cpu"
@@ -635,7 +856,7 @@ func (ci *ComponentInstance) getEngineProfileName(engineType engines.EngineType)
 		}
 	case ComponentTypeCache:
 		switch engineType {
-		case engines.NetworkEngineType:
+		case engines.NetworkEngineType, engines.NetworkInEngineType, engines.NetworkOutEngineType:
 			return "cache_network"
 		case engines.CPUEngineType:
 			return "cache_cpu"
@@ -646,7 +867,7 @@ func (ci *ComponentInstance) getEngineProfileName(engineType engines.EngineType)
 		}
 	case ComponentTypeLoadBalancer:
 		switch engineType {
-		case engines.NetworkEngineType:
+		case engines.NetworkEngineType, engines.NetworkInEngineType, engines.NetworkOutEngineType:
 			return "load_balancer_network"
 		case engines.CPUEngineType:
 			return "load_balancer_cpu"
@@ -686,6 +907,12 @@ func (ci *ComponentInstance) getEngineComplexityLevel(engineType engines.EngineT
 
 // updateComponentState updates the instance's health and metrics
 func (ci *ComponentInstance) updateComponentState() {
+	// A crashed instance stays unhealthy; its failed operation is already counted
+	if ci.crashed.Load() {
+		ci.recordState()
+		return
+	}
+
 	// Update health based on engine status
 	healthyEngines := 0
 	totalEngines := len(ci.Engines)
@@ -697,7 +924,12 @@ func (ci *ComponentInstance) updateComponentState() {
 	}
 
 	// Calculate health
-	healthRatio := float64(healthyEngines) / float64(totalEngines)
+	healthRatio := 1.0
+	if totalEngines > 0 {
+		healthRatio = float64(healthyEngines) / float64(totalEngines)
+	}
+	// Health is read by load balancers and metrics scrapes on other goroutines
+	ci.healthMutex.Lock()
 	ci.Health.AvailableCapacity = healthRatio
 
 	if healthRatio >= 0.8 {
@@ -712,8 +944,17 @@ func (ci *ComponentInstance) updateComponentState() {
 	}
 
 	ci.Health.LastHealthCheck = time.Now()
+	ci.healthMutex.Unlock()
+
+	ci.recordState()
+}
+
+// recordState stamps the instance's current state into its metrics
+func (ci *ComponentInstance) recordState() {
+	state := ci.GetState()
 
-	// Update metrics
-	ci.Metrics.State = ci.GetState()
+	ci.metricsMutex.Lock()
+	ci.Metrics.State = state
 	ci.Metrics.LastUpdated = time.Now()
+	ci.metricsMutex.Unlock()
 }
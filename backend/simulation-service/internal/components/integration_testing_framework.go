@@ -7,8 +7,6 @@ import (
 	"sync"
 	"testing"
 	"time"
-
-	"github.com/systemsim/simulation-service/internal/engines"
 )
 
 // IntegrationTestingFramework provides comprehensive integration testing
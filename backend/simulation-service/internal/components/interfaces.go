@@ -1,11 +1,17 @@
 package components
 
 import (
+	"context"
+
 	"github.com/systemsim/simulation-service/internal/engines"
 )
 
 // ComponentLoadBalancerInterface defines the interface for component load balancers
 type ComponentLoadBalancerInterface interface {
+	// Lifecycle
+	Start(ctx context.Context) error
+	Stop() error
+	
 	// Graph access for Engine Output Queues
 	GetComponentGraph() *DecisionGraph
 	
@@ -22,13 +28,9 @@ type ComponentLoadBalancerInterface interface {
 	GetComponentType() ComponentType
 }
 
-// GlobalRegistryInterface defines the interface for global registry
-type GlobalRegistryInterface interface {
-	// Component registration and discovery
-	RegisterComponent(componentID string, channel chan *engines.Operation) error
-	UnregisterComponent(componentID string) error
-	GetChannel(componentID string) chan *engines.Operation
-	
+// SystemGraphRegistry is implemented by registries that also hold the system-level
+// decision graphs and per-request routing context
+type SystemGraphRegistry interface {
 	// System graph access
 	GetSystemGraph(flowID string) *DecisionGraph
 	UpdateSystemGraph(flowID string, graph *DecisionGraph) error
@@ -36,32 +38,6 @@ type GlobalRegistryInterface interface {
 	// Request context management
 	GetRequestContext(requestID string) *RequestContext
 	UpdateRequestContext(requestID string, context *RequestContext) error
-	
-	// Health and discovery
-	GetComponentHealth(componentID string) float64
-	ListComponents() []string
-}
-
-// DecisionGraph represents a routing decision graph (static data structure)
-type DecisionGraph struct {
-	Name      string                  `json:"name"`
-	StartNode string                  `json:"start_node"`
-	Nodes     map[string]*DecisionNode `json:"nodes"`
-	Level     GraphLevel              `json:"level"`
-}
-
-// DecisionNode represents a node in the decision graph
-type DecisionNode struct {
-	// Basic routing
-	Target     string            `json:"target"`      // Engine or component target
-	Operation  string            `json:"operation"`   // Operation to perform
-	Next       string            `json:"next"`        // Default next node
-	Conditions map[string]string `json:"conditions"`  // condition -> destination mapping
-	
-	// Advanced routing types
-	RoutingType        string              `json:"routing_type"`         // "standard", "probability_based", "dynamic_state_based"
-	ProbabilityConfig  *ProbabilityConfig  `json:"probability_config"`   // For probability-based routing
-	StateConfig        *StateConfig        `json:"state_config"`         // For state-based routing
 }
 
 // ProbabilityConfig defines probability-based routing configuration
@@ -94,51 +70,6 @@ type RequestContext struct {
 	LastUpdate        string `json:"last_update"`
 }
 
-// ComponentInstance represents a component instance
-type ComponentInstance struct {
-	ID          string                        `json:"id"`
-	ComponentID string                        `json:"component_id"`
-	Health      float64                       `json:"health"`
-	Engines     map[engines.EngineType]*Engine `json:"engines"`
-	
-	// Communication
-	InputChannel  chan *engines.Operation `json:"-"`
-	OutputChannel chan *engines.Operation `json:"-"`
-	
-	// Metrics
-	ActiveConnections int     `json:"active_connections"`
-	MaxConnections    int     `json:"max_connections"`
-	CurrentLoad       float64 `json:"current_load"`
-}
-
-// Engine represents an engine within a component instance
-type Engine struct {
-	ID     string             `json:"id"`
-	Type   engines.EngineType `json:"type"`
-	Health float64            `json:"health"`
-	
-	// Processing
-	InputQueue  chan *engines.Operation `json:"-"`
-	OutputQueue chan *engines.Operation `json:"-"`
-	
-	// Metrics
-	CurrentLoad float64 `json:"current_load"`
-	Utilization float64 `json:"utilization"`
-}
-
-// ComponentType represents the type of component
-type ComponentType string
-
-const (
-	WebServerComponent    ComponentType = "web_server"
-	DatabaseComponent     ComponentType = "database"
-	CacheComponent        ComponentType = "cache"
-	LoadBalancerComponent ComponentType = "load_balancer"
-	APIGatewayComponent   ComponentType = "api_gateway"
-	FileServerComponent   ComponentType = "file_server"
-	CustomComponent       ComponentType = "custom"
-)
-
 // LoadBalancerMetrics tracks load balancer performance
 type LoadBalancerMetrics struct {
 	TotalRequests       int64   `json:"total_requests"`
@@ -181,9 +112,7 @@ const (
 	AutoScaling    AutoScalingMode = "auto"
 )
 
-// LoadBalancingAlgorithm defines the load balancing algorithm
-type LoadBalancingAlgorithm string
-
+// Load balancing algorithms of the enhanced component load balancer
 const (
 	RoundRobin       LoadBalancingAlgorithm = "round_robin"
 	Weighted         LoadBalancingAlgorithm = "weighted"
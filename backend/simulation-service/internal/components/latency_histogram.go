@@ -0,0 +1,269 @@
+package components
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// Latency histograms
+//
+// Latencies are counted in log-linear buckets in the style of HdrHistogram: every
+// power of two is split into histogramSubBuckets equal buckets, so any recorded
+// value is reported within 1/histogramSubBuckets (under 0.8%) of its true value
+// while memory stays bounded no matter how many samples are recorded. Values are
+// nanoseconds of simulated time.
+
+const (
+	histogramSubBucketBits = 7
+	histogramSubBuckets    = 1 << histogramSubBucketBits
+)
+
+// LatencyHistogram records a latency distribution. It is safe for concurrent use.
+type LatencyHistogram struct {
+	counts []int64 // Indexed by bucket, grown on demand
+	count  int64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+	mutex  sync.Mutex
+}
+
+// HistogramSnapshot is a point-in-time copy of a latency histogram. Snapshots of
+// different histograms can be merged, e.g. to combine per-instance distributions
+// into a per-component one.
+type HistogramSnapshot struct {
+	Count   int64             `json:"count"`
+	Sum     time.Duration     `json:"sum_ns"`
+	Min     time.Duration     `json:"min_ns"`
+	Max     time.Duration     `json:"max_ns"`
+	Buckets []HistogramBucket `json:"buckets"` // Non-empty buckets in ascending order
+}
+
+// HistogramBucket counts the samples between two bounds, both inclusive
+type HistogramBucket struct {
+	LowerBound time.Duration `json:"lower_bound_ns"`
+	UpperBound time.Duration `json:"upper_bound_ns"`
+	Count      int64         `json:"count"`
+}
+
+// LatencyPercentiles summarizes a latency distribution
+type LatencyPercentiles struct {
+	Count int64         `json:"count"`
+	Mean  time.Duration `json:"mean_ns"`
+	P50   time.Duration `json:"p50_ns"`
+	P90   time.Duration `json:"p90_ns"`
+	P99   time.Duration `json:"p99_ns"`
+	P999  time.Duration `json:"p999_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// NewLatencyHistogram creates an empty latency histogram
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{}
+}
+
+// Record adds one latency sample. Negative latencies are recorded as zero.
+func (h *LatencyHistogram) Record(latency time.Duration) {
+	h.RecordN(latency, 1)
+}
+
+// RecordN adds the same latency sample n times
+func (h *LatencyHistogram) RecordN(latency time.Duration, n int64) {
+	if n <= 0 {
+		return
+	}
+	if latency < 0 {
+		latency = 0
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.add(histogramBucketIndex(int64(latency)), n)
+	if h.count == 0 || latency < h.min {
+		h.min = latency
+	}
+	if latency > h.max {
+		h.max = latency
+	}
+	h.count += n
+	h.sum += latency * time.Duration(n)
+}
+
+// Merge adds every sample of a snapshot to the histogram
+func (h *LatencyHistogram) Merge(snapshot *HistogramSnapshot) {
+	if snapshot == nil || snapshot.Count == 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, bucket := range snapshot.Buckets {
+		h.add(histogramBucketIndex(int64(bucket.LowerBound)), bucket.Count)
+	}
+	if h.count == 0 || snapshot.Min < h.min {
+		h.min = snapshot.Min
+	}
+	if snapshot.Max > h.max {
+		h.max = snapshot.Max
+	}
+	h.count += snapshot.Count
+	h.sum += snapshot.Sum
+}
+
+// Count returns the number of recorded samples
+func (h *LatencyHistogram) Count() int64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.count
+}
+
+// Reset discards every recorded sample
+func (h *LatencyHistogram) Reset() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.counts = nil
+	h.count, h.sum, h.min, h.max = 0, 0, 0, 0
+}
+
+// Snapshot returns a copy of the histogram's current contents
+func (h *LatencyHistogram) Snapshot() *HistogramSnapshot {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	snapshot := &HistogramSnapshot{
+		Count: h.count,
+		Sum:   h.sum,
+		Min:   h.min,
+		Max:   h.max,
+	}
+	for index, count := range h.counts {
+		if count == 0 {
+			continue
+		}
+		lower, upper := histogramBucketBounds(index)
+		snapshot.Buckets = append(snapshot.Buckets, HistogramBucket{
+			LowerBound: time.Duration(lower),
+			UpperBound: time.Duration(upper),
+			Count:      count,
+		})
+	}
+	return snapshot
+}
+
+// Percentiles returns the histogram's current percentiles
+func (h *LatencyHistogram) Percentiles() LatencyPercentiles {
+	return h.Snapshot().Percentiles()
+}
+
+// add counts n samples in a bucket (caller must hold the lock)
+func (h *LatencyHistogram) add(index int, n int64) {
+	if index >= len(h.counts) {
+		grown := make([]int64, index+1)
+		copy(grown, h.counts)
+		h.counts = grown
+	}
+	h.counts[index] += n
+}
+
+// Merge returns a new snapshot holding the samples of both snapshots
+func (s *HistogramSnapshot) Merge(other *HistogramSnapshot) *HistogramSnapshot {
+	return MergeHistogramSnapshots(s, other)
+}
+
+// Quantile returns the latency below which the given fraction of samples fall,
+// e.g. 0.99 for p99. The result is the upper bound of the bucket holding that
+// rank, clamped to the recorded minimum and maximum.
+func (s *HistogramSnapshot) Quantile(q float64) time.Duration {
+	if s == nil || s.Count == 0 {
+		return 0
+	}
+
+	rank := int64(math.Ceil(q * float64(s.Count)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen int64
+	for _, bucket := range s.Buckets {
+		seen += bucket.Count
+		if seen >= rank {
+			return clampDuration(bucket.UpperBound, s.Min, s.Max)
+		}
+	}
+	return s.Max
+}
+
+// Mean returns the mean of the recorded samples
+func (s *HistogramSnapshot) Mean() time.Duration {
+	if s == nil || s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
+// Percentiles summarizes the snapshot as p50/p90/p99/p99.9
+func (s *HistogramSnapshot) Percentiles() LatencyPercentiles {
+	if s == nil || s.Count == 0 {
+		return LatencyPercentiles{}
+	}
+
+	return LatencyPercentiles{
+		Count: s.Count,
+		Mean:  s.Mean(),
+		P50:   s.Quantile(0.50),
+		P90:   s.Quantile(0.90),
+		P99:   s.Quantile(0.99),
+		P999:  s.Quantile(0.999),
+		Max:   s.Max,
+	}
+}
+
+// MergeHistogramSnapshots merges any number of snapshots into one
+func MergeHistogramSnapshots(snapshots ...*HistogramSnapshot) *HistogramSnapshot {
+	merged := NewLatencyHistogram()
+	for _, snapshot := range snapshots {
+		merged.Merge(snapshot)
+	}
+	return merged.Snapshot()
+}
+
+// histogramBucketIndex returns the bucket a value falls into. Values below
+// histogramSubBuckets get a bucket each; above that, every power of two is split
+// into histogramSubBuckets buckets.
+func histogramBucketIndex(value int64) int {
+	if value < histogramSubBuckets {
+		if value < 0 {
+			return 0
+		}
+		return int(value)
+	}
+
+	shift := bits.Len64(uint64(value)) - histogramSubBucketBits - 1
+	return (shift+1)<<histogramSubBucketBits + int(value>>shift) - histogramSubBuckets
+}
+
+// histogramBucketBounds returns the smallest and largest value of a bucket
+func histogramBucketBounds(index int) (int64, int64) {
+	if index < histogramSubBuckets {
+		return int64(index), int64(index)
+	}
+
+	shift := index>>histogramSubBucketBits - 1
+	lower := int64(index&(histogramSubBuckets-1)+histogramSubBuckets) << shift
+	return lower, lower + int64(1)<<shift - 1
+}
+
+func clampDuration(d, lowest, highest time.Duration) time.Duration {
+	if d < lowest {
+		return lowest
+	}
+	if d > highest {
+		return highest
+	}
+	return d
+}
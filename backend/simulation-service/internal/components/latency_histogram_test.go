@@ -0,0 +1,161 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// withinPrecision reports whether a histogram value is within bucket precision of the exact value
+func withinPrecision(got, want time.Duration) bool {
+	return math.Abs(float64(got-want)) <= float64(want)/histogramSubBuckets+1
+}
+
+func TestLatencyHistogram_Percentiles(t *testing.T) {
+	histogram := NewLatencyHistogram()
+	for i := 1; i <= 1000; i++ {
+		histogram.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	p := histogram.Percentiles()
+	if p.Count != 1000 || p.Max != time.Second || p.Mean != 500500*time.Microsecond {
+		t.Errorf("Unexpected count/max/mean: %+v", p)
+	}
+
+	expected := map[string][2]time.Duration{
+		"p50":   {p.P50, 500 * time.Millisecond},
+		"p90":   {p.P90, 900 * time.Millisecond},
+		"p99":   {p.P99, 990 * time.Millisecond},
+		"p99.9": {p.P999, 999 * time.Millisecond},
+	}
+	for name, values := range expected {
+		if !withinPrecision(values[0], values[1]) {
+			t.Errorf("Expected %s near %v, got %v", name, values[1], values[0])
+		}
+	}
+}
+
+func TestLatencyHistogram_BucketBounds(t *testing.T) {
+	for _, value := range []int64{0, 1, 127, 128, 255, 256, 257, 1000, 123456789, math.MaxInt64} {
+		lower, upper := histogramBucketBounds(histogramBucketIndex(value))
+		if value < lower || value > upper {
+			t.Errorf("Value %d outside its bucket [%d, %d]", value, lower, upper)
+		}
+		if value >= histogramSubBuckets && float64(upper-lower) > float64(value)/histogramSubBuckets {
+			t.Errorf("Bucket [%d, %d] too wide for value %d", lower, upper, value)
+		}
+	}
+}
+
+func TestLatencyHistogram_MergeSnapshots(t *testing.T) {
+	fast, slow, all := NewLatencyHistogram(), NewLatencyHistogram(), NewLatencyHistogram()
+	for i := 1; i <= 900; i++ {
+		fast.Record(time.Duration(i) * time.Microsecond)
+		all.Record(time.Duration(i) * time.Microsecond)
+	}
+	for i := 1; i <= 100; i++ {
+		slow.Record(time.Duration(i) * time.Second)
+		all.Record(time.Duration(i) * time.Second)
+	}
+
+	merged := fast.Snapshot().Merge(slow.Snapshot())
+	if merged.Percentiles() != all.Percentiles() {
+		t.Errorf("Expected merged percentiles %+v, got %+v", all.Percentiles(), merged.Percentiles())
+	}
+	if merged.Min != time.Microsecond || merged.Max != 100*time.Second {
+		t.Errorf("Unexpected merged range [%v, %v]", merged.Min, merged.Max)
+	}
+
+	if empty := MergeHistogramSnapshots(); empty.Count != 0 || empty.Quantile(0.99) != 0 {
+		t.Errorf("Expected an empty merge, got %+v", empty)
+	}
+}
+
+func TestPerformanceTracker_LatencyScopes(t *testing.T) {
+	pms := NewPerformanceMonitoringSystem(&PerformanceMonitoringConfig{MetricsInterval: time.Hour})
+	defer pms.Stop()
+
+	pms.performanceTracker.AddRequestMetrics(&RequestMetrics{
+		RequestID:        "req-1",
+		Flow:             "checkout",
+		TotalLatency:     time.Hour, // Wall clock, ignored in favour of simulated time
+		SimulatedLatency: 40 * time.Millisecond,
+		Simulated:        true,
+		ComponentLatency: map[string]time.Duration{"web": 10 * time.Millisecond, "db": 30 * time.Millisecond},
+		EngineLatency:    map[string]time.Duration{"db/Storage": 25 * time.Millisecond},
+	})
+	pms.RecordLatency(LatencyScopeFlow, "checkout", 60*time.Millisecond)
+
+	flow := pms.GetLatencySnapshot(LatencyScopeFlow, "checkout")
+	if flow.Count != 2 || flow.Max != 60*time.Millisecond {
+		t.Errorf("Unexpected flow distribution: %+v", flow.Percentiles())
+	}
+	if system := pms.GetLatencySnapshot(LatencyScopeSystem, SystemLatencyName); system.Max != 40*time.Millisecond {
+		t.Errorf("Expected the simulated end-to-end latency, got %v", system.Max)
+	}
+
+	components := pms.GetLatencyPercentiles(LatencyScopeComponent)
+	if len(components) != 2 || components["db"].P50 != 30*time.Millisecond {
+		t.Errorf("Unexpected component percentiles: %+v", components)
+	}
+	if engine := pms.GetLatencySnapshot(LatencyScopeEngine, "db/Storage"); engine.Count != 1 {
+		t.Errorf("Expected one engine sample, got %d", engine.Count)
+	}
+
+	// A component's final result counts for the component, an engine result for its engine
+	pms.RecordOperationResult("db", &engines.OperationResult{
+		ProcessingTime: 50 * time.Millisecond,
+		Metrics:        map[string]interface{}{"is_final_result": true},
+	})
+	pms.RecordOperationResult("db", &engines.OperationResult{
+		ProcessingTime: 45 * time.Millisecond,
+		Metrics:        map[string]interface{}{"engine_type": "Storage"},
+	})
+	if db := pms.GetLatencySnapshot(LatencyScopeComponent, "db"); db.Count != 2 || db.Max != 50*time.Millisecond {
+		t.Errorf("Expected the final result under the component, got %+v", db.Percentiles())
+	}
+	if engine := pms.GetLatencySnapshot(LatencyScopeEngine, "db/Storage"); engine.Count != 2 || engine.Max != 45*time.Millisecond {
+		t.Errorf("Expected the engine result under its engine, got %+v", engine.Percentiles())
+	}
+
+	if missing := pms.GetLatencySnapshot(LatencyScopeEngine, "missing"); missing.Count != 0 {
+		t.Errorf("Expected an empty snapshot for an unknown name, got %+v", missing)
+	}
+}
+
+func TestPerformanceTracker_RequestMetrics(t *testing.T) {
+	pms := NewPerformanceMonitoringSystem(&PerformanceMonitoringConfig{MetricsInterval: time.Hour})
+	defer pms.Stop()
+	tracker := pms.performanceTracker
+
+	// Wall-clock latency alone must not reach the simulated-time histograms
+	tracker.AddRequestMetrics(&RequestMetrics{RequestID: "wall", Flow: "checkout", TotalLatency: time.Second})
+	if system := pms.GetLatencySnapshot(LatencyScopeSystem, SystemLatencyName); system.Count != 0 {
+		t.Errorf("Expected no system samples, got %d", system.Count)
+	}
+	if flow := pms.GetLatencySnapshot(LatencyScopeFlow, "checkout"); flow.Count != 0 {
+		t.Errorf("Expected no flow samples, got %d", flow.Count)
+	}
+
+	// A request finishing within its first tick still counts
+	tracker.AddRequestMetrics(&RequestMetrics{RequestID: "same-tick", Flow: "checkout", TotalLatency: time.Second, Simulated: true})
+	if flow := pms.GetLatencySnapshot(LatencyScopeFlow, "checkout"); flow.Count != 1 || flow.Max != 0 {
+		t.Errorf("Expected one zero-latency flow sample, got %+v", flow.Percentiles())
+	}
+
+	for i := 0; i < maxTrackedRequests+10; i++ {
+		tracker.AddRequestMetrics(&RequestMetrics{RequestID: fmt.Sprintf("req-%d", i), Simulated: true})
+	}
+	if len(tracker.requestMetrics) != maxTrackedRequests || len(tracker.requestOrder) != maxTrackedRequests {
+		t.Errorf("Expected %d tracked requests, got %d", maxTrackedRequests, len(tracker.requestMetrics))
+	}
+	if _, ok := tracker.requestMetrics["wall"]; ok {
+		t.Error("Expected the oldest requests to be evicted")
+	}
+	if _, ok := tracker.requestMetrics[fmt.Sprintf("req-%d", maxTrackedRequests+9)]; !ok {
+		t.Error("Expected the newest request to be kept")
+	}
+}
@@ -85,7 +85,7 @@ func (lb *LoadBalancer) Start(ctx context.Context) error {
 	lb.mutex.Lock()
 	defer lb.mutex.Unlock()
 
-	if lb.running {
+	if lb.running.Load() {
 		return fmt.Errorf("load balancer %s is already running", lb.ComponentID)
 	}
 
@@ -108,10 +108,12 @@ func (lb *LoadBalancer) Start(ctx context.Context) error {
 		}
 	}
 
-	// Start load balancer main goroutine
-	go lb.runLoadBalancer()
+	// Start load balancer main goroutine; tick-driven load balancers route inside ProcessTick
+	if !lb.tickDriven {
+		go lb.runLoadBalancer()
+	}
 
-	lb.running = true
+	lb.running.Store(true)
 	log.Printf("LoadBalancer %s: Started successfully with %d instances", lb.ComponentID, len(lb.Instances))
 
 	return nil
@@ -127,14 +129,14 @@ func (lb *LoadBalancer) StopWithTimeout(timeout time.Duration) error {
 	lb.mutex.Lock()
 	defer lb.mutex.Unlock()
 
-	if !lb.running {
+	if !lb.running.Load() {
 		return fmt.Errorf("load balancer %s is not running", lb.ComponentID)
 	}
 
 	log.Printf("LoadBalancer %s: Starting graceful shutdown (timeout: %v)", lb.ComponentID, timeout)
 
 	// Phase 1: Stop accepting new operations
-	lb.running = false // This prevents new operations from being accepted
+	lb.running.Store(false) // This prevents new operations from being accepted
 
 	// Phase 2: Cancel context to signal shutdown to goroutines
 	if lb.cancel != nil {
@@ -147,12 +149,13 @@ func (lb *LoadBalancer) StopWithTimeout(timeout time.Duration) error {
 	for _, instance := range lb.Instances {
 		go func(inst *ComponentInstance) {
 			// Set shutdown flag to stop accepting new operations
-			if shutdownFlag, exists := lb.InstanceShutdown[inst.ID]; exists {
-				shutdownFlag.Store(true)
-			}
+			inst.signalShutdown()
 
-			// Wait for instance to finish current operations
-			lb.waitForInstanceCompletion(inst, timeout/2) // Give each instance half the total timeout
+			// Wait for instance to finish current operations; tick-driven instances only
+			// make progress when ticked, so there is nothing to wait for
+			if !lb.tickDriven {
+				lb.waitForInstanceCompletion(inst, timeout/2) // Give each instance half the total timeout
+			}
 
 			// Stop instance
 			if err := inst.Stop(); err != nil {
@@ -294,7 +297,7 @@ func (lb *LoadBalancer) GetState() ComponentState {
 	lb.mutex.RLock()
 	defer lb.mutex.RUnlock()
 
-	if !lb.running {
+	if !lb.running.Load() {
 		return ComponentStateStopped
 	}
 
@@ -367,10 +370,10 @@ func (lb *LoadBalancer) GetMetrics() *ComponentMetrics {
 	lb.mutex.RLock()
 	defer lb.mutex.RUnlock()
 
-	// Aggregate metrics from all instances
-	totalOps := int64(0)
+	// Aggregate metrics from all instances, plus operations the load balancer could not route
+	totalOps := atomic.LoadInt64(&lb.Metrics.FailedOps)
 	completedOps := int64(0)
-	failedOps := int64(0)
+	failedOps := atomic.LoadInt64(&lb.Metrics.FailedOps)
 
 	for _, instance := range lb.Instances {
 		instanceMetrics := instance.GetMetrics()
@@ -392,10 +395,28 @@ func (lb *LoadBalancer) GetMetrics() *ComponentMetrics {
 	}
 }
 
+// metricsSnapshot copies the load balancer's own metrics, loading the counters
+// that routing updates atomically
+func (lb *LoadBalancer) metricsSnapshot() *ComponentMetrics {
+	return &ComponentMetrics{
+		ComponentID:        lb.Metrics.ComponentID,
+		ComponentType:      lb.Metrics.ComponentType,
+		State:              lb.Metrics.State,
+		Uptime:             lb.Metrics.Uptime,
+		TotalOperations:    atomic.LoadInt64(&lb.Metrics.TotalOperations),
+		CompletedOps:       atomic.LoadInt64(&lb.Metrics.CompletedOps),
+		FailedOps:          atomic.LoadInt64(&lb.Metrics.FailedOps),
+		AverageLatency:     lb.Metrics.AverageLatency,
+		CurrentUtilization: lb.Metrics.CurrentUtilization,
+		EngineMetrics:      lb.Metrics.EngineMetrics,
+		LastUpdated:        lb.Metrics.LastUpdated,
+	}
+}
+
 // ProcessOperation implements the Component interface for LoadBalancer
 func (lb *LoadBalancer) ProcessOperation(op *engines.Operation) error {
 	// Check if load balancer is shutting down
-	if !lb.running {
+	if !lb.running.Load() {
 		return fmt.Errorf("load balancer %s is shutting down, rejecting operation %s", lb.ComponentID, op.ID)
 	}
 
@@ -404,17 +425,90 @@ func (lb *LoadBalancer) ProcessOperation(op *engines.Operation) error {
 	case lb.InputChannel <- op:
 		return nil
 	default:
-		return fmt.Errorf("load balancer %s input channel is full", lb.ComponentID)
 	}
+
+	// Give the routing goroutine up to one tick timeout to drain a full channel
+	if timeout := lb.ComponentConfig.TickTimeout; timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case lb.InputChannel <- op:
+			return nil
+		case <-timer.C:
+		}
+	}
+
+	err := fmt.Errorf("load balancer %s input channel is full", lb.ComponentID)
+	compErr := WrapError(err, lb.ComponentID, op.ID)
+	compErr.Category = ErrorCategoryResource
+	compErr.Severity = ErrorSeverityHigh
+	if GlobalErrorHandler != nil {
+		GlobalErrorHandler.HandleError(context.Background(), compErr, lb.ComponentID)
+	}
+	return compErr
 }
 
-// ProcessTick implements the Component interface for LoadBalancer
+// ProcessTick implements the Component interface for LoadBalancer. A tick-driven
+// load balancer first routes its queued operations; the tick then goes to every instance.
 func (lb *LoadBalancer) ProcessTick(currentTick int64) error {
-	// Load balancer doesn't process ticks directly - instances do
-	// This could be used for auto-scaling decisions in the future
+	if lb.tickDriven {
+		for routed := false; !routed; {
+			select {
+			case op := <-lb.InputChannel:
+				lb.dispatchOperation(op)
+			default:
+				routed = true
+			}
+		}
+	}
+
+	lb.mutex.RLock()
+	instances := make([]*ComponentInstance, len(lb.Instances))
+	copy(instances, lb.Instances)
+	lb.mutex.RUnlock()
+
+	for _, instance := range instances {
+		if err := instance.ProcessTick(currentTick); err != nil {
+			log.Printf("LoadBalancer %s: Instance %s tick failed: %v", lb.ComponentID, instance.ID, err)
+		}
+	}
 	return nil
 }
 
+// SetTickDriven makes the load balancer and its instances run on the caller's
+// goroutine, driven by ProcessTick, with each tick lasting tickDuration of simulated
+// time. Auto-scaling runs on a wall-clock timer and is not applied in this mode.
+// Must be called before Start.
+func (lb *LoadBalancer) SetTickDriven(tickDuration time.Duration) {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	lb.tickDriven = true
+	lb.tickDuration = tickDuration
+	for _, instance := range lb.Instances {
+		instance.SetTickDriven(tickDuration)
+	}
+}
+
+// dispatchOperation routes one operation, counting and reporting operations that
+// no instance could take
+func (lb *LoadBalancer) dispatchOperation(op *engines.Operation) {
+	if err := lb.routeOperation(op); err != nil {
+		log.Printf("LoadBalancer %s: Error routing operation %s: %v", lb.ComponentID, op.ID, err)
+		atomic.AddInt64(&lb.Metrics.FailedOps, 1)
+
+		compErr := WrapError(err, lb.ComponentID, op.ID)
+		compErr.Category = ErrorCategoryResource
+		compErr.Severity = ErrorSeverityHigh
+		if GlobalErrorHandler != nil {
+			GlobalErrorHandler.HandleError(context.Background(), compErr, lb.ComponentID)
+		}
+		return
+	}
+	atomic.AddInt64(&lb.Metrics.TotalOperations, 1)
+}
+
 // runLoadBalancer is the main goroutine that handles load balancing
 func (lb *LoadBalancer) runLoadBalancer() {
 	log.Printf("LoadBalancer %s: Starting load balancer goroutine", lb.ComponentID)
@@ -434,12 +528,7 @@ func (lb *LoadBalancer) runLoadBalancer() {
 			select {
 			case op := <-lb.InputChannel:
 				// Route operation to appropriate instance
-				if err := lb.routeOperation(op); err != nil {
-					log.Printf("LoadBalancer %s: Error routing operation %s: %v", lb.ComponentID, op.ID, err)
-					lb.Metrics.FailedOps++
-				} else {
-					lb.Metrics.TotalOperations++
-				}
+				lb.dispatchOperation(op)
 
 			case <-autoScaleChannel:
 				// Perform auto-scaling check
@@ -454,12 +543,7 @@ func (lb *LoadBalancer) runLoadBalancer() {
 			select {
 			case op := <-lb.InputChannel:
 				// Route operation to appropriate instance
-				if err := lb.routeOperation(op); err != nil {
-					log.Printf("LoadBalancer %s: Error routing operation %s: %v", lb.ComponentID, op.ID, err)
-					lb.Metrics.FailedOps++
-				} else {
-					lb.Metrics.TotalOperations++
-				}
+				lb.dispatchOperation(op)
 
 			case <-lb.ctx.Done():
 				log.Printf("LoadBalancer %s: Load balancer goroutine stopping", lb.ComponentID)
@@ -492,10 +576,12 @@ func (lb *LoadBalancer) routeOperation(op *engines.Operation) error {
 	}
 }
 
-// selectInstance selects an instance based on the configured algorithm
+// selectInstance selects an instance based on the configured algorithm. The
+// round-robin and weighted algorithms advance their selection state, so it
+// takes the write lock.
 func (lb *LoadBalancer) selectInstance() (*ComponentInstance, error) {
-	lb.mutex.RLock()
-	defer lb.mutex.RUnlock()
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
 
 	if len(lb.Instances) == 0 {
 		return nil, fmt.Errorf("no instances available")
@@ -548,7 +634,7 @@ func (lb *LoadBalancer) selectRoundRobin(instances []*ComponentInstance) *Compon
 		return nil
 	}
 
-	// Use atomic operation for thread-safe round-robin
+	// Callers hold the write lock
 	index := lb.RoundRobinIndex % len(instances)
 	lb.RoundRobinIndex = (lb.RoundRobinIndex + 1) % len(instances)
 
@@ -713,18 +799,43 @@ func (lb *LoadBalancer) createInstance() error {
 	instanceID := fmt.Sprintf("%s-instance-%d", lb.ComponentID, lb.NextInstanceID)
 	lb.NextInstanceID++
 
+	// Instances run the component's engines with its profiles and limits
+	config := lb.ComponentConfig
+	requiredEngines := config.RequiredEngines
+	if len(requiredEngines) == 0 {
+		requiredEngines = []engines.EngineType{engines.NetworkEngineType, engines.CPUEngineType, engines.MemoryEngineType, engines.StorageEngineType}
+	}
+	maxConcurrentOps := config.MaxConcurrentOps
+	if maxConcurrentOps <= 0 {
+		maxConcurrentOps = 5
+	}
+	queueCapacity := config.QueueCapacity
+	if queueCapacity <= 0 {
+		queueCapacity = 50
+	}
+	tickTimeout := config.TickTimeout
+	if tickTimeout <= 0 {
+		tickTimeout = time.Millisecond * 10
+	}
+
 	// Create instance configuration
 	instanceConfig := &ComponentConfig{
 		ID:               instanceID,
 		Type:             lb.ComponentType,
 		Name:             fmt.Sprintf("%s Instance %d", lb.ComponentID, lb.NextInstanceID-1),
 		Description:      fmt.Sprintf("Instance of component %s", lb.ComponentID),
-		RequiredEngines:  []engines.EngineType{engines.NetworkEngineType, engines.CPUEngineType, engines.MemoryEngineType, engines.StorageEngineType},
-		MaxConcurrentOps: 5,
-		QueueCapacity:    50,
-		TickTimeout:      time.Millisecond * 10,
-		EngineProfiles:   make(map[engines.EngineType]string),
-		ComplexityLevels: make(map[engines.EngineType]int),
+		RequiredEngines:  requiredEngines,
+		EngineProfiles:   config.EngineProfiles,
+		ComplexityLevels: config.ComplexityLevels,
+		Runtime:          config.Runtime,
+		DecisionGraph:    config.DecisionGraph,
+		MaxConcurrentOps: maxConcurrentOps,
+		QueueCapacity:    queueCapacity,
+		TickTimeout:      tickTimeout,
+		UserFlow:         config.UserFlow,
+		RoutingRules:     config.RoutingRules,
+		ProfilesPath:     config.ProfilesPath,
+		Seed:             engines.DeriveSeed(config.Seed, instanceID),
 	}
 
 	// Create the instance
@@ -756,12 +867,35 @@ func (lb *LoadBalancer) createInstance() error {
 	// Set the atomic flags in the instance
 	instance.ReadyFlag = readyFlag
 	instance.ShutdownFlag = shutdownFlag
+	instance.resultObserver = lb.bindObserver()
+	instance.crashObserver = lb.handleInstanceCrash
+
+	// Results are routed on behalf of the component, not the instance
+	instance.ComponentID = lb.ComponentID
+	instance.Executor.ComponentID = lb.ComponentID
+	instance.CentralizedOutput.ComponentID = lb.ComponentID
+	if lb.GlobalRegistry != nil {
+		instance.SetRegistry(lb.GlobalRegistry)
+	}
+	if lb.tickDriven {
+		instance.SetTickDriven(lb.tickDuration)
+	}
 
 	log.Printf("LoadBalancer %s: Created instance %s with weight %d (total weight: %d)",
 		lb.ComponentID, instanceID, instanceWeight, lb.TotalWeight)
 	return nil
 }
 
+// handleInstanceCrash marks an instance whose process was killed as unhealthy.
+// Its ready flag is already cleared, so no further operations are routed to it.
+func (lb *LoadBalancer) handleInstanceCrash(instanceID string, err error) {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	lb.InstanceHealth[instanceID] = 0
+	log.Printf("LoadBalancer %s: Instance %s crashed: %v", lb.ComponentID, instanceID, err)
+}
+
 // removeInstance removes an instance from the load balancer
 func (lb *LoadBalancer) removeInstance(instanceID string) error {
 	lb.mutex.Lock()
@@ -803,13 +937,139 @@ func (lb *LoadBalancer) GetInputChannel() chan *engines.Operation {
 	return lb.InputChannel
 }
 
+// GetQueueDepth returns the number of operations waiting in the load balancer and all instance queues
+func (lb *LoadBalancer) GetQueueDepth() int {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+
+	depth := len(lb.InputChannel)
+	for _, instance := range lb.Instances {
+		depth += len(instance.InputChannel)
+	}
+
+	return depth
+}
+
+// GetInstances returns a snapshot of the load balancer's current instances
+func (lb *LoadBalancer) GetInstances() []*ComponentInstance {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+
+	instances := make([]*ComponentInstance, len(lb.Instances))
+	copy(instances, lb.Instances)
+	return instances
+}
+
+// GetLoadBalancerMetrics returns request totals and instance health across all instances
+func (lb *LoadBalancer) GetLoadBalancerMetrics() *LoadBalancerMetrics {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+
+	metrics := &LoadBalancerMetrics{
+		InstanceCount:   len(lb.Instances),
+		RoundRobinIndex: lb.RoundRobinIndex,
+	}
+	if !lb.LastScaleUp.IsZero() {
+		metrics.LastScaleUp = lb.LastScaleUp.Format(time.RFC3339)
+	}
+	if !lb.LastScaleDown.IsZero() {
+		metrics.LastScaleDown = lb.LastScaleDown.Format(time.RFC3339)
+	}
+
+	var totalLatency time.Duration
+	for _, instance := range lb.Instances {
+		if instance.IsHealthy() {
+			metrics.HealthyInstances++
+		} else {
+			metrics.UnhealthyInstances++
+		}
+
+		instanceMetrics := instance.GetMetrics()
+		if instanceMetrics == nil {
+			continue
+		}
+		metrics.TotalRequests += instanceMetrics.TotalOperations
+		metrics.SuccessfulRequests += instanceMetrics.CompletedOps
+		metrics.FailedRequests += instanceMetrics.FailedOps
+		totalLatency += instanceMetrics.AverageLatency * time.Duration(instanceMetrics.CompletedOps)
+	}
+
+	// Request-weighted mean of the instance latencies, in milliseconds
+	if metrics.SuccessfulRequests > 0 {
+		metrics.AverageResponseTime = float64(totalLatency) / float64(metrics.SuccessfulRequests) / float64(time.Millisecond)
+	}
+
+	return metrics
+}
+
+// GetAutoScalingMetrics returns the scaling actions taken by the load balancer's own auto-scaler
+func (lb *LoadBalancer) GetAutoScalingMetrics() *AutoScalingMetrics {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+
+	return &AutoScalingMetrics{
+		TotalScaleUps:   lb.TotalScaleUps,
+		TotalScaleDowns: lb.TotalScaleDowns,
+		EfficiencyScore: scalingEfficiency(lb.TotalScaleUps + lb.TotalScaleDowns),
+		CostSavings:     float64(lb.TotalScaleDowns) * scaleDownSavings,
+	}
+}
+
+// NextEventTick implements next-event scheduling for the clock coordinator: the
+// earliest tick at which the load balancer or any of its instances has work to do
+func (lb *LoadBalancer) NextEventTick(currentTick int64) (int64, bool) {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+
+	if len(lb.InputChannel) > 0 {
+		return currentTick + 1, true
+	}
+
+	next, pending := int64(0), false
+	for _, instance := range lb.Instances {
+		if tick, ok := instance.NextEventTick(currentTick); ok && (!pending || tick < next) {
+			next, pending = tick, true
+		}
+	}
+
+	return next, pending
+}
+
 // GetOutputChannel returns the load balancer's output channel
 func (lb *LoadBalancer) GetOutputChannel() chan *engines.OperationResult {
 	return lb.OutputChannel
 }
 
+// SetObserver registers an observer for engine results produced by every instance,
+// including instances created later by auto-scaling. Must be called before Start.
+func (lb *LoadBalancer) SetObserver(observer OperationObserver) {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	lb.observer = observer
+	for _, instance := range lb.Instances {
+		instance.resultObserver = lb.bindObserver()
+	}
+}
+
+// bindObserver returns the observer bound to this component's ID (caller must hold the lock)
+func (lb *LoadBalancer) bindObserver() func(*engines.OperationResult) {
+	if lb.observer == nil {
+		return nil
+	}
+
+	observer := lb.observer
+	componentID := lb.ComponentID
+	return func(result *engines.OperationResult) {
+		observer(componentID, result)
+	}
+}
+
 // SetRegistry sets the global registry for the load balancer and all instances
 func (lb *LoadBalancer) SetRegistry(registry GlobalRegistryInterface) {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
 	lb.GlobalRegistry = registry
 
 	// Set registry for all instances
@@ -826,7 +1086,10 @@ func (lb *LoadBalancer) SaveState() error {
 
 	log.Printf("LoadBalancer %s: Saving state", lb.ComponentID)
 
-	if err := GlobalStatePersistenceManager.SaveLoadBalancerState(lb); err != nil {
+	lb.mutex.RLock()
+	err := GlobalStatePersistenceManager.SaveLoadBalancerState(lb)
+	lb.mutex.RUnlock()
+	if err != nil {
 		return fmt.Errorf("failed to save load balancer state: %w", err)
 	}
 
@@ -847,6 +1110,9 @@ func (lb *LoadBalancer) LoadState(componentID string) error {
 		return fmt.Errorf("failed to load load balancer state: %w", err)
 	}
 
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
 	// Restore basic state
 	lb.ComponentID = state.ComponentID
 	lb.ComponentType = state.ComponentType
@@ -987,40 +1253,23 @@ func (lb *LoadBalancer) scaleUp() {
 		return
 	}
 
-	newInstanceID := fmt.Sprintf("%s-instance-%d", lb.ComponentID, len(lb.Instances)+1)
-
-	log.Printf("LoadBalancer %s: Scaling up - adding instance %s", lb.ComponentID, newInstanceID)
+	log.Printf("LoadBalancer %s: Scaling up - adding instance %s-instance-%d", lb.ComponentID, lb.ComponentID, lb.NextInstanceID)
 
-	// Create new instance config based on existing config
-	instanceConfig := &ComponentConfig{
-		ID:               newInstanceID,
-		Type:             lb.ComponentConfig.Type,
-		Name:             fmt.Sprintf("%s Instance %d", lb.ComponentConfig.Name, len(lb.Instances)+1),
-		Description:      fmt.Sprintf("Auto-scaled instance for %s", lb.ComponentID),
-		RequiredEngines:  lb.ComponentConfig.RequiredEngines,
-		MaxConcurrentOps: lb.ComponentConfig.MaxConcurrentOps,
-		QueueCapacity:    lb.ComponentConfig.QueueCapacity,
-		TickTimeout:      lb.ComponentConfig.TickTimeout,
-		EngineProfiles:   lb.ComponentConfig.EngineProfiles,
-		ComplexityLevels: lb.ComponentConfig.ComplexityLevels,
-	}
-
-	// Create new instance
-	newInstance, err := NewComponentInstance(instanceConfig)
-	if err != nil {
+	// Create new instance the same way as the initial ones
+	if err := lb.createInstance(); err != nil {
 		log.Printf("LoadBalancer %s: Failed to create new instance: %v", lb.ComponentID, err)
 		return
 	}
 
 	// Start the new instance
+	newInstance := lb.Instances[len(lb.Instances)-1]
 	if err := newInstance.Start(lb.ctx); err != nil {
 		log.Printf("LoadBalancer %s: Failed to start new instance: %v", lb.ComponentID, err)
 		return
 	}
 
-	// Add to instances list
-	lb.Instances = append(lb.Instances, newInstance)
 	lb.LastScaleUp = time.Now()
+	lb.TotalScaleUps++
 
 	log.Printf("LoadBalancer %s: Successfully scaled up to %d instances", lb.ComponentID, len(lb.Instances))
 }
@@ -1050,6 +1299,7 @@ func (lb *LoadBalancer) scaleDown() {
 	// Remove from instances list
 	lb.Instances = append(lb.Instances[:leastLoadedIndex], lb.Instances[leastLoadedIndex+1:]...)
 	lb.LastScaleDown = time.Now()
+	lb.TotalScaleDowns++
 
 	log.Printf("LoadBalancer %s: Successfully scaled down to %d instances", lb.ComponentID, len(lb.Instances))
 }
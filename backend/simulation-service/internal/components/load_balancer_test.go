@@ -241,3 +241,69 @@ func TestLoadBalancer_HealthChecking(t *testing.T) {
 		t.Fatalf("Failed to stop load balancer: %v", err)
 	}
 }
+
+// TestLoadBalancer_OOMKillCrashesInstance tests that an allocation past the
+// memory engine's limit kills the instance's process: the instance leaves
+// rotation and the operation is counted as failed once
+func TestLoadBalancer_OOMKillCrashesInstance(t *testing.T) {
+	config := &ComponentConfig{
+		ID:   "oom-component",
+		Type: ComponentTypeWebServer,
+		LoadBalancer: &LoadBalancingConfig{
+			Algorithm:    LoadBalancingNone,
+			MinInstances: 1,
+			MaxInstances: 1,
+		},
+		RequiredEngines:  []engines.EngineType{engines.MemoryEngineType},
+		MaxConcurrentOps: 5,
+		QueueCapacity:    50,
+		TickTimeout:      time.Millisecond * 10,
+		EngineProfiles:   make(map[engines.EngineType]string),
+		ComplexityLevels: make(map[engines.EngineType]int),
+	}
+
+	lb, err := NewLoadBalancer(config)
+	if err != nil {
+		t.Fatalf("Failed to create load balancer: %v", err)
+	}
+	lb.SetTickDriven(10 * time.Microsecond)
+	if err := lb.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start load balancer: %v", err)
+	}
+	defer lb.Stop()
+
+	instance := lb.Instances[0]
+	memory, ok := instance.Engines[engines.MemoryEngineType].GetEngine().(*engines.MemoryEngine)
+	if !ok {
+		t.Fatalf("Expected the instance to run a memory engine")
+	}
+	memory.Capacity.LimitBytes = 1 << 20
+	memory.Capacity.SwapBytes = 0
+
+	// The allocation is twice the instance's memory limit
+	operation := &engines.Operation{ID: "leak", Type: engines.OpMemoryAlloc, DataSize: 2 << 20, Complexity: engines.ComplexityO1}
+	if err := lb.ProcessOperation(operation); err != nil {
+		t.Fatalf("Failed to process operation: %v", err)
+	}
+
+	for tick := int64(1); tick <= 1000 && !instance.crashed.Load(); tick++ {
+		lb.ProcessTick(tick)
+	}
+	lb.ProcessTick(1001)
+
+	if !instance.crashed.Load() {
+		t.Fatalf("Expected the allocation to OOM-kill the instance")
+	}
+	if instance.ReadyFlag.Load() || instance.Health.Status != "RED" || instance.Health.IsAcceptingLoad {
+		t.Errorf("Expected the killed instance to leave rotation, got ready=%t health=%+v", instance.ReadyFlag.Load(), instance.Health)
+	}
+	if failed := instance.Metrics.FailedOps; failed != 1 {
+		t.Errorf("Expected the killed operation to be counted once, got %d failed", failed)
+	}
+	if completed := instance.Metrics.CompletedOps; completed != 0 {
+		t.Errorf("Expected no completed operations, got %d", completed)
+	}
+	if health := lb.InstanceHealth[instance.ID]; health != 0 {
+		t.Errorf("Expected the load balancer to mark the instance unhealthy, got %v", health)
+	}
+}
@@ -2,7 +2,6 @@ package components
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"sync"
 	"time"
@@ -51,14 +50,18 @@ type PerformanceMonitoringConfig struct {
 
 // PerformanceTracker tracks detailed performance metrics
 type PerformanceTracker struct {
-	// Request tracking
+	// Request tracking, oldest first in requestOrder
 	requestMetrics    map[string]*RequestMetrics
-	componentMetrics  map[string]*ComponentMetrics
+	requestOrder      []string
+	componentMetrics  map[string]*ComponentPerformanceMetrics
 	systemMetrics     *SystemMetrics
 	
 	// Time series data
 	timeSeriesData    map[string][]TimeSeriesPoint
 	
+	// Latency distributions in simulated time, by scope and name
+	latencyHistograms map[LatencyScope]map[string]*LatencyHistogram
+	
 	// Lifecycle
 	mutex             sync.RWMutex
 	ctx               context.Context
@@ -69,9 +72,12 @@ type PerformanceTracker struct {
 // RequestMetrics tracks metrics for individual requests
 type RequestMetrics struct {
 	RequestID         string            `json:"request_id"`
+	Flow              string            `json:"flow,omitempty"`
 	StartTime         time.Time         `json:"start_time"`
 	EndTime           time.Time         `json:"end_time"`
 	TotalLatency      time.Duration     `json:"total_latency"`
+	SimulatedLatency  time.Duration     `json:"simulated_latency"` // End-to-end latency in simulated time
+	Simulated         bool              `json:"simulated"`         // Whether SimulatedLatency was measured; only these reach the histograms
 	ComponentLatency  map[string]time.Duration `json:"component_latency"`
 	EngineLatency     map[string]time.Duration `json:"engine_latency"`
 	ComponentCount    int               `json:"component_count"`
@@ -80,8 +86,8 @@ type RequestMetrics struct {
 	ErrorType         string            `json:"error_type,omitempty"`
 }
 
-// ComponentMetrics tracks metrics for components
-type ComponentMetrics struct {
+// ComponentPerformanceMetrics tracks request metrics for components
+type ComponentPerformanceMetrics struct {
 	ComponentID       string            `json:"component_id"`
 	TotalRequests     int64             `json:"total_requests"`
 	SuccessfulRequests int64            `json:"successful_requests"`
@@ -102,12 +108,29 @@ type SystemMetrics struct {
 	ActiveRequests    int64             `json:"active_requests"`
 	SystemThroughput  float64           `json:"system_throughput"`
 	AvgSystemLatency  time.Duration     `json:"avg_system_latency"`
+	LatencyPercentiles LatencyPercentiles `json:"latency_percentiles"` // End-to-end, in simulated time
 	SystemHealth      float64           `json:"system_health"`
 	ComponentCount    int               `json:"component_count"`
 	InstanceCount     int               `json:"instance_count"`
 	LastUpdate        time.Time         `json:"last_update"`
 }
 
+// LatencyScope groups latency histograms by what they measure
+type LatencyScope string
+
+const (
+	LatencyScopeSystem    LatencyScope = "system"    // End-to-end request latency across the whole system
+	LatencyScopeFlow      LatencyScope = "flow"      // End-to-end request latency per user flow
+	LatencyScopeComponent LatencyScope = "component" // Per-hop latency spent in each component
+	LatencyScopeEngine    LatencyScope = "engine"    // Per-hop latency spent in each engine, named "<component>/<engine>"
+)
+
+// SystemLatencyName is the name of the single histogram in LatencyScopeSystem
+const SystemLatencyName = "system"
+
+// maxTrackedRequests bounds how many finished requests the tracker keeps
+const maxTrackedRequests = 10000
+
 // TimeSeriesPoint represents a point in time series data
 type TimeSeriesPoint struct {
 	Timestamp time.Time   `json:"timestamp"`
@@ -256,7 +279,7 @@ type DashboardManager struct {
 // DashboardData contains all dashboard information
 type DashboardData struct {
 	SystemOverview    *SystemOverview    `json:"system_overview"`
-	ComponentMetrics  []*ComponentMetrics `json:"component_metrics"`
+	ComponentMetrics  []*ComponentPerformanceMetrics `json:"component_metrics"`
 	PerformanceCharts []PerformanceChart `json:"performance_charts"`
 	Insights          []Insight          `json:"insights"`
 	ABTestResults     []*ABTestResult    `json:"ab_test_results"`
@@ -353,8 +376,23 @@ func (pms *PerformanceMonitoringSystem) Stop() error {
 	return nil
 }
 
-// TrackRequest tracks a request through the system
+// TrackRequest tracks a request through the system; having only wall-clock
+// latency, it stays out of the end-to-end latency histograms
 func (pms *PerformanceMonitoringSystem) TrackRequest(request *Request) {
+	pms.performanceTracker.AddRequestMetrics(newRequestMetrics(request))
+}
+
+// TrackSimulatedRequest tracks a finished request whose end-to-end latency was
+// measured in simulated time, recording it in the latency histograms
+func (pms *PerformanceMonitoringSystem) TrackSimulatedRequest(request *Request, simulatedLatency time.Duration) {
+	metrics := newRequestMetrics(request)
+	metrics.SimulatedLatency = simulatedLatency
+	metrics.Simulated = true
+	pms.performanceTracker.AddRequestMetrics(metrics)
+}
+
+// newRequestMetrics builds the metrics recorded for a request
+func newRequestMetrics(request *Request) *RequestMetrics {
 	metrics := &RequestMetrics{
 		RequestID:        request.ID,
 		StartTime:        request.StartTime,
@@ -367,11 +405,55 @@ func (pms *PerformanceMonitoringSystem) TrackRequest(request *Request) {
 		Success:          request.Status == RequestStatusCompleted,
 	}
 	
+	if request.FlowChain != nil && len(request.FlowChain.Flows) > 0 {
+		metrics.Flow = request.FlowChain.Flows[0]
+	}
+	
 	if request.Status == RequestStatusFailed {
 		metrics.ErrorType = "request_failed"
 	}
 	
-	pms.performanceTracker.AddRequestMetrics(metrics)
+	return metrics
+}
+
+// RecordLatency records a latency sample, in simulated time, under a scope and name
+func (pms *PerformanceMonitoringSystem) RecordLatency(scope LatencyScope, name string, latency time.Duration) {
+	pms.performanceTracker.RecordLatency(scope, name, latency)
+}
+
+// RecordOperationResult records the per-hop latency of a result produced by a
+// component: a component's final result under the component, an engine result
+// under "<component>/<engine>"
+func (pms *PerformanceMonitoringSystem) RecordOperationResult(componentID string, result *engines.OperationResult) {
+	if final, _ := result.Metrics["is_final_result"].(bool); final {
+		pms.performanceTracker.RecordLatency(LatencyScopeComponent, componentID, result.ProcessingTime)
+		return
+	}
+	
+	if engineType, ok := result.Metrics["engine_type"].(string); ok && engineType != "" {
+		pms.performanceTracker.RecordLatency(LatencyScopeEngine, componentID+"/"+engineType, result.ProcessingTime)
+	}
+}
+
+// GetLatencySnapshot returns the latency distribution recorded under a scope and name
+func (pms *PerformanceMonitoringSystem) GetLatencySnapshot(scope LatencyScope, name string) *HistogramSnapshot {
+	return pms.performanceTracker.GetLatencySnapshot(scope, name)
+}
+
+// GetLatencySnapshots returns every latency distribution recorded under a scope, by name
+func (pms *PerformanceMonitoringSystem) GetLatencySnapshots(scope LatencyScope) map[string]*HistogramSnapshot {
+	return pms.performanceTracker.GetLatencySnapshots(scope)
+}
+
+// GetLatencyPercentiles returns p50/p90/p99/p99.9 for every name under a scope
+func (pms *PerformanceMonitoringSystem) GetLatencyPercentiles(scope LatencyScope) map[string]LatencyPercentiles {
+	snapshots := pms.performanceTracker.GetLatencySnapshots(scope)
+	
+	percentiles := make(map[string]LatencyPercentiles, len(snapshots))
+	for name, snapshot := range snapshots {
+		percentiles[name] = snapshot.Percentiles()
+	}
+	return percentiles
 }
 
 // NewPerformanceTracker creates a new performance tracker
@@ -380,9 +462,10 @@ func NewPerformanceTracker(ctx context.Context, interval time.Duration) *Perform
 	
 	return &PerformanceTracker{
 		requestMetrics:   make(map[string]*RequestMetrics),
-		componentMetrics: make(map[string]*ComponentMetrics),
+		componentMetrics: make(map[string]*ComponentPerformanceMetrics),
 		systemMetrics:    &SystemMetrics{},
 		timeSeriesData:   make(map[string][]TimeSeriesPoint),
+		latencyHistograms: make(map[LatencyScope]map[string]*LatencyHistogram),
 		ctx:              trackerCtx,
 		cancel:           cancel,
 		ticker:           time.NewTicker(interval),
@@ -443,6 +526,10 @@ func (pt *PerformanceTracker) updateSystemMetrics() {
 		pt.systemMetrics.SystemHealth = totalHealth / float64(componentCount)
 	}
 	
+	if system := pt.latencyHistograms[LatencyScopeSystem][SystemLatencyName]; system != nil {
+		pt.systemMetrics.LatencyPercentiles = system.Percentiles()
+	}
+	
 	pt.systemMetrics.TotalRequests = totalRequests
 	pt.systemMetrics.ComponentCount = componentCount
 	pt.systemMetrics.LastUpdate = time.Now()
@@ -490,10 +577,83 @@ func (pt *PerformanceTracker) AddRequestMetrics(metrics *RequestMetrics) {
 	pt.mutex.Lock()
 	defer pt.mutex.Unlock()
 	
+	if _, exists := pt.requestMetrics[metrics.RequestID]; !exists {
+		pt.requestOrder = append(pt.requestOrder, metrics.RequestID)
+	}
 	pt.requestMetrics[metrics.RequestID] = metrics
 	
-	// Update component metrics based on request
-	// This would be implemented based on the request's component journey
+	// Evict the oldest requests once the cap is reached
+	for len(pt.requestOrder) > maxTrackedRequests {
+		delete(pt.requestMetrics, pt.requestOrder[0])
+		pt.requestOrder = pt.requestOrder[1:]
+	}
+	
+	// The histograms are in simulated time, so wall-clock-only requests stay out of them
+	if metrics.Simulated {
+		pt.histogram(LatencyScopeSystem, SystemLatencyName).Record(metrics.SimulatedLatency)
+		if metrics.Flow != "" {
+			pt.histogram(LatencyScopeFlow, metrics.Flow).Record(metrics.SimulatedLatency)
+		}
+	}
+	
+	// Record the per-hop latencies of the request's journey
+	for componentID, hop := range metrics.ComponentLatency {
+		pt.histogram(LatencyScopeComponent, componentID).Record(hop)
+	}
+	for engine, hop := range metrics.EngineLatency {
+		pt.histogram(LatencyScopeEngine, engine).Record(hop)
+	}
+}
+
+// RecordLatency records a latency sample under a scope and name
+func (pt *PerformanceTracker) RecordLatency(scope LatencyScope, name string, latency time.Duration) {
+	pt.mutex.Lock()
+	histogram := pt.histogram(scope, name)
+	pt.mutex.Unlock()
+	
+	histogram.Record(latency)
+}
+
+// GetLatencySnapshot returns a snapshot of the histogram for a scope and name,
+// or an empty snapshot if nothing was recorded under it
+func (pt *PerformanceTracker) GetLatencySnapshot(scope LatencyScope, name string) *HistogramSnapshot {
+	pt.mutex.RLock()
+	histogram := pt.latencyHistograms[scope][name]
+	pt.mutex.RUnlock()
+	
+	if histogram == nil {
+		return &HistogramSnapshot{}
+	}
+	return histogram.Snapshot()
+}
+
+// GetLatencySnapshots returns snapshots of every histogram under a scope, by name
+func (pt *PerformanceTracker) GetLatencySnapshots(scope LatencyScope) map[string]*HistogramSnapshot {
+	pt.mutex.RLock()
+	defer pt.mutex.RUnlock()
+	
+	snapshots := make(map[string]*HistogramSnapshot, len(pt.latencyHistograms[scope]))
+	for name, histogram := range pt.latencyHistograms[scope] {
+		snapshots[name] = histogram.Snapshot()
+	}
+	return snapshots
+}
+
+// histogram returns the histogram for a scope and name, creating it on first use
+// (caller must hold the write lock)
+func (pt *PerformanceTracker) histogram(scope LatencyScope, name string) *LatencyHistogram {
+	byName, exists := pt.latencyHistograms[scope]
+	if !exists {
+		byName = make(map[string]*LatencyHistogram)
+		pt.latencyHistograms[scope] = byName
+	}
+	
+	histogram, exists := byName[name]
+	if !exists {
+		histogram = NewLatencyHistogram()
+		byName[name] = histogram
+	}
+	return histogram
 }
 
 // GetSystemMetrics returns current system metrics
@@ -507,6 +667,7 @@ func (pt *PerformanceTracker) GetSystemMetrics() *SystemMetrics {
 		ActiveRequests:   pt.systemMetrics.ActiveRequests,
 		SystemThroughput: pt.systemMetrics.SystemThroughput,
 		AvgSystemLatency: pt.systemMetrics.AvgSystemLatency,
+		LatencyPercentiles: pt.systemMetrics.LatencyPercentiles,
 		SystemHealth:     pt.systemMetrics.SystemHealth,
 		ComponentCount:   pt.systemMetrics.ComponentCount,
 		InstanceCount:    pt.systemMetrics.InstanceCount,
@@ -586,7 +747,7 @@ func NewDashboardManager(enabled bool, port int) *DashboardManager {
 	return &DashboardManager{
 		dashboardData: &DashboardData{
 			SystemOverview:    &SystemOverview{},
-			ComponentMetrics:  make([]*ComponentMetrics, 0),
+			ComponentMetrics:  make([]*ComponentPerformanceMetrics, 0),
 			PerformanceCharts: make([]PerformanceChart, 0),
 			Insights:          make([]Insight, 0),
 			ABTestResults:     make([]*ABTestResult, 0),
@@ -656,7 +817,6 @@ func (dm *DashboardManager) updateDashboard() {
 
 	// Update system overview
 	dm.dashboardData.SystemOverview.Status = "running"
-	dm.dashboardData.SystemOverview.LastUpdate = time.Now()
 }
 
 // Helper functions for creating default configurations
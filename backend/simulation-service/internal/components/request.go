@@ -2,8 +2,6 @@ package components
 
 import (
 	"time"
-
-	"github.com/systemsim/simulation-service/internal/engines"
 )
 
 // Request represents a request flowing through the system with shared references
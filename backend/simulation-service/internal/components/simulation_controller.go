@@ -6,8 +6,6 @@ import (
 	"log"
 	"sync"
 	"time"
-
-	"github.com/systemsim/simulation-service/internal/engines"
 )
 
 // SimulationController manages the entire simulation lifecycle with pause/resume,
@@ -21,7 +19,7 @@ type SimulationController struct {
 	timeoutErrorSystem     *TimeoutErrorSystem
 	
 	// Component management
-	components             map[string]ComponentInterface
+	components             map[string]Component
 	loadBalancers          map[string]ComponentLoadBalancerInterface
 	
 	// Simulation state
@@ -204,7 +202,7 @@ func NewSimulationController(config *SimulationControllerConfig) *SimulationCont
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	return &SimulationController{
-		components:            make(map[string]ComponentInterface),
+		components:            make(map[string]Component),
 		loadBalancers:         make(map[string]ComponentLoadBalancerInterface),
 		simulationID:          fmt.Sprintf("sim_%d", time.Now().Unix()),
 		status:                SimulationStatusStopped,
@@ -377,6 +375,26 @@ func (sc *SimulationController) Stop() error {
 	return nil
 }
 
+// GetStatus returns the current simulation status
+func (sc *SimulationController) GetStatus() SimulationStatus {
+	sc.mutex.RLock()
+	defer sc.mutex.RUnlock()
+	
+	return sc.status
+}
+
+// GetAutoScalingMetrics returns the auto-scaling metrics of every component managed by
+// the auto-scaling system, or nil when auto-scaling is not enabled
+func (sc *SimulationController) GetAutoScalingMetrics() map[string]*AutoScalingMetrics {
+	sc.mutex.RLock()
+	defer sc.mutex.RUnlock()
+
+	if sc.autoScalingSystem == nil {
+		return nil
+	}
+	return sc.autoScalingSystem.GetMetrics()
+}
+
 // run is the main simulation loop
 func (sc *SimulationController) run() {
 	ticker := time.NewTicker(1 * time.Second)
@@ -492,10 +510,10 @@ func (sc *SimulationController) checkSimulationHealth() {
 	totalComponents := len(sc.components)
 	
 	for componentID, component := range sc.components {
-		if component.GetHealth() < 0.5 {
+		if !component.IsHealthy() {
 			unhealthyComponents++
-			log.Printf("SimulationController: Component %s is unhealthy (health: %.2f)", 
-				componentID, component.GetHealth())
+			log.Printf("SimulationController: Component %s is unhealthy (status: %s)", 
+				componentID, component.GetHealth().Status)
 		}
 	}
 	
@@ -1,9 +1,13 @@
 package components
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -84,29 +88,29 @@ func (esm *EngineStateManager) RestoreAllEngineStates(states map[string]*EngineS
 
 // captureEngineState captures the state of a single engine
 func (esm *EngineStateManager) captureEngineState(engineID string, engine *engines.EngineWrapper) *EngineState {
-	// Get engine metrics and state
-	metrics := engine.GetMetrics()
-	
+	baseEngine := engine.GetEngine()
+	health := baseEngine.GetHealth()
+
 	state := &EngineState{
-		EngineID:         engineID,
-		EngineType:       engine.GetType(),
-		ComponentID:      engine.GetComponentID(),
-		InstanceID:       engine.GetInstanceID(),
-		Profile:          engine.GetProfile(),
-		Configuration:    engine.GetConfiguration(),
-		IsRunning:        engine.IsRunning(),
-		CurrentLoad:      engine.GetCurrentLoad(),
-		Health:           engine.GetHealth(),
-		ActiveOperations: engine.GetActiveOperations(),
-		OperationQueue:   engine.GetOperationQueue(),
-		Metrics:          metrics,
-		LastUpdate:       time.Now(),
+		EngineID:        engineID,
+		EngineType:      baseEngine.GetEngineType(),
+		Profile:         baseEngine.GetProfile(),
+		ComplexityLevel: engine.GetComplexityLevel(),
+		Seed:            engine.GetSeed(),
+		IsRunning:       engine.IsRunning(),
+		CurrentLoad:     baseEngine.GetUtilization(),
+		Metrics:         engine.GetMetrics(),
+		LastUpdate:      time.Now(),
 	}
-	
+	if health != nil {
+		state.Health = health.Score
+	}
+
 	return state
 }
 
-// restoreEngineState restores the state of a single engine
+// restoreEngineState restores the state of a single engine. Queued and in-flight
+// operations are persisted by the wrapper's own SaveState/LoadState.
 func (esm *EngineStateManager) restoreEngineState(engineID string, state *EngineState) error {
 	engine, exists := esm.engines[engineID]
 	if !exists {
@@ -114,26 +118,15 @@ func (esm *EngineStateManager) restoreEngineState(engineID string, state *Engine
 	}
 	
 	// Restore engine configuration
-	if err := engine.SetConfiguration(state.Configuration); err != nil {
-		return fmt.Errorf("failed to set engine configuration: %w", err)
+	if err := engine.SetComplexityLevel(state.ComplexityLevel); err != nil {
+		return fmt.Errorf("failed to set engine complexity level: %w", err)
 	}
-	
-	// Restore operation queue
-	if err := engine.RestoreOperationQueue(state.OperationQueue); err != nil {
-		return fmt.Errorf("failed to restore operation queue: %w", err)
-	}
-	
-	// Restore active operations
-	if err := engine.RestoreActiveOperations(state.ActiveOperations); err != nil {
-		return fmt.Errorf("failed to restore active operations: %w", err)
+	if err := engine.SetSeed(state.Seed); err != nil {
+		return fmt.Errorf("failed to set engine seed: %w", err)
 	}
 	
-	// Restore runtime state
-	engine.SetHealth(state.Health)
-	engine.SetCurrentLoad(state.CurrentLoad)
-	
 	if state.IsRunning && !engine.IsRunning() {
-		if err := engine.Start(); err != nil {
+		if err := engine.Start(context.Background()); err != nil {
 			return fmt.Errorf("failed to start engine: %w", err)
 		}
 	} else if !state.IsRunning && engine.IsRunning() {
@@ -276,11 +269,11 @@ type ComponentInterface interface {
 	GetID() string
 	GetType() ComponentType
 	GetInstances() map[string]*ComponentInstance
-	GetLoadBalancerState() *LoadBalancerState
+	GetLoadBalancerState() *LoadBalancerLevelState
 	GetConfiguration() map[string]interface{}
 	GetMetrics() *ComponentMetrics
 	RestoreConfiguration(map[string]interface{}) error
-	RestoreLoadBalancerState(*LoadBalancerState) error
+	RestoreLoadBalancerState(*LoadBalancerLevelState) error
 }
 
 // NewComponentStateManager creates a new component state manager
@@ -301,11 +294,11 @@ func (csm *ComponentStateManager) Stop() {
 }
 
 // CaptureAllComponentStates captures the state of all registered components
-func (csm *ComponentStateManager) CaptureAllComponentStates() map[string]*ComponentState {
+func (csm *ComponentStateManager) CaptureAllComponentStates() map[string]*ComponentLevelState {
 	csm.mutex.RLock()
 	defer csm.mutex.RUnlock()
 	
-	states := make(map[string]*ComponentState)
+	states := make(map[string]*ComponentLevelState)
 	
 	for componentID, component := range csm.components {
 		state := csm.captureComponentState(componentID, component)
@@ -317,7 +310,7 @@ func (csm *ComponentStateManager) CaptureAllComponentStates() map[string]*Compon
 }
 
 // RestoreAllComponentStates restores the state of all components
-func (csm *ComponentStateManager) RestoreAllComponentStates(states map[string]*ComponentState) error {
+func (csm *ComponentStateManager) RestoreAllComponentStates(states map[string]*ComponentLevelState) error {
 	csm.mutex.Lock()
 	defer csm.mutex.Unlock()
 	
@@ -332,7 +325,7 @@ func (csm *ComponentStateManager) RestoreAllComponentStates(states map[string]*C
 }
 
 // captureComponentState captures the state of a single component
-func (csm *ComponentStateManager) captureComponentState(componentID string, component ComponentInterface) *ComponentState {
+func (csm *ComponentStateManager) captureComponentState(componentID string, component ComponentInterface) *ComponentLevelState {
 	instances := component.GetInstances()
 	instanceStates := make(map[string]*InstanceState)
 	
@@ -340,8 +333,8 @@ func (csm *ComponentStateManager) captureComponentState(componentID string, comp
 		instanceStates[instanceID] = &InstanceState{
 			InstanceID:       instanceID,
 			ComponentID:      componentID,
-			Health:           instance.Health,
-			CurrentLoad:      instance.CurrentLoad,
+			Health:           instance.GetHealth().AvailableCapacity,
+			CurrentLoad:      instance.GetHealth().CurrentCPU,
 			IsRunning:        true, // Simplified
 			EngineIDs:        []string{}, // Would be populated with actual engine IDs
 			InputQueueState:  nil, // Would capture actual queue state
@@ -350,7 +343,7 @@ func (csm *ComponentStateManager) captureComponentState(componentID string, comp
 		}
 	}
 	
-	return &ComponentState{
+	return &ComponentLevelState{
 		ComponentID:       componentID,
 		ComponentType:     component.GetType(),
 		Instances:         instanceStates,
@@ -362,7 +355,7 @@ func (csm *ComponentStateManager) captureComponentState(componentID string, comp
 }
 
 // restoreComponentState restores the state of a single component
-func (csm *ComponentStateManager) restoreComponentState(componentID string, state *ComponentState) error {
+func (csm *ComponentStateManager) restoreComponentState(componentID string, state *ComponentLevelState) error {
 	component, exists := csm.components[componentID]
 	if !exists {
 		return fmt.Errorf("component %s not found for restoration", componentID)
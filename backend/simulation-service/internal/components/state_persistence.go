@@ -118,13 +118,13 @@ func (spm *StatePersistenceManager) SaveComponentInstanceState(instance *Compone
 		ID:            instance.ID,
 		ComponentID:   instance.ComponentID,
 		ComponentType: instance.ComponentType,
-		Running:       instance.running,
+		Running:       instance.GetState() != ComponentStateStopped,
 		Ready:         instance.ReadyFlag.Load(),
 		Processing:    instance.ProcessingFlag.Load(),
 		StartTime:     instance.StartTime,
 		LastTick:      instance.LastTickTime,
-		Health:        instance.Health,
-		Metrics:       instance.Metrics,
+		Health:        instance.GetHealth(),
+		Metrics:       instance.GetMetrics(),
 		EngineStates:  engineStates,
 		Config:        instance.Config,
 		SavedAt:       time.Now(),
@@ -206,13 +206,13 @@ func (spm *StatePersistenceManager) SaveLoadBalancerState(lb *LoadBalancer) erro
 			ID:            instance.ID,
 			ComponentID:   instance.ComponentID,
 			ComponentType: instance.ComponentType,
-			Running:       instance.running,
+			Running:       instance.GetState() != ComponentStateStopped,
 			Ready:         instance.ReadyFlag.Load(),
 			Processing:    instance.ProcessingFlag.Load(),
 			StartTime:     instance.StartTime,
 			LastTick:      instance.LastTickTime,
-			Health:        instance.Health,
-			Metrics:       instance.Metrics,
+			Health:        instance.GetHealth(),
+			Metrics:       instance.GetMetrics(),
 			EngineStates:  engineStates,
 			Config:        instance.Config,
 			SavedAt:       time.Now(),
@@ -234,8 +234,8 @@ func (spm *StatePersistenceManager) SaveLoadBalancerState(lb *LoadBalancer) erro
 		LastScaleUp:        lb.LastScaleUp,
 		LastScaleDown:      lb.LastScaleDown,
 		InstanceStates:     instanceStates,
-		Running:            lb.running,
-		Metrics:            lb.Metrics,
+		Running:            lb.running.Load(),
+		Metrics:            lb.metricsSnapshot(),
 		SavedAt:            time.Now(),
 		Version:            "1.0",
 	}
@@ -27,7 +27,7 @@ func TestStatePersistenceManager_ComponentInstance(t *testing.T) {
 			engines.CPUEngineType,
 		},
 		EngineProfiles: map[engines.EngineType]string{
-			engines.CPUEngineType: "default_cpu",
+			engines.CPUEngineType: "intel_xeon_6248r",
 		},
 	}
 
@@ -125,7 +125,7 @@ func TestStatePersistenceManager_LoadBalancer(t *testing.T) {
 			engines.CPUEngineType,
 		},
 		EngineProfiles: map[engines.EngineType]string{
-			engines.CPUEngineType: "default_cpu",
+			engines.CPUEngineType: "intel_xeon_6248r",
 		},
 	}
 
@@ -369,7 +369,7 @@ func TestComponentInstance_SaveLoadState_Integration(t *testing.T) {
 			engines.CPUEngineType,
 		},
 		EngineProfiles: map[engines.EngineType]string{
-			engines.CPUEngineType: "default_cpu",
+			engines.CPUEngineType: "intel_xeon_6248r",
 		},
 	}
 
@@ -454,7 +454,7 @@ func TestLoadBalancer_SaveLoadState_Integration(t *testing.T) {
 			engines.CPUEngineType,
 		},
 		EngineProfiles: map[engines.EngineType]string{
-			engines.CPUEngineType: "default_cpu",
+			engines.CPUEngineType: "intel_xeon_6248r",
 		},
 	}
 
@@ -26,7 +26,7 @@ type TimeoutErrorSystem struct {
 	failureInjector *FailureInjector
 	
 	// Error handling
-	errorHandler *ErrorHandler
+	recoveryHandler *RecoveryHandler
 	
 	// Lifecycle
 	ctx    context.Context
@@ -54,6 +54,9 @@ type TimeoutErrorConfig struct {
 	RetryEnabled           bool       `json:"retry_enabled"`
 	MaxRetries            int        `json:"max_retries"`
 	RetryBackoff          time.Duration `json:"retry_backoff"`
+
+	// Seed for failure injection decisions (0 = unseeded)
+	Seed                  int64      `json:"seed"`
 }
 
 // BackpressureManager manages natural backpressure in the system
@@ -122,8 +125,8 @@ const (
 	FailureStorageFull FailureType = "storage_full"
 )
 
-// ErrorHandler handles various types of errors and recovery
-type ErrorHandler struct {
+// RecoveryHandler retries failed requests and routes unrecovered ones to the error end node
+type RecoveryHandler struct {
 	// Configuration
 	retryEnabled bool
 	maxRetries   int
@@ -137,6 +140,9 @@ type ErrorHandler struct {
 	
 	// Global registry for error routing
 	globalRegistry GlobalRegistryInterface
+	
+	// Random generator for recovery outcomes, used under mutex
+	random *rand.Rand
 }
 
 // RecoveryStats tracks error recovery statistics
@@ -156,7 +162,7 @@ func NewTimeoutErrorSystem(config *TimeoutErrorConfig, globalRegistry GlobalRegi
 		timeoutManager:      NewRequestTimeoutManager(config.DefaultTimeout),
 		backpressureManager: NewBackpressureManager(config),
 		failureInjector:     NewFailureInjector(config),
-		errorHandler:        NewErrorHandler(config, globalRegistry),
+		recoveryHandler:     NewRecoveryHandler(config, globalRegistry),
 		ctx:                 ctx,
 		cancel:              cancel,
 	}
@@ -168,7 +174,7 @@ func (tes *TimeoutErrorSystem) Start() error {
 	
 	tes.timeoutManager.Start()
 	tes.backpressureManager.Start()
-	tes.errorHandler.Start()
+	tes.recoveryHandler.Start()
 	
 	if tes.config.FailureInjectionEnabled {
 		tes.failureInjector.Start()
@@ -185,7 +191,7 @@ func (tes *TimeoutErrorSystem) Stop() error {
 	tes.timeoutManager.Stop()
 	tes.backpressureManager.Stop()
 	tes.failureInjector.Stop()
-	tes.errorHandler.Stop()
+	tes.recoveryHandler.Stop()
 	
 	return nil
 }
@@ -292,7 +298,7 @@ func NewFailureInjector(config *TimeoutErrorConfig) *FailureInjector {
 		educationalMode: config.EducationalMode,
 		scenarios:       createDefaultFailureScenarios(),
 		currentScenario: 0,
-		random:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		random:          engines.NewSeededRand(engines.DeriveSeed(config.Seed, "failure_injector")),
 		ctx:             ctx,
 		cancel:          cancel,
 		ticker:          time.NewTicker(10 * time.Second),
@@ -404,9 +410,9 @@ func createDefaultFailureScenarios() []FailureScenario {
 	}
 }
 
-// NewErrorHandler creates a new error handler
-func NewErrorHandler(config *TimeoutErrorConfig, globalRegistry GlobalRegistryInterface) *ErrorHandler {
-	return &ErrorHandler{
+// NewRecoveryHandler creates a new recovery handler
+func NewRecoveryHandler(config *TimeoutErrorConfig, globalRegistry GlobalRegistryInterface) *RecoveryHandler {
+	return &RecoveryHandler{
 		retryEnabled:   config.RetryEnabled,
 		maxRetries:     config.MaxRetries,
 		retryBackoff:   config.RetryBackoff,
@@ -414,21 +420,22 @@ func NewErrorHandler(config *TimeoutErrorConfig, globalRegistry GlobalRegistryIn
 		errorTypes:     make(map[string]map[string]int64),
 		recoveryStats:  make(map[string]*RecoveryStats),
 		globalRegistry: globalRegistry,
+		random:         engines.NewSeededRand(engines.DeriveSeed(config.Seed, "recovery_handler")),
 	}
 }
 
 // Start starts the error handler
-func (eh *ErrorHandler) Start() {
-	log.Printf("ErrorHandler: Starting error handling system")
+func (eh *RecoveryHandler) Start() {
+	log.Printf("RecoveryHandler: Starting error handling system")
 }
 
 // Stop stops the error handler
-func (eh *ErrorHandler) Stop() {
-	log.Printf("ErrorHandler: Stopping error handling system")
+func (eh *RecoveryHandler) Stop() {
+	log.Printf("RecoveryHandler: Stopping error handling system")
 }
 
 // HandleError handles various types of errors with recovery attempts
-func (eh *ErrorHandler) HandleError(componentID string, errorType string, err error, request *Request) error {
+func (eh *RecoveryHandler) HandleError(componentID string, errorType string, err error, request *Request) error {
 	eh.mutex.Lock()
 	defer eh.mutex.Unlock()
 	
@@ -448,7 +455,7 @@ func (eh *ErrorHandler) HandleError(componentID string, errorType string, err er
 	stats := eh.recoveryStats[componentID]
 	stats.TotalErrors++
 	
-	log.Printf("ErrorHandler: Handling %s error in %s: %v", errorType, componentID, err)
+	log.Printf("RecoveryHandler: Handling %s error in %s: %v", errorType, componentID, err)
 	
 	// Attempt recovery based on error type
 	if eh.retryEnabled {
@@ -460,11 +467,11 @@ func (eh *ErrorHandler) HandleError(componentID string, errorType string, err er
 }
 
 // attemptRecovery attempts to recover from an error
-func (eh *ErrorHandler) attemptRecovery(componentID, errorType string, err error, request *Request, stats *RecoveryStats) error {
+func (eh *RecoveryHandler) attemptRecovery(componentID, errorType string, err error, request *Request, stats *RecoveryStats) error {
 	startTime := time.Now()
 	
 	for attempt := 1; attempt <= eh.maxRetries; attempt++ {
-		log.Printf("ErrorHandler: Recovery attempt %d/%d for %s in %s", 
+		log.Printf("RecoveryHandler: Recovery attempt %d/%d for %s in %s", 
 			attempt, eh.maxRetries, errorType, componentID)
 		
 		// Wait before retry
@@ -476,7 +483,7 @@ func (eh *ErrorHandler) attemptRecovery(componentID, errorType string, err error
 			recoveryTime := time.Since(startTime)
 			eh.updateAverageRecoveryTime(stats, recoveryTime)
 			
-			log.Printf("ErrorHandler: Successfully recovered from %s error in %s after %d attempts", 
+			log.Printf("RecoveryHandler: Successfully recovered from %s error in %s after %d attempts", 
 				errorType, componentID, attempt)
 			return nil
 		}
@@ -484,30 +491,30 @@ func (eh *ErrorHandler) attemptRecovery(componentID, errorType string, err error
 	
 	// Recovery failed
 	stats.FailedRecovery++
-	log.Printf("ErrorHandler: Failed to recover from %s error in %s after %d attempts", 
+	log.Printf("RecoveryHandler: Failed to recover from %s error in %s after %d attempts", 
 		errorType, componentID, eh.maxRetries)
 	
 	return eh.routeToErrorEndNode(request, errorType, err.Error())
 }
 
-// simulateRecovery simulates recovery success/failure
-func (eh *ErrorHandler) simulateRecovery(errorType string) bool {
+// simulateRecovery simulates recovery success/failure. Callers hold the mutex.
+func (eh *RecoveryHandler) simulateRecovery(errorType string) bool {
 	// Different recovery rates for different error types
 	switch errorType {
 	case "timeout":
-		return rand.Float64() < 0.7 // 70% recovery rate
+		return eh.random.Float64() < 0.7 // 70% recovery rate
 	case "network_loss":
-		return rand.Float64() < 0.5 // 50% recovery rate
+		return eh.random.Float64() < 0.5 // 50% recovery rate
 	case "high_latency":
-		return rand.Float64() < 0.8 // 80% recovery rate
+		return eh.random.Float64() < 0.8 // 80% recovery rate
 	default:
-		return rand.Float64() < 0.6 // 60% default recovery rate
+		return eh.random.Float64() < 0.6 // 60% default recovery rate
 	}
 }
 
 // routeToErrorEndNode routes a failed request to error end node
-func (eh *ErrorHandler) routeToErrorEndNode(request *Request, errorType, errorMessage string) error {
-	log.Printf("ErrorHandler: Routing request %s to error end node (error: %s)", 
+func (eh *RecoveryHandler) routeToErrorEndNode(request *Request, errorType, errorMessage string) error {
+	log.Printf("RecoveryHandler: Routing request %s to error end node (error: %s)", 
 		request.ID, errorType)
 	
 	// Mark request as failed
@@ -521,7 +528,7 @@ func (eh *ErrorHandler) routeToErrorEndNode(request *Request, errorType, errorMe
 }
 
 // updateAverageRecoveryTime updates the average recovery time
-func (eh *ErrorHandler) updateAverageRecoveryTime(stats *RecoveryStats, recoveryTime time.Duration) {
+func (eh *RecoveryHandler) updateAverageRecoveryTime(stats *RecoveryStats, recoveryTime time.Duration) {
 	if stats.AvgRecoveryTime == 0 {
 		stats.AvgRecoveryTime = recoveryTime
 	} else {
@@ -532,7 +539,7 @@ func (eh *ErrorHandler) updateAverageRecoveryTime(stats *RecoveryStats, recovery
 }
 
 // GetErrorStats returns error statistics for a component
-func (eh *ErrorHandler) GetErrorStats(componentID string) *RecoveryStats {
+func (eh *RecoveryHandler) GetErrorStats(componentID string) *RecoveryStats {
 	eh.mutex.RLock()
 	defer eh.mutex.RUnlock()
 	
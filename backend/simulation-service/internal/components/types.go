@@ -75,6 +75,8 @@ type LoadBalancer struct {
 	RoundRobinIndex  int                    `json:"round_robin_index"`
 	LastScaleUp      time.Time              `json:"last_scale_up"`
 	LastScaleDown    time.Time              `json:"last_scale_down"`
+	TotalScaleUps    int64                  `json:"total_scale_ups"`
+	TotalScaleDowns  int64                  `json:"total_scale_downs"`
 
 	// Weighted load balancing state
 	WeightedSelections map[string]int       `json:"weighted_selections"` // instanceID -> current selections count
@@ -87,6 +89,13 @@ type LoadBalancer struct {
 	// Global registry integration
 	GlobalRegistry   GlobalRegistryInterface     `json:"-"`
 
+	// Optional observer for engine results of all instances
+	observer         OperationObserver           `json:"-"`
+
+	// Tick-driven load balancers route and run their instances inside ProcessTick
+	tickDriven       bool                        `json:"-"`
+	tickDuration     time.Duration               `json:"-"`
+
 	// Metrics and health
 	Metrics          *ComponentMetrics           `json:"metrics"`
 	Health           *ComponentHealth            `json:"health"`
@@ -95,7 +104,7 @@ type LoadBalancer struct {
 	ctx              context.Context             `json:"-"`
 	cancel           context.CancelFunc          `json:"-"`
 	mutex            sync.RWMutex                `json:"-"`
-	running          bool                        `json:"-"`
+	running          atomic.Bool                 `json:"-"` // Read without mutex so ProcessOperation never waits on a shutdown
 }
 
 // ComponentInstance represents a single instance within a component (managed by LoadBalancer)
@@ -146,10 +155,29 @@ type ComponentInstance struct {
 	// Error handling
 	ErrorHandler      *ErrorHandler          `json:"-"`
 
+	// Optional observer for engine results, bound to the owning component by the load balancer
+	resultObserver    func(*engines.OperationResult) `json:"-"`
+
+	// Optional callback when the instance's process is killed, set by the load balancer
+	crashObserver     func(instanceID string, err error) `json:"-"`
+	crashed           atomic.Bool                        `json:"-"` // Set once the instance's process is killed
+
+	// Runs each operation's engine sequence on the instance's engines
+	Executor          *ComponentInstanceExecutor `json:"-"`
+
+	// Tick-driven instances run on the caller's goroutine: ProcessTick admits queued
+	// operations and steps the engines instead of feeding the engines' goroutines
+	tickDriven        bool                   `json:"-"`
+	currentTick       atomic.Int64           `json:"-"` // Latest tick from the coordinator
+	inFlight          atomic.Int64           `json:"-"` // Admitted operations not yet completed
+	wake              chan struct{}          `json:"-"` // Wakes runInstance when a slot frees or shutdown begins
+
 	// Lifecycle management
 	ctx               context.Context        `json:"-"`
 	cancel            context.CancelFunc     `json:"-"`
 	mutex             sync.RWMutex           `json:"-"`
+	healthMutex       sync.RWMutex           `json:"-"` // Guards Health; never held while calling out
+	metricsMutex      sync.Mutex             `json:"-"` // Guards Metrics; never held while calling out
 	running           bool                   `json:"-"`
 }
 
@@ -181,6 +209,7 @@ type CentralizedOutputManager struct {
 
 	// Fallback routing configuration
 	FallbackRouting       *FallbackRoutingConfig    `json:"fallback_routing"`
+	fallbackRotation      atomic.Uint64             // Round-robin offset for fallback targets
 
 	// Lifecycle management
 	ctx               context.Context               `json:"-"`
@@ -327,6 +356,10 @@ type ComponentConfig struct {
 	EngineProfiles    map[engines.EngineType]string     `json:"engine_profiles"`
 	ComplexityLevels  map[engines.EngineType]int        `json:"complexity_levels"`
 
+	// Language runtime whose garbage collector pauses the instance's CPU and memory
+	// engines (go, java_g1, java_zgc, dotnet, python, node; empty = none)
+	Runtime           string                            `json:"runtime"`
+
 	// Decision graph configuration
 	DecisionGraph     *DecisionGraphConfig              `json:"decision_graph"`
 
@@ -338,8 +371,19 @@ type ComponentConfig struct {
 	// User flow and routing configuration
 	UserFlow          *UserFlowConfig                   `json:"user_flow"`
 	RoutingRules      map[string]string                 `json:"routing_rules"` // operation_type -> next_component
+
+	// Seed for deterministic runs (0 = unseeded). Instances and engines derive their
+	// own seeds from it with engines.DeriveSeed.
+	Seed              int64                             `json:"seed"`
+
+	// Directory holding engine profiles (empty = ./profiles)
+	ProfilesPath      string                            `json:"profiles_path"`
 }
 
+// OperationObserver is notified of every engine result produced by a component's
+// instances. Used to collect per-operation latency and penalty data outside the component.
+type OperationObserver func(componentID string, result *engines.OperationResult)
+
 // DecisionGraphConfig represents the configuration for a decision graph
 type DecisionGraphConfig struct {
 	StartNode string                    `json:"start_node"`
@@ -353,6 +397,16 @@ type DecisionNode struct {
 	Type       string            `json:"type"`       // "engine", "decision", "end"
 	EngineType engines.EngineType `json:"engine_type,omitempty"` // For engine nodes
 	Conditions map[string]string `json:"conditions"` // For decision nodes
+
+	// Routing between nodes and components
+	Target     string            `json:"target,omitempty"`    // Engine or component target
+	Operation  string            `json:"operation,omitempty"` // Operation to perform
+	Next       string            `json:"next,omitempty"`      // Default next node
+
+	// Advanced routing types
+	RoutingType       string             `json:"routing_type,omitempty"`       // "standard", "probability_based", "dynamic_state_based"
+	ProbabilityConfig *ProbabilityConfig `json:"probability_config,omitempty"` // For probability-based routing
+	StateConfig       *StateConfig       `json:"state_config,omitempty"`       // For state-based routing
 }
 
 // GoroutineTracker tracks information about engine goroutines
@@ -25,7 +25,7 @@ func NewRedisClient(cfg config.RedisConfig) (*RedisClient, error) {
 		PoolSize: 20,
 		MinIdleConns: 5,
 		MaxRetries: 3,
-		RetryDelay: time.Millisecond * 100,
+		MinRetryBackoff: time.Millisecond * 100,
 		DialTimeout: time.Second * 5,
 		ReadTimeout: time.Second * 3,
 		WriteTimeout: time.Second * 3,
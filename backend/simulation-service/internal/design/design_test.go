@@ -0,0 +1,246 @@
+package design
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+	"github.com/systemsim/simulation-service/internal/traffic"
+)
+
+const profilesPath = "../../profiles"
+
+func testCatalog(t *testing.T) ProfileCatalog {
+	catalog, err := NewProfileCatalog(engines.NewProfileLoader(profilesPath))
+	if err != nil {
+		t.Fatalf("Failed to build profile catalog: %v", err)
+	}
+	return catalog
+}
+
+func TestDesign_ExampleFileIsValid(t *testing.T) {
+	d, err := ReadFile("../../designs/web_app.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read design: %v", err)
+	}
+
+	if err := d.Validate(testCatalog(t)); err != nil {
+		t.Fatalf("Expected example design to be valid: %v", err)
+	}
+
+	if len(d.Components) != 3 || len(d.Graphs) != 1 || len(d.UserFlows) != 1 {
+		t.Errorf("Unexpected design contents: %+v", d)
+	}
+}
+
+func TestProfileCatalog_UsesDeclaredNames(t *testing.T) {
+	catalog := testCatalog(t)
+
+	// ddr5_6400_quad_channel.json declares the name engines look it up by
+	if !catalog.Has(engines.MemoryEngineType, "ddr5_6400_server_dual_socket") {
+		t.Error("Expected the catalog to list the profile's declared name")
+	}
+	if catalog.Has(engines.MemoryEngineType, "ddr5_6400_quad_channel") {
+		t.Error("Expected the catalog not to list the profile's file name")
+	}
+	if !catalog.Has(engines.NetworkInEngineType, "Gigabit Ethernet LAN") || !catalog.Has(engines.CPUEngineType, "intel_xeon_6248r") {
+		t.Error("Expected the catalog to list loaded and built-in profiles")
+	}
+}
+
+func TestDesign_ParseJSONRejectsUnknownFields(t *testing.T) {
+	valid := `{"version": "v1", "name": "json", "components": [{"id": "web", "type": "web_server"}]}`
+	d, err := Parse([]byte(valid), FormatJSON)
+	if err != nil {
+		t.Fatalf("Failed to parse JSON design: %v", err)
+	}
+	if err := d.Validate(nil); err != nil {
+		t.Errorf("Expected JSON design to be valid: %v", err)
+	}
+
+	typo := `{"version": "v1", "name": "json", "componnets": []}`
+	if _, err := Parse([]byte(typo), FormatJSON); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+func TestDesign_ValidationProblems(t *testing.T) {
+	document := `
+version: v1
+name: broken
+components:
+  - id: web
+    type: web_server
+    engines:
+      cpu:
+        profile: no_such_cpu
+  - id: db
+    type: database
+graphs:
+  - name: dangling
+    start_node: web
+    end_nodes: [done]
+    nodes:
+      web:
+        type: component
+        next: missing
+      done:
+        type: end
+  - name: island
+    start_node: web
+    end_nodes: [done]
+    nodes:
+      web:
+        type: component
+        next: db
+      db:
+        type: component
+      done:
+        type: end
+`
+	d, err := Parse([]byte(document), FormatYAML)
+	if err != nil {
+		t.Fatalf("Failed to parse design: %v", err)
+	}
+
+	err = d.Validate(testCatalog(t))
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	expected := []string{
+		`unknown cpu profile "no_such_cpu"`,
+		`graph dangling: node web points to missing node "missing"`,
+		`graph dangling: end node "done" is unreachable`,
+		`graph island: end node "done" is unreachable`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected problem %q in %v", want, err)
+		}
+	}
+}
+
+func TestDesign_TrafficPatterns(t *testing.T) {
+	document := `
+version: v1
+name: traffic
+components:
+  - id: web
+    type: web_server
+user_flows:
+  - name: browse
+    steps:
+      - component: web
+        operation: http_get
+traffic:
+  - flow: browse
+    pattern: diurnal
+    rate: 10
+    peak_rate: 100
+    period: 24h
+    peak_at: 20h
+  - flow: browse
+    pattern: spike
+    rate: 10
+    peak_rate: 5000
+    start: 10m
+    ramp_up: 30s
+    hold: 2m
+    decay: 5m
+  - flow: browse
+    pattern: step
+    steps:
+      - {at: 0s, rate: 10}
+      - {at: 1m, rate: 100}
+  - flow: browse
+    pattern: trace
+    arrivals: [0.5, 1s, 1500ms]
+    speed: 2
+  - flow: browse
+    pattern: spike
+    rate: 100
+    peak_rate: 10
+  - flow: browse
+    pattern: step
+  - flow: browse
+    pattern: trace
+    arrivals: [-1s]
+  - flow: browse
+    rate: 10
+    mix: {http_get: 0, http_post: 0}
+`
+	d, err := Parse([]byte(document), FormatYAML)
+	if err != nil {
+		t.Fatalf("Failed to parse design: %v", err)
+	}
+
+	if got := time.Duration(d.Traffic[1].Hold); got != 2*time.Minute {
+		t.Errorf("Expected hold of 2m, got %v", got)
+	}
+	if got := time.Duration(d.Traffic[3].Arrivals[0]); got != 500*time.Millisecond {
+		t.Errorf("Expected a bare number to be read as seconds, got %v", got)
+	}
+
+	err = d.Validate(nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(verr.Problems) != 4 {
+		t.Errorf("Expected 4 problems, got %v", verr.Problems)
+	}
+
+	expected := []string{
+		`traffic 4: peak_rate 10 is below rate 100`,
+		`traffic 5: step pattern requires at least one step`,
+		`traffic 6: arrival 0 cannot be negative`,
+		`traffic 7: mix needs at least one positive weight`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected problem %q in %v", want, err)
+		}
+	}
+
+	if _, ok := d.Traffic[3].Stream().Model.(*traffic.TraceModel); !ok {
+		t.Error("Expected the trace pattern to build a trace model")
+	}
+}
+
+func TestLoader_Build(t *testing.T) {
+	factory := components.NewComponentFactory(profilesPath, engines.NewEngineFactory())
+	loader := NewLoader(factory, nil, profilesPath)
+
+	system, err := loader.LoadFile("../../designs/web_app.yaml")
+	if err != nil {
+		t.Fatalf("Failed to build design: %v", err)
+	}
+
+	web := system.Components["web"]
+	if web == nil {
+		t.Fatal("Expected web component to be created")
+	}
+	if web.Config.MinInstances != 3 || web.Config.MaxInstances != 6 {
+		t.Errorf("Expected 3-6 web instances, got %d-%d", web.Config.MinInstances, web.Config.MaxInstances)
+	}
+	if profile := web.ComponentConfig.EngineProfiles[engines.CPUEngineType]; profile != "Web Server CPU" {
+		t.Errorf("Expected web CPU profile Web Server CPU, got %s", profile)
+	}
+
+	db := system.Components["db"]
+	if db.Config.Algorithm != components.LoadBalancingLeastConnections {
+		t.Errorf("Expected least connections for db, got %s", db.Config.Algorithm)
+	}
+
+	if _, err := system.FlowManager.GetFlow("browse"); err != nil {
+		t.Errorf("Expected browse flow to be registered: %v", err)
+	}
+	if graph := system.Graphs["read_path"]; graph == nil || graph.Level != components.SystemLevel {
+		t.Errorf("Expected system-level read_path graph, got %+v", graph)
+	}
+}
@@ -0,0 +1,293 @@
+package design
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// System is a design instantiated into components that are ready to be started
+type System struct {
+	Design      *Design
+	Components  map[string]*components.LoadBalancer
+	FlowManager *components.UserFlowManager
+	Graphs      map[string]*components.DecisionGraph
+	Registry    *components.GlobalRegistry // Where component input channels are published; nil if the loader has none
+}
+
+// Loader validates design documents and instantiates them into a System
+type Loader struct {
+	factory  *components.ComponentFactory
+	registry *components.GlobalRegistry
+	profiles *engines.ProfileLoader
+}
+
+// NewLoader creates a loader that builds components with the given factory and
+// registers them, together with the system graphs, in the given registry.
+// Engine profile names are resolved against the files under profilesPath.
+func NewLoader(factory *components.ComponentFactory, registry *components.GlobalRegistry, profilesPath string) *Loader {
+	return &Loader{
+		factory:  factory,
+		registry: registry,
+		profiles: engines.NewProfileLoader(profilesPath),
+	}
+}
+
+// LoadFile reads, validates and instantiates a design file in one call
+func (l *Loader) LoadFile(path string) (*System, error) {
+	d, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Build(d)
+}
+
+// Build validates a design and instantiates every component, system graph and
+// user flow it describes. Nothing is registered unless the whole design builds.
+func (l *Loader) Build(d *Design) (*System, error) {
+	catalog, err := NewProfileCatalog(l.profiles)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Validate(catalog); err != nil {
+		return nil, err
+	}
+
+	system := &System{
+		Design:      d,
+		Components:  make(map[string]*components.LoadBalancer, len(d.Components)),
+		FlowManager: components.NewUserFlowManager(),
+		Graphs:      make(map[string]*components.DecisionGraph, len(d.Graphs)),
+		Registry:    l.registry,
+	}
+
+	for _, flow := range d.UserFlows {
+		if err := system.FlowManager.AddFlow(flow.Name, buildUserFlow(flow)); err != nil {
+			return nil, fmt.Errorf("failed to add user flow %s: %w", flow.Name, err)
+		}
+	}
+
+	for _, graph := range d.Graphs {
+		built := buildSystemGraph(graph)
+		built.Seed = engines.DeriveSeed(d.Seed, "graph", graph.Name)
+		system.Graphs[graph.Name] = built
+	}
+
+	for _, spec := range d.Components {
+		config, err := l.factory.LoadComponentConfig(spec.Type, spec.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load profile for component %s: %w", spec.ID, err)
+		}
+
+		applyComponentSpec(config, spec)
+		config.UserFlow = system.FlowManager.GetConfig()
+		config.Seed = engines.DeriveSeed(d.Seed, "component", spec.ID)
+
+		lb, err := l.factory.CreateComponentFromConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create component %s: %w", spec.ID, err)
+		}
+		system.Components[spec.ID] = lb
+	}
+
+	if err := l.register(system); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Design: Built %s with %d components, %d graphs and %d user flows",
+		d.Name, len(system.Components), len(system.Graphs), len(d.UserFlows))
+
+	return system, nil
+}
+
+// register publishes component channels and system graphs to the global registry.
+// Graphs bound to a user flow are registered under the flow name, others under their own.
+func (l *Loader) register(system *System) error {
+	if l.registry == nil {
+		return nil
+	}
+
+	for id, lb := range system.Components {
+		l.registry.Register(id, lb.GetInputChannel())
+	}
+
+	bound := make(map[string]bool)
+	for _, flow := range system.Design.UserFlows {
+		if flow.Graph == "" {
+			continue
+		}
+		if err := l.registry.UpdateSystemGraph(flow.Name, system.Graphs[flow.Graph]); err != nil {
+			return fmt.Errorf("failed to register graph for user flow %s: %w", flow.Name, err)
+		}
+		bound[flow.Graph] = true
+	}
+
+	for name, graph := range system.Graphs {
+		if bound[name] {
+			continue
+		}
+		if err := l.registry.UpdateSystemGraph(name, graph); err != nil {
+			return fmt.Errorf("failed to register graph %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyComponentSpec overlays the design's settings on a profile configuration
+func applyComponentSpec(config *components.ComponentConfig, spec *ComponentSpec) {
+	if spec.Name != "" {
+		config.Name = spec.Name
+	}
+	if spec.Description != "" {
+		config.Description = spec.Description
+	}
+	if spec.MaxConcurrentOps > 0 {
+		config.MaxConcurrentOps = spec.MaxConcurrentOps
+	}
+	if spec.QueueCapacity > 0 {
+		config.QueueCapacity = spec.QueueCapacity
+	}
+	if len(spec.RoutingRules) > 0 {
+		config.RoutingRules = make(map[string]string, len(spec.RoutingRules))
+		for operation, target := range spec.RoutingRules {
+			config.RoutingRules[operation] = target
+		}
+	}
+
+	if config.EngineProfiles == nil {
+		config.EngineProfiles = make(map[engines.EngineType]string)
+	}
+	if config.ComplexityLevels == nil {
+		config.ComplexityLevels = make(map[engines.EngineType]int)
+	}
+	for _, name := range sortedKeys(spec.Engines) {
+		engineSpec := spec.Engines[name]
+
+		for _, engineType := range configuredEngineTypes(config.RequiredEngines, engineNames[name]) {
+			config.EngineProfiles[engineType] = engineSpec.Profile
+			if engineSpec.Complexity != nil {
+				config.ComplexityLevels[engineType] = *engineSpec.Complexity
+			}
+			if !hasEngine(config.RequiredEngines, engineType) {
+				config.RequiredEngines = addEngine(config.RequiredEngines, engineType)
+			}
+		}
+	}
+
+	lb := config.LoadBalancer
+	if spec.Instances > 0 {
+		lb.MinInstances = spec.Instances
+		if lb.MaxInstances < spec.Instances {
+			lb.MaxInstances = spec.Instances
+		}
+	}
+	if lbSpec := spec.LoadBalancer; lbSpec != nil {
+		if lbSpec.Algorithm != "" {
+			lb.Algorithm = lbSpec.Algorithm
+		}
+		if lbSpec.MaxInstances > 0 {
+			lb.MaxInstances = lbSpec.MaxInstances
+		}
+		if lbSpec.AutoScaling != nil {
+			lb.AutoScaling = *lbSpec.AutoScaling
+		}
+		if len(lbSpec.Weights) > 0 {
+			lb.InstanceWeights = make(map[string]int, len(lbSpec.Weights))
+			for instanceID, weight := range lbSpec.Weights {
+				lb.InstanceWeights[instanceID] = weight
+			}
+		}
+	}
+	if lb.MinInstances > lb.MaxInstances {
+		lb.MinInstances = lb.MaxInstances
+	}
+}
+
+func hasEngine(required []engines.EngineType, engineType engines.EngineType) bool {
+	for _, t := range required {
+		if t == engineType {
+			return true
+		}
+	}
+	return false
+}
+
+// configuredEngineTypes returns the engines a design's engine entry configures. A
+// plain "network" entry configures both sides of a component whose defaults already
+// split the link into Network-In and Network-Out engines.
+func configuredEngineTypes(required []engines.EngineType, engineType engines.EngineType) []engines.EngineType {
+	if engineType == engines.NetworkEngineType &&
+		(hasEngine(required, engines.NetworkInEngineType) || hasEngine(required, engines.NetworkOutEngineType)) {
+		return []engines.EngineType{engines.NetworkInEngineType, engines.NetworkOutEngineType}
+	}
+	return []engines.EngineType{engineType}
+}
+
+// addEngine inserts an engine ahead of the trailing network output engine, if there is one
+func addEngine(required []engines.EngineType, engineType engines.EngineType) []engines.EngineType {
+	n := len(required)
+	if n < 2 || (required[n-1] != engines.NetworkEngineType && required[n-1] != engines.NetworkOutEngineType) {
+		return append(required, engineType)
+	}
+
+	result := make([]engines.EngineType, 0, n+1)
+	result = append(result, required[:n-1]...)
+	result = append(result, engineType, required[n-1])
+	return result
+}
+
+// buildSystemGraph converts a graph spec into a system-level decision graph. A node's
+// default next node is stored under the "default" condition, as in component graphs.
+func buildSystemGraph(spec *GraphSpec) *components.DecisionGraph {
+	nodes := make(map[string]*components.DecisionNode, len(spec.Nodes))
+	for id, nodeSpec := range spec.Nodes {
+		conditions := make(map[string]string, len(nodeSpec.Conditions)+1)
+		for condition, target := range nodeSpec.Conditions {
+			conditions[condition] = target
+		}
+		if nodeSpec.Next != "" {
+			conditions["default"] = nodeSpec.Next
+		}
+
+		nodes[id] = &components.DecisionNode{
+			ID:         id,
+			Type:       nodeSpec.Type,
+			Conditions: conditions,
+		}
+	}
+
+	return &components.DecisionGraph{
+		Name:      spec.Name,
+		StartNode: spec.StartNode,
+		EndNodes:  graphEndNodes(spec),
+		Nodes:     nodes,
+		Level:     components.SystemLevel,
+	}
+}
+
+// buildUserFlow converts a user flow spec into a components.UserFlow
+func buildUserFlow(spec *UserFlowSpec) *components.UserFlow {
+	flow := &components.UserFlow{
+		Name:        spec.Name,
+		Description: spec.Description,
+		Steps:       make([]*components.UserFlowStep, 0, len(spec.Steps)),
+	}
+
+	for _, step := range spec.Steps {
+		conditions := make(map[string]string, len(step.Conditions))
+		for condition, target := range step.Conditions {
+			conditions[condition] = target
+		}
+		flow.Steps = append(flow.Steps, &components.UserFlowStep{
+			ComponentID: step.Component,
+			Operation:   step.Operation,
+			Conditions:  conditions,
+		})
+	}
+
+	return flow
+}
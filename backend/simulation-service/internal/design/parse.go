@@ -0,0 +1,84 @@
+package design
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies the encoding of a design document
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// FormatFromPath infers the document format from a file extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported design file extension: %s", filepath.Ext(path))
+	}
+}
+
+// ReadFile reads and decodes a design document from disk, resolving relative
+// trace file paths against the document's directory. The document is not validated.
+func ReadFile(path string) (*Design, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read design file: %w", err)
+	}
+
+	d, err := Parse(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trace := range d.Traces {
+		if trace != nil && trace.File != "" && !filepath.IsAbs(trace.File) {
+			trace.File = filepath.Join(filepath.Dir(path), trace.File)
+		}
+	}
+
+	return d, nil
+}
+
+// Parse decodes a design document. Unknown fields are rejected so that typos
+// surface as errors instead of silently falling back to defaults.
+func Parse(data []byte, format Format) (*Design, error) {
+	var d Design
+
+	switch format {
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&d); err != nil {
+			return nil, fmt.Errorf("failed to parse design YAML: %w", err)
+		}
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&d); err != nil {
+			return nil, fmt.Errorf("failed to parse design JSON: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported design format: %s", format)
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,76 @@
+package design
+
+import (
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/traffic"
+)
+
+// Stream converts a validated traffic spec into a traffic generator stream. Every
+// call builds a fresh arrival model, so a trace can be replayed by more than one run.
+func (spec *TrafficSpec) Stream() traffic.StreamConfig {
+	return traffic.StreamConfig{
+		Flow:     spec.Flow,
+		Model:    spec.model(),
+		Mix:      spec.Mix,
+		DataSize: spec.DataSize,
+	}
+}
+
+func (spec *TrafficSpec) model() traffic.ArrivalModel {
+	switch spec.Pattern {
+	case TrafficPatternPoisson:
+		return &traffic.PoissonModel{Rate: spec.Rate}
+	case TrafficPatternDiurnal:
+		return &traffic.DiurnalModel{
+			BaseRate: spec.Rate,
+			PeakRate: spec.PeakRate,
+			Period:   time.Duration(spec.Period),
+			PeakAt:   time.Duration(spec.PeakAt),
+		}
+	case TrafficPatternStep:
+		steps := make([]traffic.Step, 0, len(spec.Steps))
+		for _, step := range spec.Steps {
+			steps = append(steps, traffic.Step{At: time.Duration(step.At), Rate: step.Rate})
+		}
+		return traffic.NewStepModel(steps)
+	case TrafficPatternSpike:
+		return &traffic.SpikeModel{
+			BaseRate: spec.Rate,
+			PeakRate: spec.PeakRate,
+			Start:    time.Duration(spec.Start),
+			RampUp:   time.Duration(spec.RampUp),
+			Hold:     time.Duration(spec.Hold),
+			Decay:    time.Duration(spec.Decay),
+		}
+	case TrafficPatternTrace:
+		arrivals := make([]traffic.Arrival, 0, len(spec.Arrivals))
+		for _, at := range spec.Arrivals {
+			arrivals = append(arrivals, traffic.Arrival{At: time.Duration(at)})
+		}
+		return traffic.NewTraceModel(arrivals, spec.Speed)
+	default:
+		return &traffic.ConstantModel{Rate: spec.Rate}
+	}
+}
+
+// Import reads a trace spec's file and splits its requests across the user flows
+// of the given flow manager
+func (spec *TraceSpec) Import(flows *components.UserFlowManager) (*traffic.TraceImport, error) {
+	records, err := traffic.ReadTraceFile(spec.File, traffic.TraceFormat(spec.Format))
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]traffic.Route, 0, len(spec.Routes))
+	for _, route := range spec.Routes {
+		routes = append(routes, traffic.Route{
+			Endpoint:  route.Endpoint,
+			Flow:      route.Flow,
+			Operation: route.Operation,
+		})
+	}
+
+	return traffic.ImportTrace(records, routes, flows)
+}
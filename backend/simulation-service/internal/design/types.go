@@ -0,0 +1,202 @@
+package design
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+)
+
+// CurrentVersion is the design document version understood by this package
+const CurrentVersion = "v1"
+
+// Design is a declarative description of a whole system: the components that
+// make it up, the system-level decision graphs routing between them, the user
+// flows executed against it and the traffic driving those flows, synthetic or
+// replayed from recorded traces
+type Design struct {
+	Version     string           `yaml:"version" json:"version"`
+	Name        string           `yaml:"name" json:"name"`
+	Description string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Components  []*ComponentSpec `yaml:"components" json:"components"`
+	Graphs      []*GraphSpec     `yaml:"graphs,omitempty" json:"graphs,omitempty"`
+	UserFlows   []*UserFlowSpec  `yaml:"user_flows,omitempty" json:"user_flows,omitempty"`
+	Traffic     []*TrafficSpec   `yaml:"traffic,omitempty" json:"traffic,omitempty"`
+	Traces      []*TraceSpec     `yaml:"traces,omitempty" json:"traces,omitempty"`
+
+	// Seed makes runs of the design reproducible. Every component, instance and
+	// engine derives its own seed from it; zero leaves the design unseeded.
+	Seed int64 `yaml:"seed,omitempty" json:"seed,omitempty"`
+}
+
+// ComponentSpec describes a single component and how its engines are configured.
+// Anything left unset falls back to the component factory's profile for the type.
+type ComponentSpec struct {
+	ID          string                   `yaml:"id" json:"id"`
+	Type        components.ComponentType `yaml:"type" json:"type"`
+	Name        string                   `yaml:"name,omitempty" json:"name,omitempty"`
+	Description string                   `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Instances is the number of instances started behind the component's load balancer
+	Instances    int               `yaml:"instances,omitempty" json:"instances,omitempty"`
+	LoadBalancer *LoadBalancerSpec `yaml:"load_balancer,omitempty" json:"load_balancer,omitempty"`
+
+	// Engines is keyed by engine name: cpu, memory, storage, network, network_in,
+	// network_out or coordination
+	Engines map[string]*EngineSpec `yaml:"engines,omitempty" json:"engines,omitempty"`
+
+	MaxConcurrentOps int               `yaml:"max_concurrent_ops,omitempty" json:"max_concurrent_ops,omitempty"`
+	QueueCapacity    int               `yaml:"queue_capacity,omitempty" json:"queue_capacity,omitempty"`
+	RoutingRules     map[string]string `yaml:"routing_rules,omitempty" json:"routing_rules,omitempty"` // operation_type -> next_component
+}
+
+// EngineSpec selects the hardware profile and complexity level for one engine
+type EngineSpec struct {
+	Profile    string `yaml:"profile" json:"profile"`
+	Complexity *int   `yaml:"complexity,omitempty" json:"complexity,omitempty"` // 0=Minimal, 1=Basic, 2=Advanced, 3=Maximum
+}
+
+// LoadBalancerSpec configures the load balancer in front of a component's instances
+type LoadBalancerSpec struct {
+	Algorithm    components.LoadBalancingAlgorithm `yaml:"algorithm,omitempty" json:"algorithm,omitempty"`
+	MaxInstances int                               `yaml:"max_instances,omitempty" json:"max_instances,omitempty"`
+	AutoScaling  *bool                             `yaml:"auto_scaling,omitempty" json:"auto_scaling,omitempty"`
+	Weights      map[string]int                    `yaml:"weights,omitempty" json:"weights,omitempty"` // instanceID -> weight
+}
+
+// GraphSpec describes a system-level decision graph. Component nodes are keyed by
+// the ID of the component they route to; decision and end nodes use free-form names.
+type GraphSpec struct {
+	Name      string               `yaml:"name" json:"name"`
+	StartNode string               `yaml:"start_node" json:"start_node"`
+	EndNodes  []string             `yaml:"end_nodes,omitempty" json:"end_nodes,omitempty"`
+	Nodes     map[string]*NodeSpec `yaml:"nodes" json:"nodes"`
+}
+
+// Node types accepted in a system-level graph
+const (
+	NodeTypeComponent = "component"
+	NodeTypeDecision  = "decision"
+	NodeTypeEnd       = "end"
+)
+
+// NodeSpec is a single node of a system-level decision graph
+type NodeSpec struct {
+	Type       string            `yaml:"type" json:"type"`
+	Next       string            `yaml:"next,omitempty" json:"next,omitempty"`             // Default next node
+	Conditions map[string]string `yaml:"conditions,omitempty" json:"conditions,omitempty"` // condition -> next node
+}
+
+// UserFlowSpec describes a user flow as an ordered list of component steps,
+// optionally bound to a system-level graph
+type UserFlowSpec struct {
+	Name        string      `yaml:"name" json:"name"`
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
+	Graph       string      `yaml:"graph,omitempty" json:"graph,omitempty"`
+	Steps       []*StepSpec `yaml:"steps" json:"steps"`
+}
+
+// StepSpec is a single step of a user flow
+type StepSpec struct {
+	Component  string            `yaml:"component" json:"component"`
+	Operation  string            `yaml:"operation" json:"operation"`
+	Conditions map[string]string `yaml:"conditions,omitempty" json:"conditions,omitempty"` // condition -> next_component
+}
+
+// Traffic patterns accepted in a traffic spec
+const (
+	TrafficPatternConstant = "constant"
+	TrafficPatternPoisson  = "poisson"
+	TrafficPatternDiurnal  = "diurnal"
+	TrafficPatternStep     = "step"
+	TrafficPatternSpike    = "spike"
+	TrafficPatternTrace    = "trace"
+)
+
+// TrafficSpec describes the load offered to one user flow. Which fields apply
+// depends on the pattern:
+//
+//	constant, poisson  rate
+//	diurnal            rate (quietest), peak_rate, period, peak_at
+//	step               steps
+//	spike              rate (baseline), peak_rate, start, ramp_up, hold, decay
+//	trace              arrivals, speed
+type TrafficSpec struct {
+	Flow     string             `yaml:"flow" json:"flow"`
+	Pattern  string             `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Rate     float64            `yaml:"rate" json:"rate"`                   // Requests per simulated second
+	Mix      map[string]float64 `yaml:"mix,omitempty" json:"mix,omitempty"` // operation type -> relative weight
+	DataSize int64              `yaml:"data_size,omitempty" json:"data_size,omitempty"`
+
+	PeakRate float64     `yaml:"peak_rate,omitempty" json:"peak_rate,omitempty"`
+	Period   Duration    `yaml:"period,omitempty" json:"period,omitempty"`
+	PeakAt   Duration    `yaml:"peak_at,omitempty" json:"peak_at,omitempty"`
+	Steps    []*RateStep `yaml:"steps,omitempty" json:"steps,omitempty"`
+	Start    Duration    `yaml:"start,omitempty" json:"start,omitempty"`
+	RampUp   Duration    `yaml:"ramp_up,omitempty" json:"ramp_up,omitempty"`
+	Hold     Duration    `yaml:"hold,omitempty" json:"hold,omitempty"`
+	Decay    Duration    `yaml:"decay,omitempty" json:"decay,omitempty"`
+	Arrivals []Duration  `yaml:"arrivals,omitempty" json:"arrivals,omitempty"` // Offsets of recorded requests
+	Speed    float64     `yaml:"speed,omitempty" json:"speed,omitempty"`       // Trace replay speed
+}
+
+// Trace formats accepted in a trace spec
+const (
+	TraceFormatCSV  = "csv"
+	TraceFormatOTLP = "otlp"
+)
+
+// TraceSpec replays a recorded production trace, splitting its requests across
+// user flows by endpoint. A relative file path is resolved against the directory
+// of the design file.
+type TraceSpec struct {
+	File   string            `yaml:"file" json:"file"`
+	Format string            `yaml:"format,omitempty" json:"format,omitempty"` // csv or otlp; inferred from the extension when empty
+	Speed  float64           `yaml:"speed,omitempty" json:"speed,omitempty"`   // Replay speed; 2 replays twice as fast
+	Routes []*TraceRouteSpec `yaml:"routes" json:"routes"`
+}
+
+// TraceRouteSpec sends the trace requests whose endpoint matches a glob to a user
+// flow. Routes are tried in order and the first match wins.
+type TraceRouteSpec struct {
+	Endpoint  string `yaml:"endpoint" json:"endpoint"`
+	Flow      string `yaml:"flow" json:"flow"`
+	Operation string `yaml:"operation,omitempty" json:"operation,omitempty"`
+}
+
+// RateStep is one level of a step traffic pattern
+type RateStep struct {
+	At   Duration `yaml:"at" json:"at"`
+	Rate float64  `yaml:"rate" json:"rate"`
+}
+
+// Duration is a time.Duration written as "90s" or "24h" in design documents.
+// A bare number is read as seconds.
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(text []byte) error {
+	if seconds, err := strconv.ParseFloat(string(text), 64); err == nil {
+		*d = Duration(seconds * float64(time.Second))
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", text)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// UnmarshalJSON reads a duration from either a string or a number of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	return d.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
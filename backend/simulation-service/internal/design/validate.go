@@ -0,0 +1,447 @@
+package design
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// engineNames maps the engine names used in design documents to engine types
+var engineNames = map[string]engines.EngineType{
+	"cpu":          engines.CPUEngineType,
+	"memory":       engines.MemoryEngineType,
+	"storage":      engines.StorageEngineType,
+	"network":      engines.NetworkEngineType,
+	"network_in":   engines.NetworkInEngineType,
+	"network_out":  engines.NetworkOutEngineType,
+	"coordination": engines.CoordinationEngineType,
+}
+
+// supportedComponentTypes lists the component types the component factory has profiles for
+var supportedComponentTypes = map[components.ComponentType]bool{
+	components.ComponentTypeDatabase:     true,
+	components.ComponentTypeWebServer:    true,
+	components.ComponentTypeCache:        true,
+	components.ComponentTypeLoadBalancer: true,
+}
+
+var supportedAlgorithms = map[components.LoadBalancingAlgorithm]bool{
+	components.LoadBalancingNone:             true,
+	components.LoadBalancingRoundRobin:       true,
+	components.LoadBalancingLeastConnections: true,
+	components.LoadBalancingWeighted:         true,
+	components.LoadBalancingHealthAware:      true,
+}
+
+var supportedTraceFormats = map[string]bool{
+	TraceFormatCSV:  true,
+	TraceFormatOTLP: true,
+}
+
+var supportedTrafficPatterns = map[string]bool{
+	TrafficPatternConstant: true,
+	TrafficPatternPoisson:  true,
+	TrafficPatternDiurnal:  true,
+	TrafficPatternStep:     true,
+	TrafficPatternSpike:    true,
+	TrafficPatternTrace:    true,
+}
+
+// ProfileCatalog lists the engine profile names available for each engine type
+type ProfileCatalog map[engines.EngineType]map[string]bool
+
+// NewProfileCatalog builds a catalog from the profiles a profile loader loads. Like
+// component instances, it uses the built-in profiles when the directory does not exist.
+// Profiles are listed under the name they declare, which is how engines look them up.
+func NewProfileCatalog(loader *engines.ProfileLoader) (ProfileCatalog, error) {
+	manager := engines.NewProfileManager()
+	if _, err := os.Stat(loader.ProfilesDir); err == nil {
+		if manager, err = loader.LoadProfilesFromDirectory(); err != nil {
+			return nil, fmt.Errorf("failed to load engine profiles: %w", err)
+		}
+	}
+
+	catalog := make(ProfileCatalog)
+	for _, engineType := range engineNames {
+		names := make(map[string]bool)
+		for _, name := range manager.ListProfiles(engineType) {
+			names[name] = true
+		}
+		catalog[engineType] = names
+	}
+
+	return catalog, nil
+}
+
+// Has reports whether a profile exists for the given engine type
+func (pc ProfileCatalog) Has(engineType engines.EngineType, profile string) bool {
+	return pc[engineType][profile]
+}
+
+// ValidationError collects every problem found in a design document
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid design: %s", strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) addf(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks a design for structural problems and broken references. Profile
+// names are only checked when a catalog is given. All problems are reported at once
+// as a *ValidationError.
+func (d *Design) Validate(catalog ProfileCatalog) error {
+	verr := &ValidationError{}
+
+	if d.Version != CurrentVersion {
+		verr.addf("unsupported version %q (expected %q)", d.Version, CurrentVersion)
+	}
+	if d.Name == "" {
+		verr.addf("name is required")
+	}
+	if len(d.Components) == 0 {
+		verr.addf("at least one component is required")
+	}
+
+	componentIDs := make(map[string]bool, len(d.Components))
+	for i, spec := range d.Components {
+		if spec == nil || spec.ID == "" {
+			verr.addf("component %d: id is required", i)
+			continue
+		}
+		if componentIDs[spec.ID] {
+			verr.addf("component %s: duplicate id", spec.ID)
+		}
+		componentIDs[spec.ID] = true
+	}
+
+	for _, spec := range d.Components {
+		if spec != nil && spec.ID != "" {
+			validateComponent(verr, spec, componentIDs, catalog)
+		}
+	}
+
+	graphNames := make(map[string]bool, len(d.Graphs))
+	for i, graph := range d.Graphs {
+		if graph == nil || graph.Name == "" {
+			verr.addf("graph %d: name is required", i)
+			continue
+		}
+		if graphNames[graph.Name] {
+			verr.addf("graph %s: duplicate name", graph.Name)
+		}
+		graphNames[graph.Name] = true
+		validateGraph(verr, graph, componentIDs)
+	}
+
+	flowNames := make(map[string]bool, len(d.UserFlows))
+	for i, flow := range d.UserFlows {
+		if flow == nil || flow.Name == "" {
+			verr.addf("user flow %d: name is required", i)
+			continue
+		}
+		if flowNames[flow.Name] {
+			verr.addf("user flow %s: duplicate name", flow.Name)
+		}
+		flowNames[flow.Name] = true
+		validateFlow(verr, flow, componentIDs, graphNames)
+	}
+
+	for i, traffic := range d.Traffic {
+		if traffic == nil {
+			verr.addf("traffic %d: empty entry", i)
+			continue
+		}
+		if !flowNames[traffic.Flow] {
+			verr.addf("traffic %d: unknown user flow %q", i, traffic.Flow)
+		}
+		if traffic.Pattern != "" && !supportedTrafficPatterns[traffic.Pattern] {
+			verr.addf("traffic %d: unknown pattern %q", i, traffic.Pattern)
+		}
+		if traffic.Rate < 0 {
+			verr.addf("traffic %d: rate cannot be negative", i)
+		}
+		if traffic.DataSize < 0 {
+			verr.addf("traffic %d: data_size cannot be negative", i)
+		}
+		mixTotal := 0.0
+		for operation, weight := range traffic.Mix {
+			if weight < 0 {
+				verr.addf("traffic %d: mix weight for %q cannot be negative", i, operation)
+			}
+			mixTotal += weight
+		}
+		if len(traffic.Mix) > 0 && mixTotal == 0 {
+			verr.addf("traffic %d: mix needs at least one positive weight", i)
+		}
+		validateTrafficPattern(verr, i, traffic)
+	}
+
+	for i, trace := range d.Traces {
+		if trace == nil {
+			verr.addf("trace %d: empty entry", i)
+			continue
+		}
+		validateTrace(verr, i, trace, flowNames)
+	}
+
+	if len(verr.Problems) > 0 {
+		return verr
+	}
+	return nil
+}
+
+func validateComponent(verr *ValidationError, spec *ComponentSpec, componentIDs map[string]bool, catalog ProfileCatalog) {
+	if !supportedComponentTypes[spec.Type] {
+		verr.addf("component %s: unsupported type %q", spec.ID, spec.Type)
+	}
+	if spec.Instances < 0 {
+		verr.addf("component %s: instances cannot be negative", spec.ID)
+	}
+	if spec.MaxConcurrentOps < 0 || spec.QueueCapacity < 0 {
+		verr.addf("component %s: max_concurrent_ops and queue_capacity cannot be negative", spec.ID)
+	}
+
+	if lb := spec.LoadBalancer; lb != nil {
+		if lb.Algorithm != "" && !supportedAlgorithms[lb.Algorithm] {
+			verr.addf("component %s: unknown load balancer algorithm %q", spec.ID, lb.Algorithm)
+		}
+		if lb.MaxInstances > 0 && lb.MaxInstances < spec.Instances {
+			verr.addf("component %s: max_instances %d is below instances %d", spec.ID, lb.MaxInstances, spec.Instances)
+		}
+	}
+
+	for _, name := range sortedKeys(spec.Engines) {
+		engineSpec := spec.Engines[name]
+		engineType, ok := engineNames[name]
+		if !ok {
+			verr.addf("component %s: unknown engine %q", spec.ID, name)
+			continue
+		}
+		if engineSpec == nil {
+			verr.addf("component %s: engine %s has no configuration", spec.ID, name)
+			continue
+		}
+		if engineSpec.Profile == "" {
+			verr.addf("component %s: engine %s requires a profile", spec.ID, name)
+		} else if catalog != nil && !catalog.Has(engineType, engineSpec.Profile) {
+			verr.addf("component %s: unknown %s profile %q", spec.ID, name, engineSpec.Profile)
+		}
+		if c := engineSpec.Complexity; c != nil && (*c < 0 || *c > 3) {
+			verr.addf("component %s: engine %s complexity %d out of range 0-3", spec.ID, name, *c)
+		}
+	}
+
+	for _, operation := range sortedKeys(spec.RoutingRules) {
+		if target := spec.RoutingRules[operation]; !componentIDs[target] {
+			verr.addf("component %s: routing rule %q targets unknown component %q", spec.ID, operation, target)
+		}
+	}
+}
+
+func validateGraph(verr *ValidationError, graph *GraphSpec, componentIDs map[string]bool) {
+	if len(graph.Nodes) == 0 {
+		verr.addf("graph %s: at least one node is required", graph.Name)
+		return
+	}
+	if _, ok := graph.Nodes[graph.StartNode]; !ok {
+		verr.addf("graph %s: start node %q does not exist", graph.Name, graph.StartNode)
+	}
+
+	for _, id := range sortedKeys(graph.Nodes) {
+		node := graph.Nodes[id]
+		if node == nil {
+			verr.addf("graph %s: node %s has no definition", graph.Name, id)
+			continue
+		}
+		switch node.Type {
+		case NodeTypeComponent:
+			if !componentIDs[id] {
+				verr.addf("graph %s: node %s does not name a component", graph.Name, id)
+			}
+		case NodeTypeDecision, NodeTypeEnd:
+		default:
+			verr.addf("graph %s: node %s has unknown type %q", graph.Name, id, node.Type)
+		}
+		for _, target := range nodeTargets(node) {
+			if _, ok := graph.Nodes[target]; !ok {
+				verr.addf("graph %s: node %s points to missing node %q", graph.Name, id, target)
+			}
+		}
+	}
+
+	endNodes := graphEndNodes(graph)
+	if len(endNodes) == 0 {
+		verr.addf("graph %s: no end nodes", graph.Name)
+	}
+
+	reachable := reachableNodes(graph)
+	for _, end := range endNodes {
+		if _, ok := graph.Nodes[end]; !ok {
+			verr.addf("graph %s: end node %q does not exist", graph.Name, end)
+		} else if !reachable[end] {
+			verr.addf("graph %s: end node %q is unreachable from %q", graph.Name, end, graph.StartNode)
+		}
+	}
+}
+
+func validateFlow(verr *ValidationError, flow *UserFlowSpec, componentIDs, graphNames map[string]bool) {
+	if flow.Graph != "" && !graphNames[flow.Graph] {
+		verr.addf("user flow %s: unknown graph %q", flow.Name, flow.Graph)
+	}
+	if len(flow.Steps) == 0 {
+		verr.addf("user flow %s: at least one step is required", flow.Name)
+	}
+
+	for i, step := range flow.Steps {
+		if step == nil {
+			verr.addf("user flow %s: step %d is empty", flow.Name, i)
+			continue
+		}
+		if !componentIDs[step.Component] {
+			verr.addf("user flow %s: step %d references unknown component %q", flow.Name, i, step.Component)
+		}
+		if step.Operation == "" {
+			verr.addf("user flow %s: step %d requires an operation", flow.Name, i)
+		}
+		for _, condition := range sortedKeys(step.Conditions) {
+			if target := step.Conditions[condition]; !componentIDs[target] {
+				verr.addf("user flow %s: step %d condition %q targets unknown component %q", flow.Name, i, condition, target)
+			}
+		}
+	}
+}
+
+// validateTrafficPattern checks the settings used by a traffic entry's pattern
+func validateTrafficPattern(verr *ValidationError, i int, traffic *TrafficSpec) {
+	switch traffic.Pattern {
+	case TrafficPatternDiurnal, TrafficPatternSpike:
+		if traffic.PeakRate < traffic.Rate {
+			verr.addf("traffic %d: peak_rate %g is below rate %g", i, traffic.PeakRate, traffic.Rate)
+		}
+		if traffic.Period < 0 || traffic.Start < 0 || traffic.RampUp < 0 || traffic.Hold < 0 || traffic.Decay < 0 {
+			verr.addf("traffic %d: durations cannot be negative", i)
+		}
+	case TrafficPatternStep:
+		if len(traffic.Steps) == 0 {
+			verr.addf("traffic %d: step pattern requires at least one step", i)
+		}
+		for j, step := range traffic.Steps {
+			if step == nil {
+				verr.addf("traffic %d: step %d is empty", i, j)
+				continue
+			}
+			if step.At < 0 || step.Rate < 0 {
+				verr.addf("traffic %d: step %d cannot have a negative time or rate", i, j)
+			}
+		}
+	case TrafficPatternTrace:
+		if len(traffic.Arrivals) == 0 {
+			verr.addf("traffic %d: trace pattern requires arrivals", i)
+		}
+		if traffic.Speed < 0 {
+			verr.addf("traffic %d: speed cannot be negative", i)
+		}
+		for j, at := range traffic.Arrivals {
+			if at < 0 {
+				verr.addf("traffic %d: arrival %d cannot be negative", i, j)
+			}
+		}
+	}
+}
+
+func validateTrace(verr *ValidationError, i int, trace *TraceSpec, flowNames map[string]bool) {
+	if trace.File == "" {
+		verr.addf("trace %d: file is required", i)
+	}
+	if trace.Format != "" && !supportedTraceFormats[trace.Format] {
+		verr.addf("trace %d: unknown format %q", i, trace.Format)
+	}
+	if trace.Speed < 0 {
+		verr.addf("trace %d: speed cannot be negative", i)
+	}
+	if len(trace.Routes) == 0 {
+		verr.addf("trace %d: at least one route is required", i)
+	}
+
+	for j, route := range trace.Routes {
+		if route == nil {
+			verr.addf("trace %d: route %d is empty", i, j)
+			continue
+		}
+		if _, err := path.Match(route.Endpoint, ""); err != nil || route.Endpoint == "" {
+			verr.addf("trace %d: route %d has an invalid endpoint pattern %q", i, j, route.Endpoint)
+		}
+		if !flowNames[route.Flow] {
+			verr.addf("trace %d: route %d references unknown user flow %q", i, j, route.Flow)
+		}
+	}
+}
+
+// nodeTargets returns every node a node can route to
+func nodeTargets(node *NodeSpec) []string {
+	targets := make([]string, 0, len(node.Conditions)+1)
+	if node.Next != "" {
+		targets = append(targets, node.Next)
+	}
+	for _, condition := range sortedKeys(node.Conditions) {
+		targets = append(targets, node.Conditions[condition])
+	}
+	return targets
+}
+
+// graphEndNodes returns the declared end nodes, or every node of type end when none are declared
+func graphEndNodes(graph *GraphSpec) []string {
+	if len(graph.EndNodes) > 0 {
+		return graph.EndNodes
+	}
+
+	var ends []string
+	for _, id := range sortedKeys(graph.Nodes) {
+		if node := graph.Nodes[id]; node != nil && node.Type == NodeTypeEnd {
+			ends = append(ends, id)
+		}
+	}
+	return ends
+}
+
+// reachableNodes walks the graph from its start node
+func reachableNodes(graph *GraphSpec) map[string]bool {
+	reachable := make(map[string]bool)
+	queue := []string{graph.StartNode}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		node, ok := graph.Nodes[id]
+		if !ok || reachable[id] {
+			continue
+		}
+		reachable[id] = true
+
+		if node != nil {
+			queue = append(queue, nodeTargets(node)...)
+		}
+	}
+
+	return reachable
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -444,3 +444,56 @@ func TestNetworkEngineBaseEngineCompliance(t *testing.T) {
 
 	t.Logf("✅ All BaseEngine interface methods implemented correctly")
 }
+
+// TestNetworkEngineShippedProfiles loads the network profiles under profiles/network
+// and checks their transport, TLS, HTTP and duplex settings reach the engine
+func TestNetworkEngineShippedProfiles(t *testing.T) {
+	profileLoader := NewProfileLoader("../../profiles")
+	manager, err := profileLoader.LoadProfilesFromDirectory()
+	if err != nil {
+		t.Fatalf("Failed to load profiles: %v", err)
+	}
+	for _, name := range []string{"10 Gigabit Datacenter Network", "Gigabit Ethernet LAN", "WAN Internet Connection", "WiFi 6 (802.11ax)"} {
+		if _, ok := manager.NetworkProfiles[name]; !ok {
+			t.Errorf("Expected network profile %q to load", name)
+		}
+	}
+
+	profile, err := profileLoader.LoadProfileFromFile(profileLoader.GetProfilePath(NetworkEngineType, "wan_connection"))
+	if err != nil {
+		t.Fatalf("Failed to load WAN profile: %v", err)
+	}
+	network := NewNetworkEngine(100)
+	if err := network.LoadProfile(profile); err != nil {
+		t.Fatalf("Failed to load WAN profile into engine: %v", err)
+	}
+
+	if network.Protocol != "TCP" || network.NetworkType != "WAN" {
+		t.Errorf("Expected TCP over WAN, got %s over %s", network.Protocol, network.NetworkType)
+	}
+	if network.Congestion.Algorithm != CongestionAlgorithmCubic || network.Congestion.InitialWindowSegments != 4 ||
+		network.Congestion.SlowStartThreshold != 16384 || network.Congestion.MaxWindowSegments != 32768 {
+		t.Errorf("Expected the profile's congestion behavior, got %+v", network.Congestion)
+	}
+	if network.Retransmission.MaxRetransmits != 15 || network.Retransmission.MinRTO != 200*time.Millisecond ||
+		network.Retransmission.MaxRTO != 120*time.Second {
+		t.Errorf("Expected the profile's retransmission settings, got %+v", network.Retransmission)
+	}
+	if network.TLS.Version != TLSVersion12 || network.TLS.CertificateValidation != 50*time.Millisecond ||
+		network.TLS.FullHandshakeCPU != 2*time.Millisecond || network.TLS.SessionReuseProbability != 0.7 {
+		t.Errorf("Expected the profile's TLS settings, got %+v", network.TLS)
+	}
+	if network.HTTP.MaxConnectionsPerOrigin != 6 || network.HTTP.MaxConcurrentStreams != 100 {
+		t.Errorf("Expected the profile's HTTP settings, got %+v", network.HTTP)
+	}
+
+	// Both directions of the full-duplex link take the profile's bandwidth and NIC settings
+	for _, link := range []*NetworkLinkState{&network.BandwidthState, &network.EgressState} {
+		if capacity := network.linkCapacityMbps(link); capacity != 100 {
+			t.Errorf("Expected %s capacity of 100 Mbps, got %d", link.Direction, capacity)
+		}
+		if link.Offload.PerPacketCostUs != 1.0 || link.Offload.MTUBytes != 1500 {
+			t.Errorf("Expected %s to use the profile's NIC offload, got %+v", link.Direction, link.Offload)
+		}
+	}
+}
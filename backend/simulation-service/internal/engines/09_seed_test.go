@@ -0,0 +1,86 @@
+package engines
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestDeriveSeed tests that derived seeds are stable and independent per path
+func TestDeriveSeed(t *testing.T) {
+	if DeriveSeed(0, "web", "CPU") != 0 {
+		t.Error("Expected an unseeded parent to derive an unseeded child")
+	}
+
+	first := DeriveSeed(42, "web", "CPU")
+	if first == 0 {
+		t.Fatal("Expected a non-zero derived seed")
+	}
+	if again := DeriveSeed(42, "web", "CPU"); again != first {
+		t.Errorf("Expected derivation to be stable, got %d and %d", first, again)
+	}
+
+	others := map[string]int64{
+		"different parent": DeriveSeed(43, "web", "CPU"),
+		"different engine": DeriveSeed(42, "web", "Memory"),
+		"joined path":      DeriveSeed(42, "webCPU"),
+	}
+	for name, seed := range others {
+		if seed == first {
+			t.Errorf("Expected %s to derive a different seed", name)
+		}
+	}
+}
+
+// TestSeededNetworkEngineReproducible tests that identically seeded network engines
+// make identical jitter, packet loss and connection reuse decisions
+func TestSeededNetworkEngineReproducible(t *testing.T) {
+	run := func(seed int64) []string {
+		networkEngine := NewNetworkEngine(100)
+		networkEngine.BandwidthMbps = 1000
+		networkEngine.BaseLatencyMs = 0.1
+		networkEngine.MaxConnections = 10000
+		networkEngine.Protocol = "TCP"
+		networkEngine.NetworkType = "LAN"
+		networkEngine.BandwidthState.PacketLossProbability = 0.2
+		networkEngine.SetSeed(seed)
+
+		outcomes := make([]string, 0, 50)
+		for i := 0; i < 50; i++ {
+			op := &Operation{
+				ID:       fmt.Sprintf("seeded-op-%d", i),
+				Type:     "network_request",
+				DataSize: 1024,
+			}
+			result := networkEngine.ProcessOperation(op, int64(i+1))
+			outcomes = append(outcomes, fmt.Sprintf("%v/%v", result.Success, result.ProcessingTime))
+		}
+		return outcomes
+	}
+
+	first, second := run(7), run(7)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Operation %d diverged between runs with the same seed: %s vs %s", i, first[i], second[i])
+		}
+	}
+
+	t.Logf("✅ %d operations reproduced exactly with seed 7", len(first))
+}
+
+// TestSeedChangesCacheDecisions tests that the seed is mixed into CPU cache decisions
+func TestSeedChangesCacheDecisions(t *testing.T) {
+	op := &Operation{ID: "cache-op", Type: "compute", DataSize: 4096}
+
+	unseeded := NewCPUEngine(10)
+	seeded := NewCPUEngine(10)
+	seeded.SetSeed(99)
+	reseeded := NewCPUEngine(10)
+	reseeded.SetSeed(99)
+
+	if seeded.hashOperationForCacheDecision(op) != reseeded.hashOperationForCacheDecision(op) {
+		t.Error("Expected engines with the same seed to hash operations identically")
+	}
+	if seeded.hashOperationForCacheDecision(op) == unseeded.hashOperationForCacheDecision(op) {
+		t.Error("Expected the seed to change cache decision hashes")
+	}
+}
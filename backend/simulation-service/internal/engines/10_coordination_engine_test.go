@@ -0,0 +1,199 @@
+package engines
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// newTestCoordinationEngine creates a seeded engine with jitter and elections off
+func newTestCoordinationEngine(t *testing.T, level int) *CoordinationEngine {
+	coordination := NewCoordinationEngine(100)
+	coordination.SetSeed(42)
+	if err := coordination.SetComplexityLevel(level); err != nil {
+		t.Fatalf("Failed to set complexity level: %v", err)
+	}
+	return coordination
+}
+
+func coordinationPenalties(t *testing.T, result *OperationResult) *CoordinationPenaltyDetails {
+	if result.PenaltyInfo == nil || result.PenaltyInfo.CoordinationPenalties == nil {
+		t.Fatalf("Expected coordination penalty details for %s", result.OperationID)
+	}
+	return result.PenaltyInfo.CoordinationPenalties
+}
+
+// TestCoordinationEngineQuorumLatency tests that writes wait for a majority to be durable
+func TestCoordinationEngineQuorumLatency(t *testing.T) {
+	for _, tc := range []struct {
+		clusterSize int
+		protocol    string
+		quorumMs    float64
+		rounds      int
+	}{
+		{1, "raft", 2.0, 1},        // Leader fsync only
+		{3, "raft", 2.5, 1},        // One follower round trip plus its fsync
+		{5, "multi_paxos", 2.5, 1}, // Followers ack in parallel
+		{3, "paxos", 5.0, 2},       // Prepare and accept phases
+	} {
+		coordination := newTestCoordinationEngine(t, int(ComplexityMinimal))
+		coordination.ClusterSize = tc.clusterSize
+		coordination.Protocol = tc.protocol
+
+		result := coordination.ProcessOperation(&Operation{ID: "write", Type: OpCoordinationConsensus}, 0)
+		penalties := coordinationPenalties(t, result)
+
+		if math.Abs(penalties.QuorumLatencyMs-tc.quorumMs) > 1e-9 || penalties.QuorumRoundTrips != tc.rounds {
+			t.Errorf("%d-node %s: expected %.1fms over %d rounds, got %.3fms over %d",
+				tc.clusterSize, tc.protocol, tc.quorumMs, tc.rounds, penalties.QuorumLatencyMs, penalties.QuorumRoundTrips)
+		}
+		if !result.Success || result.CompletedTick < 1 {
+			t.Errorf("Expected a successful write completing after tick 0, got %+v", result)
+		}
+	}
+}
+
+// TestCoordinationEngineLockContention tests waiting behind a held lock and deadlines
+func TestCoordinationEngineLockContention(t *testing.T) {
+	coordination := newTestCoordinationEngine(t, int(ComplexityMinimal))
+	acquire := func(id string, tick, deadline int64) *OperationResult {
+		return coordination.ProcessOperation(&Operation{
+			ID:       id,
+			Type:     OpCoordinationLockAcquire,
+			Deadline: deadline,
+			Metadata: map[string]interface{}{"lock_key": "orders", "hold_ms": 10},
+		}, tick)
+	}
+
+	first := acquire("first", 0, 0)
+	if coordinationPenalties(t, first).LockWaitMs != 0 {
+		t.Errorf("Expected an uncontended first acquisition")
+	}
+
+	// Held for the 2-tick commit plus 10ms, so a waiter at tick 1 waits 11 ticks
+	second := acquire("second", 1, 0)
+	if wait := coordinationPenalties(t, second).LockWaitMs; wait != 11 {
+		t.Errorf("Expected an 11ms wait behind the holder, got %.2fms", wait)
+	}
+
+	timedOut := acquire("timed-out", 2, 5)
+	if timedOut.Success || timedOut.ErrorMessage == "" {
+		t.Errorf("Expected the acquisition to fail past its deadline")
+	}
+
+	other := coordination.ProcessOperation(&Operation{
+		ID:       "other-key",
+		Type:     OpCoordinationLockAcquire,
+		Metadata: map[string]interface{}{"lock_key": "payments"},
+	}, 2)
+	if coordinationPenalties(t, other).LockWaitMs != 0 {
+		t.Errorf("Expected locks on different keys not to contend")
+	}
+
+	coordination.ProcessOperation(&Operation{
+		ID:       "release",
+		Type:     OpCoordinationLockRelease,
+		Metadata: map[string]interface{}{"lock_key": "orders"},
+	}, 3)
+	if after := acquire("after-release", 4, 0); coordinationPenalties(t, after).LockWaitMs != 0 {
+		t.Errorf("Expected no wait after the lock was released")
+	}
+
+	if ratio := coordination.calculateContentionRatio(); ratio != 0.25 {
+		t.Errorf("Expected 1 of 4 acquisitions contended, got %.2f", ratio)
+	}
+	if coordination.LockState.Timeouts != 1 {
+		t.Errorf("Expected one lock timeout, got %d", coordination.LockState.Timeouts)
+	}
+}
+
+// TestCoordinationEngineLeaderElection tests that writes stall until a new leader is elected
+func TestCoordinationEngineLeaderElection(t *testing.T) {
+	coordination := newTestCoordinationEngine(t, int(ComplexityBasic))
+	coordination.ElectionTimeoutMinMs = 150
+	coordination.ElectionTimeoutMaxMs = 300
+
+	election := coordination.ProcessOperation(&Operation{ID: "election", Type: OpCoordinationLeaderElection}, 0)
+	electionDelay := coordinationPenalties(t, election).ElectionDelayMs
+	if electionDelay < 150 || electionDelay > 310 {
+		t.Errorf("Expected the election to take the timeout plus a vote round, got %.2fms", electionDelay)
+	}
+	if coordination.ConsensusState.Term != 2 || coordination.leaderAvailable() {
+		t.Errorf("Expected term 2 with no leader yet, got term %d", coordination.ConsensusState.Term)
+	}
+
+	write := coordination.ProcessOperation(&Operation{ID: "stalled", Type: OpCoordinationConsensus}, 100)
+	if delay := coordinationPenalties(t, write).ElectionDelayMs; math.Abs(delay-(electionDelay-100)) > 1 {
+		t.Errorf("Expected the write to wait out the election, got %.2fms", delay)
+	}
+
+	endTick := coordination.ConsensusState.ElectionEndsTick
+	read := coordination.ProcessOperation(&Operation{ID: "read", Type: OpCoordinationRead}, endTick)
+	if penalties := coordinationPenalties(t, read); penalties.ElectionDelayMs != 0 || penalties.QuorumRoundTrips != 0 {
+		t.Errorf("Expected a lease read with no wait once elected, got %+v", penalties)
+	}
+}
+
+// TestCoordinationEngineTwoPhaseCommit tests 2PC phase timing and participant aborts
+func TestCoordinationEngineTwoPhaseCommit(t *testing.T) {
+	coordination := newTestCoordinationEngine(t, int(ComplexityMinimal))
+	coordination.TwoPhaseCommit.ParticipantPrepareMs = 5.0
+
+	prepare := coordination.ProcessOperation(&Operation{
+		ID:       "prepare",
+		Type:     OpCoordination2PCPrepare,
+		Metadata: map[string]interface{}{"participants": 4},
+	}, 0)
+	if !prepare.Success || coordinationPenalties(t, prepare).QuorumLatencyMs != 5.5 {
+		t.Errorf("Expected a successful prepare bound by the slowest participant, got %+v", prepare.PenaltyInfo.CoordinationPenalties)
+	}
+
+	coordination.ProcessOperation(&Operation{ID: "commit", Type: OpCoordination2PCCommit}, 1)
+	if coordination.TransactionState.Prepared != 1 || coordination.TransactionState.Committed != 1 {
+		t.Errorf("Unexpected transaction state: %+v", coordination.TransactionState)
+	}
+
+	if err := coordination.SetComplexityLevel(int(ComplexityAdvanced)); err != nil {
+		t.Fatalf("Failed to set complexity level: %v", err)
+	}
+	coordination.TwoPhaseCommit.PrepareFailureRate = 1.0
+	if vetoed := coordination.ProcessOperation(&Operation{ID: "vetoed", Type: OpCoordination2PCPrepare}, 2); vetoed.Success {
+		t.Errorf("Expected a participant to vote no")
+	}
+}
+
+// TestCoordinationEngineProfiles tests the built-in and file-based coordination profiles
+func TestCoordinationEngineProfiles(t *testing.T) {
+	factory := NewEngineFactory()
+	engine, err := factory.CreateEngineWithDefaultProfile(CoordinationEngineType, 100)
+	if err != nil {
+		t.Fatalf("Failed to create engine from default profile: %v", err)
+	}
+	if engine.GetEngineType() != CoordinationEngineType || engine.GetEngineType().String() != "Coordination" {
+		t.Errorf("Unexpected engine type %v", engine.GetEngineType())
+	}
+
+	loader := NewProfileLoader(filepath.Join("..", "..", "profiles"))
+	for name, clusterSize := range map[string]int{
+		"etcd_raft_cluster":            3,
+		"zookeeper_ensemble":           5,
+		"two_phase_commit_coordinator": 1,
+	} {
+		profile, err := loader.LoadProfileFromFile(loader.GetProfilePath(CoordinationEngineType, name))
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+
+		coordination := NewCoordinationEngine(100)
+		if err := coordination.LoadProfile(profile); err != nil {
+			t.Fatalf("Failed to load %s into engine: %v", name, err)
+		}
+		if coordination.ClusterSize != clusterSize {
+			t.Errorf("%s: expected cluster size %d, got %d", name, clusterSize, coordination.ClusterSize)
+		}
+	}
+
+	if err := NewCoordinationEngine(100).LoadProfile(&EngineProfile{Name: "cpu", Type: CPUEngineType}); err == nil {
+		t.Errorf("Expected a CPU profile to be rejected")
+	}
+}
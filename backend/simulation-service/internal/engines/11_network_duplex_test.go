@@ -0,0 +1,166 @@
+package engines
+
+import (
+	"testing"
+	"time"
+)
+
+func networkPenalties(t *testing.T, result *OperationResult) *NetworkPenaltyDetails {
+	if result.PenaltyInfo == nil || result.PenaltyInfo.NetworkPenalties == nil {
+		t.Fatalf("Expected network penalty details for %s", result.OperationID)
+	}
+	return result.PenaltyInfo.NetworkPenalties
+}
+
+// TestNetworkDuplexIndependentLinks tests that saturating egress leaves ingress untouched
+func TestNetworkDuplexIndependentLinks(t *testing.T) {
+	network := NewNetworkEngine(100)
+	network.SetSeed(42)
+	network.SetComplexityLevel(int(ComplexityMinimal))
+
+	// Large responses fill ~96% of the transmit side
+	for i := 0; i < 4; i++ {
+		network.ProcessOperation(&Operation{ID: "response-" + string(rune('a'+i)), Type: OpNetworkResponse, DataSize: 240_000}, 1)
+	}
+	network.updateBandwidthUtilization()
+
+	if network.EgressState.BandwidthUtilization < 0.95 {
+		t.Fatalf("Expected egress to be saturated, got %.2f", network.EgressState.BandwidthUtilization)
+	}
+	if network.BandwidthState.BandwidthUtilization != 0 {
+		t.Fatalf("Expected ingress to be idle, got %.2f", network.BandwidthState.BandwidthUtilization)
+	}
+
+	send := networkPenalties(t, network.ProcessOperation(&Operation{ID: "send", Type: OpNetworkSend, DataSize: 1024}, 1))
+	recv := networkPenalties(t, network.ProcessOperation(&Operation{ID: "recv", Type: OpNetworkRecv, DataSize: 1024}, 1))
+
+	if send.Direction != "egress" || send.CongestionFactor <= 3.0 {
+		t.Errorf("Expected a congested egress send, got %+v", send)
+	}
+	if recv.Direction != "ingress" || recv.CongestionFactor != 1.0 || recv.PacketLossRate != 0 {
+		t.Errorf("Expected an uncongested ingress receive, got %+v", recv)
+	}
+
+	// Metadata overrides the direction inferred from the operation type
+	upload := network.ProcessOperation(&Operation{
+		ID: "upload", Type: "network_request", DataSize: 1024,
+		Metadata: map[string]interface{}{"direction": "egress"},
+	}, 1)
+	if direction := networkPenalties(t, upload).Direction; direction != "egress" {
+		t.Errorf("Expected metadata to route the request to egress, got %s", direction)
+	}
+}
+
+// TestNetworkInOutEngines tests the single-direction engines and their factory wiring
+func TestNetworkInOutEngines(t *testing.T) {
+	in, out := NewNetworkInEngine(100), NewNetworkOutEngine(100)
+	if in.GetEngineType().String() != "NetworkIn" || out.GetEngineType().String() != "NetworkOut" {
+		t.Fatalf("Unexpected engine types %v and %v", in.GetEngineType(), out.GetEngineType())
+	}
+
+	// Each engine models its own side whatever the operation type
+	op := &Operation{ID: "recv", Type: OpNetworkRecv, DataSize: 1024}
+	if direction := networkPenalties(t, out.ProcessOperation(op, 1)).Direction; direction != "egress" {
+		t.Errorf("Expected Network-Out to transmit on egress, got %s", direction)
+	}
+	result := in.ProcessOperation(op, 1)
+	if networkPenalties(t, result).Direction != "ingress" || result.PenaltyInfo.EngineType != NetworkInEngineType {
+		t.Errorf("Expected Network-In penalties on ingress, got %+v", result.PenaltyInfo)
+	}
+
+	factory := NewEngineFactory()
+	for _, engineType := range []EngineType{NetworkInEngineType, NetworkOutEngineType} {
+		engine, err := factory.CreateEngine(engineType, "gigabit_ethernet", 100)
+		if err != nil {
+			t.Fatalf("Failed to create %v engine: %v", engineType, err)
+		}
+		if engine.GetEngineType() != engineType {
+			t.Errorf("Expected %v engine, got %v", engineType, engine.GetEngineType())
+		}
+	}
+}
+
+// TestNetworkAsymmetricBandwidth tests per-direction capacity from a profile
+func TestNetworkAsymmetricBandwidth(t *testing.T) {
+	network := NewNetworkEngine(100)
+	network.SetComplexityLevel(int(ComplexityMinimal))
+
+	err := network.LoadProfile(&EngineProfile{
+		Name: "cable_broadband",
+		Type: NetworkEngineType,
+		BaselinePerformance: map[string]float64{
+			"bandwidth_mbps":        100,
+			"base_latency_ms":       0,
+			"egress_bandwidth_mbps": 10,
+		},
+		EngineSpecific: map[string]interface{}{
+			"nic_offload": map[string]interface{}{"segmentation_offload": false, "mtu_bytes": 9000.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+
+	op := &Operation{ID: "transfer", DataSize: 1_250_000} // 10ms at 1 Gbps
+	ingress := network.calculateBaseTransmissionTime(op, &network.BandwidthState)
+	egress := network.calculateBaseTransmissionTime(op, &network.EgressState)
+
+	if ingress != 100*time.Millisecond || egress != time.Second {
+		t.Errorf("Expected 100ms down and 1s up, got %v and %v", ingress, egress)
+	}
+	if network.EgressState.Offload.SegmentationOffload || network.EgressState.Offload.MTUBytes != 9000 {
+		t.Errorf("Expected NIC offload overrides from the profile, got %+v", network.EgressState.Offload)
+	}
+
+	// Reset keeps the link's profile configuration
+	network.Reset()
+	if network.EgressState.CapacityMbps != 10 || network.EgressState.Offload.MTUBytes != 9000 {
+		t.Errorf("Expected Reset to keep profile capacity and offloads, got %+v", network.EgressState)
+	}
+}
+
+// TestNetworkNICOffload tests per-packet host cost with and without segmentation offload
+func TestNetworkNICOffload(t *testing.T) {
+	network := NewNetworkEngine(100)
+	op := &Operation{ID: "bulk", DataSize: 1_500_000} // 1000 MTU-sized packets
+
+	// TSO hands the NIC 64KB at a time: 23 sends at 0.85μs with checksum offload
+	withTSO := network.applyNICOffload(0, op, &network.EgressState)
+	network.EgressState.Offload.SegmentationOffload = false
+	withoutTSO := network.applyNICOffload(0, op, &network.EgressState)
+
+	if withTSO != time.Duration(23*850) || withoutTSO != time.Duration(1000*850) {
+		t.Errorf("Expected 19.55μs with TSO and 850μs without, got %v and %v", withTSO, withoutTSO)
+	}
+}
+
+// TestNetworkCongestionWindow tests per-direction AIMD and retirement of finished transmissions
+func TestNetworkCongestionWindow(t *testing.T) {
+	network := NewNetworkEngine(100)
+	link := &network.EgressState
+
+	network.updateCongestionWindow(link, true)
+	if link.CongestionWindowSegments != 500 || network.effectiveBandwidthMbps(link) != 500 {
+		t.Errorf("Expected loss to halve the window and bandwidth, got %d segments", link.CongestionWindowSegments)
+	}
+	for i := 0; i < 10; i++ {
+		network.updateCongestionWindow(link, true)
+	}
+	if link.CongestionWindowSegments != link.MinCongestionWindowSegments {
+		t.Errorf("Expected the window to stop at %d segments, got %d", link.MinCongestionWindowSegments, link.CongestionWindowSegments)
+	}
+	network.updateCongestionWindow(link, false)
+	if link.CongestionWindowSegments != link.MinCongestionWindowSegments+1 {
+		t.Errorf("Expected additive increase, got %d segments", link.CongestionWindowSegments)
+	}
+	if network.BandwidthState.CongestionWindowSegments != 1000 {
+		t.Errorf("Expected ingress window to be unaffected, got %d", network.BandwidthState.CongestionWindowSegments)
+	}
+
+	network.ProcessOperation(&Operation{ID: "done", Type: OpNetworkSend, DataSize: 1024}, 1)
+	network.CurrentTick = 1_000_000
+	network.updateBandwidthUtilization()
+	if len(network.ActiveTransmissions) != 0 {
+		t.Errorf("Expected finished transmissions to be retired, got %d", len(network.ActiveTransmissions))
+	}
+}
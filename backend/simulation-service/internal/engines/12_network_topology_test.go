@@ -0,0 +1,106 @@
+package engines
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newCrossRegionNetwork creates an engine in us-east-1 with two paths to eu-west-2:
+// a low-latency path across the Atlantic and a path with fewer router hops
+func newCrossRegionNetwork() *NetworkEngine {
+	network := NewNetworkEngine(100)
+	network.SetSeed(42)
+	network.NetworkTopology = &NetworkTopology{
+		Nodes: map[string]*NetworkNode{
+			"use1": {ID: "use1", Type: "datacenter", Location: "us-east-1"},
+			"use2": {ID: "use2", Type: "datacenter", Location: "us-east-2"},
+			"euw1": {ID: "euw1", Type: "datacenter", Location: "eu-west-1"},
+			"euw2": {ID: "euw2", Type: "datacenter", Location: "eu-west-2"},
+		},
+		Edges: map[string]*NetworkEdge{
+			"transatlantic": {ID: "transatlantic", SourceNodeID: "use1", TargetNodeID: "euw1", DistanceKm: 5500, BaseLatencyMs: 70, BandwidthMbps: 10000, HopCount: 8},
+			"eu-backbone":   {ID: "eu-backbone", SourceNodeID: "euw1", TargetNodeID: "euw2", DistanceKm: 500, BaseLatencyMs: 10, BandwidthMbps: 100, HopCount: 6},
+			"us-backbone":   {ID: "us-backbone", SourceNodeID: "use2", TargetNodeID: "use1", DistanceKm: 500, BaseLatencyMs: 8, BandwidthMbps: 10000, HopCount: 3},
+			"northern":      {ID: "northern", SourceNodeID: "use2", TargetNodeID: "euw2", DistanceKm: 6000, BaseLatencyMs: 80, BandwidthMbps: 1000, HopCount: 6},
+		},
+	}
+	network.CurrentNodeID = "use1"
+	return network
+}
+
+// TestNetworkTopologyShortestPath tests latency- and hop-weighted route selection
+func TestNetworkTopologyShortestPath(t *testing.T) {
+	network := newCrossRegionNetwork()
+
+	route, err := network.FindRoute("use1", "euw2")
+	if err != nil {
+		t.Fatalf("Failed to route: %v", err)
+	}
+	if !reflect.DeepEqual(route.EdgeIDs, []string{"transatlantic", "eu-backbone"}) || route.LatencyMs != 80 || route.HopCount != 14 {
+		t.Errorf("Expected the 80ms transatlantic path, got %+v", route)
+	}
+	if route.BottleneckEdgeID != "eu-backbone" || route.BottleneckMbps != 100 {
+		t.Errorf("Expected eu-backbone to limit the route to 100 Mbps, got %s at %.0f", route.BottleneckEdgeID, route.BottleneckMbps)
+	}
+
+	network.RoutingMetric = RoutingMetricHops
+	route, _ = network.FindRoute("use1", "euw2")
+	if !reflect.DeepEqual(route.NodeIDs, []string{"use1", "use2", "euw2"}) || route.HopCount != 9 {
+		t.Errorf("Expected the 9-hop northern path, got %+v", route)
+	}
+
+	if _, err := network.FindRoute("use1", "ap-south-1"); err == nil {
+		t.Error("Expected an error routing to an unknown node")
+	}
+}
+
+// TestNetworkTopologyBandwidthSharing tests that concurrent transfers share the
+// bottleneck edge and show up in per-link utilization
+func TestNetworkTopologyBandwidthSharing(t *testing.T) {
+	network := newCrossRegionNetwork()
+	network.SetComplexityLevel(int(ComplexityAdvanced))
+
+	transfer := func(id string) *OperationResult {
+		return network.ProcessOperation(&Operation{
+			ID: id, Type: OpNetworkSend, DataSize: 12_500_000,
+			Metadata: map[string]interface{}{"target_node": "euw2", "connection_id": id},
+		}, 1)
+	}
+
+	first, second := transfer("first"), transfer("second")
+	if first.Metrics["bottleneck_mbps"] != 100.0 || second.Metrics["bottleneck_mbps"] != 50.0 {
+		t.Errorf("Expected the second transfer to get half of eu-backbone, got %v and %v",
+			first.Metrics["bottleneck_mbps"], second.Metrics["bottleneck_mbps"])
+	}
+	if second.ProcessingTime <= first.ProcessingTime {
+		t.Errorf("Expected the shared transfer to be slower, got %v vs %v", second.ProcessingTime, first.ProcessingTime)
+	}
+
+	links := network.GetLinkUtilization()
+	if links["eu-backbone"].ActiveFlows != 2 || links["eu-backbone"].Utilization != 1.0 || links["transatlantic"].Utilization != 0.015 {
+		t.Errorf("Unexpected link utilization: %+v", links)
+	}
+	if network.BottleneckLink() != "eu-backbone" {
+		t.Errorf("Expected eu-backbone to be the bottleneck link, got %q", network.BottleneckLink())
+	}
+
+	// Congestion-aware routing steers new traffic around the saturated edge
+	network.SetComplexityLevel(int(ComplexityMaximum))
+	if route, _ := network.FindRoute("use1", "euw2"); route.EdgeIDs[0] != "us-backbone" {
+		t.Errorf("Expected advanced routing to avoid eu-backbone, got %v", route.EdgeIDs)
+	}
+
+	// Finished transfers release their bandwidth
+	network.CurrentTick = 1_000_000
+	network.updateBandwidthUtilization()
+	links = network.GetLinkUtilization()
+	if links["eu-backbone"].ActiveFlows != 0 || links["eu-backbone"].Utilization != 0 || links["eu-backbone"].PeakUtilization != 1.0 {
+		t.Errorf("Expected eu-backbone to drain and keep its peak, got %+v", links["eu-backbone"])
+	}
+
+	// Operations without a target node keep distance-based latency
+	local := network.ProcessOperation(&Operation{ID: "local", Type: OpNetworkSend, DataSize: 1024}, 1_000_000)
+	if _, routed := local.Metrics["route_edges"]; routed {
+		t.Error("Expected an operation without target_node not to be routed")
+	}
+}
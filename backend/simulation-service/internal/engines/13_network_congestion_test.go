@@ -0,0 +1,145 @@
+package engines
+
+import (
+	"testing"
+	"time"
+)
+
+// newWANNetwork creates an engine with wan_connection's link and congestion settings
+func newWANNetwork(algorithm string, lossRate float64) *NetworkEngine {
+	network := NewNetworkEngine(100)
+	network.SetSeed(7)
+	network.SetComplexityLevel(int(ComplexityBasic))
+	network.LoadProfile(&EngineProfile{
+		Name: "wan_connection",
+		Type: NetworkEngineType,
+		BaselinePerformance: map[string]float64{
+			"bandwidth_mbps":   100,
+			"base_latency_ms":  50,
+			"packet_loss_rate": lossRate,
+		},
+		EngineSpecific: map[string]interface{}{
+			"congestion_behavior": map[string]interface{}{
+				"algorithm":                      algorithm,
+				"congestion_window_initial":      4.0,
+				"congestion_window_max":          32768.0,
+				"slow_start_threshold":           16384.0,
+				"congestion_avoidance_increment": 1.0,
+				"fast_recovery_enabled":          true,
+			},
+		},
+	})
+	return network
+}
+
+func wanDownload(network *NetworkEngine, id string, size int64, tick int64) *OperationResult {
+	return network.ProcessOperation(&Operation{
+		ID: id, Type: OpNetworkResponse, DataSize: size,
+		Metadata: map[string]interface{}{"connection_id": "download"},
+	}, tick)
+}
+
+// TestNetworkSlowStartRampUp tests slow start on a new connection, reuse of the
+// grown window, and restart after the connection goes idle
+func TestNetworkSlowStartRampUp(t *testing.T) {
+	network := newWANNetwork(CongestionAlgorithmCubic, 0)
+
+	// 64KB needs 45 segments: windows of 4, 8, 16 and 32 over four round trips
+	cold := wanDownload(network, "cold", 64*1024, 1)
+	if cold.Metrics["tcp_round_trips"] != int64(4) || cold.Metrics["tcp_end_window"] != 64.0 {
+		t.Errorf("Expected four slow start rounds ending at 64 segments, got %v rounds and window %v",
+			cold.Metrics["tcp_round_trips"], cold.Metrics["tcp_end_window"])
+	}
+
+	// The same request on the warmed-up connection fits in one window
+	warm := wanDownload(network, "warm", 64*1024, 2)
+	if warm.Metrics["tcp_round_trips"] != int64(1) || warm.ProcessingTime >= cold.ProcessingTime-200*time.Millisecond {
+		t.Errorf("Expected the warm connection to skip three round trips, got %v vs %v", warm.ProcessingTime, cold.ProcessingTime)
+	}
+
+	// A connection idle past its retransmission timeout starts over
+	idle := wanDownload(network, "idle", 64*1024, 1_000_000)
+	if idle.Metrics["tcp_start_window"] != 4.0 {
+		t.Errorf("Expected an idle connection to restart from the initial window, got %v", idle.Metrics["tcp_start_window"])
+	}
+
+	// Other connections and UDP keep their own behaviour
+	if network.GetTCPConnections()["egress:download"].SlowStartThreshold != 16384 {
+		t.Errorf("Expected the profile's slow start threshold, got %+v", network.GetTCPConnections())
+	}
+	network.Protocol = "UDP"
+	udp := wanDownload(network, "udp", 64*1024, 1_000_001)
+	if _, ok := udp.Metrics["tcp_round_trips"]; ok {
+		t.Error("Expected UDP to bypass congestion control")
+	}
+}
+
+// TestNetworkCongestionAlgorithmsUnderLoss tests throughput collapse of a large WAN
+// transfer under 1% loss, and that BBR holds its rate where Reno and CUBIC back off
+func TestNetworkCongestionAlgorithmsUnderLoss(t *testing.T) {
+	const size = 10 * 1024 * 1024
+
+	lossless := wanDownload(newWANNetwork(CongestionAlgorithmCubic, 0), "lossless", size, 1)
+	// Slow start and the TLS handshake on the new connection add a few round trips
+	if lossless.ProcessingTime > 2500*time.Millisecond {
+		t.Fatalf("Expected a lossless 10MB transfer near line rate, got %v", lossless.ProcessingTime)
+	}
+
+	times := make(map[string]time.Duration)
+	for _, algorithm := range []string{CongestionAlgorithmReno, CongestionAlgorithmCubic, CongestionAlgorithmBBR} {
+		result := wanDownload(newWANNetwork(algorithm, 0.01), algorithm, size, 1)
+		if result.Metrics["tcp_loss_events"].(int64) == 0 || result.Metrics["congestion_algorithm"] != algorithm {
+			t.Fatalf("Expected %s to see loss, got %v", algorithm, result.Metrics)
+		}
+		times[algorithm] = result.ProcessingTime
+	}
+
+	// At 1% loss CUBIC runs in its Reno-friendly region, so both collapse alike
+	for _, algorithm := range []string{CongestionAlgorithmReno, CongestionAlgorithmCubic} {
+		if times[algorithm] < 10*lossless.ProcessingTime {
+			t.Errorf("Expected %s throughput to collapse under loss, got %v vs %v lossless", algorithm, times[algorithm], lossless.ProcessingTime)
+		}
+	}
+	if times[CongestionAlgorithmBBR] > 5*lossless.ProcessingTime {
+		t.Errorf("Expected BBR to keep most of its throughput, got %v vs %v lossless", times[CongestionAlgorithmBBR], lossless.ProcessingTime)
+	}
+}
+
+// TestNetworkCongestionWindowBackoff tests each algorithm's response to loss and CUBIC regrowth
+func TestNetworkCongestionWindowBackoff(t *testing.T) {
+	network := NewNetworkEngine(100)
+	rtt := 100 * time.Millisecond
+
+	reno := &TCPConnection{Algorithm: CongestionAlgorithmReno, CongestionWindow: 100}
+	network.onCongestionLoss(reno)
+	network.onRoundTrip(reno, rtt, 1000)
+	if reno.SlowStartThreshold != 50 || reno.CongestionWindow != 51 {
+		t.Errorf("Expected Reno to halve then add one segment, got %+v", reno)
+	}
+
+	// CUBIC keeps 70% and is back at the window before loss K seconds later
+	cubic := &TCPConnection{Algorithm: CongestionAlgorithmCubic, CongestionWindow: 100}
+	network.onCongestionLoss(cubic)
+	if cubic.CongestionWindow != 70 || cubic.CubicK.Round(time.Millisecond) != 4217*time.Millisecond {
+		t.Errorf("Expected CUBIC to keep 70 segments with K=4.2s, got %+v", cubic)
+	}
+	for i := 0; i < 43; i++ {
+		network.onRoundTrip(cubic, rtt, 1000)
+	}
+	if cubic.CongestionWindow < 100 || cubic.CongestionWindow > 101 {
+		t.Errorf("Expected CUBIC to plateau at the window before loss, got %.1f", cubic.CongestionWindow)
+	}
+
+	bbr := &TCPConnection{Algorithm: CongestionAlgorithmBBR, CongestionWindow: 200}
+	network.onCongestionLoss(bbr)
+	if bbr.CongestionWindow != 200 {
+		t.Errorf("Expected BBR to ignore random loss, got %.1f", bbr.CongestionWindow)
+	}
+
+	network.Congestion.FastRecovery = false
+	tahoe := &TCPConnection{Algorithm: CongestionAlgorithmReno, CongestionWindow: 100}
+	network.onCongestionLoss(tahoe)
+	if tahoe.CongestionWindow != 1 || !tahoe.SlowStart {
+		t.Errorf("Expected loss without fast recovery to restart slow start from one segment, got %+v", tahoe)
+	}
+}
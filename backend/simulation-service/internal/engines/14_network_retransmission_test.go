@@ -0,0 +1,101 @@
+package engines
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestNetworkRetransmissionTimeout tests RTO derivation from RTT and exponential backoff
+func TestNetworkRetransmissionTimeout(t *testing.T) {
+	network := newWANNetwork(CongestionAlgorithmCubic, 1.0) // Every packet is lost
+	rtt := 100 * time.Millisecond
+
+	// Before any sample RTO = RTT + 4*RTT/2, and never below 200ms
+	if rto := network.retransmissionTimeout(nil, rtt); rto != 300*time.Millisecond {
+		t.Errorf("Expected a 300ms RTO for a 100ms RTT, got %v", rto)
+	}
+	if rto := network.retransmissionTimeout(nil, 10*time.Millisecond); rto != 200*time.Millisecond {
+		t.Errorf("Expected the 200ms minimum RTO, got %v", rto)
+	}
+
+	// A steady RTT shrinks the variance until the RTO sits at its floor
+	conn := &TCPConnection{Algorithm: CongestionAlgorithmCubic, CongestionWindow: 40}
+	for i := 0; i < 20; i++ {
+		conn.observeRTT(rtt)
+	}
+	if rto := network.retransmissionTimeout(conn, rtt); conn.RTTVariance > time.Millisecond || rto != 200*time.Millisecond {
+		t.Errorf("Expected a settled RTT variance and the minimum RTO, got %v and %v", conn.RTTVariance, rto)
+	}
+
+	// Fast retransmit after one RTT, then RTOs of 300ms, 600ms and 1.2s before giving up
+	network.Retransmission.MaxRetransmits = 3
+	op := &Operation{ID: "lost"}
+	elapsed, attempts, err := network.retransmit(op, nil, &network.EgressState, rtt, true)
+	if elapsed != 2200*time.Millisecond || attempts != 3 {
+		t.Errorf("Expected 3 retransmissions over 2.2s, got %d over %v", attempts, elapsed)
+	}
+
+	var networkErr *NetworkError
+	if !errors.As(err, &networkErr) || networkErr.Code != NetworkErrorRetransmitsExhausted || networkErr.Attempts != 3 {
+		t.Errorf("Expected a retransmits_exhausted NetworkError, got %v", err)
+	}
+
+	// A timeout sends the connection back to slow start
+	network.retransmit(op, conn, &network.EgressState, rtt, false)
+	if conn.CongestionWindow != 1 || !conn.SlowStart {
+		t.Errorf("Expected an RTO to collapse the window to one segment, got %+v", conn)
+	}
+}
+
+// TestNetworkLossFailsOperation tests that unrecoverable loss fails the operation
+// with a typed error while recoverable loss only costs time
+func TestNetworkLossFailsOperation(t *testing.T) {
+	flaky := newWANNetwork(CongestionAlgorithmCubic, 0.02)
+	recovered := wanDownload(flaky, "recovered", 1024*1024, 1)
+	if !recovered.Success || recovered.Metrics["retransmits"].(int64) == 0 {
+		t.Errorf("Expected lost segments to be retransmitted, got success=%v metrics=%v", recovered.Success, recovered.Metrics)
+	}
+
+	dead := newWANNetwork(CongestionAlgorithmCubic, 1.0)
+	dead.Retransmission.MaxRetransmits = 2
+	failed := wanDownload(dead, "failed", 64*1024, 1)
+
+	var networkErr *NetworkError
+	if failed.Success || !errors.As(failed.Error, &networkErr) || failed.ErrorMessage != failed.Error.Error() {
+		t.Fatalf("Expected a failed operation with a NetworkError, got success=%v error=%v", failed.Success, failed.Error)
+	}
+	if networkErr.Code != NetworkErrorRetransmitsExhausted || networkErr.OperationID != "failed" || dead.FailedOps != 1 {
+		t.Errorf("Unexpected error %+v with %d failed operations", networkErr, dead.FailedOps)
+	}
+	if failed.ProcessingTime < 900*time.Millisecond {
+		t.Errorf("Expected the retry budget to be spent before failing, got %v", failed.ProcessingTime)
+	}
+}
+
+// TestNetworkLossByProtocol tests that UDP drops lost datagrams and QUIC probes until its idle timeout
+func TestNetworkLossByProtocol(t *testing.T) {
+	udp := newWANNetwork(CongestionAlgorithmCubic, 1.0)
+	udp.Protocol = "UDP"
+	result := wanDownload(udp, "datagram", 512, 1)
+
+	var networkErr *NetworkError
+	if !errors.As(result.Error, &networkErr) || networkErr.Code != NetworkErrorDatagramLost || networkErr.Attempts != 0 {
+		t.Errorf("Expected UDP to fail without retransmitting, got %v", result.Error)
+	}
+
+	quic := newWANNetwork(CongestionAlgorithmCubic, 1.0)
+	quic.Protocol = "QUIC"
+	quic.Retransmission.QUICIdleTimeout = 5 * time.Second
+	conn := &TCPConnection{Algorithm: CongestionAlgorithmCubic, CongestionWindow: 40}
+	elapsed, attempts, err := quic.retransmit(&Operation{ID: "stream"}, conn, &quic.EgressState, 100*time.Millisecond, false)
+
+	if !errors.As(err, &networkErr) || networkErr.Code != NetworkErrorIdleTimeout || elapsed <= 5*time.Second {
+		t.Errorf("Expected QUIC to give up after its idle timeout, got %v after %v", err, elapsed)
+	}
+	// Loss is seen after one RTT, then PTO = 100ms + 4*50ms + 25ms doubles:
+	// 0.1 + 0.325 + 0.65 + 1.3 + 2.6 = 4.975s, and the next probe passes 5s
+	if attempts != 5 || conn.CongestionWindow != 40 {
+		t.Errorf("Expected 5 retransmissions without collapsing the window, got %d and window %.0f", attempts, conn.CongestionWindow)
+	}
+}
@@ -0,0 +1,122 @@
+package engines
+
+import (
+	"testing"
+	"time"
+)
+
+func tlsRequest(network *NetworkEngine, id, clientID string, tick int64) *OperationResult {
+	return network.ProcessOperation(&Operation{
+		ID: id, Type: OpNetworkSend, DataSize: 1024,
+		Metadata: map[string]interface{}{"connection_id": id, "client_id": clientID},
+	}, tick)
+}
+
+// TestNetworkTLSHandshakeVersions tests handshake round trips for TLS 1.2 and
+// 1.3, full and resumed, over the 100ms WAN round trip
+func TestNetworkTLSHandshakeVersions(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		earlyData bool
+		fullRTTs  int
+		resumed   int
+	}{
+		{"TLS 1.2", TLSVersion12, false, 2, 1},
+		{"TLS 1.3", TLSVersion13, false, 1, 1},
+		{"TLS 1.3 0-RTT", TLSVersion13, true, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := newWANNetwork(CongestionAlgorithmCubic, 0)
+			network.ProtocolState.KeepAliveEnabled = false // Every request opens a new connection
+			network.TLS.Version = tt.version
+			network.TLS.EarlyData = tt.earlyData
+
+			full := tlsRequest(network, "full", "client-1", 1)
+			if full.Metrics["tls_resumed"] != false || full.Metrics["tls_handshake_rtts"] != tt.fullRTTs {
+				t.Errorf("Expected a full %d-RTT handshake, got %v", tt.fullRTTs, full.Metrics)
+			}
+			expected := float64(tt.fullRTTs)*100 + 1 // Round trips plus certificate validation
+			if ms := full.Metrics["tls_handshake_ms"].(float64); ms < expected || ms > expected+1 {
+				t.Errorf("Expected about %.0fms of handshake, got %.3fms", expected, ms)
+			}
+
+			resumed := tlsRequest(network, "resumed", "client-1", 2)
+			if resumed.Metrics["tls_resumed"] != true || resumed.Metrics["tls_handshake_rtts"] != tt.resumed {
+				t.Errorf("Expected a %d-RTT resumption with the session ticket, got %v", tt.resumed, resumed.Metrics)
+			}
+			if resumed.ProcessingTime >= full.ProcessingTime {
+				t.Errorf("Expected resumption to be faster, got %v vs %v", resumed.ProcessingTime, full.ProcessingTime)
+			}
+		})
+	}
+}
+
+// TestNetworkTLSHandshakeCPU tests that handshake crypto is handed to the CPU
+// engine, with asymmetric crypto costing far more than resumption
+func TestNetworkTLSHandshakeCPU(t *testing.T) {
+	network := newWANNetwork(CongestionAlgorithmCubic, 0)
+	network.ProtocolState.KeepAliveEnabled = false
+
+	full := tlsRequest(network, "full", "client-1", 1)
+	if len(full.FollowUpOperations) != 1 {
+		t.Fatalf("Expected one follow-up operation, got %d", len(full.FollowUpOperations))
+	}
+	cpuOp := full.FollowUpOperations[0]
+	if cpuOp.Type != OpCPUCompute || cpuOp.Metadata["source"] != "tls_handshake" || cpuOp.Metadata["cpu_time_ms"] != 1.5 {
+		t.Errorf("Expected a 1.5ms CPU operation for the full handshake, got %+v", cpuOp)
+	}
+
+	resumed := tlsRequest(network, "resumed", "client-1", 2)
+	if cpu := resumed.FollowUpOperations[0].Metadata["cpu_time_ms"].(float64); cpu >= 1.5 {
+		t.Errorf("Expected resumption to skip asymmetric crypto, got %.2fms", cpu)
+	}
+
+	// Tickets expire, and servers without tickets always pay the full handshake
+	expired := tlsRequest(network, "expired", "client-1", 2+network.DurationToTicks(3*time.Hour))
+	if expired.Metrics["tls_resumed"] != false {
+		t.Error("Expected an expired ticket to force a full handshake")
+	}
+	network.TLS.SessionTickets = false
+	noTickets := tlsRequest(network, "no-tickets", "client-1", 3+network.DurationToTicks(3*time.Hour))
+	if noTickets.Metrics["tls_resumed"] != false {
+		t.Error("Expected no resumption without session tickets")
+	}
+}
+
+// TestNetworkTLSConnectionReuse tests that reused keep-alive connections skip
+// the handshake and that profiles configure TLS
+func TestNetworkTLSConnectionReuse(t *testing.T) {
+	network := newWANNetwork(CongestionAlgorithmCubic, 0)
+
+	handshakes := 0
+	for i := 0; i < 50; i++ {
+		result := tlsRequest(network, "request", "client-1", int64(i+1))
+		if _, ok := result.Metrics["tls_handshake_ms"]; ok {
+			handshakes++
+		} else if len(result.FollowUpOperations) != 0 {
+			t.Error("Expected a reused connection not to need handshake crypto")
+		}
+	}
+	if handshakes == 0 || handshakes > 20 {
+		t.Errorf("Expected most requests to reuse a keep-alive connection, got %d handshakes in 50", handshakes)
+	}
+
+	network.LoadProfile(&EngineProfile{
+		Name: "legacy_tls",
+		Type: NetworkEngineType,
+		EngineSpecific: map[string]interface{}{
+			"security_overhead": map[string]interface{}{
+				"version":                   "1.2",
+				"session_tickets":           false,
+				"handshake_cpu_ms":          4.0,
+				"certificate_validation_ms": 50.0,
+			},
+		},
+	})
+	if network.TLS.Version != TLSVersion12 || network.TLS.SessionTickets || network.TLS.FullHandshakeCPU != 4*time.Millisecond || network.TLS.CertificateValidation != 50*time.Millisecond {
+		t.Errorf("Expected profile TLS settings to load, got %+v", network.TLS)
+	}
+}
@@ -0,0 +1,113 @@
+package engines
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newHTTPNetwork creates a WAN engine speaking the given application protocol
+func newHTTPNetwork(protocol string, lossRate float64) *NetworkEngine {
+	network := newWANNetwork(CongestionAlgorithmCubic, lossRate)
+	network.Protocol = protocol
+	network.configureProtocol()
+	return network
+}
+
+func httpRequest(network *NetworkEngine, id, origin string, tick int64) *OperationResult {
+	return network.ProcessOperation(&Operation{
+		ID: id, Type: OpNetworkResponse, DataSize: 100 * 1024,
+		Metadata: map[string]interface{}{"origin": origin},
+	}, tick)
+}
+
+// TestNetworkHTTP1ConnectionPool tests that HTTP/1.1 serializes requests per
+// connection and queues them once the per-origin pool is exhausted
+func TestNetworkHTTP1ConnectionPool(t *testing.T) {
+	network := newHTTPNetwork("HTTP/1.1", 0)
+
+	for i := 0; i < 8; i++ {
+		result := httpRequest(network, fmt.Sprintf("req-%d", i), "api", 1)
+		waited := result.Metrics["http_queue_wait_ms"].(float64) > 0
+		if waited != (i >= 6) {
+			t.Errorf("Request %d: expected to wait only once six connections are busy, waited %.1fms", i, result.Metrics["http_queue_wait_ms"])
+		}
+	}
+
+	origin := network.GetHTTPOrigins()["api"]
+	if len(origin.ConnectionBusyUntil) != 6 || origin.QueuedRequests != 2 {
+		t.Errorf("Expected six pooled connections and two queued requests, got %+v", origin)
+	}
+	if _, ok := network.GetTCPConnections()["egress:api#5"]; !ok {
+		t.Errorf("Expected each pooled connection to keep its own congestion window, got %v", network.GetTCPConnections())
+	}
+
+	// Another origin has its own pool
+	if other := httpRequest(network, "other", "cdn", 1); other.Metrics["http_queue_wait_ms"].(float64) != 0 {
+		t.Errorf("Expected a different origin not to wait, got %.1fms", other.Metrics["http_queue_wait_ms"])
+	}
+}
+
+// TestNetworkHTTP2Multiplexing tests that HTTP/2 streams share one connection
+// without waiting, up to the concurrent stream limit
+func TestNetworkHTTP2Multiplexing(t *testing.T) {
+	network := newHTTPNetwork("HTTP/2", 0)
+	network.LoadProfile(&EngineProfile{
+		Name: "h2",
+		Type: NetworkEngineType,
+		EngineSpecific: map[string]interface{}{
+			"http": map[string]interface{}{"max_concurrent_streams": 4.0},
+		},
+	})
+	network.Protocol = "HTTP/2"
+	network.configureProtocol()
+
+	for i := 0; i < 5; i++ {
+		result := httpRequest(network, fmt.Sprintf("stream-%d", i), "api", 1)
+		if result.Metrics["http_streams_in_flight"] != i {
+			t.Errorf("Stream %d: expected %d streams already in flight, got %v", i, i, result.Metrics["http_streams_in_flight"])
+		}
+		waited := result.Metrics["http_queue_wait_ms"].(float64) > 0
+		if waited != (i == 4) {
+			t.Errorf("Stream %d: expected to wait only past the 4-stream limit, waited %.1fms", i, result.Metrics["http_queue_wait_ms"])
+		}
+	}
+
+	if connections := network.GetTCPConnections(); len(connections) != 1 {
+		t.Errorf("Expected all streams on one TCP connection, got %v", connections)
+	}
+}
+
+// TestNetworkHeadOfLineBlockingUnderLoss tests that under 1% loss HTTP/2 streams
+// stall behind each other's lost segments while HTTP/3 streams do not, giving
+// HTTP/2 a longer tail
+func TestNetworkHeadOfLineBlockingUnderLoss(t *testing.T) {
+	p99 := func(protocol string, lossRate float64) (time.Duration, int64) {
+		network := newHTTPNetwork(protocol, lossRate)
+		var times []time.Duration
+		for burst := 0; burst < 20; burst++ {
+			for i := 0; i < 10; i++ {
+				result := httpRequest(network, fmt.Sprintf("req-%d-%d", burst, i), "api", int64(1+burst*1000))
+				times = append(times, result.ProcessingTime)
+			}
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		return times[len(times)*99/100], network.GetHTTPOrigins()["api"].HeadOfLineStalls
+	}
+
+	h2Lossless, _ := p99("HTTP/2", 0)
+	h3Lossless, _ := p99("HTTP/3", 0)
+	if diff := h2Lossless - h3Lossless; diff < -10*time.Millisecond || diff > 10*time.Millisecond {
+		t.Errorf("Expected HTTP/2 and HTTP/3 to match without loss, got p99 %v vs %v", h2Lossless, h3Lossless)
+	}
+
+	h2, h2Stalls := p99("HTTP/2", 0.01)
+	h3, h3Stalls := p99("HTTP/3", 0.01)
+	if h2Stalls == 0 || h3Stalls != 0 {
+		t.Errorf("Expected head-of-line stalls on HTTP/2 only, got %d and %d", h2Stalls, h3Stalls)
+	}
+	if h2 < h3*3/2 {
+		t.Errorf("Expected HTTP/2 p99 well above HTTP/3 under loss, got %v vs %v", h2, h3)
+	}
+}
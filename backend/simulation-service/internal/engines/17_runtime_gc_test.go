@@ -0,0 +1,154 @@
+package engines
+
+import (
+	"testing"
+	"time"
+)
+
+const gcTestGB = int64(1 << 30)
+
+// newGCTestEngine loads a profile from the repository's profiles into a bare engine
+func newGCTestEngine(t *testing.T, engine BaseEngine, profileName string) {
+	profileLoader := NewProfileLoader("../../profiles")
+	profile, err := profileLoader.LoadProfileFromFile(profileLoader.GetProfilePath(engine.GetEngineType(), profileName))
+	if err != nil {
+		t.Fatalf("Failed to load profile %s: %v", profileName, err)
+	}
+	if err := engine.LoadProfile(profile); err != nil {
+		t.Fatalf("Failed to apply profile %s: %v", profileName, err)
+	}
+}
+
+// TestRuntimeGCCycles tests when each runtime collects and how its pauses scale
+// with the heap
+func TestRuntimeGCCycles(t *testing.T) {
+	// Go: a cycle each time the heap doubles over what survived, with a
+	// sub-millisecond pause whatever the heap size
+	goRuntime := NewRuntimeGC(RuntimeGo)
+	for i := 0; i < 4; i++ {
+		goRuntime.RecordAllocation(0, 1<<20, 1)
+	}
+	if goRuntime.MajorCycles != 1 || goRuntime.NextMajorBytes != 8<<20 {
+		t.Errorf("Expected a Go cycle at the 4MB minimum heap and the next at 8MB, got %d cycles and %d", goRuntime.MajorCycles, goRuntime.NextMajorBytes)
+	}
+	goRuntime.RecordAllocation(0, 4*gcTestGB, 1)
+	if pause := goRuntime.LongestPause; pause > time.Millisecond {
+		t.Errorf("Expected Go pauses to stay sub-millisecond on a 4GB heap, got %v", pause)
+	}
+
+	// G1 and ZGC on the same 4GB heap: G1's mixed pause runs to its pause
+	// target, ZGC stays under a millisecond
+	g1, zgc := NewRuntimeGC(RuntimeJavaG1), NewRuntimeGC(RuntimeZGC)
+	g1Pauses := g1.RecordAllocation(0, 4*gcTestGB, 1)
+	zgcPauses := zgc.RecordAllocation(0, 4*gcTestGB, 1)
+	if last := g1Pauses[len(g1Pauses)-1]; last.Kind != GCCycleMajor || last.Duration != 200*time.Millisecond {
+		t.Errorf("Expected a G1 major pause capped at 200ms, got %+v", g1Pauses)
+	}
+	if len(zgcPauses) != 1 || zgcPauses[0].Duration > time.Millisecond {
+		t.Errorf("Expected one sub-millisecond ZGC pause, got %+v", zgcPauses)
+	}
+
+	// Young collections reclaim young garbage and pay only for survivors
+	dotnet := NewRuntimeGC(RuntimeDotNet)
+	dotnet.RecordAllocation(0, 32<<20, 1)
+	dotnet.RecordFree(32 << 20)
+	pauses := dotnet.RecordAllocation(0, 32<<20, 1)
+	if len(pauses) != 1 || pauses[0].Kind != GCCycleMinor || dotnet.HeapBytes != 32<<20 {
+		t.Errorf("Expected a gen0 collection to reclaim the freed 32MB, got %+v with a %d byte heap", pauses, dotnet.HeapBytes)
+	}
+
+	// CPython frees by reference count and runs generation 0 every 700 allocations
+	python := NewRuntimeGC(RuntimePython)
+	python.RecordAllocation(0, 1<<20, 1)
+	python.RecordFree(1 << 20)
+	if python.HeapBytes != 0 {
+		t.Errorf("Expected reference counting to reclaim freed memory at once, got %d bytes", python.HeapBytes)
+	}
+	for i := 1; i < 700; i++ {
+		python.RecordAllocation(0, 64, 1)
+	}
+	if python.MinorCycles != 1 {
+		t.Errorf("Expected a generation 0 collection after 700 allocations, got %d", python.MinorCycles)
+	}
+}
+
+// TestRuntimeGCProfileOverrides tests that memory profiles tune a runtime's
+// collector, including the older per-language gc_behavior entries
+func TestRuntimeGCProfileOverrides(t *testing.T) {
+	mem := NewMemoryEngine(100)
+	newGCTestEngine(t, mem, "ddr5_6400_server")
+
+	runtime := NewRuntimeGC(RuntimeJavaG1)
+	mem.AttachRuntime(runtime)
+	if runtime.Config.MajorPausePerGB != 6*time.Millisecond {
+		t.Errorf("Expected the profile's java pause_time_per_gb to apply to G1, got %v", runtime.Config.MajorPausePerGB)
+	}
+
+	runtime.LoadProfile(&EngineProfile{EngineSpecific: map[string]interface{}{
+		"gc_behavior": map[string]interface{}{
+			"java_g1": map[string]interface{}{"young_gen_mb": 32.0, "minor_pause_ms": 2.0, "max_pause_ms": 50.0},
+		},
+	}})
+	if runtime.Config.YoungGenBytes != 32<<20 || runtime.Config.MinorPause != 2*time.Millisecond || runtime.Config.MaxPause != 50*time.Millisecond {
+		t.Errorf("Expected runtime-keyed settings to load, got %+v", runtime.Config)
+	}
+}
+
+// TestRuntimeGCPauseStallsInstance tests that a collection triggered by the
+// memory engine delays operations already in flight on the instance's CPU and
+// memory engines, not only the allocation that triggered it
+func TestRuntimeGCPauseStallsInstance(t *testing.T) {
+	run := func(withRuntime bool) (cpuDone, readDone int64, lateResult *OperationResult) {
+		cpu := NewCPUEngine(100)
+		newGCTestEngine(t, cpu, "intel_xeon_server")
+		mem := NewMemoryEngine(100)
+		newGCTestEngine(t, mem, "ddr4_3200_dual_channel")
+		if withRuntime {
+			runtime := NewRuntimeGC(RuntimeDotNet)
+			// Warm 1GB heap one allocation short of a blocking gen2 collection
+			runtime.LiveBytes, runtime.HeapBytes, runtime.NextMajorBytes = gcTestGB, gcTestGB, gcTestGB+1
+			cpu.AttachRuntime(runtime)
+			mem.AttachRuntime(runtime)
+		}
+
+		cpu.QueueOperation(&Operation{ID: "compute", Type: OpCPUCompute, Complexity: ComplexityON, DataSize: 1 << 20, Language: LangGo})
+		mem.QueueOperation(&Operation{ID: "read", Type: OpMemoryRead, Complexity: ComplexityO1, DataSize: 4096})
+		mem.QueueOperation(&Operation{ID: "alloc", Type: OpMemoryAlloc, Complexity: ComplexityO1, DataSize: 4096})
+
+		cpuDone, readDone = -1, -1
+		for tick := int64(1); tick < 2000 && (cpuDone < 0 || readDone < 0); tick++ {
+			for _, result := range cpu.ProcessTick(tick) {
+				if result.OperationID == "compute" {
+					cpuDone = tick
+				}
+			}
+			for _, result := range mem.ProcessTick(tick) {
+				if result.OperationID == "read" {
+					readDone = tick
+				}
+			}
+		}
+
+		// Operations arriving during the pause wait for it through the direct path too
+		lateResult = mem.ProcessOperation(&Operation{ID: "late", Type: OpMemoryRead, Complexity: ComplexityO1, DataSize: 64}, 3)
+		return cpuDone, readDone, lateResult
+	}
+
+	baseCPU, baseRead, baseLate := run(false)
+	gcCPU, gcRead, gcLate := run(true)
+
+	// 10ms + 100ms per GB live of gen2 pause
+	const pauseTicks = 110
+	if baseCPU < 0 || gcCPU-baseCPU < pauseTicks || gcCPU-baseCPU > pauseTicks+5 {
+		t.Errorf("Expected the in-flight CPU operation to wait out the 110ms GC pause once, completed at tick %d vs %d", gcCPU, baseCPU)
+	}
+	if baseRead < 0 || gcRead-baseRead < pauseTicks || gcRead-baseRead > pauseTicks+5 {
+		t.Errorf("Expected the in-flight memory read to wait out the 110ms GC pause once, completed at tick %d vs %d", gcRead, baseRead)
+	}
+	if _, ok := baseLate.Metrics["gc_pause_ms"]; ok {
+		t.Error("Expected no GC pause without a runtime")
+	}
+	if ms, _ := gcLate.Metrics["gc_pause_ms"].(float64); ms < pauseTicks-10 {
+		t.Errorf("Expected an operation arriving mid-pause to stall, got %.1fms", ms)
+	}
+}
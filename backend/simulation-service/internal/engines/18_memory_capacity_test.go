@@ -0,0 +1,108 @@
+package engines
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const capacityTestMB = int64(1 << 20)
+
+// newCapacityTestMemory creates a memory engine limited to limitMB of RAM and swapMB of swap
+func newCapacityTestMemory(t *testing.T, limitMB, swapMB int64) *MemoryEngine {
+	mem := NewMemoryEngine(100)
+	newGCTestEngine(t, mem, "ddr4_3200_dual_channel")
+	mem.Capacity.loadProfile(map[string]interface{}{
+		"memory_limit_mb": float64(limitMB),
+		"swap_mb":         float64(swapMB),
+	})
+	return mem
+}
+
+func allocate(mem *MemoryEngine, id string, bytes int64) *OperationResult {
+	return mem.ProcessOperation(&Operation{ID: id, Type: OpMemoryAlloc, Complexity: ComplexityO1, DataSize: bytes}, 1)
+}
+
+// TestMemoryCapacitySwap tests that memory beyond the RAM limit is swapped out
+// at the storage engine's latency and faulted back in on access
+func TestMemoryCapacitySwap(t *testing.T) {
+	mem := newCapacityTestMemory(t, 1024, 1024)
+	if defaults := mem.defaultMemoryCapacityConfig(); defaults.LimitBytes != mem.CapacityGB<<30 || defaults.SwapBytes == 0 {
+		t.Errorf("Expected the profile's capacity and swap to be the defaults, got %+v", defaults)
+	}
+	storage := NewStorageEngine(100)
+	storage.LatencyReadUs, storage.LatencyWriteUs, storage.BandwidthMBps = 80, 20, 5000
+	mem.AttachSwapDevice(storage)
+
+	if result := allocate(mem, "fits", 768*capacityTestMB); result.Metrics["swap_ms"] != nil {
+		t.Errorf("Expected no swapping within the limit, got %.1fms", result.Metrics["swap_ms"])
+	}
+
+	// 256MB over the limit is written to swap before the allocation returns
+	result := allocate(mem, "overflow", 512*capacityTestMB)
+	expected := storage.SwapLatency(256*capacityTestMB, true)
+	if swapMs, _ := result.Metrics["swap_ms"].(float64); swapMs != float64(expected)/float64(time.Millisecond) {
+		t.Errorf("Expected the storage engine's %v to swap out 256MB, got %.1fms", expected, swapMs)
+	}
+	set := mem.GetResidentSet()
+	if set.ResidentBytes != 1024*capacityTestMB || set.SwappedBytes != 256*capacityTestMB || set.PagesSwappedOut != 256*capacityTestMB/4096 {
+		t.Errorf("Expected 1GB resident and 256MB swapped, got %+v", set)
+	}
+
+	// A fifth of the set is swapped, so a 100-page read faults 20 pages in
+	read := mem.ProcessOperation(&Operation{ID: "read", Type: OpMemoryRead, Complexity: ComplexityO1, DataSize: 100 * 4096}, 2)
+	if read.Metrics["swap_ms"] == nil || mem.GetResidentSet().PagesSwappedIn != 20 {
+		t.Errorf("Expected the read to fault 20 pages in from swap, got %d", mem.GetResidentSet().PagesSwappedIn)
+	}
+
+	// Freed memory is released from RAM and swap alike
+	mem.ProcessOperation(&Operation{ID: "free", Type: OpMemoryFree, Complexity: ComplexityO1, DataSize: 640 * capacityTestMB}, 3)
+	if set := mem.GetResidentSet(); set.AllocatedBytes != 640*capacityTestMB || set.SwappedBytes != 128*capacityTestMB {
+		t.Errorf("Expected 640MB allocated with 128MB swapped after the free, got %+v", set)
+	}
+}
+
+// TestMemoryOOMKill tests that a leak grows the resident set until the OOM
+// killer ends the process, failing the operation with a typed error
+func TestMemoryOOMKill(t *testing.T) {
+	mem := newCapacityTestMemory(t, 256, 0)
+	runtime := NewRuntimeGC(RuntimeGo)
+	mem.AttachRuntime(runtime)
+
+	// Allocations that are never freed
+	var killed *OperationResult
+	allocations := 0
+	for ; allocations < 10 && killed == nil; allocations++ {
+		if result := allocate(mem, "leak", 64*capacityTestMB); !result.Success {
+			killed = result
+		}
+	}
+
+	var memoryErr *MemoryError
+	if killed == nil || !errors.As(killed.Error, &memoryErr) || memoryErr.Code != MemoryErrorOOMKilled {
+		t.Fatalf("Expected an OOM-kill, got %+v", killed)
+	}
+	if allocations != 5 || memoryErr.AllocatedBytes != 256*capacityTestMB {
+		t.Errorf("Expected the fifth 64MB allocation to be killed with 256MB held, got allocation %d with %d bytes", allocations, memoryErr.AllocatedBytes)
+	}
+	if set := mem.GetResidentSet(); set.AllocatedBytes != 0 || set.OOMKills != 1 || set.PeakBytes != 256*capacityTestMB {
+		t.Errorf("Expected the killed process's memory to be released, got %+v", set)
+	}
+	if snapshot := runtime.Snapshot(); snapshot.HeapBytes != 0 {
+		t.Errorf("Expected the runtime to restart with an empty heap, got %d bytes", snapshot.HeapBytes)
+	}
+
+	// The tick path reports the kill on the queued allocation's result
+	mem.QueueOperation(&Operation{ID: "huge", Type: OpMemoryAlloc, Complexity: ComplexityO1, DataSize: 512 * capacityTestMB})
+	for tick := int64(10); tick < 1000; tick++ {
+		for _, result := range mem.ProcessTick(tick) {
+			if result.OperationID == "huge" {
+				if result.Success || !errors.As(result.Error, &memoryErr) {
+					t.Errorf("Expected the queued allocation to fail with a MemoryError, got %+v", result)
+				}
+				return
+			}
+		}
+	}
+	t.Error("Expected the queued allocation to complete")
+}
@@ -0,0 +1,139 @@
+package engines
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+const capacityTestGB = int64(1 << 30)
+
+// newCapacityTestStorage creates an NVMe engine of capacityGB with the given capacity section
+func newCapacityTestStorage(t *testing.T, capacityGB float64, capacity map[string]interface{}) *StorageEngine {
+	storage := NewStorageEngine(100)
+	err := storage.LoadProfile(&EngineProfile{
+		Name: "capacity_test",
+		Type: StorageEngineType,
+		BaselinePerformance: map[string]float64{
+			"capacity_gb":      capacityGB,
+			"iops_read":        500000,
+			"iops_write":       500000,
+			"latency_read_us":  20,
+			"latency_write_us": 25,
+			"bandwidth_mbps":   7000,
+			"queue_depth":      128,
+			"block_size_bytes": 4096,
+		},
+		TechnologySpecs: map[string]interface{}{"storage_type": "NVMe", "endurance_tbw": 600.0},
+		EngineSpecific:  map[string]interface{}{"capacity": capacity},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	return storage
+}
+
+func storageWrite(storage *StorageEngine, id string, bytes int64, pattern string, tick int64) *OperationResult {
+	return storage.ProcessOperation(&Operation{
+		ID: id, Type: OpStorageWrite, DataSize: bytes,
+		Metadata: map[string]interface{}{"access_pattern": pattern},
+	}, tick)
+}
+
+// TestStorageCapacityENOSPC tests that writes fail with ENOSPC once the device
+// is full and succeed again after TRIM frees space
+func TestStorageCapacityENOSPC(t *testing.T) {
+	storage := newCapacityTestStorage(t, 1, map[string]interface{}{"used_gb": 0.9})
+	if storage.Capacity.EnduranceBytes != 600e12 || storage.Capacity.OverProvisioningBytes != 0 {
+		t.Errorf("Expected the profile's 600 TBW rating and no over-provisioning on a 1GB drive, got %+v", storage.Capacity)
+	}
+
+	result := storageWrite(storage, "too-big", 200<<20, "sequential", 1)
+	var storageErr *StorageError
+	if result.Success || !errors.As(result.Error, &storageErr) || storageErr.Code != StorageErrorNoSpace {
+		t.Fatalf("Expected ENOSPC writing 200MB to a drive with 100MB free, got %+v", result)
+	}
+	if storage.Usage.UsedBytes != capacityTestGB*9/10 || storage.Usage.FailedWrites != 1 || storage.Usage.HostBytesWritten != 0 {
+		t.Errorf("Expected the refused write to leave the drive untouched, got %+v", storage.Usage)
+	}
+
+	// Overwrites need no new space
+	if result := storage.ProcessOperation(&Operation{ID: "overwrite", Type: OpStorageWrite, DataSize: 200 << 20,
+		Metadata: map[string]interface{}{"overwrite": true}}, 2); !result.Success {
+		t.Errorf("Expected an overwrite to succeed on a full drive, got %v", result.Error)
+	}
+
+	storage.ProcessOperation(&Operation{ID: "trim", Type: OpStorageTrim, DataSize: 512 << 20}, 3)
+	if result := storageWrite(storage, "fits", 200<<20, "sequential", 4); !result.Success {
+		t.Errorf("Expected the write to fit after TRIM, got %v", result.Error)
+	}
+
+	// The tick path reports ENOSPC on the queued write's result
+	storage.QueueOperation(&Operation{ID: "queued", Type: OpStorageWrite, DataSize: capacityTestGB})
+	for tick := int64(10); tick < 1000; tick++ {
+		for _, result := range storage.ProcessTick(tick) {
+			if result.OperationID == "queued" {
+				if result.Success || !errors.As(result.Error, &storageErr) {
+					t.Errorf("Expected the queued write to fail with a StorageError, got %+v", result)
+				}
+				return
+			}
+		}
+	}
+	t.Error("Expected the queued write to complete")
+}
+
+// TestStorageWriteAmplification tests that random writes to a nearly full drive
+// are amplified by garbage collection while sequential writes are not
+func TestStorageWriteAmplification(t *testing.T) {
+	fresh := newCapacityTestStorage(t, 100, map[string]interface{}{"over_provisioning_gb": 7.0})
+	if result := storageWrite(fresh, "random", 4096, "random", 1); result.Metrics["write_amplification"] != 1.0 {
+		t.Errorf("Expected no amplification on an empty drive, got %v", result.Metrics["write_amplification"])
+	}
+
+	full := newCapacityTestStorage(t, 100, map[string]interface{}{"over_provisioning_gb": 7.0, "used_gb": 90.0})
+	sequential := storageWrite(full, "sequential", 4096, "sequential", 1)
+	random := storageWrite(full, "random", 4096, "random", 2)
+
+	// 17GB spare over 90GB of data: (1+ρ)/(2ρ) ≈ 3.15
+	if amplification := random.Metrics["write_amplification"].(float64); math.Abs(amplification-3.15) > 0.05 {
+		t.Errorf("Expected random writes amplified about 3.15x at 90%% full, got %.2f", amplification)
+	}
+	if sequential.Metrics["write_amplification"] != 1.0 {
+		t.Errorf("Expected sequential writes not to be amplified, got %v", sequential.Metrics["write_amplification"])
+	}
+	if random.PenaltyInfo.StoragePenalties.CapacityUtilization < 0.9 {
+		t.Errorf("Expected the penalty details to report the drive 90%% full, got %.2f", random.PenaltyInfo.StoragePenalties.CapacityUtilization)
+	}
+}
+
+// TestStorageEnduranceReport tests that a random workload on a full drive wears
+// it out sooner than the same bytes written sequentially
+func TestStorageEnduranceReport(t *testing.T) {
+	run := func(pattern string) StorageEnduranceReport {
+		storage := newCapacityTestStorage(t, 100, map[string]interface{}{"over_provisioning_gb": 7.0, "used_gb": 90.0, "endurance_tbw": 1.0})
+		// 1GB per simulated second, overwriting data in place
+		for tick := int64(0); tick < 1000; tick++ {
+			storage.ProcessOperation(&Operation{
+				ID: "write", Type: OpStorageWrite, DataSize: 1 << 20,
+				Metadata: map[string]interface{}{"access_pattern": pattern, "overwrite": true},
+			}, tick)
+		}
+		return storage.GetEnduranceReport()
+	}
+
+	sequential, random := run("sequential"), run("random")
+	if sequential.HostBytesWritten != 1000<<20 || sequential.WriteAmplification != 1.0 {
+		t.Errorf("Expected 1000MB written without amplification, got %+v", sequential)
+	}
+	// 1TB at ~1GB/s lasts about 950s, or 0.011 days
+	if days := sequential.ProjectedLifetimeDays; days < 0.010 || days > 0.012 {
+		t.Errorf("Expected a sequential lifetime of about 0.011 days, got %.4f", days)
+	}
+	if random.WriteAmplification < 3 || random.ProjectedLifetimeDays > sequential.ProjectedLifetimeDays/3 {
+		t.Errorf("Expected random writes to wear the drive out 3x sooner, got %.4f vs %.4f days", random.ProjectedLifetimeDays, sequential.ProjectedLifetimeDays)
+	}
+	if random.RemainingTBW >= sequential.RemainingTBW || random.EnduranceUsed <= sequential.EnduranceUsed {
+		t.Errorf("Expected random writes to use more of the rating, got %+v vs %+v", random, sequential)
+	}
+}
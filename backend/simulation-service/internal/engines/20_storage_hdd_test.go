@@ -0,0 +1,166 @@
+package engines
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newHDDTestStorage creates a 2TB 7200 RPM disk ordering its queue with the given scheduler
+func newHDDTestStorage(t *testing.T, scheduler string) *StorageEngine {
+	storage := NewStorageEngine(256)
+	err := storage.LoadProfile(&EngineProfile{
+		Name: "hdd_test",
+		Type: StorageEngineType,
+		BaselinePerformance: map[string]float64{
+			"capacity_gb":      2048,
+			"iops_read":        180,
+			"iops_write":       180,
+			"latency_read_us":  8500,
+			"latency_write_us": 9000,
+			"bandwidth_mbps":   220,
+			"queue_depth":      32,
+			"block_size_bytes": 4096,
+		},
+		TechnologySpecs: map[string]interface{}{"storage_type": "HDD", "rpm": 7200.0},
+		EngineSpecific: map[string]interface{}{
+			"hdd_mechanics": map[string]interface{}{"track_to_track_seek_ms": 1.0, "average_seek_ms": 8.5},
+			"io_scheduler":  map[string]interface{}{"scheduler": scheduler},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	return storage
+}
+
+// drainHDD queues reads of the given pattern and returns the ticks the disk takes to serve them
+func drainHDD(t *testing.T, storage *StorageEngine, count int, pattern string) int64 {
+	for i := 0; i < count; i++ {
+		storage.QueueOperation(&Operation{
+			ID: "read", Type: OpStorageRead, DataSize: 4096,
+			Metadata: map[string]interface{}{"access_pattern": pattern},
+		})
+	}
+	completed := 0
+	for tick := int64(1); tick < 60000; tick++ {
+		completed += len(storage.ProcessTick(tick))
+		if completed == count {
+			return tick
+		}
+	}
+	t.Fatalf("Expected %d reads to complete, got %d", count, completed)
+	return 0
+}
+
+// TestHDDRandomVsSequential tests that random reads pay a seek and rotation each
+// while sequential reads stream from under the head
+func TestHDDRandomVsSequential(t *testing.T) {
+	storage := newHDDTestStorage(t, IOSchedulerNoop)
+	if storage.HDD.RPM != 7200 || storage.IOScheduler.Scheduler != IOSchedulerNoop {
+		t.Fatalf("Expected a 7200 RPM disk with the noop scheduler, got %+v %+v", storage.HDD, storage.IOScheduler)
+	}
+	if defaults := defaultIOSchedulerConfig("HDD"); defaults.Scheduler != IOSchedulerDeadline {
+		t.Errorf("Expected disks to default to the deadline scheduler, got %s", defaults.Scheduler)
+	}
+
+	// A random seek covers about 8/15 of a full stroke, so averages the profile's 8.5ms
+	if seek := storage.seekTime(storage.totalSectors() * 8 / 15 * 8 / 15); seek < 8*time.Millisecond || seek > 9*time.Millisecond {
+		t.Errorf("Expected a seek across the average distance to take about 8.5ms, got %v", seek)
+	}
+
+	sequential := drainHDD(t, storage, 100, "sequential")
+	if storage.HDDState.Seeks > 1 {
+		t.Errorf("Expected sequential reads to stream without seeking, got %d seeks", storage.HDDState.Seeks)
+	}
+
+	random := drainHDD(t, newHDDTestStorage(t, IOSchedulerNoop), 100, "random")
+
+	// At least 100 x (8.5ms seek + 4.2ms rotation) against 100 x 0.1ms
+	if random < 1000 {
+		t.Errorf("Expected 100 random reads to take over 1s on one actuator, got %dms", random)
+	}
+	if random < sequential*20 {
+		t.Errorf("Expected random reads to take over 20x longer than sequential, got %dms vs %dms", random, sequential)
+	}
+}
+
+// TestHDDSchedulerShortensSeeks tests that the deadline elevator sweeps the head
+// across a full queue of random reads instead of seeking in arrival order
+func TestHDDSchedulerShortensSeeks(t *testing.T) {
+	averageSeek := func(scheduler string) (time.Duration, int64) {
+		storage := newHDDTestStorage(t, scheduler)
+		ticks := drainHDD(t, storage, storage.QueueDepth, "random")
+		return storage.HDDState.SeekTime / time.Duration(storage.HDDState.Seeks), ticks
+	}
+
+	noopSeek, noopTicks := averageSeek(IOSchedulerNoop)
+	deadlineSeek, deadlineTicks := averageSeek(IOSchedulerDeadline)
+	if noopSeek < 6*time.Millisecond || noopSeek > 11*time.Millisecond {
+		t.Errorf("Expected arrival order to average the 8.5ms random seek, got %v", noopSeek)
+	}
+	if deadlineSeek > noopSeek/2 {
+		t.Errorf("Expected the elevator to halve the average seek, got %v vs %v", deadlineSeek, noopSeek)
+	}
+	if deadlineTicks >= noopTicks {
+		t.Errorf("Expected the elevator to drain the queue sooner, got %dms vs %dms", deadlineTicks, noopTicks)
+	}
+}
+
+// TestHDDSchedulerDispatchOrder tests the order each scheduler hands queued
+// requests to the disk
+func TestHDDSchedulerDispatchOrder(t *testing.T) {
+	queue := func(storage *StorageEngine) {
+		// Two processes interleaving reads, each at a descending LBA
+		for i := 0; i < 16; i++ {
+			for _, process := range []string{"a", "b"} {
+				storage.QueueOperation(&Operation{
+					ID: process, Type: OpStorageRead, DataSize: 4096,
+					Metadata: map[string]interface{}{"process": process, "lba": int64(1000000 - i*1000)},
+				})
+			}
+		}
+	}
+	dispatch := func(storage *StorageEngine) (string, []int64) {
+		var order strings.Builder
+		var lbas []int64
+		for queuedOp := storage.dequeueScheduledOperation(); queuedOp != nil; queuedOp = storage.dequeueScheduledOperation() {
+			order.WriteString(queuedOp.Operation.ID)
+			lbas = append(lbas, storage.operationLBA(queuedOp.Operation))
+		}
+		return order.String(), lbas
+	}
+
+	noop := newHDDTestStorage(t, IOSchedulerNoop)
+	queue(noop)
+	if order, _ := dispatch(noop); order != strings.Repeat("ab", 16) {
+		t.Errorf("Expected noop to dispatch in arrival order, got %s", order)
+	}
+
+	// The elevator sweeps up from the head at LBA 0
+	deadline := newHDDTestStorage(t, IOSchedulerDeadline)
+	queue(deadline)
+	_, lbas := dispatch(deadline)
+	for i := 1; i < len(lbas); i++ {
+		if lbas[i] < lbas[i-1] {
+			t.Fatalf("Expected deadline to dispatch in ascending LBA order, got %v", lbas)
+		}
+	}
+
+	// An expired read goes first even behind the head
+	deadline.QueueOperation(&Operation{ID: "old", Type: OpStorageRead, Metadata: map[string]interface{}{"lba": int64(0)}})
+	deadline.CurrentTick = 400
+	deadline.QueueOperation(&Operation{ID: "new", Type: OpStorageRead, Metadata: map[string]interface{}{"lba": int64(10)}})
+	deadline.CurrentTick = 600
+	deadline.HDDState.HeadLBA = 5
+	if next := deadline.dequeueScheduledOperation(); next.Operation.ID != "old" {
+		t.Errorf("Expected the read waiting 600ms to be served before the one ahead of the head, got %s", next.Operation.ID)
+	}
+
+	// Each process gets a turn of quantum requests
+	cfq := newHDDTestStorage(t, IOSchedulerCFQ)
+	queue(cfq)
+	if order, _ := dispatch(cfq); order != strings.Repeat("a", 8)+strings.Repeat("b", 8)+strings.Repeat("a", 8)+strings.Repeat("b", 8) {
+		t.Errorf("Expected cfq to alternate turns of 8 requests per process, got %s", order)
+	}
+}
@@ -0,0 +1,152 @@
+package engines
+
+import "testing"
+
+// newPageCacheTestStorage creates an NVMe engine caching its pages in a memory engine limited to limitMB
+func newPageCacheTestStorage(t *testing.T, limitMB int64, pageCache map[string]interface{}) (*StorageEngine, *MemoryEngine) {
+	storage := newCapacityTestStorage(t, 100, map[string]interface{}{})
+	storage.PageCacheConfig.loadProfile(pageCache)
+	mem := newCapacityTestMemory(t, limitMB, 0)
+	storage.AttachPageCache(mem)
+	return storage, mem
+}
+
+func pageRead(storage *StorageEngine, lba int64, tick int64) *OperationResult {
+	return storage.ProcessOperation(&Operation{
+		ID: "read", Type: OpStorageRead, DataSize: 4096,
+		Metadata: map[string]interface{}{"lba": lba},
+	}, tick)
+}
+
+// TestPageCacheReadHit tests that a read of a cached page is served at memory
+// latency and that the cache shrinks as the process grows
+func TestPageCacheReadHit(t *testing.T) {
+	storage, mem := newPageCacheTestStorage(t, 1024, map[string]interface{}{})
+
+	miss := pageRead(storage, 800, 1)
+	hit := pageRead(storage, 800, 2)
+	if miss.Metrics["page_cache"] != "miss" || hit.Metrics["page_cache"] != "hit" {
+		t.Fatalf("Expected a miss then a hit, got %v then %v", miss.Metrics["page_cache"], hit.Metrics["page_cache"])
+	}
+	expected := storage.PageCacheConfig.SyscallOverhead + mem.PageCacheLatency(4096)
+	if hit.ProcessingTime != expected || hit.ProcessingTime >= miss.ProcessingTime {
+		t.Errorf("Expected the hit to take the memory engine's %v against the device's %v, got %v", expected, miss.ProcessingTime, hit.ProcessingTime)
+	}
+	if stats := storage.GetPageCacheStats(); stats.Hits != 1 || stats.Misses != 1 || stats.HitRatio != 0.5 {
+		t.Errorf("Expected one hit and one miss, got %+v", stats)
+	}
+	if hit.PenaltyInfo.StoragePenalties.PageCacheHitRatio != 0.5 {
+		t.Errorf("Expected the penalty details to report the hit ratio, got %v", hit.PenaltyInfo.StoragePenalties.PageCacheHitRatio)
+	}
+
+	// O_DIRECT reads bypass the cache
+	direct := storage.ProcessOperation(&Operation{ID: "direct", Type: OpStorageRead, DataSize: 4096,
+		Metadata: map[string]interface{}{"lba": int64(800), "direct": true}}, 3)
+	if direct.Metrics["page_cache"] != nil {
+		t.Errorf("Expected a direct read to skip the cache, got %v", direct.Metrics["page_cache"])
+	}
+
+	// The cache holds whatever RAM the process leaves free
+	if pages := storage.PageCache.capacityPages(); pages != 1024*capacityTestMB/4096 {
+		t.Errorf("Expected 1GB of free RAM to hold 262144 pages, got %d", pages)
+	}
+	allocate(mem, "heap", 1000*capacityTestMB)
+	if pages := storage.PageCache.capacityPages(); pages != 24*capacityTestMB/4096 {
+		t.Errorf("Expected 24MB left for the cache after a 1000MB heap, got %d pages", pages)
+	}
+}
+
+// TestPageCacheScanResistance tests that under 2Q a scan larger than the cache
+// leaves the hot set cached, while LRU evicts it
+func TestPageCacheScanResistance(t *testing.T) {
+	hotHits := func(policy string) int64 {
+		config := defaultPageCacheConfig()
+		config.Policy = policy
+		config.CapacityBytes = 256 * 4096
+		cache := NewPageCache(config, nil)
+
+		readHot := func() int64 {
+			hits := cache.Stats.Hits
+			for block := int64(0); block < 64; block++ {
+				cache.read(block, 1)
+			}
+			return cache.Stats.Hits - hits
+		}
+		scan := func(from, pages int64) {
+			for block := from; block < from+pages; block++ {
+				cache.read(block, 1)
+			}
+		}
+
+		// The hot set is read, pushed out by a scan and read again soon after
+		readHot()
+		scan(1000, 256)
+		readHot()
+		scan(10000, 1000)
+		return readHot()
+	}
+
+	if hits := hotHits(PageCache2Q); hits != 64 {
+		t.Errorf("Expected 2Q to keep all 64 hot pages through the scan, got %d hits", hits)
+	}
+	if hits := hotHits(PageCacheLRU); hits != 0 {
+		t.Errorf("Expected LRU to lose the hot set to the scan, got %d hits", hits)
+	}
+}
+
+// TestPageCacheWriteBack tests that writes return once buffered, that the
+// flusher writes back expired dirty pages, that storage_sync writes back the
+// rest and that writers are throttled past the dirty ratio
+func TestPageCacheWriteBack(t *testing.T) {
+	storage, _ := newPageCacheTestStorage(t, 1024, map[string]interface{}{"size_mb": 4.0, "dirty_ratio": 0.5})
+
+	write := func(id string, lba int64, tick int64) *OperationResult {
+		return storage.ProcessOperation(&Operation{
+			ID: id, Type: OpStorageWrite, DataSize: 64 * 1024,
+			Metadata: map[string]interface{}{"lba": lba},
+		}, tick)
+	}
+	buffered := write("buffered", 0, 1)
+	if buffered.Metrics["page_cache"] != "buffered" || storage.GetPageCacheStats().DirtyPages != 16 {
+		t.Fatalf("Expected the write to leave 16 dirty pages, got %v with %+v", buffered.Metrics["page_cache"], storage.GetPageCacheStats())
+	}
+	if storage.Usage.HostBytesWritten != 64*1024 {
+		t.Errorf("Expected the buffered write to reserve its space, got %d bytes written", storage.Usage.HostBytesWritten)
+	}
+
+	// The flusher wakes every 5s and writes back pages dirty for 30s
+	for tick := int64(2); tick <= 29000; tick++ {
+		storage.ProcessTick(tick)
+	}
+	write("later", 1024, 29001)
+	if stats := storage.GetPageCacheStats(); stats.WrittenBackPages != 0 {
+		t.Errorf("Expected nothing written back before 30s, got %d pages", stats.WrittenBackPages)
+	}
+	for tick := int64(29002); tick <= 35000; tick++ {
+		storage.ProcessTick(tick)
+	}
+	if stats := storage.GetPageCacheStats(); stats.WrittenBackPages != 16 || stats.DirtyPages != 16 {
+		t.Errorf("Expected the first write's 16 pages written back and the later write's still dirty, got %+v", stats)
+	}
+
+	// fsync writes back the later write before returning
+	sync := storage.ProcessOperation(&Operation{ID: "sync", Type: OpStorageSync}, 35001)
+	writeBack := storage.calculateBaseStorageAccessTime(&Operation{Type: OpStorageWrite, DataSize: 64 * 1024})
+	if stats := storage.GetPageCacheStats(); stats.SyncedPages != 16 || stats.DirtyPages != 0 {
+		t.Errorf("Expected the sync to write back 16 pages, got %+v", stats)
+	}
+	if sync.ProcessingTime < writeBack {
+		t.Errorf("Expected the sync to wait %v for the write-back, got %v", writeBack, sync.ProcessingTime)
+	}
+
+	// 4MB holds 1024 pages, so writers are throttled at 512 dirty
+	throttled := 0
+	for i := int64(0); i < 40; i++ {
+		if result := write("bulk", 100000+i*128, 35002); result.Metrics["page_cache"] == "miss" {
+			throttled++
+		}
+	}
+	if stats := storage.GetPageCacheStats(); throttled != 8 || stats.ThrottledWrites != 8 || stats.DirtyPages != 512 {
+		t.Errorf("Expected the last 8 of 40 writes throttled at 512 dirty pages, got %d with %+v", throttled, stats)
+	}
+}
@@ -0,0 +1,101 @@
+package engines
+
+import (
+	"testing"
+	"time"
+)
+
+// newCloudTestStorage loads a cloud volume profile from the repository's profiles
+func newCloudTestStorage(t *testing.T, profileName string) *StorageEngine {
+	storage := NewStorageEngine(100)
+	newGCTestEngine(t, storage, profileName)
+	return storage
+}
+
+// cloudReads issues perTick 4KB reads each tick and returns the last result
+func cloudReads(storage *StorageEngine, from, to int64, perTick int, bytes int64) *OperationResult {
+	var result *OperationResult
+	for tick := from; tick < to; tick++ {
+		for i := 0; i < perTick; i++ {
+			result = storage.ProcessOperation(&Operation{ID: "read", Type: OpStorageRead, DataSize: bytes}, tick)
+		}
+	}
+	return result
+}
+
+// TestCloudVolumeProfiles tests that the gp2 and gp3 profiles load with their
+// type's limits and that every I/O pays the network round trip
+func TestCloudVolumeProfiles(t *testing.T) {
+	gp2 := newCloudTestStorage(t, "aws_ebs_gp2_100gb")
+	if config := gp2.CloudVolume; config.BaselineIOPS != 300 || config.BurstIOPS != 3000 || config.CreditCapacity != 5.4e6 || config.BaselineMBps != 128 {
+		t.Errorf("Expected a 100GB gp2 volume to get 300 IOPS bursting to 3000 on 5.4M credits, got %+v", config)
+	}
+	gp3 := newCloudTestStorage(t, "aws_ebs_gp3_500gb")
+	if config := gp3.CloudVolume; config.BaselineIOPS != 6000 || config.BaselineMBps != 250 || config.CreditCapacity != 0 {
+		t.Errorf("Expected gp3 to run at its provisioned 6000 IOPS and 250MB/s without credits, got %+v", config)
+	}
+
+	result := gp3.ProcessOperation(&Operation{ID: "read", Type: OpStorageRead, DataSize: 4096}, 1)
+	if result.ProcessingTime < 500*time.Microsecond || result.Metrics["volume_throttle_ms"] != 0.0 {
+		t.Errorf("Expected an unthrottled read to take at least the 500us round trip, got %v", result.ProcessingTime)
+	}
+
+	loader := NewProfileLoader("../../profiles")
+	profile := &EngineProfile{
+		Name: "bad_volume", Type: StorageEngineType,
+		BaselinePerformance: map[string]float64{"capacity_gb": 100, "max_iops": 3000, "avg_latency_ms": 0.5},
+		EngineSpecific:      map[string]interface{}{"cloud_volume": map[string]interface{}{"volume_type": "st1"}},
+	}
+	if err := loader.ValidateProfile(profile); err == nil {
+		t.Error("Expected an unknown volume type to fail validation")
+	}
+}
+
+// TestCloudVolumeBurstCredits tests that a gp2 volume bursts while its credits
+// last, then is held to its baseline with the exhaustion in the penalty details
+func TestCloudVolumeBurstCredits(t *testing.T) {
+	storage := newCloudTestStorage(t, "aws_ebs_gp2_100gb")
+	storage.CloudVolume.InitialCredits = 3400
+	storage.resetCloudVolume()
+
+	// 2000 IOPS drains 1700 credits a second over the 300 refilled
+	bursting := cloudReads(storage, 0, 1000, 2, 4096)
+	if bursting.PenaltyInfo.StoragePenalties.CreditsExhausted || bursting.Metrics["volume_throttle_ms"] != 0.0 {
+		t.Fatalf("Expected the first second to burst unthrottled, got %+v", bursting.PenaltyInfo.StoragePenalties)
+	}
+	if balance := storage.CloudVolumeState.CreditBalance; balance < 1650 || balance > 1750 {
+		t.Errorf("Expected about 1700 credits left after a second, got %.0f", balance)
+	}
+
+	exhausted := cloudReads(storage, 1000, 3000, 2, 4096)
+	details := exhausted.PenaltyInfo.StoragePenalties
+	if at := storage.CloudVolumeState.ExhaustedAtTick; !details.CreditsExhausted || details.BurstCreditBalance > 0.001 || at < 1990 || at > 2010 {
+		t.Errorf("Expected the credits to run out at 2s, got %+v exhausted at tick %d", details, storage.CloudVolumeState.ExhaustedAtTick)
+	}
+	if storage.CloudVolumeState.CurrentIOPSLimit != 300 || details.VolumeThrottleMs < 1000 {
+		t.Errorf("Expected reads queued over a second behind the 300 IOPS baseline, got %.0fms at %v IOPS", details.VolumeThrottleMs, storage.CloudVolumeState.CurrentIOPSLimit)
+	}
+	if exhausted.PenaltyInfo.TotalPenaltyFactor <= bursting.PenaltyInfo.TotalPenaltyFactor {
+		t.Errorf("Expected throttling to raise the penalty factor, got %.2f vs %.2f", exhausted.PenaltyInfo.TotalPenaltyFactor, bursting.PenaltyInfo.TotalPenaltyFactor)
+	}
+
+	// Idle once the queue drains, the bucket refills at the baseline
+	if idle := cloudReads(storage, 20000, 20001, 1, 4096); idle.PenaltyInfo.StoragePenalties.CreditsExhausted || idle.Metrics["volume_throttle_ms"] != 0.0 || storage.CloudVolumeState.CreditBalance < 5000 {
+		t.Errorf("Expected 17s idle to earn about 5100 credits, got %.0f", storage.CloudVolumeState.CreditBalance)
+	}
+}
+
+// TestCloudVolumeThroughputLimit tests that large I/Os are held to the provisioned
+// throughput while counting once per 256KB against the IOPS limit
+func TestCloudVolumeThroughputLimit(t *testing.T) {
+	storage := newCloudTestStorage(t, "aws_ebs_gp3_500gb")
+
+	// 1000MB/s of 1MB reads against 250MB/s: 3s behind after a second
+	result := cloudReads(storage, 0, 1000, 1, 1<<20)
+	if throttle := result.Metrics["volume_throttle_ms"].(float64); throttle < 2900 || throttle > 3100 {
+		t.Errorf("Expected 1MB reads queued about 3s behind the throughput limit, got %.0fms", throttle)
+	}
+	if state := storage.GetCloudVolumeState(); state.IOUnitsAdmitted != 4000 || state.ThrottledIOs < 990 {
+		t.Errorf("Expected 1000 reads counted as 4000 I/Os and nearly all throttled, got %+v", state)
+	}
+}
@@ -0,0 +1,232 @@
+package engines
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newArrayTestStorage creates an array of 100GB SSDs whose 500MB/s bandwidth
+// dominates large transfers; a nil array section gives a single device
+func newArrayTestStorage(t *testing.T, array map[string]interface{}) *StorageEngine {
+	engineSpecific := map[string]interface{}{}
+	if array != nil {
+		engineSpecific["storage_array"] = array
+	}
+	storage := NewStorageEngine(100)
+	err := storage.LoadProfile(&EngineProfile{
+		Name: "array_test",
+		Type: StorageEngineType,
+		BaselinePerformance: map[string]float64{
+			"capacity_gb":      100,
+			"iops_read":        100000,
+			"iops_write":       100000,
+			"latency_read_us":  100,
+			"latency_write_us": 100,
+			"bandwidth_mbps":   500,
+			"queue_depth":      32,
+			"block_size_bytes": 4096,
+		},
+		TechnologySpecs: map[string]interface{}{"storage_type": "SSD"},
+		EngineSpecific:  engineSpecific,
+	})
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	return storage
+}
+
+func arrayIO(storage *StorageEngine, opType string, lba, bytes, tick int64) *OperationResult {
+	return storage.ProcessOperation(&Operation{
+		ID: opType, Type: opType, DataSize: bytes,
+		Metadata: map[string]interface{}{"lba": lba, "access_pattern": "sequential"},
+	}, tick)
+}
+
+// TestStorageArrayStriping tests that RAID0 splits a large read across its
+// devices, that mirrors write every copy and that each layout exposes the
+// space left for data
+func TestStorageArrayStriping(t *testing.T) {
+	for layout, capacityGB := range map[string]int64{
+		StorageArrayRAID0: 400, StorageArrayRAID5: 300, StorageArrayRAID10: 200, StorageArrayRAID1: 100,
+	} {
+		storage := newArrayTestStorage(t, map[string]interface{}{"layout": layout, "devices": 4.0})
+		if storage.CapacityGB != capacityGB || len(storage.ArrayDevices) != 4 {
+			t.Errorf("Expected four 100GB devices in %s to hold %dGB, got %dGB on %d devices", layout, capacityGB, storage.CapacityGB, len(storage.ArrayDevices))
+		}
+	}
+
+	single := arrayIO(newArrayTestStorage(t, nil), OpStorageRead, 0, 256*1024, 1)
+	raid0 := newArrayTestStorage(t, map[string]interface{}{"layout": "raid0", "devices": 4.0, "stripe_kb": 64.0})
+	striped := arrayIO(raid0, OpStorageRead, 0, 256*1024, 1)
+	if striped.Metrics["array_device_ios"] != 4 || striped.Metrics["array_layout"] != StorageArrayRAID0 {
+		t.Fatalf("Expected a 256KB read to take a 64KB chunk from each of 4 devices, got %v", striped.Metrics["array_device_ios"])
+	}
+	if striped.ProcessingTime > single.ProcessingTime*3/4 {
+		t.Errorf("Expected the striped read to beat one device's %v, got %v", single.ProcessingTime, striped.ProcessingTime)
+	}
+
+	raid1 := newArrayTestStorage(t, map[string]interface{}{"layout": "raid1", "devices": 2.0})
+	if write := arrayIO(raid1, OpStorageWrite, 0, 4096, 1); write.Metrics["array_device_ios"] != 2 || !write.Success {
+		t.Errorf("Expected a mirrored write to go to both devices, got %v", write.Metrics["array_device_ios"])
+	}
+	for device, member := range raid1.ArrayDevices {
+		if member.Usage.HostBytesWritten != 4096 {
+			t.Errorf("Expected mirror %d to hold a copy of the write, got %d bytes written", device, member.Usage.HostBytesWritten)
+		}
+	}
+
+	// Each volume of the profile's array keeps its own gp3 limits
+	cloud := newCloudTestStorage(t, "aws_ebs_gp3_raid10_4x500gb")
+	if cloud.CapacityGB != 1000 || len(cloud.ArrayDevices) != 4 || cloud.ArrayDevices[3].CloudVolume.BaselineIOPS != 6000 || !cloud.Array.HotSpare {
+		t.Errorf("Expected RAID10 over four 500GB gp3 volumes with a hot spare, got %dGB with %+v", cloud.CapacityGB, cloud.Array)
+	}
+
+	loader := NewProfileLoader("../../profiles")
+	profile := &EngineProfile{
+		Name: "bad_array", Type: StorageEngineType,
+		BaselinePerformance: map[string]float64{"capacity_gb": 100, "max_iops": 3000, "avg_latency_ms": 0.5},
+		EngineSpecific:      map[string]interface{}{"storage_array": map[string]interface{}{"layout": "raid5", "devices": 2.0}},
+	}
+	if err := loader.ValidateProfile(profile); err == nil {
+		t.Error("Expected RAID5 on two devices to fail validation")
+	}
+}
+
+// TestStorageArrayParityWritePenalty tests that a small RAID5 write reads the
+// old data and parity before writing both, while RAID10 writes two copies and
+// a full-stripe RAID5 write needs no reads
+func TestStorageArrayParityWritePenalty(t *testing.T) {
+	raid5 := newArrayTestStorage(t, map[string]interface{}{"layout": "raid5", "devices": 4.0, "stripe_kb": 64.0})
+	raid10 := newArrayTestStorage(t, map[string]interface{}{"layout": "raid10", "devices": 4.0, "stripe_kb": 64.0})
+
+	// The parity rotates one device to the left each stripe
+	if data, parity := raid5.raid5Devices(0, 0); data != 0 || parity != 3 {
+		t.Errorf("Expected stripe 0 to keep its parity on device 3, got data %d parity %d", data, parity)
+	}
+	if data, parity := raid5.raid5Devices(1, 0); data != 3 || parity != 2 {
+		t.Errorf("Expected stripe 1 to keep its parity on device 2, got data %d parity %d", data, parity)
+	}
+
+	small := arrayIO(raid5, OpStorageWrite, 0, 4096, 1)
+	mirrored := arrayIO(raid10, OpStorageWrite, 0, 4096, 1)
+	if small.Metrics["array_device_ios"] != 4 || raid5.ArrayState.ReadModifyWrites != 1 {
+		t.Errorf("Expected a 4KB RAID5 write to read and write the data and parity, got %v I/Os", small.Metrics["array_device_ios"])
+	}
+	if mirrored.Metrics["array_device_ios"] != 2 {
+		t.Errorf("Expected a 4KB RAID10 write to write two copies, got %v I/Os", mirrored.Metrics["array_device_ios"])
+	}
+	if small.ProcessingTime < mirrored.ProcessingTime*3/2 {
+		t.Errorf("Expected the read-modify-write to take well over RAID10's %v, got %v", mirrored.ProcessingTime, small.ProcessingTime)
+	}
+
+	// Three 64KB data chunks fill stripe 1, so the parity is computed from them
+	full := arrayIO(raid5, OpStorageWrite, 192*1024/hddSectorBytes, 192*1024, 2)
+	if full.Metrics["array_device_ios"] != 4 || raid5.ArrayState.FullStripeWrites != 1 || raid5.ArrayState.ReadModifyWrites != 1 {
+		t.Errorf("Expected a full-stripe write to write 3 chunks and the parity without reading, got %v I/Os and %+v", full.Metrics["array_device_ios"], raid5.ArrayState)
+	}
+}
+
+// TestStorageArrayDegradedRebuild tests that RAID5 reconstructs reads of a
+// failed device from the others, that the rebuild slows the devices it copies
+// from until it completes, and that a second failure loses the array
+func TestStorageArrayDegradedRebuild(t *testing.T) {
+	storage := newArrayTestStorage(t, map[string]interface{}{
+		"layout": "raid5", "devices": 4.0, "stripe_kb": 64.0,
+		"rebuild_gb": 1.0, "rebuild_mbps": 250.0,
+		"failures": []interface{}{map[string]interface{}{"device": 1.0, "at_ms": 100.0}},
+	})
+
+	// Stripe 0's second chunk is on device 1
+	healthy := arrayIO(storage, OpStorageRead, 128, 4096, 1)
+	if next, ok := storage.NextEventTick(); !ok || next != 100 {
+		t.Errorf("Expected the engine to wake for the failure at tick 100, got %d", next)
+	}
+	storage.ProcessTick(100)
+	degraded := arrayIO(storage, OpStorageRead, 128, 4096, 101)
+	if storage.ArrayState.FailedDevices != 1 || degraded.Metrics["array_device_ios"] != 3 || !degraded.PenaltyInfo.StoragePenalties.ArrayDegraded {
+		t.Fatalf("Expected the read to be reconstructed from the 3 other devices, got %v I/Os with %+v", degraded.Metrics["array_device_ios"], storage.ArrayState)
+	}
+	if healthy.Metrics["array_device_ios"] != 1 || storage.ArrayState.DegradedReads != 1 || !degraded.Success {
+		t.Errorf("Expected one read before the failure and one degraded read, got %+v", storage.ArrayState)
+	}
+
+	// Rebuilding 1GB at 250MB/s takes half of every 500MB/s device
+	before := arrayIO(storage, OpStorageRead, 0, 256*1024, 102)
+	if err := storage.ReplaceDevice(1, 102); err != nil {
+		t.Fatalf("Failed to replace the device: %v", err)
+	}
+	rebuilding := arrayIO(storage, OpStorageRead, 0, 256*1024, 103)
+	if rebuilding.ProcessingTime < before.ProcessingTime*3/2 {
+		t.Errorf("Expected the rebuild to slow a read from %v, got %v", before.ProcessingTime, rebuilding.ProcessingTime)
+	}
+	next, _ := storage.NextEventTick()
+	storage.ProcessTick(next)
+	if state := storage.GetStorageArrayState(); state.RebuildsCompleted != 1 || state.Rebuilding != -1 || state.LastRebuildTime < 4*time.Second || state.LastRebuildTime > 4200*time.Millisecond {
+		t.Fatalf("Expected the rebuild to finish after about 4.1s, got %+v", state)
+	}
+	if rebuilt := arrayIO(storage, OpStorageRead, 128, 4096, next+1); rebuilt.Metrics["array_device_ios"] != 1 || rebuilt.PenaltyInfo.StoragePenalties.ArrayDegraded {
+		t.Errorf("Expected the rebuilt device to serve its chunk, got %v I/Os", rebuilt.Metrics["array_device_ios"])
+	}
+
+	// RAID5 survives one failure, not two
+	storage.FailDevice(0, next+2)
+	storage.FailDevice(2, next+3)
+	lost := arrayIO(storage, OpStorageRead, 0, 4096, next+4)
+	var storageErr *StorageError
+	if !storage.ArrayState.Lost || lost.Success || !errors.As(lost.Error, &storageErr) || storageErr.Code != StorageErrorArrayFailed {
+		t.Errorf("Expected reads to fail with array_failed after two failures, got %v", lost.Error)
+	}
+
+	// A replicated set keeps taking writes while a quorum of replicas is up
+	replicated := newArrayTestStorage(t, map[string]interface{}{"layout": "replicated", "devices": 3.0})
+	replicated.FailDevice(0, 1)
+	if write := arrayIO(replicated, OpStorageWrite, 0, 4096, 2); !write.Success || write.Metrics["array_device_ios"] != 2 {
+		t.Errorf("Expected two of three replicas to acknowledge the write, got %v", write.Error)
+	}
+	replicated.FailDevice(1, 3)
+	write := arrayIO(replicated, OpStorageWrite, 0, 4096, 4)
+	read := arrayIO(replicated, OpStorageRead, 0, 4096, 4)
+	if write.Success || !read.Success {
+		t.Errorf("Expected writes to fail without a quorum while the last replica serves reads, got %v and %v", write.Error, read.Error)
+	}
+}
+
+// TestStorageArrayHotSpareQueue tests that the hot spare for a device failing
+// during another rebuild is rebuilt once that rebuild completes, and that a
+// scheduled failure that cannot be applied is recorded
+func TestStorageArrayHotSpareQueue(t *testing.T) {
+	storage := newArrayTestStorage(t, map[string]interface{}{
+		"layout": "raid10", "devices": 4.0, "hot_spare": true,
+		"rebuild_gb": 1.0, "rebuild_mbps": 250.0,
+		"failures": []interface{}{
+			map[string]interface{}{"device": 0.0, "at_ms": 100.0},
+			map[string]interface{}{"device": 2.0, "at_ms": 200.0},
+			map[string]interface{}{"device": 2.0, "at_ms": 300.0},
+		},
+	})
+
+	storage.ProcessTick(100)
+	storage.ProcessTick(200)
+	if state := storage.GetStorageArrayState(); state.Rebuilding != 0 || len(state.PendingSpares) != 1 || state.PendingSpares[0] != 2 {
+		t.Fatalf("Expected device 2 to wait for device 0's rebuild, got %+v", state)
+	}
+	storage.ProcessTick(300)
+	if state := storage.GetStorageArrayState(); state.LastError == "" || state.FailedDevices != 1 {
+		t.Errorf("Expected failing device 2 twice to be recorded, got %+v", state)
+	}
+
+	for wakes := 0; storage.ArrayState.RebuildsCompleted < 2; wakes++ {
+		next, ok := storage.NextEventTick()
+		if !ok || wakes > 10 {
+			t.Fatalf("Expected both rebuilds to complete, got %+v", storage.ArrayState)
+		}
+		storage.ProcessTick(next)
+		if storage.ArrayState.RebuildsCompleted == 1 && storage.ArrayState.Rebuilding != 2 {
+			t.Fatalf("Expected device 2's spare to start as device 0's rebuild completes, got %+v", storage.ArrayState)
+		}
+	}
+	if state := storage.GetStorageArrayState(); state.FailedDevices != 0 || state.Replacements != 2 || len(state.PendingSpares) != 0 {
+		t.Errorf("Expected both devices replaced and rebuilt, got %+v", state)
+	}
+}
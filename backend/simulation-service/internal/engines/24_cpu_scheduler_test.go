@@ -0,0 +1,168 @@
+package engines
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+)
+
+// newSchedulerTestCPU loads the 24-core Xeon profile
+func newSchedulerTestCPU(t *testing.T) *CPUEngine {
+	cpu := NewCPUEngine(100)
+	newGCTestEngine(t, cpu, "intel_xeon_server")
+	return cpu
+}
+
+// startThreads starts n single-threaded operations of work each at tick, as
+// ProcessTick does once the scheduler has caught up to it
+func startThreads(cpu *CPUEngine, n int, work time.Duration, tick int64) {
+	cpu.advanceScheduler(tick)
+	for i := 0; i < n; i++ {
+		heap.Push(cpu.ActiveOperations, ProcessingOperation{
+			Operation:     &Operation{ID: "thread", Type: "compute"},
+			StartTick:     tick,
+			CoresUsed:     1,
+			RemainingWork: work,
+			Cores:         cpu.placeThreads(1),
+		})
+		cpu.BusyCores++
+	}
+	cpu.scheduleCompletions(tick)
+}
+
+// runScheduled wakes the engine only at its event ticks until every operation
+// completes and returns the tick each completed at
+func runScheduled(t *testing.T, cpu *CPUEngine) []int64 {
+	var completed []int64
+	for wakes := 0; cpu.ActiveOperations.Len() > 0; wakes++ {
+		if wakes > 1000 {
+			t.Fatalf("Expected the operations to complete, %d still running", cpu.ActiveOperations.Len())
+		}
+		next, _ := cpu.NextEventTick()
+		for _, result := range cpu.ProcessTick(next) {
+			completed = append(completed, result.CompletedTick)
+		}
+	}
+	return completed
+}
+
+// TestCPUSchedulerProfile tests the scheduler's defaults, a Kubernetes CPU
+// limit in the profile and the kernel's limits on the quota
+func TestCPUSchedulerProfile(t *testing.T) {
+	cpu := newSchedulerTestCPU(t)
+	if config := cpu.Scheduler; config.SchedLatency != 24*time.Millisecond || config.MinGranularity != 3*time.Millisecond || config.MaxThreads != 24 || config.CFSQuota != 0 {
+		t.Errorf("Expected 24 cores to get a 24ms latency, 3ms slices and a thread each without a quota, got %+v", config)
+	}
+
+	profile := *cpu.Profile
+	profile.EngineSpecific = map[string]interface{}{
+		"cpu_scheduler": map[string]interface{}{"cpu_limit": 2.0, "cfs_period_us": 50000.0, "max_threads": 200.0},
+	}
+	if err := cpu.LoadProfile(&profile); err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	if config := cpu.Scheduler; config.CFSQuota != 100*time.Millisecond || config.CFSPeriod != 50*time.Millisecond || config.MaxThreads != 200 {
+		t.Errorf("Expected a 2 CPU limit to give 100ms of quota every 50ms, got %+v", config)
+	}
+	if err := cpu.SetCPUQuota(500*time.Microsecond, 100*time.Millisecond); err == nil {
+		t.Error("Expected a quota under 1ms to be rejected")
+	}
+
+	loader := NewProfileLoader("../../profiles")
+	profile.EngineSpecific = map[string]interface{}{"cpu_scheduler": map[string]interface{}{"cfs_period_us": 2e6}}
+	if err := loader.ValidateProfile(&profile); err == nil {
+		t.Error("Expected a 2s cfs period to fail validation")
+	}
+}
+
+// TestCPUSchedulerTimeSlicing tests that two threads a core take twice as long
+// and a little more for the context switches between them
+func TestCPUSchedulerTimeSlicing(t *testing.T) {
+	cpu := newSchedulerTestCPU(t)
+	startThreads(cpu, 24, 10*time.Millisecond, 0)
+	if cpu.admitThreads(1) {
+		t.Error("Expected a thread a core to fill max_threads")
+	}
+	for _, tick := range runScheduled(t, cpu) {
+		if tick != 10 {
+			t.Fatalf("Expected a thread a core to finish its 10ms at tick 10, got %d", tick)
+		}
+	}
+	if state := cpu.GetSchedulerState(); state.ContextSwitches != 0 || state.RunnableThreads != 0 {
+		t.Errorf("Expected no switches between lone threads and empty run queues after, got %+v", state)
+	}
+
+	startThreads(cpu, 48, 10*time.Millisecond, 20)
+	if state := cpu.GetSchedulerState(); state.RunQueues[0] != 2 || state.RunQueues[23] != 2 {
+		t.Fatalf("Expected two threads on every core, got %v", state.RunQueues)
+	}
+	for _, tick := range runScheduled(t, cpu) {
+		if tick != 41 {
+			t.Fatalf("Expected halves of the cores less the 5us switches every 12ms slice to take 21 ticks, finished at %d", tick)
+		}
+	}
+	state := cpu.GetSchedulerState()
+	if state.ContextSwitches < 40 || state.ContextSwitches > 44 || state.SwitchTime <= 0 {
+		t.Errorf("Expected about 42 context switches over 21ms of 12ms slices on 24 cores, got %.1f", state.ContextSwitches)
+	}
+	if cpu.GetDynamicState().HardwareSpecific["context_switches"] != state.ContextSwitches {
+		t.Error("Expected the dynamic state to report the context switches")
+	}
+}
+
+// TestCPUQuotaThrottling tests that a CPU limit well under the cores runs the
+// threads for the quota each period and throttles them for the rest, and that
+// operations arriving after the quota is used wait for the next period
+func TestCPUQuotaThrottling(t *testing.T) {
+	cpu := newSchedulerTestCPU(t)
+	if err := cpu.SetCPUQuota(240*time.Millisecond, 100*time.Millisecond); err != nil {
+		t.Fatalf("Failed to set the quota: %v", err)
+	}
+
+	// 24 threads use the 240ms quota in 10ms, so 50ms of work takes 5 periods
+	startThreads(cpu, 24, 50*time.Millisecond, 0)
+	for _, tick := range runScheduled(t, cpu) {
+		if tick != 410 {
+			t.Fatalf("Expected the threads to finish 10ms into the fifth period, got tick %d", tick)
+		}
+	}
+	state := cpu.GetSchedulerState()
+	if state.NrPeriods != 5 || state.NrThrottled != 4 || state.ThrottledTime != 360*time.Millisecond {
+		t.Errorf("Expected 4 of 5 periods throttled for 90ms each, got %+v", state)
+	}
+
+	// The threads still running exhaust the quota, holding a new operation until
+	// the period ends
+	startThreads(cpu, 24, 20*time.Millisecond, 500)
+	for tick := int64(501); tick <= 520; tick++ {
+		cpu.ProcessTick(tick)
+	}
+	result := cpu.ProcessOperation(&Operation{ID: "request", Type: "compute", DataSize: 1024}, 520)
+	throttled := result.Metrics["cfs_throttled_ms"].(float64)
+	if throttled < 79 || throttled > 80 || result.PenaltyInfo.CPUPenalties.QuotaThrottledMs != throttled {
+		t.Errorf("Expected the request throttled for the 80ms left in the period, got %.1fms", throttled)
+	}
+	if result.Metrics["nr_throttled"] != int64(5) || result.PenaltyInfo.TotalPenaltyFactor < 2 {
+		t.Errorf("Expected the fifth throttled period to grade the CPU for redirection, got %v throttled and %.2f", result.Metrics["nr_throttled"], result.PenaltyInfo.TotalPenaltyFactor)
+	}
+}
+
+// TestCPUSchedulerOversubscribedHeat tests that threads beyond the cores heat
+// the package no more than a thread on every core does
+func TestCPUSchedulerOversubscribedHeat(t *testing.T) {
+	full, oversubscribed := newSchedulerTestCPU(t), newSchedulerTestCPU(t)
+	oversubscribed.Scheduler.MaxThreads = 200
+	startThreads(full, 24, 10*time.Millisecond, 0)
+	startThreads(oversubscribed, 96, 10*time.Millisecond, 0)
+	if oversubscribed.BusyCores <= oversubscribed.CoreCount {
+		t.Fatalf("Expected more runnable threads than cores, got %d", oversubscribed.BusyCores)
+	}
+
+	for i := 0; i < 100; i++ {
+		full.updateThermalState()
+		oversubscribed.updateThermalState()
+	}
+	if got, want := oversubscribed.ThermalState.CurrentTemperatureC, full.ThermalState.CurrentTemperatureC; got != want {
+		t.Errorf("Expected 4 threads a core to run as hot as one (%.2f°C), got %.2f°C", want, got)
+	}
+}
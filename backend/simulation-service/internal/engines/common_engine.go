@@ -3,6 +3,7 @@ package engines
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"sync"
 	"time"
 )
@@ -42,6 +43,10 @@ type CommonEngine struct {
 	// Performance history for convergence
 	OperationHistory []time.Duration `json:"operation_history"`
 	LoadHistory      []float64       `json:"load_history"`
+
+	// Deterministic randomness (zero seed = unseeded)
+	Seed   int64      `json:"seed"`
+	random *rand.Rand // Guarded by mutex
 }
 
 // NewCommonEngine creates a new common engine foundation
@@ -118,6 +123,31 @@ func (ce *CommonEngine) DequeueOperation() *QueuedOperation {
 	return op
 }
 
+// PeekQueue returns the queued operations in arrival order without removing them
+func (ce *CommonEngine) PeekQueue() []*QueuedOperation {
+	ce.mutex.RLock()
+	defer ce.mutex.RUnlock()
+
+	queue := make([]*QueuedOperation, len(ce.Queue))
+	copy(queue, ce.Queue)
+	return queue
+}
+
+// DequeueQueuedOperation removes a specific queued operation, for engines that
+// schedule their queue out of arrival order
+func (ce *CommonEngine) DequeueQueuedOperation(target *QueuedOperation) bool {
+	ce.mutex.Lock()
+	defer ce.mutex.Unlock()
+
+	for i, queuedOp := range ce.Queue {
+		if queuedOp == target {
+			ce.Queue = append(ce.Queue[:i], ce.Queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // GetQueueLength returns the current queue length
 func (ce *CommonEngine) GetQueueLength() int {
 	ce.mutex.RLock()
@@ -180,6 +210,50 @@ func (ce *CommonEngine) GetComplexityLevel() CPUComplexityLevel {
 	return ce.ComplexityLevel
 }
 
+// SetSeed seeds every random decision made by the engine. Hash-based decisions
+// (cache hits, memory behavior) also mix in the seed, so engines with the same
+// seed and the same operations make the same decisions.
+func (ce *CommonEngine) SetSeed(seed int64) {
+	ce.mutex.Lock()
+	defer ce.mutex.Unlock()
+	ce.Seed = seed
+	ce.random = nil
+	if seed != 0 {
+		ce.random = NewSeededRand(seed)
+	}
+}
+
+// GetSeed returns the engine seed, or zero if the engine is unseeded
+func (ce *CommonEngine) GetSeed() int64 {
+	return ce.Seed
+}
+
+// randomFloat64 returns a random number in [0.0, 1.0) from the engine's seeded
+// source, falling back to the global source when the engine is unseeded
+func (ce *CommonEngine) randomFloat64() float64 {
+	ce.mutex.Lock()
+	defer ce.mutex.Unlock()
+	if ce.random == nil {
+		return rand.Float64()
+	}
+	return ce.random.Float64()
+}
+
+// nextEventTick combines the earliest completion in an engine's processing heap
+// with its queue: queued operations are picked up on the very next tick
+func (ce *CommonEngine) nextEventTick(completionTick int64, hasCompletion bool) (int64, bool) {
+	if ce.GetQueueLength() > 0 {
+		return ce.CurrentTick + 1, true
+	}
+	if !hasCompletion {
+		return 0, false
+	}
+	if completionTick <= ce.CurrentTick {
+		return ce.CurrentTick + 1, true
+	}
+	return completionTick, true
+}
+
 // GetHealth returns current health metrics
 func (ce *CommonEngine) GetHealth() *HealthMetrics {
 	return ce.Health
@@ -202,6 +276,9 @@ func (ce *CommonEngine) Reset() {
 	ce.FailedOps = 0
 	ce.OperationHistory = ce.OperationHistory[:0]
 	ce.LoadHistory = ce.LoadHistory[:0]
+	if ce.Seed != 0 {
+		ce.random = NewSeededRand(ce.Seed)
+	}
 
 	// Reset health
 	ce.Health.Score = 1.0
@@ -0,0 +1,870 @@
+package engines
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// CoordinationEngine implements the BaseEngine interface for distributed coordination:
+// lock acquisition and contention, quorum-replicated writes (Raft, Multi-Paxos, ZAB),
+// leader elections and two-phase commit. It lets components such as etcd, ZooKeeper
+// or a transaction coordinator be composed from the universal engines.
+type CoordinationEngine struct {
+	*CommonEngine
+
+	// Complexity control interface
+	ComplexityInterface *CoordinationInterface `json:"complexity_interface"`
+
+	// Coordination properties from profile (NO HARDCODED VALUES)
+	Protocol             string  `json:"protocol"`                // raft, multi_paxos, paxos, zab
+	ClusterSize          int     `json:"cluster_size"`            // Voting members including the leader
+	RTTMs                float64 `json:"rtt_ms"`                  // Leader to follower round trip
+	RTTJitter            float64 `json:"rtt_jitter"`              // Relative round trip variance
+	FsyncMs              float64 `json:"fsync_ms"`                // Log append durability cost
+	ProcessingMs         float64 `json:"processing_ms"`           // State machine apply cost
+	ElectionTimeoutMinMs float64 `json:"election_timeout_min_ms"` // Randomized election timeout range
+	ElectionTimeoutMaxMs float64 `json:"election_timeout_max_ms"`
+	LockHoldMs           float64 `json:"lock_hold_ms"`        // Default hold time of an acquired lock
+	MaxInflight          int     `json:"max_inflight"`        // Pipelined proposals before backpressure
+	LeaseReads           bool    `json:"lease_reads"`         // Leader serves reads without a quorum round
+	LeaderFailureRate    float64 `json:"leader_failure_rate"` // Probability an operation finds the leader gone
+
+	// Two-phase commit configuration
+	TwoPhaseCommit struct {
+		Participants         int     `json:"participants"`
+		ParticipantPrepareMs float64 `json:"participant_prepare_ms"` // Participant prepare log write
+		ParticipantCommitMs  float64 `json:"participant_commit_ms"`  // Participant commit and lock release
+		PrepareFailureRate   float64 `json:"prepare_failure_rate"`   // Probability a participant votes no
+	} `json:"two_phase_commit"`
+
+	// Lock table: key -> tick until which the lock is held
+	LockState struct {
+		Holders               map[string]int64 `json:"holders"`
+		Acquisitions          int64            `json:"acquisitions"`
+		ContendedAcquisitions int64            `json:"contended_acquisitions"`
+		Timeouts              int64            `json:"timeouts"`
+		TotalWaitMs           float64          `json:"total_wait_ms"`
+	} `json:"lock_state"`
+
+	// Replication and leadership state
+	ConsensusState struct {
+		Term             int64   `json:"term"`
+		ElectionEndsTick int64   `json:"election_ends_tick"` // Writes stall until a leader is elected
+		Elections        int64   `json:"elections"`
+		QuorumRounds     int64   `json:"quorum_rounds"`
+		LastQuorumMs     float64 `json:"last_quorum_ms"`
+	} `json:"consensus_state"`
+
+	// Two-phase commit outcomes
+	TransactionState struct {
+		Prepared  int64 `json:"prepared"`
+		Committed int64 `json:"committed"`
+		Aborted   int64 `json:"aborted"`
+	} `json:"transaction_state"`
+
+	// Completion ticks of proposals still in flight
+	InFlight []int64 `json:"in_flight"`
+}
+
+// coordinationTiming breaks an operation's latency into service time, which load
+// slows down, and waiting time spent behind locks or elections, which it does not
+type coordinationTiming struct {
+	serviceMs       float64
+	lockWaitMs      float64
+	electionDelayMs float64
+	quorumMs        float64
+	rounds          int
+}
+
+// NewCoordinationEngine creates a new Coordination engine with three-node Raft defaults
+func NewCoordinationEngine(queueCapacity int) *CoordinationEngine {
+	common := NewCommonEngine(CoordinationEngineType, queueCapacity)
+
+	coordination := &CoordinationEngine{
+		CommonEngine:         common,
+		ComplexityInterface:  NewCoordinationInterface(ComplexityAdvanced), // Default to advanced complexity
+		Protocol:             "raft",
+		ClusterSize:          3,
+		RTTMs:                0.5, // Same-datacenter round trip
+		RTTJitter:            0.2,
+		FsyncMs:              2.0, // SSD fsync of the WAL
+		ProcessingMs:         0.05,
+		ElectionTimeoutMinMs: 150, // Raft paper defaults
+		ElectionTimeoutMaxMs: 300,
+		LockHoldMs:           10,
+		MaxInflight:          1000,
+		LeaseReads:           true,
+		InFlight:             make([]int64, 0, 1000),
+	}
+
+	coordination.TwoPhaseCommit.Participants = 2
+	coordination.TwoPhaseCommit.ParticipantPrepareMs = 2.0
+	coordination.TwoPhaseCommit.ParticipantCommitMs = 1.0
+
+	coordination.LockState.Holders = make(map[string]int64)
+	coordination.ConsensusState.Term = 1
+
+	// Initialize convergence models
+	coordination.initializeConvergenceModels()
+
+	return coordination
+}
+
+// ProcessOperation processes a single coordination operation
+func (coordination *CoordinationEngine) ProcessOperation(op *Operation, currentTick int64) *OperationResult {
+	coordination.CurrentTick = currentTick
+	coordination.pruneInFlight(currentTick)
+
+	success := true
+	errorMessage := ""
+	var timing coordinationTiming
+
+	switch op.Type {
+	case OpCoordinationLockAcquire:
+		timing = coordination.leaderWrite(currentTick)
+		if coordination.ComplexityInterface.ShouldEnableFeature("lock_contention") {
+			success = coordination.acquireLock(op, currentTick, &timing)
+			if !success {
+				errorMessage = "lock wait exceeded deadline"
+			}
+		}
+
+	case OpCoordinationLockRelease:
+		timing = coordination.leaderWrite(currentTick)
+		delete(coordination.LockState.Holders, coordination.lockKey(op))
+
+	case OpCoordinationRead:
+		timing = coordination.leaderRead(currentTick)
+
+	case OpCoordinationLeaderElection:
+		coordination.startElection(currentTick)
+		timing.electionDelayMs = coordination.ticksToMs(coordination.ConsensusState.ElectionEndsTick - currentTick)
+		timing.serviceMs = coordination.ProcessingMs
+
+	case OpCoordination2PCPrepare:
+		timing = coordination.twoPhaseRound(op, coordination.TwoPhaseCommit.ParticipantPrepareMs)
+		coordination.TransactionState.Prepared++
+		if coordination.participantVotedNo(op) {
+			success = false
+			errorMessage = "participant voted to abort"
+		}
+
+	case OpCoordination2PCCommit:
+		timing = coordination.twoPhaseRound(op, coordination.TwoPhaseCommit.ParticipantCommitMs)
+		coordination.TransactionState.Committed++
+
+	case OpCoordination2PCAbort:
+		timing = coordination.twoPhaseRound(op, coordination.TwoPhaseCommit.ParticipantCommitMs)
+		coordination.TransactionState.Aborted++
+
+	default:
+		// OpCoordinationConsensus and any other replicated write
+		timing = coordination.leaderWrite(currentTick)
+	}
+
+	baseTime := msToDuration(timing.serviceMs)
+	waitTime := msToDuration(timing.lockWaitMs + timing.electionDelayMs)
+
+	// Apply common performance factors (load, queue, health, variance) to the service time only
+	utilization := coordination.calculateCurrentUtilization()
+	finalTime := coordination.ApplyCommonPerformanceFactors(baseTime, utilization) + waitTime
+
+	// Ensure operations take at least 1 tick to complete
+	ticksToComplete := coordination.DurationToTicks(finalTime)
+	if ticksToComplete < 1 {
+		ticksToComplete = 1
+	}
+	if success {
+		coordination.InFlight = append(coordination.InFlight, currentTick+ticksToComplete)
+	}
+
+	// Calculate penalty factors for routing decisions
+	contentionRatio := coordination.calculateContentionRatio()
+	loadPenalty := 1.0 + utilization*0.5
+	queuePenalty := coordination.calculateQueuePenaltyFactor()
+	thermalPenalty := 1.0 // Coordination is bound by round trips, not heat
+	contentionPenalty := 1.0 + contentionRatio
+	healthPenalty := 1.0 + (1.0-coordination.GetHealth().Score)*0.25
+
+	// Waiting behind locks and elections shows up as a penalty over the service time
+	waitPenalty := 1.0
+	if baseTime > 0 {
+		waitPenalty = 1.0 + float64(waitTime)/float64(baseTime)
+	}
+
+	totalPenaltyFactor := loadPenalty * queuePenalty * contentionPenalty * healthPenalty * waitPenalty
+
+	// Determine performance grade
+	performanceGrade := "A"
+	recommendedAction := "continue"
+	if totalPenaltyFactor > 3.0 {
+		performanceGrade = "F"
+		recommendedAction = "redirect"
+	} else if totalPenaltyFactor > 2.0 {
+		performanceGrade = "D"
+		recommendedAction = "throttle"
+	} else if totalPenaltyFactor > 1.5 {
+		performanceGrade = "C"
+		recommendedAction = "throttle"
+	} else if totalPenaltyFactor > 1.2 {
+		performanceGrade = "B"
+	}
+
+	result := &OperationResult{
+		OperationID:    op.ID,
+		OperationType:  op.Type,
+		ProcessingTime: finalTime,
+		CompletedTick:  currentTick + ticksToComplete,
+		Success:        success,
+		ErrorMessage:   errorMessage,
+		PenaltyInfo: &PenaltyInformation{
+			EngineType:           CoordinationEngineType,
+			EngineID:             coordination.ID,
+			BaseProcessingTime:   baseTime,
+			ActualProcessingTime: finalTime,
+			LoadPenalty:          loadPenalty,
+			QueuePenalty:         queuePenalty,
+			ThermalPenalty:       thermalPenalty,
+			ContentionPenalty:    contentionPenalty,
+			HealthPenalty:        healthPenalty,
+			TotalPenaltyFactor:   totalPenaltyFactor,
+			PerformanceGrade:     performanceGrade,
+			RecommendedAction:    recommendedAction,
+			CoordinationPenalties: &CoordinationPenaltyDetails{
+				LockWaitMs:       timing.lockWaitMs,
+				ContentionRatio:  contentionRatio,
+				QuorumRoundTrips: timing.rounds,
+				QuorumLatencyMs:  timing.quorumMs,
+				ElectionDelayMs:  timing.electionDelayMs,
+			},
+		},
+		Metrics: map[string]interface{}{
+			"service_time_ms":   timing.serviceMs,
+			"lock_wait_ms":      timing.lockWaitMs,
+			"election_delay_ms": timing.electionDelayMs,
+			"quorum_latency_ms": timing.quorumMs,
+			"quorum_rounds":     timing.rounds,
+			"quorum_size":       coordination.quorumSize(),
+			"term":              coordination.ConsensusState.Term,
+			"protocol":          coordination.Protocol,
+		},
+	}
+
+	// Update dynamic state tracking (if enabled)
+	if coordination.ComplexityInterface.ShouldEnableFeature("dynamic_behavior") {
+		coordination.Health.Utilization = coordination.calculateCurrentUtilization()
+		coordination.ConvergenceState.OperationCount++
+		coordination.ConvergenceState.DataProcessed += op.DataSize
+	}
+
+	// Update operation history for convergence
+	coordination.AddOperationToHistory(finalTime)
+	if result.Success {
+		coordination.CompletedOps++
+	} else {
+		coordination.FailedOps++
+	}
+
+	return result
+}
+
+// NextEventTick returns the next tick if operations are waiting in the queue.
+// Coordination operations complete in the tick they are dequeued, so there is no heap.
+func (coordination *CoordinationEngine) NextEventTick() (int64, bool) {
+	return coordination.nextEventTick(0, false)
+}
+
+// ProcessTick processes one simulation tick
+func (coordination *CoordinationEngine) ProcessTick(currentTick int64) []OperationResult {
+	coordination.CurrentTick = currentTick
+	coordination.pruneInFlight(currentTick)
+	results := make([]OperationResult, 0)
+
+	// Process queued operations while the pipeline has room
+	for len(coordination.InFlight) < coordination.MaxInflight && coordination.GetQueueLength() > 0 {
+		queuedOp := coordination.DequeueOperation()
+		if queuedOp != nil {
+			result := coordination.ProcessOperation(queuedOp.Operation, currentTick)
+			results = append(results, *result)
+		}
+	}
+
+	// Update health metrics
+	coordination.UpdateHealth()
+
+	// Update dynamic behavior
+	coordination.UpdateDynamicBehavior()
+
+	return results
+}
+
+// leaderWrite models a replicated write: wait for a leader, then one or more quorum rounds
+func (coordination *CoordinationEngine) leaderWrite(currentTick int64) coordinationTiming {
+	timing := coordinationTiming{
+		electionDelayMs: coordination.leaderWait(currentTick),
+	}
+
+	if !coordination.ComplexityInterface.ShouldEnableFeature("quorum_modeling") {
+		timing.serviceMs = coordination.ProcessingMs + coordination.FsyncMs
+		return timing
+	}
+
+	timing.rounds = coordination.protocolRounds()
+	for i := 0; i < timing.rounds; i++ {
+		timing.quorumMs += coordination.quorumLatencyMs(coordination.FsyncMs)
+	}
+	timing.serviceMs = timing.quorumMs + coordination.ProcessingMs
+
+	coordination.ConsensusState.QuorumRounds += int64(timing.rounds)
+	coordination.ConsensusState.LastQuorumMs = timing.quorumMs
+	return timing
+}
+
+// leaderRead models a linearizable read: served locally under a leader lease, or
+// confirmed with one heartbeat round (ReadIndex) that needs no log append
+func (coordination *CoordinationEngine) leaderRead(currentTick int64) coordinationTiming {
+	timing := coordinationTiming{
+		electionDelayMs: coordination.leaderWait(currentTick),
+		serviceMs:       coordination.ProcessingMs,
+	}
+
+	if coordination.LeaseReads && coordination.ComplexityInterface.ShouldEnableFeature("lease_reads") {
+		return timing
+	}
+	if coordination.ComplexityInterface.ShouldEnableFeature("quorum_modeling") {
+		timing.rounds = 1
+		timing.quorumMs = coordination.quorumLatencyMs(0)
+		timing.serviceMs += timing.quorumMs
+	}
+	return timing
+}
+
+// leaderWait returns how long an operation waits for a leader, first giving the
+// leader a chance to fail when failures are modeled
+func (coordination *CoordinationEngine) leaderWait(currentTick int64) float64 {
+	if !coordination.ComplexityInterface.ShouldEnableFeature("leader_election") {
+		return 0
+	}
+
+	if coordination.ComplexityInterface.ShouldEnableFeature("leader_failures") &&
+		coordination.ConsensusState.ElectionEndsTick <= currentTick &&
+		coordination.randomFloat64() < coordination.LeaderFailureRate {
+		coordination.startElection(currentTick)
+	}
+
+	if coordination.ConsensusState.ElectionEndsTick > currentTick {
+		return coordination.ticksToMs(coordination.ConsensusState.ElectionEndsTick - currentTick)
+	}
+	return 0
+}
+
+// startElection begins a new term: followers wait out a randomized election timeout,
+// then the candidate collects votes in one round with the vote persisted to disk
+func (coordination *CoordinationEngine) startElection(currentTick int64) {
+	if coordination.ConsensusState.ElectionEndsTick > currentTick {
+		return // An election is already running
+	}
+
+	electionMs := coordination.quorumLatencyMs(coordination.FsyncMs)
+	if coordination.ComplexityInterface.ShouldEnableFeature("leader_election") {
+		timeoutRange := coordination.ElectionTimeoutMaxMs - coordination.ElectionTimeoutMinMs
+		electionMs += coordination.ElectionTimeoutMinMs + coordination.randomFloat64()*math.Max(0, timeoutRange)
+	}
+
+	ticks := coordination.DurationToTicks(msToDuration(electionMs))
+	if ticks < 1 {
+		ticks = 1
+	}
+
+	coordination.ConsensusState.Term++
+	coordination.ConsensusState.Elections++
+	coordination.ConsensusState.ElectionEndsTick = currentTick + ticks
+}
+
+// acquireLock queues the operation behind the current holder of its lock. It fails
+// without taking the lock when the wait would run past the operation's deadline.
+func (coordination *CoordinationEngine) acquireLock(op *Operation, currentTick int64, timing *coordinationTiming) bool {
+	key := coordination.lockKey(op)
+
+	grantTick := currentTick
+	if heldUntil, held := coordination.LockState.Holders[key]; held && heldUntil > currentTick {
+		grantTick = heldUntil
+	}
+
+	if op.Deadline > 0 && grantTick > op.Deadline {
+		coordination.LockState.Timeouts++
+		timing.lockWaitMs = coordination.ticksToMs(op.Deadline - currentTick)
+		return false
+	}
+
+	waitTicks := grantTick - currentTick
+	timing.lockWaitMs = coordination.ticksToMs(waitTicks)
+
+	holdMs := coordination.LockHoldMs
+	if hold, ok := coordinationMetadataFloat(op, "hold_ms"); ok {
+		holdMs = hold
+	}
+
+	// The lock is granted once the write commits and held for the hold time after that
+	serviceTicks := coordination.DurationToTicks(msToDuration(timing.serviceMs + timing.electionDelayMs))
+	coordination.LockState.Holders[key] = grantTick + serviceTicks + coordination.DurationToTicks(msToDuration(holdMs))
+
+	coordination.LockState.Acquisitions++
+	if waitTicks > 0 {
+		coordination.LockState.ContendedAcquisitions++
+		coordination.LockState.TotalWaitMs += timing.lockWaitMs
+	}
+	return true
+}
+
+// twoPhaseRound models one 2PC phase: the coordinator logs its record, then waits for
+// the slowest participant to write its own record and answer
+func (coordination *CoordinationEngine) twoPhaseRound(op *Operation, participantMs float64) coordinationTiming {
+	if !coordination.ComplexityInterface.ShouldEnableFeature("two_phase_commit") {
+		return coordinationTiming{serviceMs: coordination.ProcessingMs + coordination.FsyncMs}
+	}
+
+	slowest := 0.0
+	for i := 0; i < coordination.participants(op); i++ {
+		slowest = math.Max(slowest, coordination.sampleRTTMs()+participantMs)
+	}
+
+	return coordinationTiming{
+		serviceMs: coordination.FsyncMs + slowest + coordination.ProcessingMs,
+		quorumMs:  slowest,
+		rounds:    1,
+	}
+}
+
+// participantVotedNo reports whether any participant refuses to prepare
+func (coordination *CoordinationEngine) participantVotedNo(op *Operation) bool {
+	if !coordination.ComplexityInterface.ShouldEnableFeature("participant_abort") {
+		return false
+	}
+	for i := 0; i < coordination.participants(op); i++ {
+		if coordination.randomFloat64() < coordination.TwoPhaseCommit.PrepareFailureRate {
+			return true
+		}
+	}
+	return false
+}
+
+// quorumLatencyMs returns when a majority has the entry durable: the leader appends
+// locally while followers append in parallel, so the commit waits for the leader and
+// for the fastest followers that complete the majority with it
+func (coordination *CoordinationEngine) quorumLatencyMs(fsyncMs float64) float64 {
+	followers := coordination.ClusterSize - 1
+	acksNeeded := coordination.quorumSize() - 1
+	if followers <= 0 || acksNeeded <= 0 {
+		return fsyncMs
+	}
+
+	acks := make([]float64, followers)
+	for i := range acks {
+		acks[i] = coordination.sampleRTTMs() + fsyncMs
+	}
+	sort.Float64s(acks)
+
+	return math.Max(fsyncMs, acks[acksNeeded-1])
+}
+
+// sampleRTTMs returns a round trip to one peer, with jitter when it is modeled
+func (coordination *CoordinationEngine) sampleRTTMs() float64 {
+	if !coordination.ComplexityInterface.ShouldEnableFeature("network_jitter") {
+		return coordination.RTTMs
+	}
+	return coordination.RTTMs * (1.0 + coordination.RTTJitter*coordination.randomFloat64())
+}
+
+// protocolRounds returns the quorum rounds a write needs. Single-decree Paxos runs
+// a prepare and an accept phase; leader-based protocols skip prepare once elected.
+func (coordination *CoordinationEngine) protocolRounds() int {
+	if coordination.Protocol == "paxos" {
+		return 2
+	}
+	return 1
+}
+
+// quorumSize returns the majority of the cluster
+func (coordination *CoordinationEngine) quorumSize() int {
+	return coordination.ClusterSize/2 + 1
+}
+
+// participants returns the 2PC participant count, overridable per operation
+func (coordination *CoordinationEngine) participants(op *Operation) int {
+	if participants, ok := coordinationMetadataFloat(op, "participants"); ok {
+		return int(participants)
+	}
+	return coordination.TwoPhaseCommit.Participants
+}
+
+// lockKey returns the lock an operation targets; operations without a key share one lock
+func (coordination *CoordinationEngine) lockKey(op *Operation) string {
+	if op.Metadata != nil {
+		if key, ok := op.Metadata["lock_key"].(string); ok && key != "" {
+			return key
+		}
+	}
+	return "default"
+}
+
+// pruneInFlight drops proposals that have completed by the current tick
+func (coordination *CoordinationEngine) pruneInFlight(currentTick int64) {
+	pending := coordination.InFlight[:0]
+	for _, completionTick := range coordination.InFlight {
+		if completionTick > currentTick {
+			pending = append(pending, completionTick)
+		}
+	}
+	coordination.InFlight = pending
+}
+
+// calculateCurrentUtilization returns the fraction of the proposal pipeline in use
+func (coordination *CoordinationEngine) calculateCurrentUtilization() float64 {
+	if coordination.MaxInflight <= 0 {
+		return 0.0
+	}
+	return math.Min(1.0, float64(len(coordination.InFlight))/float64(coordination.MaxInflight))
+}
+
+// calculateContentionRatio returns the fraction of lock acquisitions that had to wait
+func (coordination *CoordinationEngine) calculateContentionRatio() float64 {
+	if coordination.LockState.Acquisitions == 0 {
+		return 0.0
+	}
+	return float64(coordination.LockState.ContendedAcquisitions) / float64(coordination.LockState.Acquisitions)
+}
+
+// leaderAvailable reports whether the cluster currently has a leader
+func (coordination *CoordinationEngine) leaderAvailable() bool {
+	return coordination.ConsensusState.ElectionEndsTick <= coordination.CurrentTick
+}
+
+// ticksToMs converts ticks to fractional milliseconds
+func (coordination *CoordinationEngine) ticksToMs(ticks int64) float64 {
+	return float64(coordination.TicksToDuration(ticks)) / float64(time.Millisecond)
+}
+
+// msToDuration converts fractional milliseconds to a duration
+func msToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// coordinationMetadataFloat reads a numeric operation metadata value
+func coordinationMetadataFloat(op *Operation, key string) (float64, bool) {
+	if op.Metadata == nil {
+		return 0, false
+	}
+	switch value := op.Metadata[key].(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	default:
+		return 0, false
+	}
+}
+
+// initializeConvergenceModels initializes statistical convergence models
+func (coordination *CoordinationEngine) initializeConvergenceModels() {
+	coordination.ConvergenceState.Models["quorum_behavior"] = &StatisticalModel{
+		Name:             "quorum_behavior",
+		ConvergencePoint: 1.0, // Quorum latency at the profile's round trip plus fsync
+		BaseVariance:     0.1,
+		MinOperations:    1000,
+		CurrentValue:     1.0,
+		IsConverged:      false,
+	}
+
+	coordination.ConvergenceState.Models["lock_behavior"] = &StatisticalModel{
+		Name:             "lock_behavior",
+		ConvergencePoint: 0.0, // No contention under normal load
+		BaseVariance:     0.05,
+		MinOperations:    1000,
+		CurrentValue:     0.0,
+		IsConverged:      false,
+	}
+
+	coordination.ConvergenceState.Models["leader_stability"] = &StatisticalModel{
+		Name:             "leader_stability",
+		ConvergencePoint: 1.0, // A leader is available
+		BaseVariance:     0.01,
+		MinOperations:    500,
+		CurrentValue:     1.0,
+		IsConverged:      false,
+	}
+}
+
+// updateConvergenceModels updates statistical convergence models based on recent operations
+func (coordination *CoordinationEngine) updateConvergenceModels() {
+	if quorumModel, exists := coordination.ConvergenceState.Models["quorum_behavior"]; exists {
+		expectedMs := float64(coordination.protocolRounds()) * (coordination.RTTMs + coordination.FsyncMs)
+		if expectedMs > 0 && coordination.ConsensusState.LastQuorumMs > 0 {
+			quorumModel.CurrentValue = coordination.ConsensusState.LastQuorumMs / expectedMs
+		}
+	}
+
+	if lockModel, exists := coordination.ConvergenceState.Models["lock_behavior"]; exists {
+		lockModel.CurrentValue = coordination.calculateContentionRatio()
+	}
+
+	if leaderModel, exists := coordination.ConvergenceState.Models["leader_stability"]; exists {
+		if coordination.leaderAvailable() {
+			leaderModel.CurrentValue = 1.0
+		} else {
+			leaderModel.CurrentValue = 0.0
+		}
+	}
+
+	for _, model := range coordination.ConvergenceState.Models {
+		if coordination.CompletedOps >= model.MinOperations {
+			variance := math.Abs(model.CurrentValue - model.ConvergencePoint)
+			model.IsConverged = variance <= model.BaseVariance
+		}
+	}
+}
+
+// LoadProfile loads a coordination profile into the engine (BaseEngine interface)
+func (coordination *CoordinationEngine) LoadProfile(profile *EngineProfile) error {
+	if profile == nil {
+		return fmt.Errorf("profile cannot be nil")
+	}
+
+	if profile.Type != CoordinationEngineType {
+		return fmt.Errorf("profile type mismatch: expected Coordination, got %v", profile.Type)
+	}
+
+	if err := coordination.CommonEngine.LoadProfile(profile); err != nil {
+		return err
+	}
+
+	// Load baseline performance settings
+	if val, ok := profile.BaselinePerformance["cluster_size"]; ok {
+		coordination.ClusterSize = int(val)
+	}
+	if val, ok := profile.BaselinePerformance["rtt_ms"]; ok {
+		coordination.RTTMs = val
+	}
+	if val, ok := profile.BaselinePerformance["rtt_jitter"]; ok {
+		coordination.RTTJitter = val
+	}
+	if val, ok := profile.BaselinePerformance["fsync_ms"]; ok {
+		coordination.FsyncMs = val
+	}
+	if val, ok := profile.BaselinePerformance["processing_ms"]; ok {
+		coordination.ProcessingMs = val
+	}
+	if val, ok := profile.BaselinePerformance["election_timeout_min_ms"]; ok {
+		coordination.ElectionTimeoutMinMs = val
+	}
+	if val, ok := profile.BaselinePerformance["election_timeout_max_ms"]; ok {
+		coordination.ElectionTimeoutMaxMs = val
+	}
+	if val, ok := profile.BaselinePerformance["lock_hold_ms"]; ok {
+		coordination.LockHoldMs = val
+	}
+	if val, ok := profile.BaselinePerformance["max_inflight"]; ok {
+		coordination.MaxInflight = int(val)
+	}
+
+	// Load technology specs
+	if protocol, ok := profile.TechnologySpecs["protocol"].(string); ok {
+		coordination.Protocol = protocol
+	}
+	if leaseReads, ok := profile.TechnologySpecs["lease_reads"].(bool); ok {
+		coordination.LeaseReads = leaseReads
+	}
+
+	// Load engine-specific settings
+	if rate, ok := profile.EngineSpecific["leader_failure_rate"].(float64); ok {
+		coordination.LeaderFailureRate = rate
+	}
+	if twoPhase, ok := profile.EngineSpecific["two_phase_commit"].(map[string]interface{}); ok {
+		if val, ok := twoPhase["participants"].(float64); ok {
+			coordination.TwoPhaseCommit.Participants = int(val)
+		}
+		if val, ok := twoPhase["participant_prepare_ms"].(float64); ok {
+			coordination.TwoPhaseCommit.ParticipantPrepareMs = val
+		}
+		if val, ok := twoPhase["participant_commit_ms"].(float64); ok {
+			coordination.TwoPhaseCommit.ParticipantCommitMs = val
+		}
+		if val, ok := twoPhase["prepare_failure_rate"].(float64); ok {
+			coordination.TwoPhaseCommit.PrepareFailureRate = val
+		}
+	}
+
+	// Initialize convergence models
+	coordination.initializeConvergenceModels()
+
+	return nil
+}
+
+// SetComplexityLevel sets the coordination simulation complexity level (BaseEngine interface)
+func (coordination *CoordinationEngine) SetComplexityLevel(level int) error {
+	if err := ValidateCoordinationComplexityLevel(CoordinationComplexityLevel(level)); err != nil {
+		return err
+	}
+	return coordination.ComplexityInterface.SetComplexityLevel(CoordinationComplexityLevel(level))
+}
+
+// GetComplexityLevel returns the current complexity level (BaseEngine interface)
+func (coordination *CoordinationEngine) GetComplexityLevel() int {
+	return int(coordination.ComplexityInterface.ComplexityLevel)
+}
+
+// GetDynamicState returns the current dynamic state with coordination-specific data (BaseEngine interface)
+func (coordination *CoordinationEngine) GetDynamicState() *DynamicState {
+	performanceFactor := 1.0
+	if expectedMs := coordination.RTTMs + coordination.FsyncMs; expectedMs > 0 && coordination.ConsensusState.LastQuorumMs > 0 {
+		performanceFactor = coordination.ConsensusState.LastQuorumMs / (float64(coordination.protocolRounds()) * expectedMs)
+	}
+
+	return &DynamicState{
+		CurrentUtilization:  coordination.calculateCurrentUtilization(),
+		PerformanceFactor:   performanceFactor,
+		ConvergenceProgress: coordination.CommonEngine.calculateConvergenceProgress(),
+		HardwareSpecific: map[string]interface{}{
+			"protocol":              coordination.Protocol,
+			"cluster_size":          coordination.ClusterSize,
+			"quorum_size":           coordination.quorumSize(),
+			"term":                  coordination.ConsensusState.Term,
+			"leader_available":      coordination.leaderAvailable(),
+			"elections":             coordination.ConsensusState.Elections,
+			"last_quorum_ms":        coordination.ConsensusState.LastQuorumMs,
+			"held_locks":            len(coordination.LockState.Holders),
+			"lock_contention_ratio": coordination.calculateContentionRatio(),
+			"lock_timeouts":         coordination.LockState.Timeouts,
+			"in_flight":             len(coordination.InFlight),
+			"transactions_aborted":  coordination.TransactionState.Aborted,
+		},
+		LastUpdated: coordination.CurrentTick,
+	}
+}
+
+// UpdateDynamicBehavior updates coordination convergence models and lock table (BaseEngine interface)
+func (coordination *CoordinationEngine) UpdateDynamicBehavior() {
+	// Update convergence models based on recent operations
+	if coordination.ComplexityInterface.ShouldEnableFeature("statistical_modeling") {
+		coordination.updateConvergenceModels()
+	}
+
+	// Forget locks whose hold time has passed
+	if coordination.ComplexityInterface.ShouldEnableFeature("dynamic_behavior") {
+		for key, heldUntil := range coordination.LockState.Holders {
+			if heldUntil <= coordination.CurrentTick {
+				delete(coordination.LockState.Holders, key)
+			}
+		}
+	}
+
+	// Update health metrics
+	coordination.UpdateHealth()
+}
+
+// GetConvergenceMetrics returns coordination convergence metrics (BaseEngine interface)
+func (coordination *CoordinationEngine) GetConvergenceMetrics() *ConvergenceMetrics {
+	totalModels := len(coordination.ConvergenceState.Models)
+	convergedModels := 0
+	totalVariance := 0.0
+	convergenceFactors := make(map[string]float64)
+
+	for name, model := range coordination.ConvergenceState.Models {
+		if model.IsConverged {
+			convergedModels++
+		}
+		totalVariance += math.Abs(model.CurrentValue - model.ConvergencePoint)
+		convergenceFactors[name] = model.CurrentValue
+	}
+
+	avgVariance := 0.0
+	if totalModels > 0 {
+		avgVariance = totalVariance / float64(totalModels)
+	}
+
+	return &ConvergenceMetrics{
+		OperationCount:     coordination.CompletedOps,
+		ConvergencePoint:   1.0, // Target convergence point
+		CurrentVariance:    avgVariance,
+		IsConverged:        convergedModels == totalModels && totalModels > 0,
+		TimeToConvergence:  coordination.CurrentTick,
+		ConvergenceFactors: convergenceFactors,
+	}
+}
+
+// Reset resets the coordination engine to initial state (BaseEngine interface)
+func (coordination *CoordinationEngine) Reset() {
+	coordination.LockState.Holders = make(map[string]int64)
+	coordination.LockState.Acquisitions = 0
+	coordination.LockState.ContendedAcquisitions = 0
+	coordination.LockState.Timeouts = 0
+	coordination.LockState.TotalWaitMs = 0
+
+	coordination.ConsensusState.Term = 1
+	coordination.ConsensusState.ElectionEndsTick = 0
+	coordination.ConsensusState.Elections = 0
+	coordination.ConsensusState.QuorumRounds = 0
+	coordination.ConsensusState.LastQuorumMs = 0
+
+	coordination.TransactionState.Prepared = 0
+	coordination.TransactionState.Committed = 0
+	coordination.TransactionState.Aborted = 0
+
+	coordination.InFlight = coordination.InFlight[:0]
+
+	// Reset convergence models
+	coordination.initializeConvergenceModels()
+
+	// Reset common engine state
+	coordination.CommonEngine.Reset()
+}
+
+// GetCurrentState returns the current coordination engine state (BaseEngine interface)
+func (coordination *CoordinationEngine) GetCurrentState() map[string]interface{} {
+	return map[string]interface{}{
+		"engine_type":      coordination.GetEngineType().String(),
+		"engine_id":        coordination.GetEngineID(),
+		"complexity_level": coordination.GetComplexityLevel(),
+		"protocol":         coordination.Protocol,
+		"cluster_size":     coordination.ClusterSize,
+		"quorum_size":      coordination.quorumSize(),
+		"rtt_ms":           coordination.RTTMs,
+		"fsync_ms":         coordination.FsyncMs,
+
+		// Dynamic state
+		"term":                   coordination.ConsensusState.Term,
+		"leader_available":       coordination.leaderAvailable(),
+		"elections":              coordination.ConsensusState.Elections,
+		"quorum_rounds":          coordination.ConsensusState.QuorumRounds,
+		"held_locks":             len(coordination.LockState.Holders),
+		"lock_acquisitions":      coordination.LockState.Acquisitions,
+		"lock_contention_ratio":  coordination.calculateContentionRatio(),
+		"lock_timeouts":          coordination.LockState.Timeouts,
+		"transactions_prepared":  coordination.TransactionState.Prepared,
+		"transactions_committed": coordination.TransactionState.Committed,
+		"transactions_aborted":   coordination.TransactionState.Aborted,
+		"in_flight":              len(coordination.InFlight),
+
+		// Queue state
+		"queue_length":      coordination.GetQueueLength(),
+		"queue_capacity":    coordination.GetQueueCapacity(),
+		"queue_utilization": coordination.Health.QueueUtilization,
+
+		// Health metrics
+		"health_score":    coordination.Health.Score,
+		"utilization":     coordination.Health.Utilization,
+		"error_rate":      coordination.Health.ErrorRate,
+		"average_latency": coordination.Health.AverageLatency,
+		"throughput_ops":  coordination.Health.ThroughputOps,
+
+		// Convergence state
+		"convergence_progress": coordination.CommonEngine.calculateConvergenceProgress(),
+		"operations_processed": coordination.CompletedOps,
+		"current_tick":         coordination.CurrentTick,
+		"last_updated":         coordination.Health.LastUpdated,
+	}
+}
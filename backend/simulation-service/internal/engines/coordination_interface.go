@@ -0,0 +1,203 @@
+package engines
+
+import (
+	"fmt"
+)
+
+// CoordinationComplexityLevel defines the level of coordination simulation complexity
+// Using the same integer-based system as CPU/Memory/Storage/Network for consistency
+type CoordinationComplexityLevel = ComplexityLevel
+
+// CoordinationFeatures defines which coordination simulation features are enabled
+type CoordinationFeatures struct {
+	// Essential coordination modeling (Minimal+)
+	EnableQuorumModeling bool `json:"enable_quorum_modeling"`  // Majority acknowledgement round trips
+	EnableLockContention bool `json:"enable_lock_contention"`  // Waiting behind held locks
+	EnableTwoPhaseCommit bool `json:"enable_two_phase_commit"` // Prepare/commit across participants
+
+	// Core coordination modeling (Basic+)
+	EnableLeaderElection bool `json:"enable_leader_election"` // Election timeouts and vote rounds
+	EnableNetworkJitter  bool `json:"enable_network_jitter"`  // Per-follower round trip variance
+	EnableLeaseReads     bool `json:"enable_lease_reads"`     // Leader-local reads under a lease
+
+	// Advanced coordination modeling (Advanced+)
+	EnableLeaderFailures   bool `json:"enable_leader_failures"`   // Random leader loss triggering elections
+	EnableParticipantAbort bool `json:"enable_participant_abort"` // Participants voting no in 2PC
+
+	// Statistical and behavioral modeling
+	EnableStatisticalModeling bool `json:"enable_statistical_modeling"` // Statistical convergence
+	EnableConvergenceTracking bool `json:"enable_convergence_tracking"` // Model convergence monitoring
+	EnableDynamicBehavior     bool `json:"enable_dynamic_behavior"`     // Adaptive behavior patterns
+}
+
+// CoordinationInterface provides control over coordination simulation complexity and features
+type CoordinationInterface struct {
+	ComplexityLevel CoordinationComplexityLevel `json:"complexity_level"`
+	Features        *CoordinationFeatures       `json:"features"`
+}
+
+// NewCoordinationInterface creates a new coordination interface with the specified complexity level
+func NewCoordinationInterface(level CoordinationComplexityLevel) *CoordinationInterface {
+	ci := &CoordinationInterface{
+		ComplexityLevel: level,
+		Features:        &CoordinationFeatures{},
+	}
+
+	// SetComplexityLevel should not fail for valid levels, but handle error just in case
+	if err := ci.SetComplexityLevel(level); err != nil {
+		// Fallback to Advanced if invalid level
+		ci.SetComplexityLevel(ComplexityAdvanced)
+	}
+	return ci
+}
+
+// SetComplexityLevel configures features based on complexity level
+func (ci *CoordinationInterface) SetComplexityLevel(level CoordinationComplexityLevel) error {
+	// Validate the complexity level
+	if err := ValidateCoordinationComplexityLevel(level); err != nil {
+		return err
+	}
+
+	ci.ComplexityLevel = level
+
+	switch level {
+	case ComplexityMinimal:
+		ci.configureMinimalFeatures()
+	case ComplexityBasic:
+		ci.configureBasicFeatures()
+	case ComplexityAdvanced:
+		ci.configureAdvancedFeatures()
+	case ComplexityMaximum:
+		ci.configureMaximumFeatures()
+	default:
+		ci.configureAdvancedFeatures() // Default to Advanced
+	}
+
+	return nil
+}
+
+// configureMinimalFeatures - Fixed round trips with quorum, locks and 2PC phases only
+func (ci *CoordinationInterface) configureMinimalFeatures() {
+	*ci.Features = CoordinationFeatures{
+		// Essential real-world features
+		EnableQuorumModeling: true, // Consensus latency is the quorum round trip
+		EnableLockContention: true, // Lock waits dominate coordination latency under load
+		EnableTwoPhaseCommit: true, // 2PC phases are the core of a transaction coordinator
+
+		// Disable advanced features for performance
+		EnableLeaderElection:   false,
+		EnableNetworkJitter:    false,
+		EnableLeaseReads:       false,
+		EnableLeaderFailures:   false,
+		EnableParticipantAbort: false,
+
+		// Basic behavioral modeling
+		EnableStatisticalModeling: true,
+		EnableConvergenceTracking: false,
+		EnableDynamicBehavior:     true,
+	}
+}
+
+// configureBasicFeatures - Adds elections, jitter and lease reads
+func (ci *CoordinationInterface) configureBasicFeatures() {
+	*ci.Features = CoordinationFeatures{
+		// Core real-world coordination features
+		EnableQuorumModeling: true,
+		EnableLockContention: true,
+		EnableTwoPhaseCommit: true,
+
+		// Important real-world features
+		EnableLeaderElection: true, // Elections stall writes until a leader exists
+		EnableNetworkJitter:  true, // Slow followers shape the quorum tail
+		EnableLeaseReads:     true, // Lease reads skip the quorum round trip
+
+		// Skip failure injection
+		EnableLeaderFailures:   false,
+		EnableParticipantAbort: false,
+
+		// Enhanced behavioral modeling
+		EnableStatisticalModeling: true,
+		EnableConvergenceTracking: false,
+		EnableDynamicBehavior:     true,
+	}
+}
+
+// configureAdvancedFeatures - Adds leader failures and participant aborts
+func (ci *CoordinationInterface) configureAdvancedFeatures() {
+	*ci.Features = CoordinationFeatures{
+		EnableQuorumModeling: true,
+		EnableLockContention: true,
+		EnableTwoPhaseCommit: true,
+		EnableLeaderElection: true,
+		EnableNetworkJitter:  true,
+		EnableLeaseReads:     true,
+
+		// Failure modeling from the profile's rates
+		EnableLeaderFailures:   true,
+		EnableParticipantAbort: true,
+
+		// Advanced behavioral modeling
+		EnableStatisticalModeling: true,
+		EnableConvergenceTracking: true,
+		EnableDynamicBehavior:     true,
+	}
+}
+
+// configureMaximumFeatures - Everything enabled
+func (ci *CoordinationInterface) configureMaximumFeatures() {
+	ci.configureAdvancedFeatures()
+}
+
+// ShouldEnableFeature checks if a specific feature should be enabled
+func (ci *CoordinationInterface) ShouldEnableFeature(featureName string) bool {
+	switch featureName {
+	case "quorum_modeling":
+		return ci.Features.EnableQuorumModeling
+	case "lock_contention":
+		return ci.Features.EnableLockContention
+	case "two_phase_commit":
+		return ci.Features.EnableTwoPhaseCommit
+	case "leader_election":
+		return ci.Features.EnableLeaderElection
+	case "network_jitter":
+		return ci.Features.EnableNetworkJitter
+	case "lease_reads":
+		return ci.Features.EnableLeaseReads
+	case "leader_failures":
+		return ci.Features.EnableLeaderFailures
+	case "participant_abort":
+		return ci.Features.EnableParticipantAbort
+	case "statistical_modeling":
+		return ci.Features.EnableStatisticalModeling
+	case "convergence_tracking":
+		return ci.Features.EnableConvergenceTracking
+	case "dynamic_behavior":
+		return ci.Features.EnableDynamicBehavior
+	default:
+		return false
+	}
+}
+
+// GetDescription returns a human-readable description of the current complexity level
+func (ci *CoordinationInterface) GetDescription() string {
+	switch ci.ComplexityLevel {
+	case ComplexityMinimal:
+		return "Minimal coordination simulation - fixed quorum round trips, lock contention and 2PC phases."
+	case ComplexityBasic:
+		return "Basic coordination simulation - adds leader elections, follower jitter and lease reads."
+	case ComplexityAdvanced:
+		return "Advanced coordination simulation - adds leader failures and participant aborts."
+	case ComplexityMaximum:
+		return "Maximum coordination simulation - all coordination features enabled."
+	default:
+		return "Unknown complexity level"
+	}
+}
+
+// ValidateCoordinationComplexityLevel validates that the complexity level is valid
+func ValidateCoordinationComplexityLevel(level CoordinationComplexityLevel) error {
+	if level < ComplexityMinimal || level > ComplexityMaximum {
+		return fmt.Errorf("invalid coordination complexity level: %d (must be 0-3)", level)
+	}
+	return nil
+}
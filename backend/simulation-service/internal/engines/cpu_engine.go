@@ -13,6 +13,10 @@ type ProcessingOperation struct {
 	StartTick      int64      `json:"start_tick"`
 	CompletionTick int64      `json:"completion_tick"`
 	CoresUsed      int        `json:"cores_used"`
+
+	// Scheduled as threads: the work left on the slowest and the run queues holding them
+	RemainingWork time.Duration `json:"remaining_work"`
+	Cores         []int         `json:"cores"`
 }
 
 // ProcessingHeap implements heap.Interface for ProcessingOperation
@@ -98,6 +102,14 @@ type CPUEngine struct {
 	ActiveOperations *ProcessingHeap `json:"active_operations"`
 	BusyCores        int             `json:"busy_cores"`
 
+	// Language runtime shared with the instance's memory engine; its GC pauses stall in-flight operations
+	Runtime       *RuntimeGC         `json:"-"`
+	RuntimeCursor RuntimePauseCursor `json:"runtime_cursor"`
+
+	// OS scheduler running operations as threads under the cgroup's CPU quota
+	Scheduler      CPUSchedulerConfig `json:"scheduler"`
+	SchedulerState CPUSchedulerState  `json:"scheduler_state"`
+
 	// Boost clock state (dynamic frequency scaling)
 	BoostState struct {
 		CurrentClockGHz    float64 `json:"current_clock_ghz"`
@@ -216,6 +228,8 @@ func NewCPUEngine(queueCapacity int) *CPUEngine {
 	// Initialize processing heap
 	cpu.ActiveOperations = &ProcessingHeap{}
 	heap.Init(cpu.ActiveOperations)
+	cpu.Scheduler = defaultCPUSchedulerConfig(cpu.CoreCount)
+	cpu.resetScheduler()
 
 	// Initialize thermal state with safe defaults (profile will override these)
 	cpu.ThermalState.CurrentTemperatureC = 22.0 // Safe default ambient temperature
@@ -336,6 +350,20 @@ func (cpu *CPUEngine) ProcessOperation(op *Operation, currentTick int64) *Operat
 	utilization := cpu.calculateCurrentUtilization()
 	finalTime := cpu.ApplyCommonPerformanceFactors(thermalAdjustedTime, utilization)
 
+	// Stop for any GC pause of the instance's runtime under way while the operation runs
+	gcStall := cpu.runtimeStall(currentTick, finalTime, math.MaxInt64)
+	finalTime += gcStall
+
+	// Share the cores with the threads the tick path has running and wait out
+	// the CPU quota, which direct calls charge too
+	var schedDelay, quotaThrottle time.Duration
+	runQueueLength := 0
+	if cpu.schedulerEnabled() {
+		schedDelay, runQueueLength = cpu.schedulerDelay(finalTime, coresAllocated)
+		quotaThrottle = cpu.chargeQuota(cpu.TicksToDuration(currentTick), finalTime, coresAllocated)
+		finalTime += schedDelay + quotaThrottle
+	}
+
 	// Update remaining dynamic state
 	cpu.updateRemainingDynamicState(op, finalTime)
 	
@@ -346,7 +374,13 @@ func (cpu *CPUEngine) ProcessOperation(op *Operation, currentTick int64) *Operat
 	contentionPenalty := cpu.getMemoryContentionFactor(1) // Memory contention
 	healthPenalty := 1.0 + (1.0 - cpu.GetHealth().Score) * 0.2 // Health issues increase penalty
 
-	totalPenaltyFactor := loadPenalty * queuePenalty * thermalPenalty * contentionPenalty * healthPenalty
+	// Quota throttling impact: time held until a period refills the quota
+	throttlePenalty := 1.0
+	if quotaThrottle > 0 {
+		throttlePenalty = float64(finalTime) / float64(finalTime-quotaThrottle)
+	}
+
+	totalPenaltyFactor := loadPenalty * queuePenalty * thermalPenalty * contentionPenalty * healthPenalty * throttlePenalty
 
 	// Determine performance grade
 	performanceGrade := "A"
@@ -390,6 +424,8 @@ func (cpu *CPUEngine) ProcessOperation(op *Operation, currentTick int64) *Operat
 				ThermalThrottling:  cpu.ThermalState.ThrottleFactor,
 				CoreUtilization:    utilization,
 				MemoryContention:   contentionPenalty,
+				RunQueueLength:     float64(runQueueLength),
+				QuotaThrottledMs:   float64(quotaThrottle) / float64(time.Millisecond),
 			},
 		},
 		Metrics: map[string]interface{}{
@@ -406,6 +442,15 @@ func (cpu *CPUEngine) ProcessOperation(op *Operation, currentTick int64) *Operat
 			"temperature_c":        cpu.ThermalState.CurrentTemperatureC,
 		},
 	}
+	if gcStall > 0 {
+		result.Metrics["gc_pause_ms"] = float64(gcStall) / float64(time.Millisecond)
+	}
+	if cpu.schedulerEnabled() {
+		result.Metrics["run_queue_length"] = runQueueLength
+		result.Metrics["sched_delay_ms"] = float64(schedDelay) / float64(time.Millisecond)
+		result.Metrics["cfs_throttled_ms"] = float64(quotaThrottle) / float64(time.Millisecond)
+		result.Metrics["nr_throttled"] = cpu.SchedulerState.NrThrottled
+	}
 	
 	// Update operation history for convergence
 	cpu.AddOperationToHistory(finalTime)
@@ -414,17 +459,39 @@ func (cpu *CPUEngine) ProcessOperation(op *Operation, currentTick int64) *Operat
 	return result
 }
 
+// NextEventTick returns the earliest completion tick in the processing heap, or the
+// next tick if operations are waiting in the queue
+func (cpu *CPUEngine) NextEventTick() (int64, bool) {
+	if cpu.ActiveOperations == nil || cpu.ActiveOperations.Len() == 0 {
+		return cpu.nextEventTick(0, false)
+	}
+	return cpu.nextEventTick((*cpu.ActiveOperations)[0].CompletionTick, true)
+}
+
 // ProcessTick processes one simulation tick with proper state tracking
 func (cpu *CPUEngine) ProcessTick(currentTick int64) []OperationResult {
 	cpu.CurrentTick = currentTick
 	results := make([]OperationResult, 0)
 
+	// STEP 0: Hold in-flight operations through any new GC pause
+	cpu.applyRuntimePauses()
+
+	// Run the scheduled threads up to this tick
+	scheduled := cpu.schedulerEnabled()
+	if scheduled {
+		cpu.advanceScheduler(currentTick)
+	}
+
 	// STEP 1: Check for completed operations and move to output
 	completedOps := cpu.checkCompletedOperations(currentTick)
 	results = append(results, completedOps...)
 
 	// STEP 2: Start new operations from input queue
 	cpu.startNewOperationsFromQueue(currentTick)
+	if scheduled {
+		// The new threads slow those sharing their cores
+		cpu.scheduleCompletions(currentTick)
+	}
 
 	// STEP 3: Update metrics based on actual busy state
 	cpu.updateThermalState()
@@ -465,6 +532,7 @@ func (cpu *CPUEngine) checkCompletedOperations(currentTick int64) []OperationRes
 
 			// Free the cores (cores are released when operation completes)
 			cpu.BusyCores -= completedOp.CoresUsed
+			cpu.releaseThreads(completedOp.Cores)
 		} else {
 			// No more completed operations this tick
 			break
@@ -478,6 +546,7 @@ func (cpu *CPUEngine) checkCompletedOperations(currentTick int64) []OperationRes
 func (cpu *CPUEngine) startNewOperationsFromQueue(currentTick int64) {
 	maxQueuedOpsPerTick := cpu.getProfileInt("queue_processing", "max_ops_per_tick", 3)
 	opsStartedThisTick := 0
+	scheduled := cpu.schedulerEnabled()
 
 	for cpu.GetQueueLength() > 0 && opsStartedThisTick < maxQueuedOpsPerTick {
 		// INTRA-ENGINE FLOW: Check heap length before accepting operations
@@ -487,8 +556,9 @@ func (cpu *CPUEngine) startNewOperationsFromQueue(currentTick int64) {
 		}
 
 		// Check if we have available cores
+		// (scheduled threads instead wait their turn on the run queues)
 		availableCores := cpu.CoreCount - cpu.BusyCores
-		if availableCores <= 0 {
+		if availableCores <= 0 && !scheduled {
 			break // No cores available
 		}
 
@@ -500,7 +570,12 @@ func (cpu *CPUEngine) startNewOperationsFromQueue(currentTick int64) {
 		// Calculate processing requirements
 		coresNeeded := cpu.calculateCoresNeeded(queuedOp.Operation)
 
-		if coresNeeded <= availableCores {
+		fits := coresNeeded <= availableCores
+		if scheduled {
+			fits = cpu.admitThreads(min(coresNeeded, cpu.CoreCount))
+		}
+
+		if fits {
 			// Start processing
 			cpu.startProcessing(queuedOp, currentTick)
 			opsStartedThisTick++
@@ -516,6 +591,7 @@ func (cpu *CPUEngine) startNewOperationsFromQueue(currentTick int64) {
 func (cpu *CPUEngine) startProcessing(queuedOp *QueuedOperation, currentTick int64) {
 	// Calculate processing time using existing logic
 	processingTime := cpu.calculateProcessingTimeForOperation(queuedOp.Operation)
+	processingTime += cpu.runtimeStall(currentTick, processingTime, cpu.RuntimeCursor.Seq)
 	coresNeeded := cpu.calculateCoresNeeded(queuedOp.Operation)
 	completionTick := currentTick + cpu.DurationToTicks(processingTime)
 
@@ -526,6 +602,10 @@ func (cpu *CPUEngine) startProcessing(queuedOp *QueuedOperation, currentTick int
 		CompletionTick: completionTick,
 		CoresUsed:      coresNeeded,
 	}
+	if cpu.schedulerEnabled() {
+		activeOp.RemainingWork = time.Duration(completionTick-currentTick) * cpu.TickDuration
+		activeOp.Cores = cpu.placeThreads(coresNeeded)
+	}
 
 	// Add to processing heap
 	heap.Push(cpu.ActiveOperations, activeOp)
@@ -966,8 +1046,9 @@ func (cpu *CPUEngine) updateThermalState() {
 	// Use tick time for baseline idle heat, but work time for cooling
 	tickTimeSeconds := float64(cpu.TickDuration) / float64(time.Second)
 
-	// Calculate heat based on actual core utilization
-	currentUtilization := float64(cpu.BusyCores) / float64(cpu.CoreCount)
+	// Calculate heat based on actual core utilization; with max_threads above the
+	// core count BusyCores counts runnable threads, which can't exceed full load
+	currentUtilization := cpu.calculateCurrentUtilization()
 	activeHeatRate := heatGenerationRate * currentUtilization
 	idleHeatRate := heatGenerationRate * 0.1 * (1.0 - currentUtilization)
 
@@ -1304,6 +1385,7 @@ func (cpu *CPUEngine) hashOperationForCacheDecision(op *Operation) uint32 {
 	combined ^= (complexityHash << 13) | (complexityHash >> 19) // Rotate left 13
 	combined ^= (languageHash << 19) | (languageHash >> 13)     // Rotate left 19
 	combined ^= (idHash << 3) | (idHash >> 29)         // Rotate left 3
+	combined ^= seedHashMix(cpu.Seed)                   // Seeded runs get their own decision sequence
 
 	// Final mixing to eliminate any remaining patterns
 	combined ^= combined >> 16
@@ -1471,6 +1553,9 @@ func (cpu *CPUEngine) Reset() {
 	// Reset branch prediction state
 	cpu.BranchPredictionState.TotalBranches = 0
 	cpu.BranchPredictionState.TotalMispredictions = 0
+
+	// Reset run queues and throttling statistics
+	cpu.resetScheduler()
 }
 
 // initializeBoostClockState initializes boost clock behavior from profile
@@ -1918,6 +2003,10 @@ func (cpu *CPUEngine) GetDynamicState() *DynamicState {
 			"scalar_operations":    cpu.VectorizationState.ScalarOperationsCount,
 			"average_vector_speedup": cpu.VectorizationState.AverageSpeedup,
 			"vector_width":         cpu.VectorizationState.VectorWidth,
+			"runnable_threads":     cpu.SchedulerState.RunnableThreads,
+			"context_switches":     cpu.SchedulerState.ContextSwitches,
+			"nr_throttled":         cpu.SchedulerState.NrThrottled,
+			"throttled_time_ms":    float64(cpu.SchedulerState.ThrottledTime) / float64(time.Millisecond),
 		},
 		LastUpdated: cpu.CurrentTick,
 	}
@@ -1975,6 +2064,7 @@ func (cpu *CPUEngine) GetCurrentState() map[string]interface{} {
 	baseState["branch_prediction_state"] = cpu.BranchPredictionState
 	baseState["memory_bandwidth_state"] = cpu.MemoryBandwidthState
 	baseState["advanced_prefetch_state"] = cpu.AdvancedPrefetchState
+	baseState["scheduler_state"] = cpu.SchedulerState
 
 	return baseState
 }
@@ -2131,6 +2221,11 @@ func (cpu *CPUEngine) loadCPUSpecificProfile() error {
 		cpu.loadEngineSpecificConfigs()
 	}
 
+	// Load the OS scheduler and any CPU quota
+	if err := cpu.loadCPUScheduler(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -72,6 +72,7 @@ type CPUFeatures struct {
 	EnableAdvancedPrefetching bool `json:"enable_advanced_prefetching"`
 	EnableMemoryBandwidth     bool `json:"enable_memory_bandwidth_contention"`
 	EnableParallelProcessing  bool `json:"enable_parallel_processing"`
+	EnableOSScheduler         bool `json:"enable_os_scheduler"`
 	
 	// Behavioral Features
 	EnableStatisticalModeling bool `json:"enable_statistical_modeling"`
@@ -129,6 +130,7 @@ func (ci *CPUInterface) configureMinimalFeatures() {
 		EnableAdvancedPrefetching: false,
 		EnableMemoryBandwidth:     false,
 		EnableParallelProcessing:  false,
+		EnableOSScheduler:         false,
 		
 		// Minimal behavioral modeling
 		EnableStatisticalModeling: false,
@@ -158,6 +160,7 @@ func (ci *CPUInterface) configureBasicFeatures() {
 		EnableAdvancedPrefetching: false,
 		EnableMemoryBandwidth:     false,
 		EnableParallelProcessing:  true,  // Basic parallel processing
+		EnableOSScheduler:         false,
 		
 		// Basic behavioral modeling
 		EnableStatisticalModeling: true,
@@ -185,6 +188,7 @@ func (ci *CPUInterface) configureAdvancedFeatures() {
 		EnableAdvancedPrefetching: true,  // Include for enhanced accuracy
 		EnableMemoryBandwidth:     true,  // Memory bandwidth contention
 		EnableParallelProcessing:  true,
+		EnableOSScheduler:         true,  // Run queues, time slices and CPU quotas
 		
 		// Advanced behavioral modeling
 		EnableStatisticalModeling: true,
@@ -210,6 +214,7 @@ func (ci *CPUInterface) configureMaximumFeatures() {
 		EnableAdvancedPrefetching: true,  // Most computationally expensive feature
 		EnableMemoryBandwidth:     true,
 		EnableParallelProcessing:  true,
+		EnableOSScheduler:         true,
 		EnableStatisticalModeling: true,
 		EnableConvergenceTracking: true,
 		EnableDynamicBehavior:     true,
@@ -245,6 +250,8 @@ func (ci *CPUInterface) ShouldEnableFeature(feature string) bool {
 		return ci.Features.EnableMemoryBandwidth
 	case "parallel_processing":
 		return ci.Features.EnableParallelProcessing
+	case "os_scheduler":
+		return ci.Features.EnableOSScheduler
 	case "statistical_modeling":
 		return ci.Features.EnableStatisticalModeling
 	case "convergence_tracking":
@@ -290,6 +297,7 @@ func (ci *CPUInterface) GetEnabledFeatures() []string {
 		"advanced_prefetching":   ci.Features.EnableAdvancedPrefetching,
 		"memory_bandwidth":       ci.Features.EnableMemoryBandwidth,
 		"parallel_processing":    ci.Features.EnableParallelProcessing,
+		"os_scheduler":           ci.Features.EnableOSScheduler,
 		"statistical_modeling":   ci.Features.EnableStatisticalModeling,
 		"convergence_tracking":   ci.Features.EnableConvergenceTracking,
 		"dynamic_behavior":       ci.Features.EnableDynamicBehavior,
@@ -358,6 +366,8 @@ func (ci *CPUInterface) EnableFeature(feature string) error {
 		ci.Features.EnableMemoryBandwidth = true
 	case "parallel_processing":
 		ci.Features.EnableParallelProcessing = true
+	case "os_scheduler":
+		ci.Features.EnableOSScheduler = true
 	case "statistical_modeling":
 		ci.Features.EnableStatisticalModeling = true
 	case "convergence_tracking":
@@ -399,6 +409,8 @@ func (ci *CPUInterface) DisableFeature(feature string) error {
 		ci.Features.EnableMemoryBandwidth = false
 	case "parallel_processing":
 		ci.Features.EnableParallelProcessing = false
+	case "os_scheduler":
+		ci.Features.EnableOSScheduler = false
 	case "statistical_modeling":
 		ci.Features.EnableStatisticalModeling = false
 	case "convergence_tracking":
@@ -0,0 +1,395 @@
+package engines
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"time"
+)
+
+// The OS scheduler running the instance's operations as threads. Each started
+// operation puts one runnable thread per core it uses on the run queues of the
+// least loaded cores. The threads queued on a core share it CFS-style: each
+// runs for its slice of the scheduling latency, never less than the minimum
+// granularity, and each switch between them costs the core a context switch.
+// An operation finishes once its slowest thread has done its work.
+//
+// A cgroup CPU limit (cpu.cfs_quota_us per cpu.cfs_period_us, which Kubernetes
+// sets from a container's CPU limit) caps the CPU time the instance's threads
+// get each period. Once they have used the quota, every thread is throttled
+// until the next period refills it, however many cores sit idle.
+
+// CPUSchedulerConfig is loaded from a profile's cpu_scheduler section
+type CPUSchedulerConfig struct {
+	SchedLatency   time.Duration `json:"sched_latency"`   // Period in which every runnable thread of a core runs once
+	MinGranularity time.Duration `json:"min_granularity"` // Shortest slice a thread runs for
+	ContextSwitch  time.Duration `json:"context_switch"`  // Core time lost switching between threads
+	MaxThreads     int           `json:"max_threads"`     // Runnable threads admitted at once; 0 for no limit
+	CFSPeriod      time.Duration `json:"cfs_period"`      // cpu.cfs_period_us
+	CFSQuota       time.Duration `json:"cfs_quota"`       // cpu.cfs_quota_us; 0 without a limit
+}
+
+// CPUSchedulerState tracks the run queues and the cgroup's cpu.stat
+type CPUSchedulerState struct {
+	RunQueues       []int         `json:"run_queues"` // Runnable threads on each core
+	RunnableThreads int           `json:"runnable_threads"`
+	ContextSwitches float64       `json:"context_switches"`
+	SwitchTime      time.Duration `json:"switch_time"` // Core time spent switching threads
+	LastTick        int64         `json:"last_tick"`
+
+	// Quota accounting; use past the quota is carried into the next periods
+	PeriodStart         time.Duration `json:"period_start"`
+	PeriodUsage         time.Duration `json:"period_usage"`
+	Throttled           bool          `json:"throttled"`
+	LastActivePeriod    time.Duration `json:"last_active_period"`
+	LastThrottledPeriod time.Duration `json:"last_throttled_period"`
+	ThrottledUntil      time.Duration `json:"throttled_until"`
+
+	NrPeriods     int64         `json:"nr_periods"`     // Periods with runnable threads
+	NrThrottled   int64         `json:"nr_throttled"`   // Periods that ran out of quota
+	ThrottledTime time.Duration `json:"throttled_time"` // Time spent throttled
+}
+
+// defaultCPUSchedulerConfig returns Linux's defaults, which scale the latency
+// targets with the log of the CPUs up to 8, for a thread per core; a larger
+// max_threads, such as a thread pool's size, oversubscribes the cores
+func defaultCPUSchedulerConfig(cores int) CPUSchedulerConfig {
+	factor := 1 + math.Floor(math.Log2(float64(min(max(cores, 1), 8))))
+	return CPUSchedulerConfig{
+		SchedLatency:   time.Duration(factor * float64(6*time.Millisecond)),
+		MinGranularity: time.Duration(factor * float64(750*time.Microsecond)),
+		ContextSwitch:  5 * time.Microsecond,
+		MaxThreads:     max(cores, 1),
+		CFSPeriod:      100 * time.Millisecond,
+	}
+}
+
+// loadProfile overrides the defaults with settings present in a profile's
+// cpu_scheduler section; cpu_limit takes a Kubernetes limit in cores
+func (config *CPUSchedulerConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["sched_latency_ms"].(float64); ok && val > 0 {
+		config.SchedLatency = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["min_granularity_ms"].(float64); ok && val > 0 {
+		config.MinGranularity = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["context_switch_us"].(float64); ok && val >= 0 {
+		config.ContextSwitch = time.Duration(val * float64(time.Microsecond))
+	}
+	if val, ok := settings["max_threads"].(float64); ok && val >= 0 {
+		config.MaxThreads = int(val)
+	}
+	if val, ok := settings["cfs_period_us"].(float64); ok {
+		config.CFSPeriod = time.Duration(val * float64(time.Microsecond))
+	}
+	if val, ok := settings["cfs_quota_us"].(float64); ok {
+		config.CFSQuota = time.Duration(max(val, 0) * float64(time.Microsecond))
+	}
+	if val, ok := settings["cpu_limit"].(float64); ok && val > 0 {
+		config.CFSQuota = time.Duration(val * float64(config.CFSPeriod))
+	}
+}
+
+// validate checks the quota against the kernel's limits
+func (config CPUSchedulerConfig) validate() error {
+	if config.CFSPeriod < time.Millisecond || config.CFSPeriod > time.Second {
+		return fmt.Errorf("cfs period must be between 1ms and 1s, got %v", config.CFSPeriod)
+	}
+	if config.CFSQuota != 0 && config.CFSQuota < time.Millisecond {
+		return fmt.Errorf("cfs quota must be at least 1ms, got %v", config.CFSQuota)
+	}
+	return nil
+}
+
+// loadCPUScheduler loads the profile's cpu_scheduler section over the defaults
+// for the engine's cores and empties the run queues
+func (cpu *CPUEngine) loadCPUScheduler() error {
+	cpu.Scheduler = defaultCPUSchedulerConfig(cpu.CoreCount)
+	if settings, ok := cpu.Profile.EngineSpecific["cpu_scheduler"].(map[string]interface{}); ok {
+		cpu.Scheduler.loadProfile(settings)
+	}
+	if err := cpu.Scheduler.validate(); err != nil {
+		return err
+	}
+	cpu.resetScheduler()
+	return nil
+}
+
+// SetCPUQuota limits the instance to quota CPU time per period, as a cgroup's
+// cpu.cfs_quota_us and cpu.cfs_period_us do; a zero quota removes the limit
+func (cpu *CPUEngine) SetCPUQuota(quota, period time.Duration) error {
+	config := cpu.Scheduler
+	config.CFSQuota, config.CFSPeriod = quota, period
+	if err := config.validate(); err != nil {
+		return err
+	}
+	cpu.Scheduler = config
+	state := &cpu.SchedulerState
+	state.PeriodStart, state.PeriodUsage, state.Throttled = 0, 0, false
+	state.LastActivePeriod, state.LastThrottledPeriod = -1, -1
+	return nil
+}
+
+// schedulerEnabled reports whether operations run as scheduled threads
+func (cpu *CPUEngine) schedulerEnabled() bool {
+	return cpu.CoreCount > 0 && cpu.ComplexityInterface.ShouldEnableFeature("os_scheduler")
+}
+
+// resetScheduler empties the run queues and clears the cgroup's statistics
+func (cpu *CPUEngine) resetScheduler() {
+	cpu.SchedulerState = CPUSchedulerState{
+		RunQueues:           make([]int, max(cpu.CoreCount, 0)),
+		LastActivePeriod:    -1,
+		LastThrottledPeriod: -1,
+	}
+}
+
+// threadShare returns the share of a core each of n threads queued on it runs
+// for, after the context switches between them, and how long their slices are
+func (cpu *CPUEngine) threadShare(n int) (float64, time.Duration) {
+	if n <= 1 {
+		return 1.0, 0
+	}
+	slice := max(cpu.Scheduler.SchedLatency/time.Duration(n), cpu.Scheduler.MinGranularity)
+	switching := float64(cpu.Scheduler.ContextSwitch) / float64(slice+cpu.Scheduler.ContextSwitch)
+	return (1 - switching) / float64(n), slice
+}
+
+// placeThreads queues threads on the cores with the shortest run queues
+func (cpu *CPUEngine) placeThreads(threads int) []int {
+	state := &cpu.SchedulerState
+	if len(state.RunQueues) != cpu.CoreCount && state.RunnableThreads == 0 {
+		state.RunQueues = make([]int, cpu.CoreCount)
+	}
+	cores := make([]int, 0, threads)
+	for len(cores) < min(threads, len(state.RunQueues)) {
+		shortest := -1
+		for core, queued := range state.RunQueues {
+			if !containsCore(cores, core) && (shortest < 0 || queued < state.RunQueues[shortest]) {
+				shortest = core
+			}
+		}
+		cores = append(cores, shortest)
+		state.RunQueues[shortest]++
+	}
+	state.RunnableThreads += len(cores)
+	return cores
+}
+
+// releaseThreads takes a finished operation's threads off their run queues
+func (cpu *CPUEngine) releaseThreads(cores []int) {
+	state := &cpu.SchedulerState
+	for _, core := range cores {
+		if core < len(state.RunQueues) && state.RunQueues[core] > 0 {
+			state.RunQueues[core]--
+			state.RunnableThreads--
+		}
+	}
+}
+
+func containsCore(cores []int, core int) bool {
+	for _, c := range cores {
+		if c == core {
+			return true
+		}
+	}
+	return false
+}
+
+// operationShare returns the share of a tick an operation's slowest thread runs for
+func (cpu *CPUEngine) operationShare(cores []int) float64 {
+	share := 1.0
+	for _, core := range cores {
+		coreShare, _ := cpu.threadShare(cpu.SchedulerState.RunQueues[core])
+		share = math.Min(share, coreShare)
+	}
+	return share
+}
+
+// admitThreads reports whether threads more runnable threads fit under max_threads
+func (cpu *CPUEngine) admitThreads(threads int) bool {
+	return cpu.Scheduler.MaxThreads <= 0 || cpu.SchedulerState.RunnableThreads+threads <= cpu.Scheduler.MaxThreads
+}
+
+// rollPeriod moves the quota accounting to the period holding now, with each
+// period passed using up to a quota of the carried usage
+func (cpu *CPUEngine) rollPeriod(now time.Duration) {
+	config, state := cpu.Scheduler, &cpu.SchedulerState
+	if elapsed := (now - state.PeriodStart) / config.CFSPeriod; elapsed > 0 {
+		state.PeriodUsage = max(state.PeriodUsage-elapsed*config.CFSQuota, 0)
+		state.PeriodStart += elapsed * config.CFSPeriod
+	}
+	state.Throttled = config.CFSQuota > 0 && state.PeriodUsage >= config.CFSQuota
+}
+
+// countPeriod counts a period in which the instance had runnable threads
+func (state *CPUSchedulerState) countPeriod(periodStart time.Duration) {
+	if periodStart > state.LastActivePeriod {
+		state.NrPeriods++
+		state.LastActivePeriod = periodStart
+	}
+}
+
+// throttle records the instance throttled from from to to in the period
+// starting at periodStart, counting time other operations were throttled once
+func (state *CPUSchedulerState) throttle(periodStart, from, to time.Duration) {
+	if periodStart > state.LastThrottledPeriod {
+		state.NrThrottled++
+		state.LastThrottledPeriod = periodStart
+	}
+	if from = max(from, state.ThrottledUntil); to > from {
+		state.ThrottledTime += to - from
+		state.ThrottledUntil = to
+	}
+}
+
+// advanceScheduler runs the runnable threads through each tick since the last
+// call and reschedules the completions of their operations
+func (cpu *CPUEngine) advanceScheduler(currentTick int64) {
+	state := &cpu.SchedulerState
+	if state.RunnableThreads > 0 {
+		for tick := state.LastTick; tick < currentTick; tick++ {
+			cpu.runSchedulerTick(tick)
+		}
+	}
+	state.LastTick = currentTick
+	cpu.scheduleCompletions(currentTick)
+}
+
+// runSchedulerTick shares each core between its threads for one tick, for the
+// part of the tick the quota lets them run
+func (cpu *CPUEngine) runSchedulerTick(tick int64) {
+	config, state := cpu.Scheduler, &cpu.SchedulerState
+	from, length := cpu.TicksToDuration(tick), cpu.TickDuration
+
+	running := 1.0
+	if config.CFSQuota > 0 {
+		cpu.rollPeriod(from)
+		state.countPeriod(state.PeriodStart)
+
+		busyCores := 0
+		for _, queued := range state.RunQueues {
+			if queued > 0 {
+				busyCores++
+			}
+		}
+		used := time.Duration(busyCores) * length
+		if left := max(config.CFSQuota-state.PeriodUsage, 0); used > left {
+			running = float64(left) / float64(used)
+			state.throttle(state.PeriodStart, from+time.Duration(running*float64(length)), from+length)
+			state.Throttled = true
+			used = left
+		}
+		state.PeriodUsage += used
+	}
+	if running == 0 {
+		return
+	}
+
+	for _, queued := range state.RunQueues {
+		if share, slice := cpu.threadShare(queued); slice > 0 {
+			ran := running * float64(length)
+			state.ContextSwitches += ran / float64(slice)
+			state.SwitchTime += time.Duration(ran * (1 - share*float64(queued)))
+		}
+	}
+	for i := range *cpu.ActiveOperations {
+		active := &(*cpu.ActiveOperations)[i]
+		if active.Cores != nil {
+			active.RemainingWork -= time.Duration(math.Ceil(running * cpu.operationShare(active.Cores) * float64(length)))
+		}
+	}
+}
+
+// scheduleCompletions estimates when each scheduled operation finishes at the
+// threads' current shares, after the rest of the period if throttled. The
+// shares only fall until the next completion or start, so no operation
+// finishes before its estimate.
+func (cpu *CPUEngine) scheduleCompletions(currentTick int64) {
+	if cpu.ActiveOperations.Len() == 0 {
+		return
+	}
+	resumeTick := currentTick
+	if cpu.SchedulerState.Throttled {
+		periodEnd := cpu.SchedulerState.PeriodStart + cpu.Scheduler.CFSPeriod
+		resumeTick = max(int64(periodEnd/cpu.TickDuration), currentTick)
+	}
+	for i := range *cpu.ActiveOperations {
+		active := &(*cpu.ActiveOperations)[i]
+		if active.Cores == nil {
+			continue
+		}
+		if active.RemainingWork <= 0 {
+			active.CompletionTick = currentTick
+			continue
+		}
+		perTick := cpu.operationShare(active.Cores) * float64(cpu.TickDuration)
+		active.CompletionTick = resumeTick + int64(math.Ceil(float64(active.RemainingWork)/perTick))
+	}
+	heap.Init(cpu.ActiveOperations)
+}
+
+// schedulerDelay estimates how much longer work on threads cores takes sharing
+// them with the runnable threads, and how many threads it shares its busiest
+// core with, without queueing it. Only operations started by ProcessTick queue
+// threads, so on an engine driven solely through ProcessOperation the run
+// queues stay empty and this reports no delay; there the cgroup quota is the
+// only contention between operations.
+func (cpu *CPUEngine) schedulerDelay(work time.Duration, threads int) (time.Duration, int) {
+	queues := append([]int(nil), cpu.SchedulerState.RunQueues...)
+	busiest := 1
+	for placed := 0; placed < min(threads, len(queues)); placed++ {
+		shortest := 0
+		for core, queued := range queues {
+			if queued < queues[shortest] {
+				shortest = core
+			}
+		}
+		queues[shortest] = math.MaxInt
+		busiest = max(busiest, cpu.SchedulerState.RunQueues[shortest]+1)
+	}
+	share, _ := cpu.threadShare(busiest)
+	return time.Duration(float64(work)/share) - work, busiest
+}
+
+// chargeQuota books work running on threads cores from now against the quota,
+// in arrival order, and returns how long the quota throttles it
+func (cpu *CPUEngine) chargeQuota(now, work time.Duration, threads int) time.Duration {
+	config, state := cpu.Scheduler, &cpu.SchedulerState
+	if config.CFSQuota <= 0 || work <= 0 {
+		return 0
+	}
+	threads = max(threads, 1)
+	cpu.rollPeriod(now)
+
+	var throttled time.Duration
+	t, periodStart, used := now, state.PeriodStart, state.PeriodUsage
+	for remaining := work; remaining > 0; {
+		periodEnd := periodStart + config.CFSPeriod
+		state.countPeriod(periodStart)
+		if left := config.CFSQuota - used; left > 0 {
+			run := min(remaining, periodEnd-t, left/time.Duration(threads))
+			remaining -= run
+			t += run
+			used += run * time.Duration(threads)
+		}
+		if remaining > 0 && t < periodEnd {
+			// Out of quota until the period ends
+			state.throttle(periodStart, t, periodEnd)
+			throttled += periodEnd - t
+			t = periodEnd
+		}
+		if t == periodEnd {
+			periodStart, used = periodEnd, max(used-config.CFSQuota, 0)
+		}
+	}
+
+	state.PeriodUsage += work * time.Duration(threads)
+	state.Throttled = state.PeriodUsage >= config.CFSQuota
+	return throttled
+}
+
+// GetSchedulerState returns the run queues and the cgroup's throttling statistics
+func (cpu *CPUEngine) GetSchedulerState() CPUSchedulerState {
+	return cpu.SchedulerState
+}
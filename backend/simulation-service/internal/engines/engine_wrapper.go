@@ -36,6 +36,9 @@ type EngineWrapper struct {
 	mutex        sync.RWMutex
 	wg           sync.WaitGroup
 
+	// Held while the engine's state changes, so snapshots never see a tick half applied
+	engineMutex sync.Mutex
+
 	// Metrics aggregation (single goroutine responsibility)
 	processedOps    int64
 	queuedOps       int64
@@ -49,6 +52,9 @@ type EngineWrapper struct {
 	// Sequential processing state (matches real CPU pipeline)
 	pendingResults  []*OperationResult // Results waiting to be routed (like CPU write buffer)
 
+	// Receives operations that complete within this engine (nil drops them)
+	resultHandler func(*OperationResult)
+
 	// State persistence (built-in)
 	stateDir        string             // Directory for state files
 }
@@ -77,7 +83,7 @@ type WrapperState struct {
 	RoutingTable        map[string]string         `json:"routing_table"`
 	ProcessedOps        int64                     `json:"processed_operations"`
 	QueuedOps           int64                     `json:"queued_operations"`
-	CompletedOperations int64                     `json:"completed_operations"`
+	CompletedOperations int64                     `json:"wrapper_completed_operations"`
 
 	// Engine state (embedded)
 	EngineState         map[string]interface{}    `json:"engine_state"`
@@ -162,6 +168,12 @@ func NewEngineWrapperWithProfile(engineType EngineType, profileName string, comp
 		engine = NewStorageEngine(queueCapacity)
 	case NetworkEngineType:
 		engine = NewNetworkEngine(queueCapacity)
+	case NetworkInEngineType:
+		engine = NewNetworkInEngine(queueCapacity)
+	case NetworkOutEngineType:
+		engine = NewNetworkOutEngine(queueCapacity)
+	case CoordinationEngineType:
+		engine = NewCoordinationEngine(queueCapacity)
 	default:
 		return nil, fmt.Errorf("unknown engine type: %v", engineType)
 	}
@@ -203,16 +215,16 @@ func (ew *EngineWrapper) Start(ctx context.Context) error {
 // Stop gracefully shuts down the wrapper
 func (ew *EngineWrapper) Stop() error {
 	ew.mutex.Lock()
-	defer ew.mutex.Unlock()
-
 	if !ew.running {
+		ew.mutex.Unlock()
 		return nil
 	}
 
 	ew.running = false
 	close(ew.stopChannel)
+	ew.mutex.Unlock()
 
-	// Wait for single goroutine to finish
+	// Wait for single goroutine to finish; it takes the lock to check for pauses
 	ew.wg.Wait()
 
 	return nil
@@ -329,10 +341,12 @@ func (ew *EngineWrapper) sequentialProcessor(ctx context.Context) {
 			// SEQUENTIAL PROCESSING CYCLES (like real CPU pipeline stages):
 
 			// CYCLE 1: Input Stage (Fetch) - Process incoming operations
+			ew.engineMutex.Lock()
 			ew.processInputCycle()
 
 			// CYCLE 2: Execute Stage (Decode + Execute) - Process tick in engine
 			results := ew.engine.ProcessTick(currentTick)
+			ew.engineMutex.Unlock()
 
 			// CYCLE 3: Output Stage (Write Back) - Route completed operations
 			ew.processOutputCycle(results)
@@ -353,9 +367,12 @@ func (ew *EngineWrapper) sequentialProcessor(ctx context.Context) {
 			}
 
 			// Check if engine can accept operation BEFORE taking from inputQueue
+			ew.engineMutex.Lock()
 			if ew.engine.GetQueueLength() < ew.engine.GetQueueCapacity() {
 				// Engine has space - transfer operation
-				if err := ew.engine.QueueOperation(op); err != nil {
+				err := ew.engine.QueueOperation(op)
+				ew.engineMutex.Unlock()
+				if err != nil {
 					// This should rarely happen since we checked capacity
 					fmt.Printf("Warning: Engine queue operation failed: %v\n", err)
 				} else {
@@ -364,6 +381,8 @@ func (ew *EngineWrapper) sequentialProcessor(ctx context.Context) {
 					ew.mutex.Unlock()
 				}
 			} else {
+				ew.engineMutex.Unlock()
+
 				// ✅ PROPER BACKPRESSURE: Engine queue full - put operation back
 				// This creates backpressure by blocking the inputQueue
 				select {
@@ -511,6 +530,32 @@ func (ew *EngineWrapper) routeCompletedOperation(result *OperationResult) bool {
 	}
 }
 
+// Step runs one tick synchronously on the caller's goroutine. It is the
+// tick-driven alternative to Start: the owner calls Step once per tick instead
+// of feeding the tick channel, so results are delivered before Step returns.
+func (ew *EngineWrapper) Step(currentTick int64) error {
+	ew.mutex.RLock()
+	running, paused := ew.running, ew.paused
+	ew.mutex.RUnlock()
+	if running {
+		return fmt.Errorf("engine wrapper %s is running its own processor", ew.engine.GetEngineID())
+	}
+	if paused {
+		return nil
+	}
+
+	ew.lastTickTime = time.Now()
+	ew.engineMutex.Lock()
+	ew.processInputCycle()
+	results := ew.engine.ProcessTick(currentTick)
+	ew.engineMutex.Unlock()
+	ew.processOutputCycle(results)
+	if len(ew.pendingResults) > 0 {
+		ew.processPendingResults()
+	}
+	return nil
+}
+
 // Component interface implementation for clock coordinator integration
 
 // ProcessTick implements the Component interface for the clock coordinator
@@ -528,11 +573,54 @@ func (ew *EngineWrapper) ProcessTick(currentTick int64) error {
 	}
 }
 
+// NextEventTick implements next-event scheduling for the clock coordinator.
+// Operations in the input queue and results still waiting to be routed need the
+// very next tick; otherwise the engine's next completion decides.
+func (ew *EngineWrapper) NextEventTick(currentTick int64) (int64, bool) {
+	if len(ew.inputQueue) > 0 || len(ew.tickChannel) > 0 || len(ew.pendingResults) > 0 {
+		return currentTick + 1, true
+	}
+
+	tick, ok := ew.engine.NextEventTick()
+	if ok && tick <= currentTick {
+		tick = currentTick + 1
+	}
+	return tick, ok
+}
+
 // GetID returns the wrapped engine's ID
 func (ew *EngineWrapper) GetID() string {
 	return ew.engine.GetEngineID()
 }
 
+// GetEngine returns the wrapped engine for read-only inspection such as metrics export
+func (ew *EngineWrapper) GetEngine() BaseEngine {
+	return ew.engine
+}
+
+// EngineSnapshot is a copy of an engine's observable state taken between ticks
+type EngineSnapshot struct {
+	Health      *HealthMetrics
+	State       *DynamicState
+	Convergence *ConvergenceMetrics
+}
+
+// GetSnapshot reads the engine's health, dynamic state and convergence while it is not
+// processing, for observers such as the metrics exporter that run on other goroutines
+func (ew *EngineWrapper) GetSnapshot() EngineSnapshot {
+	ew.engineMutex.Lock()
+	defer ew.engineMutex.Unlock()
+
+	var snapshot EngineSnapshot
+	if health := ew.engine.GetHealth(); health != nil {
+		copied := *health
+		snapshot.Health = &copied
+	}
+	snapshot.State = ew.engine.GetDynamicState()
+	snapshot.Convergence = ew.engine.GetConvergenceMetrics()
+	return snapshot
+}
+
 // GetTickChannel returns the tick channel for global coordinator integration
 func (ew *EngineWrapper) GetTickChannel() chan int64 {
 	return ew.tickChannel
@@ -589,6 +677,21 @@ func (ew *EngineWrapper) SetComplexityLevel(level int) error {
 	return ew.engine.SetComplexityLevel(level)
 }
 
+// SetSeed seeds the wrapped engine's random decisions (requires restart)
+func (ew *EngineWrapper) SetSeed(seed int64) error {
+	if ew.running {
+		return fmt.Errorf("cannot change seed while running")
+	}
+
+	ew.engine.SetSeed(seed)
+	return nil
+}
+
+// GetSeed returns the wrapped engine's seed
+func (ew *EngineWrapper) GetSeed() int64 {
+	return ew.engine.GetSeed()
+}
+
 // SetRouting configures where to send completed operations based on operation type
 func (ew *EngineWrapper) SetRouting(operationType string, nextDestination string) {
 	ew.mutex.Lock()
@@ -629,8 +732,22 @@ func (ew *EngineWrapper) drainCompletedOperation(result *OperationResult) {
 	// fmt.Printf("✅ Operation %s completed in %v (drained)\n",
 	//     result.OperationID, result.ProcessingTime)
 
-	// Operation is successfully drained - no further action needed
-	// This maintains realistic queue flow without external dependencies
+	// Hand the result to the owner (e.g. the component's engine sequence)
+	ew.mutex.RLock()
+	handler := ew.resultHandler
+	ew.mutex.RUnlock()
+	if handler != nil {
+		handler(result)
+	}
+}
+
+// SetResultHandler registers the callback that receives operations completing
+// in this engine. It runs on the wrapper's processing goroutine, or on the
+// caller's goroutine when the wrapper is driven by Step.
+func (ew *EngineWrapper) SetResultHandler(handler func(*OperationResult)) {
+	ew.mutex.Lock()
+	defer ew.mutex.Unlock()
+	ew.resultHandler = handler
 }
 
 // routeIntraEngine handles intra-engine routing for multi-stage operations
@@ -719,6 +836,8 @@ func calculateProfileDrivenQueueSizes(engine BaseEngine, complexity ComplexityLe
 	case NetworkEngineType:
 		inputSize, _ = calculateNetworkQueueSizeFromProfile(profile, complexity)
 		return inputSize, 0 // No output queue needed
+	case CoordinationEngineType:
+		return calculateOptimalQueueSizeFromProfile(profile, complexity), 0 // No output queue needed
 	default:
 		return 0, 0
 	}
@@ -810,6 +929,14 @@ func calculateOptimalQueueSizeFromProfile(profile *EngineProfile, complexity Com
 		queueSize := int(bandwidth/100 + latency*100) // Heuristic calculation
 		return int(float64(queueSize) * getComplexityQueueMultiplier(int(complexity)))
 
+	case CoordinationEngineType:
+		// Coordination pipelines proposals; queue behind a full pipeline with a 2x buffer
+		maxInflight := profile.BaselinePerformance["max_inflight"]
+		if maxInflight <= 0 {
+			maxInflight = 1000
+		}
+		return int(maxInflight * 2 * getComplexityQueueMultiplier(int(complexity)))
+
 	default:
 		return 5000 // Default fallback
 	}
@@ -838,6 +965,15 @@ func calculateEngineQueueSizes(engineType EngineType) (inputSize, outputSize int
 		// Medium queue size for network buffering
 		return 2000, 2000
 
+	case NetworkInEngineType, NetworkOutEngineType:
+		// One side of a full-duplex link: each direction buffers independently
+		return 2000, 2000
+
+	case CoordinationEngineType:
+		// Coordination: quorum round trips (50-500 ticks), pipelined up to max_inflight
+		// Queue behind a full pipeline: 1000 in flight × 2 buffer
+		return 2000, 2000
+
 	default:
 		// Default to CPU sizing for unknown engine types
 		return 3200, 3200
@@ -1120,10 +1256,10 @@ func (ew *EngineWrapper) extractWrapperState() *WrapperState {
 		EngineID:            ew.engine.GetEngineID(),
 		EngineType:          ew.engine.GetEngineType(),
 		ProfileName:         "",
-		CurrentTick:         engineState["current_tick"].(int64),
-		TotalOperations:     engineState["total_operations"].(int64),
-		CompletedOps:        engineState["completed_ops"].(int64),
-		FailedOps:           engineState["failed_ops"].(int64),
+		CurrentTick:         stateCounter(engineState, "current_tick"),
+		TotalOperations:     stateCounter(engineState, "total_operations"),
+		CompletedOps:        stateCounter(engineState, "completed_ops"),
+		FailedOps:           stateCounter(engineState, "failed_ops"),
 		SavedAt:             time.Now(),
 		Architecture:        "single_goroutine_sequential",
 		IsRunning:           ew.running,
@@ -1213,6 +1349,13 @@ func (ew *EngineWrapper) SetStateDirectory(dir string) {
 	ew.stateDir = dir
 }
 
+// stateCounter reads an int64 counter from an engine state map; engines that do
+// not report the counter yield 0
+func stateCounter(state map[string]interface{}, key string) int64 {
+	value, _ := state[key].(int64)
+	return value
+}
+
 // SaveState saves the complete wrapper and engine state to a file
 func (ew *EngineWrapper) SaveState() error {
 	ew.mutex.RLock()
@@ -69,6 +69,20 @@ func (ef *EngineFactory) CreateEngine(engineType EngineType, profileName string,
 		networkEngine := NewNetworkEngine(queueCapacity)
 		ef.configureNetworkEngine(networkEngine, profile)
 		engine = networkEngine
+
+	case NetworkInEngineType:
+		networkEngine := NewNetworkInEngine(queueCapacity)
+		ef.configureNetworkEngine(networkEngine, profile)
+		engine = networkEngine
+
+	case NetworkOutEngineType:
+		networkEngine := NewNetworkOutEngine(queueCapacity)
+		ef.configureNetworkEngine(networkEngine, profile)
+		engine = networkEngine
+
+	case CoordinationEngineType:
+		// All coordination configuration is handled by LoadProfile
+		engine = NewCoordinationEngine(queueCapacity)
 		
 	default:
 		return nil, fmt.Errorf("unknown engine type: %v", engineType)
@@ -236,6 +250,12 @@ func (ef *EngineFactory) configureMemoryEngine(memory *MemoryEngine, profile *En
 			}
 		}
 	}
+
+	// Configure the resident set limit and swap
+	memory.Capacity = memory.defaultMemoryCapacityConfig()
+	if capacity, ok := profile.EngineSpecific["capacity"].(map[string]interface{}); ok {
+		memory.Capacity.loadProfile(capacity)
+	}
 }
 
 // configureStorageEngine configures a Storage engine with profile-specific settings
@@ -314,6 +334,9 @@ func (ef *EngineFactory) GetAvailableProfiles() map[string][]string {
 		"Memory":  ef.ProfileManager.ListProfiles(MemoryEngineType),
 		"Storage": ef.ProfileManager.ListProfiles(StorageEngineType),
 		"Network": ef.ProfileManager.ListProfiles(NetworkEngineType),
+		"Coordination": ef.ProfileManager.ListProfiles(CoordinationEngineType),
+		"NetworkIn": ef.ProfileManager.ListProfiles(NetworkInEngineType),
+		"NetworkOut": ef.ProfileManager.ListProfiles(NetworkOutEngineType),
 	}
 }
 
@@ -364,6 +387,20 @@ func (ef *EngineFactory) createEngineWithProfile(engineType EngineType, profile
 		ef.configureNetworkEngine(networkEngine, profile)
 		engine = networkEngine
 
+	case NetworkInEngineType:
+		networkEngine := NewNetworkInEngine(queueCapacity)
+		ef.configureNetworkEngine(networkEngine, profile)
+		engine = networkEngine
+
+	case NetworkOutEngineType:
+		networkEngine := NewNetworkOutEngine(queueCapacity)
+		ef.configureNetworkEngine(networkEngine, profile)
+		engine = networkEngine
+
+	case CoordinationEngineType:
+		// All coordination configuration is handled by LoadProfile
+		engine = NewCoordinationEngine(queueCapacity)
+
 	default:
 		return nil, fmt.Errorf("unknown engine type: %v", engineType)
 	}
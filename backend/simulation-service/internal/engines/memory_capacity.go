@@ -0,0 +1,205 @@
+package engines
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Resident memory of the component instance a memory engine belongs to.
+// memory_alloc grows the allocated set and memory_free shrinks it. Once it
+// exceeds the RAM limit the kernel pages the least recently used memory out
+// to swap, stalling the allocation while it writes; later reads and writes
+// touching swapped pages fault them back in from the swap device. When swap
+// is full too (or disabled) the OOM killer ends the process: the operation
+// fails with a *MemoryError and the process restarts with an empty heap.
+
+// MemoryErrorCode identifies why a memory operation failed
+type MemoryErrorCode string
+
+const (
+	MemoryErrorOOMKilled MemoryErrorCode = "oom_killed" // Allocation exceeded RAM plus swap
+)
+
+// MemoryError is returned for memory operations that killed their process
+type MemoryError struct {
+	Code           MemoryErrorCode `json:"code"`
+	OperationID    string          `json:"operation_id"`
+	RequestedBytes int64           `json:"requested_bytes"`
+	AllocatedBytes int64           `json:"allocated_bytes"` // Held before the request
+	LimitBytes     int64           `json:"limit_bytes"`     // RAM plus swap
+}
+
+// Error implements the error interface
+func (e *MemoryError) Error() string {
+	return fmt.Sprintf("memory %s: operation %s requested %d bytes with %d allocated over a %d byte limit",
+		e.Code, e.OperationID, e.RequestedBytes, e.AllocatedBytes, e.LimitBytes)
+}
+
+// SwapDevice is the storage a memory engine pages out to
+type SwapDevice interface {
+	SwapLatency(bytes int64, write bool) time.Duration
+}
+
+// MemoryCapacityConfig is loaded from a profile's capacity section
+type MemoryCapacityConfig struct {
+	LimitBytes  int64         `json:"limit_bytes"`  // RAM the instance may hold resident
+	SwapBytes   int64         `json:"swap_bytes"`   // Swap space; 0 disables swapping
+	PageBytes   int64         `json:"page_bytes"`   // Unit of swap-in on a fault
+	SwapLatency time.Duration `json:"swap_latency"` // Per swap I/O without a swap device attached
+}
+
+// ResidentSet tracks an instance's memory against its limit
+type ResidentSet struct {
+	AllocatedBytes  int64 `json:"allocated_bytes"`
+	ResidentBytes   int64 `json:"resident_bytes"`
+	SwappedBytes    int64 `json:"swapped_bytes"`
+	PeakBytes       int64 `json:"peak_bytes"`
+	PagesSwappedOut int64 `json:"pages_swapped_out"`
+	PagesSwappedIn  int64 `json:"pages_swapped_in"`
+	OOMKills        int64 `json:"oom_kills"`
+}
+
+// defaultMemoryCapacityConfig limits the instance to the profile's capacity and,
+// when the profile enables swap, gives it swap of the same size up to 8GB
+func (mem *MemoryEngine) defaultMemoryCapacityConfig() MemoryCapacityConfig {
+	const gb = int64(1 << 30)
+	config := MemoryCapacityConfig{
+		LimitBytes:  mem.CapacityGB * gb,
+		PageBytes:   4096,
+		SwapLatency: 100 * time.Microsecond,
+	}
+	if mem.VirtualMemoryState.SwapEnabled {
+		config.SwapBytes = min(config.LimitBytes, 8*gb)
+		if mem.VirtualMemoryState.SwapLatency > 0 {
+			config.SwapLatency = time.Duration(mem.VirtualMemoryState.SwapLatency * float64(time.Nanosecond))
+		}
+	}
+	return config
+}
+
+// loadProfile overrides capacity settings present in a profile's capacity section
+func (config *MemoryCapacityConfig) loadProfile(settings map[string]interface{}) {
+	const mb = 1 << 20
+	if val, ok := settings["memory_limit_mb"].(float64); ok && val > 0 {
+		config.LimitBytes = int64(val * mb)
+	}
+	if val, ok := settings["swap_mb"].(float64); ok && val >= 0 {
+		config.SwapBytes = int64(val * mb)
+	}
+	if val, ok := settings["page_kb"].(float64); ok && val > 0 {
+		config.PageBytes = int64(val * 1024)
+	}
+	if val, ok := settings["swap_latency_us"].(float64); ok && val >= 0 {
+		config.SwapLatency = time.Duration(val * float64(time.Microsecond))
+	}
+}
+
+// AttachSwapDevice makes the memory engine page to a storage engine, paying its latency
+func (mem *MemoryEngine) AttachSwapDevice(device SwapDevice) {
+	mem.SwapDevice = device
+}
+
+// applyCapacityEffects updates the resident set for an operation and returns
+// the time it spends swapping, or a *MemoryError when it gets the process killed
+func (mem *MemoryEngine) applyCapacityEffects(op *Operation) (time.Duration, error) {
+	config, set := mem.Capacity, &mem.ResidentSet
+	if config.LimitBytes <= 0 {
+		return 0, nil
+	}
+
+	var swapTime time.Duration
+	switch op.Type {
+	case OpMemoryAlloc, "memory_allocate":
+		if set.AllocatedBytes+op.DataSize > config.LimitBytes+config.SwapBytes {
+			err := &MemoryError{
+				Code:           MemoryErrorOOMKilled,
+				OperationID:    op.ID,
+				RequestedBytes: op.DataSize,
+				AllocatedBytes: set.AllocatedBytes,
+				LimitBytes:     config.LimitBytes + config.SwapBytes,
+			}
+			mem.oomKill()
+			return 0, err
+		}
+		set.AllocatedBytes += op.DataSize
+		set.PeakBytes = max(set.PeakBytes, set.AllocatedBytes)
+
+		// Direct reclaim: the allocation waits while older pages are written to swap
+		if overflow := set.AllocatedBytes - set.SwappedBytes - config.LimitBytes; overflow > 0 {
+			set.SwappedBytes += overflow
+			set.PagesSwappedOut += (overflow + config.PageBytes - 1) / config.PageBytes
+			swapTime += mem.swapLatency(overflow, true)
+		}
+
+	case OpMemoryFree, "memory_deallocate":
+		freed := min(op.DataSize, set.AllocatedBytes)
+		if set.AllocatedBytes > 0 {
+			// Freed memory is resident or swapped in the same proportion as the rest
+			set.SwappedBytes -= int64(float64(freed) * float64(set.SwappedBytes) / float64(set.AllocatedBytes))
+		}
+		set.AllocatedBytes -= freed
+
+	default:
+		// Pages touched by an access fault in with the chance they were swapped out
+		if set.SwappedBytes > 0 && set.AllocatedBytes > 0 {
+			pages := max((op.DataSize+config.PageBytes-1)/config.PageBytes, 1)
+			expected := float64(pages) * float64(set.SwappedBytes) / float64(set.AllocatedBytes)
+			faults := int64(expected)
+			if mem.randomFloat64() < expected-float64(faults) {
+				faults++
+			}
+			if faults > 0 {
+				// Faulted pages are read back in; kswapd writes cold pages out in the background
+				set.PagesSwappedIn += faults
+				swapTime += mem.swapLatency(faults*config.PageBytes, false)
+			}
+		}
+	}
+
+	set.ResidentBytes = set.AllocatedBytes - set.SwappedBytes
+	mem.PressureState.CurrentUsageGB = float64(set.ResidentBytes) / float64(1<<30)
+	return swapTime, nil
+}
+
+// swapLatency returns the time to move bytes to or from swap
+func (mem *MemoryEngine) swapLatency(bytes int64, write bool) time.Duration {
+	if mem.SwapDevice != nil {
+		return mem.SwapDevice.SwapLatency(bytes, write)
+	}
+	ios := math.Ceil(float64(bytes) / float64(32*1024)) // Swap clusters of eight pages
+	return time.Duration(ios * float64(mem.Capacity.SwapLatency))
+}
+
+// oomKill ends the instance's process: its memory and its runtime's heap are released
+func (mem *MemoryEngine) oomKill() {
+	mem.ResidentSet = ResidentSet{
+		PeakBytes:       mem.ResidentSet.PeakBytes,
+		PagesSwappedOut: mem.ResidentSet.PagesSwappedOut,
+		PagesSwappedIn:  mem.ResidentSet.PagesSwappedIn,
+		OOMKills:        mem.ResidentSet.OOMKills + 1,
+	}
+	mem.PressureState.CurrentUsageGB = 0
+	if mem.Runtime != nil {
+		mem.Runtime.restart()
+	}
+}
+
+// GetResidentSet returns the instance's memory usage
+func (mem *MemoryEngine) GetResidentSet() ResidentSet {
+	return mem.ResidentSet
+}
+
+// SwapLatency returns the time to page bytes to or from this device: one I/O
+// per swap cluster, at the profile's latency plus transfer time
+func (storage *StorageEngine) SwapLatency(bytes int64, write bool) time.Duration {
+	const cluster = 32 * 1024
+	opType := OpStorageRead
+	if write {
+		opType = OpStorageWrite
+	}
+
+	ios := (bytes + cluster - 1) / cluster
+	perIO := storage.calculateBaseStorageAccessTime(&Operation{Type: opType, DataSize: min(bytes, cluster)})
+	return time.Duration(ios) * perIO
+}
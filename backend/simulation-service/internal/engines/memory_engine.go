@@ -34,6 +34,7 @@ type MemoryProcessingOperation struct {
 	CompletionTick int64            `json:"completion_tick"`
 	ChannelsUsed   int              `json:"channels_used"`
 	AccessPattern  string           `json:"access_pattern"` // sequential, random, stride
+	Error          error            `json:"-"`              // Set when the operation got the process OOM-killed
 }
 
 // MemoryOrderingOp represents an operation in the memory ordering window
@@ -86,12 +87,21 @@ type MemoryEngine struct {
 	ActiveOperations *MemoryProcessingHeap `json:"active_operations"`
 	BusyChannels     int                   `json:"busy_channels"`
 
+	// Language runtime whose heap this engine's allocations grow; its GC pauses stall in-flight operations
+	Runtime       *RuntimeGC         `json:"-"`
+	RuntimeCursor RuntimePauseCursor `json:"runtime_cursor"`
+
+	// Resident set against RAM and swap; swap I/O pays the attached storage engine's latency
+	Capacity    MemoryCapacityConfig `json:"capacity"`
+	ResidentSet ResidentSet          `json:"resident_set"`
+	SwapDevice  SwapDevice           `json:"-"`
+
 	// Memory timing state (realistic DDR modeling)
 	TimingState struct {
-		tRCD            int     `json:"trcd"`             // RAS to CAS delay (from profile)
-		tRP             int     `json:"trp"`              // Row precharge time (from profile)
-		tRAS            int     `json:"tras"`             // Row active time (from profile)
-		tREFI           int     `json:"trefi"`            // Refresh interval (from profile)
+		tRCD            int // RAS to CAS delay (from profile)
+		tRP             int // Row precharge time (from profile)
+		tRAS            int // Row active time (from profile)
+		tREFI           int // Refresh interval (from profile)
 		BankGroups      int     `json:"bank_groups"`      // Number of bank groups (from profile)
 		BanksPerGroup   int     `json:"banks_per_group"`  // Banks per group (from profile)
 		RowBufferHitRate float64 `json:"row_buffer_hit_rate"` // Statistical hit rate
@@ -361,11 +371,23 @@ func (mem *MemoryEngine) ProcessOperation(op *Operation, currentTick int64) *Ope
 		pressureAdjustedTime = mem.applyMemoryPressure(numaAdjustedTime)
 	}
 
-	// Apply garbage collection effects (if enabled)
-	gcAdjustedTime := pressureAdjustedTime
+	// Track the resident set against RAM and swap (if enabled); swap I/O is added
+	// once the access time is known
+	var swapTime time.Duration
+	var capacityErr error
+	if mem.ComplexityInterface.ShouldEnableFeature("capacity_tracking") {
+		swapTime, capacityErr = mem.applyCapacityEffects(op)
+	}
+
+	// Apply garbage collection effects (if enabled): allocations grow the runtime's
+	// heap, and its pauses are added once the access time is known
 	if mem.ComplexityInterface.ShouldEnableFeature("garbage_collection") {
-		gcAdjustedTime = mem.applyGarbageCollectionEffects(pressureAdjustedTime, op)
+		mem.applyGarbageCollectionEffects(op)
 	}
+	if capacityErr == nil {
+		mem.recordRuntimeOperation(op, currentTick)
+	}
+	gcAdjustedTime := pressureAdjustedTime
 
 	// Apply memory fragmentation effects (if enabled)
 	fragmentationAdjustedTime := gcAdjustedTime
@@ -431,6 +453,11 @@ func (mem *MemoryEngine) ProcessOperation(op *Operation, currentTick int64) *Ope
 	utilization := mem.calculateCurrentUtilization()
 	finalTime := mem.ApplyCommonPerformanceFactors(thermalAdjustedTime, utilization)
 
+	// Add swap I/O, then stop for any GC pause of the runtime under way while the operation runs
+	finalTime += swapTime
+	gcStall := mem.runtimeStall(currentTick, finalTime, math.MaxInt64)
+	finalTime += gcStall
+
 	// Update dynamic state tracking (if enabled)
 	if mem.ComplexityInterface.ShouldEnableFeature("dynamic_behavior") {
 		mem.updateMemoryState(op, finalTime)
@@ -486,7 +513,8 @@ func (mem *MemoryEngine) ProcessOperation(op *Operation, currentTick int64) *Ope
 		OperationID:    op.ID,
 		ProcessingTime: finalTime,
 		CompletedTick:  currentTick + mem.DurationToTicks(finalTime),
-		Success:        true,
+		Success:        capacityErr == nil,
+		Error:          capacityErr,
 		PenaltyInfo: &PenaltyInformation{
 			EngineType:           MemoryEngineType,
 			EngineID:            mem.ID,
@@ -524,6 +552,16 @@ func (mem *MemoryEngine) ProcessOperation(op *Operation, currentTick int64) *Ope
 			"row_buffer_hit_rate":  mem.TimingState.RowBufferHitRate,
 		},
 	}
+	if gcStall > 0 {
+		result.Metrics["gc_pause_ms"] = float64(gcStall) / float64(time.Millisecond)
+	}
+	if mem.ComplexityInterface.ShouldEnableFeature("capacity_tracking") {
+		result.Metrics["resident_mb"] = float64(mem.ResidentSet.ResidentBytes) / float64(1<<20)
+		result.Metrics["swapped_mb"] = float64(mem.ResidentSet.SwappedBytes) / float64(1<<20)
+		if swapTime > 0 {
+			result.Metrics["swap_ms"] = float64(swapTime) / float64(time.Millisecond)
+		}
+	}
 
 	// Update operation history for convergence
 	mem.AddOperationToHistory(finalTime)
@@ -532,11 +570,23 @@ func (mem *MemoryEngine) ProcessOperation(op *Operation, currentTick int64) *Ope
 	return result
 }
 
+// NextEventTick returns the earliest completion tick in the processing heap, or the
+// next tick if operations are waiting in the queue
+func (mem *MemoryEngine) NextEventTick() (int64, bool) {
+	if mem.ActiveOperations == nil || mem.ActiveOperations.Len() == 0 {
+		return mem.nextEventTick(0, false)
+	}
+	return mem.nextEventTick((*mem.ActiveOperations)[0].CompletionTick, true)
+}
+
 // ProcessTick processes one simulation tick (SIMPLIFIED like CPU engine)
 func (mem *MemoryEngine) ProcessTick(currentTick int64) []OperationResult {
 	mem.CurrentTick = currentTick
 	results := make([]OperationResult, 0)
 
+	// STEP 0: Hold in-flight operations through any new GC pause
+	mem.applyRuntimePauses()
+
 	// STEP 1: Check for completed operations and move to output (like CPU engine)
 	completedOps := mem.checkCompletedOperations(currentTick)
 	results = append(results, completedOps...)
@@ -571,7 +621,8 @@ func (mem *MemoryEngine) checkCompletedOperations(currentTick int64) []Operation
 				CompletedAt:    currentTick,
 				CompletedTick:  currentTick,
 				ProcessingTime: time.Duration(completedOp.CompletionTick - completedOp.StartTick) * mem.TickDuration,
-				Success:        true,
+				Success:        completedOp.Error == nil,
+				Error:          completedOp.Error,
 				NextComponent:  completedOp.Operation.Operation.NextComponent, // For routing
 			}
 			completed = append(completed, result)
@@ -629,6 +680,13 @@ func (mem *MemoryEngine) startNewOperationsFromQueue(currentTick int64) {
 func (mem *MemoryEngine) startProcessing(queuedOp *QueuedOperation, currentTick int64) {
 	// Calculate processing time using existing logic
 	processingTime := mem.calculateProcessingTimeForOperation(queuedOp.Operation)
+	var capacityErr error
+	if mem.ComplexityInterface.ShouldEnableFeature("capacity_tracking") {
+		var swapTime time.Duration
+		swapTime, capacityErr = mem.applyCapacityEffects(queuedOp.Operation)
+		processingTime += swapTime
+	}
+	processingTime += mem.runtimeStall(currentTick, processingTime, mem.RuntimeCursor.Seq)
 	channelsNeeded := mem.calculateChannelsNeeded(queuedOp.Operation)
 	completionTick := currentTick + mem.DurationToTicks(processingTime)
 
@@ -639,11 +697,21 @@ func (mem *MemoryEngine) startProcessing(queuedOp *QueuedOperation, currentTick
 		CompletionTick: completionTick,
 		ChannelsUsed:   channelsNeeded,
 		AccessPattern:  mem.determineAccessPattern(queuedOp.Operation),
+		Error:          capacityErr,
 	}
 
 	// Add to processing heap
 	heap.Push(mem.ActiveOperations, activeOp)
 	mem.BusyChannels += channelsNeeded
+
+	// Allocations grow the runtime's heap; a collection they trigger stalls
+	// in-flight operations from the next tick
+	if mem.ComplexityInterface.ShouldEnableFeature("garbage_collection") {
+		mem.applyGarbageCollectionEffects(queuedOp.Operation)
+	}
+	if capacityErr == nil {
+		mem.recordRuntimeOperation(queuedOp.Operation, currentTick)
+	}
 }
 
 // DurationToTicks converts a duration to number of ticks (like CPU engine)
@@ -889,7 +957,8 @@ func (mem *MemoryEngine) hashOperationForMemoryDecision(op *Operation) uint32 {
 	// Include current tick for temporal variation
 	hash = hash*31 + uint32(mem.CurrentTick%1000)
 
-	return hash
+	// Seeded runs get their own decision sequence
+	return hash ^ seedHashMix(mem.Seed)
 }
 
 // calculateChannelsNeeded determines how many memory channels an operation needs (CONSERVATIVE like CPU cores)
@@ -1070,6 +1139,15 @@ func (mem *MemoryEngine) loadMemorySpecificProfile() error {
 		if memType, ok := mem.Profile.TechnologySpecs["memory_type"].(string); ok {
 			mem.MemoryType = memType
 		}
+		// Built-in profiles list their channel count with the technology specs
+		if mem.Channels <= 0 {
+			switch channels := mem.Profile.TechnologySpecs["channels"].(type) {
+			case int:
+				mem.Channels = channels
+			case float64:
+				mem.Channels = int(channels)
+			}
+		}
 	}
 
 	// Load engine-specific configurations
@@ -1077,6 +1155,12 @@ func (mem *MemoryEngine) loadMemorySpecificProfile() error {
 		mem.loadEngineSpecificConfigs()
 	}
 
+	// Capacity follows the profile's RAM and swap settings unless it sets its own
+	mem.Capacity = mem.defaultMemoryCapacityConfig()
+	if capacity, ok := mem.Profile.EngineSpecific["capacity"].(map[string]interface{}); ok {
+		mem.Capacity.loadProfile(capacity)
+	}
+
 	return nil
 }
 
@@ -1594,33 +1678,16 @@ func (mem *MemoryEngine) GetComplexityLevel() int {
 	return int(mem.ComplexityInterface.ComplexityLevel)
 }
 
-// applyGarbageCollectionEffects applies language-specific garbage collection effects
-func (mem *MemoryEngine) applyGarbageCollectionEffects(baseTime time.Duration, op *Operation) time.Duration {
-	// Language-specific GC modeling based on operation language
-	switch op.Language {
-	case "java":
-		// Java G1GC: ~8ms per GB of heap pressure
-		heapPressure := mem.calculateHeapPressure()
-		if heapPressure > 0.7 {
-			gcPause := time.Duration(heapPressure * 8.0 * float64(time.Millisecond))
-			return baseTime + gcPause
-		}
-	case "go":
-		// Go GC: ~0.5ms per GB, more frequent but shorter
-		heapPressure := mem.calculateHeapPressure()
-		if heapPressure > 0.8 {
-			gcPause := time.Duration(heapPressure * 0.5 * float64(time.Millisecond))
-			return baseTime + gcPause
-		}
-	case "csharp":
-		// .NET GC: ~3ms per GB
-		heapPressure := mem.calculateHeapPressure()
-		if heapPressure > 0.75 {
-			gcPause := time.Duration(heapPressure * 3.0 * float64(time.Millisecond))
-			return baseTime + gcPause
-		}
+// applyGarbageCollectionEffects gives the engine a collector for the operation's
+// language when the component instance did not attach one, so standalone memory
+// engines still model the runtime of the first garbage-collected language they see
+func (mem *MemoryEngine) applyGarbageCollectionEffects(op *Operation) {
+	if mem.Runtime != nil {
+		return
+	}
+	if runtime := RuntimeForLanguage(op.Language); runtime != "" {
+		mem.AttachRuntime(NewRuntimeGC(runtime))
 	}
-	return baseTime
 }
 
 // applyMemoryFragmentationEffects applies heap fragmentation effects
@@ -1637,19 +1704,6 @@ func (mem *MemoryEngine) applyMemoryFragmentationEffects(baseTime time.Duration,
 	return baseTime
 }
 
-// calculateHeapPressure calculates current heap pressure for GC modeling
-func (mem *MemoryEngine) calculateHeapPressure() float64 {
-	utilization := mem.calculateCurrentUtilization()
-
-	// Heap pressure is higher than general utilization due to allocation patterns
-	heapPressure := utilization * 1.2 // 20% higher than general utilization
-	if heapPressure > 1.0 {
-		heapPressure = 1.0
-	}
-
-	return heapPressure
-}
-
 // ========================================
 // PRIORITY 1 CRITICAL FEATURES IMPLEMENTATION
 // ========================================
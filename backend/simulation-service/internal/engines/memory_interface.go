@@ -14,6 +14,7 @@ type MemoryFeatures struct {
 	EnableDDRTimingEffects    bool `json:"enable_ddr_timing_effects"`    // Row buffer hits/misses, bank conflicts
 	EnableBandwidthSaturation bool `json:"enable_bandwidth_saturation"`  // Memory controller limits
 	EnableBasicNUMA           bool `json:"enable_basic_numa"`             // Cross-socket penalties
+	EnableCapacityTracking    bool `json:"enable_capacity_tracking"`      // Resident set, swap and OOM-kill (all levels)
 	
 	// Advanced memory modeling (Advanced+)
 	EnableMemoryPressure      bool `json:"enable_memory_pressure"`       // RAM usage impact, swap pressure
@@ -94,6 +95,7 @@ func (mi *MemoryInterface) configureMinimalFeatures() {
 		EnableDDRTimingEffects:    true,  // DDR timing is essential for real-world accuracy
 		EnableBandwidthSaturation: true,  // Keep basic bandwidth limits
 		EnableBasicNUMA:           true,  // NUMA is essential in modern systems
+		EnableCapacityTracking:    true,
 		
 		// Disable all advanced features
 		EnableMemoryPressure:      false,
@@ -127,6 +129,7 @@ func (mi *MemoryInterface) configureBasicFeatures() {
 		EnableDDRTimingEffects:    true,  // DDR timing is essential
 		EnableBandwidthSaturation: true,  // Bandwidth limits
 		EnableBasicNUMA:           true,  // NUMA penalties
+		EnableCapacityTracking:    true,
 
 		// Important real-world features
 		EnableMemoryPressure:     true,  // Memory pressure effects
@@ -162,6 +165,7 @@ func (mi *MemoryInterface) configureAdvancedFeatures() {
 		EnableDDRTimingEffects:    true,
 		EnableBandwidthSaturation: true,
 		EnableBasicNUMA:           true,
+		EnableCapacityTracking:    true,
 
 		// Enhanced real-world features
 		EnableMemoryPressure:     true,
@@ -197,6 +201,7 @@ func (mi *MemoryInterface) configureMaximumFeatures() {
 		EnableDDRTimingEffects:    true,
 		EnableBandwidthSaturation: true,
 		EnableBasicNUMA:           true,
+		EnableCapacityTracking:    true,
 		EnableMemoryPressure:     true,
 		EnableAccessPatterns:     true,
 		EnableChannelUtilization: true,
@@ -230,6 +235,8 @@ func (mi *MemoryInterface) ShouldEnableFeature(feature string) bool {
 		return mi.Features.EnableBandwidthSaturation
 	case "basic_numa":
 		return mi.Features.EnableBasicNUMA
+	case "capacity_tracking":
+		return mi.Features.EnableCapacityTracking
 	case "memory_pressure":
 		return mi.Features.EnableMemoryPressure
 	case "access_patterns":
@@ -0,0 +1,352 @@
+package engines
+
+import (
+	"math"
+	"time"
+)
+
+// TCP congestion control per connection. Each connection and direction keeps its
+// own congestion window in segments. A transfer is sent one window per round
+// trip. While the window is smaller than the bandwidth-delay product the sender
+// idles waiting for ACKs, so short-lived and lossy connections never reach line
+// rate. Loss detected in a round backs the window off according to the
+// connection's algorithm.
+
+// Congestion control algorithms
+const (
+	CongestionAlgorithmReno  = "reno"  // Halve on loss, +1 segment per round trip
+	CongestionAlgorithmCubic = "cubic" // Cubic regrowth toward the window before loss (Linux default)
+	CongestionAlgorithmBBR   = "bbr"   // Paces at the estimated bottleneck, ignores random loss
+)
+
+// CUBIC constants from RFC 8312
+const (
+	cubicScalingFactor = 0.4 // C
+	cubicBeta          = 0.7 // Multiplicative decrease factor
+)
+
+// TCPCongestionConfig is loaded from a profile's congestion_behavior section.
+// Window sizes are in segments.
+type TCPCongestionConfig struct {
+	Algorithm             string `json:"algorithm"`
+	InitialWindowSegments int    `json:"congestion_window_initial"`
+	SlowStartThreshold    int    `json:"slow_start_threshold"`
+	MaxWindowSegments     int    `json:"congestion_window_max"`
+	AvoidanceIncrement    int    `json:"congestion_avoidance_increment"`
+	FastRecovery          bool   `json:"fast_recovery_enabled"`
+}
+
+// defaultTCPCongestionConfig returns Linux defaults: CUBIC with a 10 segment initial window
+func defaultTCPCongestionConfig() TCPCongestionConfig {
+	return TCPCongestionConfig{
+		Algorithm:             CongestionAlgorithmCubic,
+		InitialWindowSegments: 10,
+		SlowStartThreshold:    65535,
+		MaxWindowSegments:     65535,
+		AvoidanceIncrement:    1,
+		FastRecovery:          true,
+	}
+}
+
+// loadProfile overrides congestion settings present in a profile's congestion_behavior section
+func (config *TCPCongestionConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["algorithm"].(string); ok {
+		config.Algorithm = val
+	}
+	if val, ok := settings["congestion_window_initial"].(float64); ok && val > 0 {
+		config.InitialWindowSegments = int(val)
+	}
+	if val, ok := settings["slow_start_threshold"].(float64); ok && val > 0 {
+		config.SlowStartThreshold = int(val)
+	}
+	if val, ok := settings["congestion_window_max"].(float64); ok && val > 0 {
+		config.MaxWindowSegments = int(val)
+	}
+	if val, ok := settings["congestion_avoidance_increment"].(float64); ok && val > 0 {
+		config.AvoidanceIncrement = int(val)
+	}
+	if val, ok := settings["fast_recovery_enabled"].(bool); ok {
+		config.FastRecovery = val
+	}
+}
+
+// TCPConnection is the congestion state of one direction of a TCP connection
+type TCPConnection struct {
+	ID                 string           `json:"id"`
+	Direction          NetworkDirection `json:"direction"`
+	Algorithm          string           `json:"algorithm"`
+	CongestionWindow   float64          `json:"congestion_window"`    // Segments in flight per round trip
+	SlowStartThreshold float64          `json:"slow_start_threshold"` // Segments
+	SlowStart          bool             `json:"slow_start"`
+
+	// CUBIC epoch: the window the curve is centred on (the window before the last
+	// reduction), time to regrow to it, and time spent regrowing so far
+	WindowBeforeLoss float64       `json:"window_before_loss"`
+	CubicK           time.Duration `json:"cubic_k"`
+	EpochElapsed     time.Duration `json:"epoch_elapsed"`
+
+	// RTT estimate driving the retransmission timeout
+	SmoothedRTT time.Duration `json:"smoothed_rtt"`
+	RTTVariance time.Duration `json:"rtt_variance"`
+
+	RoundTrips     int64 `json:"round_trips"`
+	LossEvents     int64 `json:"loss_events"`
+	Retransmits    int64 `json:"retransmits"`
+	SegmentsSent   int64 `json:"segments_sent"`
+	LastActiveTick int64 `json:"last_active_tick"`
+}
+
+// TCPTransfer summarises how congestion control shaped one transfer
+type TCPTransfer struct {
+	Segments     int64         `json:"segments"`
+	RoundTrips   int64         `json:"round_trips"`
+	LossEvents   int64         `json:"loss_events"`
+	AckWait      time.Duration `json:"ack_wait"`      // Time the sender sat idle on a small window
+	RecoveryTime time.Duration `json:"recovery_time"` // Time spent retransmitting lost segments
+	Retransmits  int64         `json:"retransmits"`
+	StartWindow  float64       `json:"start_window"`
+	EndWindow    float64       `json:"end_window"`
+	Err          error         `json:"-"` // Set when a lost segment could not be recovered
+}
+
+// tcpConnection returns the connection an operation travels on, opening it with
+// the initial window on first use. Operations without "connection_id" metadata
+// use their HTTP connection when they have one, otherwise they share one
+// long-lived connection per direction. A connection idle for longer
+// than its retransmission timeout restarts from slow start (RFC 2861).
+func (network *NetworkEngine) tcpConnection(op *Operation, link *NetworkLinkState) *TCPConnection {
+	id, _ := op.Metadata["connection_id"].(string)
+	if request, ok := network.CurrentRequests[op.ID]; ok && id == "" {
+		id = request.connectionID()
+	}
+	key := string(link.Direction) + ":" + id
+
+	config := network.Congestion
+	conn, ok := network.TCPConnections[key]
+	if !ok {
+		conn = &TCPConnection{
+			ID:                 id,
+			Direction:          link.Direction,
+			Algorithm:          config.Algorithm,
+			CongestionWindow:   float64(config.InitialWindowSegments),
+			SlowStartThreshold: float64(config.SlowStartThreshold),
+			SlowStart:          true,
+			LastActiveTick:     network.CurrentTick,
+		}
+		network.TCPConnections[key] = conn
+		return conn
+	}
+
+	if network.CurrentTick-conn.LastActiveTick > network.DurationToTicks(network.retransmissionTimeout(conn, network.roundTripTime(op))) {
+		conn.CongestionWindow = math.Min(conn.CongestionWindow, float64(config.InitialWindowSegments))
+		conn.SlowStart = true
+	}
+	return conn
+}
+
+// roundTripTime returns the connection's RTT: the routed path when the operation
+// was routed through the topology, otherwise the engine's base and propagation latency
+func (network *NetworkEngine) roundTripTime(op *Operation) time.Duration {
+	if op != nil {
+		if flow, ok := network.RouteFlows[op.ID]; ok {
+			return 2 * time.Duration(flow.Route.LatencyMs*float64(time.Millisecond))
+		}
+	}
+	oneWay := time.Duration(network.BaseLatencyMs*float64(time.Millisecond)) + network.GeographicState.PropagationDelay
+	return 2 * oneWay
+}
+
+// maxSegmentBytes returns the TCP payload per packet on one side of the link
+func (network *NetworkEngine) maxSegmentBytes(link *NetworkLinkState) int {
+	mss := link.Offload.MTUBytes - 40 // IP + TCP headers
+	if mss <= 0 {
+		return 1460
+	}
+	return mss
+}
+
+// applyCongestionControl adds the time a transfer spends waiting on its
+// congestion window and retransmitting lost segments. Serialization at the link's
+// bandwidth is already in baseTime; a window that fills the pipe adds nothing.
+func (network *NetworkEngine) applyCongestionControl(baseTime time.Duration, op *Operation, link *NetworkLinkState) time.Duration {
+	if op.DataSize <= 0 || network.Protocol == "UDP" {
+		return baseTime
+	}
+
+	conn := network.tcpConnection(op, link)
+	transfer := network.sendOverConnection(conn, op, link)
+	network.CurrentTransfers[op.ID] = transfer
+
+	conn.LastActiveTick = network.CurrentTick + network.DurationToTicks(baseTime+transfer.AckWait+transfer.RecoveryTime)
+	return baseTime + transfer.AckWait + transfer.RecoveryTime
+}
+
+// sendOverConnection sends an operation's segments one congestion window per
+// round trip, checking each round for loss. The transfer stops at the first
+// segment that cannot be recovered.
+func (network *NetworkEngine) sendOverConnection(conn *TCPConnection, op *Operation, link *NetworkLinkState) *TCPTransfer {
+	mss := network.maxSegmentBytes(link)
+	rtt := network.roundTripTime(op)
+
+	// Segments the path holds in flight: the bandwidth-delay product
+	bandwidthMbps := network.effectiveBandwidthMbps(link)
+	if flow, ok := network.RouteFlows[op.ID]; ok && flow.Route.BottleneckMbps > 0 {
+		bandwidthMbps = math.Min(bandwidthMbps, flow.Route.BottleneckMbps)
+	}
+	bdpSegments := bandwidthMbps * 1e6 / 8 * rtt.Seconds() / float64(mss)
+
+	// Streams multiplexed on the connection split its window and the pipe evenly
+	streams := 1.0
+	if request, ok := network.CurrentRequests[op.ID]; ok {
+		streams += float64(request.StreamsInFlight)
+	}
+	bdpSegments /= streams
+
+	transfer := &TCPTransfer{
+		Segments:    (op.DataSize + int64(mss) - 1) / int64(mss),
+		StartWindow: conn.CongestionWindow,
+	}
+
+	for remaining := transfer.Segments; remaining > 0; {
+		window := math.Max(1, math.Floor(conn.CongestionWindow/streams))
+		sent := int64(math.Min(window, float64(remaining)))
+		remaining -= sent
+
+		transfer.RoundTrips++
+		conn.RoundTrips++
+		conn.SegmentsSent += sent
+
+		// A window smaller than the pipe leaves the sender idle until ACKs return
+		if remaining > 0 && window < bdpSegments {
+			transfer.AckWait += time.Duration(float64(rtt) * (1 - window/bdpSegments))
+		}
+
+		if network.checkWindowLoss(link, sent) {
+			transfer.LossEvents++
+			conn.LossEvents++
+			network.onCongestionLoss(conn)
+
+			// Three segments behind the lost one trigger a fast retransmit
+			recovery, attempts, err := network.retransmit(op, conn, link, rtt, window >= 4)
+			transfer.RecoveryTime += recovery
+			transfer.Retransmits += int64(attempts)
+			conn.Retransmits += int64(attempts)
+			if err != nil {
+				transfer.Err = err
+				break
+			}
+		} else {
+			conn.observeRTT(rtt)
+			network.onRoundTrip(conn, rtt, bdpSegments)
+		}
+	}
+
+	transfer.EndWindow = conn.CongestionWindow
+	return transfer
+}
+
+// checkWindowLoss checks whether a round of segments lost at least one packet,
+// from link saturation (checkPacketLoss) or from the line's base loss rate
+func (network *NetworkEngine) checkWindowLoss(link *NetworkLinkState, segments int64) bool {
+	if link.PacketLossProbability > 0 && network.checkPacketLoss(link) {
+		return true
+	}
+	if network.PacketLossRate <= 0 {
+		return false
+	}
+	return network.randomFloat64() < 1-math.Pow(1-network.PacketLossRate, float64(segments))
+}
+
+// onRoundTrip grows the window after a round trip without loss
+func (network *NetworkEngine) onRoundTrip(conn *TCPConnection, rtt time.Duration, bdpSegments float64) {
+	config := network.Congestion
+	maxWindow := float64(config.MaxWindowSegments)
+
+	switch conn.Algorithm {
+	case CongestionAlgorithmBBR:
+		// Startup doubles the sending rate until the pipe is full, then ProbeBW
+		// keeps twice the bandwidth-delay product in flight
+		target := math.Max(2*bdpSegments, float64(config.InitialWindowSegments))
+		if conn.SlowStart {
+			conn.CongestionWindow = math.Min(conn.CongestionWindow*2, target)
+			conn.SlowStart = conn.CongestionWindow < target
+		} else {
+			conn.CongestionWindow = target
+		}
+
+	default:
+		if conn.SlowStart {
+			// Slow start doubles the window each round trip up to ssthresh
+			conn.CongestionWindow = math.Min(conn.CongestionWindow*2, conn.SlowStartThreshold)
+			if conn.CongestionWindow >= conn.SlowStartThreshold {
+				conn.SlowStart = false
+				if conn.WindowBeforeLoss == 0 {
+					// No loss yet: the curve starts convex from the current window
+					conn.WindowBeforeLoss, conn.CubicK, conn.EpochElapsed = conn.CongestionWindow, 0, 0
+				}
+			}
+		} else if conn.Algorithm == CongestionAlgorithmCubic {
+			// W(t) = C(t-K)^3 + Wmax: fast regrowth toward the window before loss,
+			// a plateau around it, then probing beyond
+			conn.EpochElapsed += rtt
+			t := (conn.EpochElapsed - conn.CubicK).Seconds()
+			cubic := cubicScalingFactor*math.Pow(t, 3) + conn.WindowBeforeLoss
+
+			// Never grow slower than Reno would with the same backoff (the TCP-friendly region)
+			rounds := float64(conn.EpochElapsed) / float64(rtt)
+			friendly := conn.WindowBeforeLoss*cubicBeta + 3*(1-cubicBeta)/(1+cubicBeta)*float64(config.AvoidanceIncrement)*rounds
+			conn.CongestionWindow = math.Max(cubic, friendly)
+		} else {
+			// Reno congestion avoidance: additive increase per round trip
+			conn.CongestionWindow += float64(config.AvoidanceIncrement)
+		}
+	}
+
+	conn.CongestionWindow = math.Min(conn.CongestionWindow, maxWindow)
+}
+
+// onCongestionLoss backs the window off after a round with loss. Reno halves it
+// and CUBIC keeps 70%; without fast recovery both fall back to one segment. BBR
+// treats random loss as noise and keeps pacing at the bottleneck rate.
+func (network *NetworkEngine) onCongestionLoss(conn *TCPConnection) {
+	if conn.Algorithm == CongestionAlgorithmBBR {
+		return
+	}
+
+	beta := 0.5
+	if conn.Algorithm == CongestionAlgorithmCubic {
+		beta = cubicBeta
+		conn.WindowBeforeLoss = conn.CongestionWindow
+		conn.CubicK = time.Duration(math.Cbrt(conn.CongestionWindow*(1-cubicBeta)/cubicScalingFactor) * float64(time.Second))
+		conn.EpochElapsed = 0
+	}
+
+	conn.SlowStartThreshold = math.Max(conn.CongestionWindow*beta, 2)
+	conn.SlowStart = false
+	if network.Congestion.FastRecovery {
+		conn.CongestionWindow = conn.SlowStartThreshold
+	} else {
+		conn.CongestionWindow = 1
+		conn.SlowStart = true
+	}
+}
+
+// pruneTCPConnections drops connections idle past their retransmission timeout.
+// They would restart from slow start anyway, so keeping them only costs memory.
+func (network *NetworkEngine) pruneTCPConnections() {
+	rtt := network.roundTripTime(nil)
+	for key, conn := range network.TCPConnections {
+		if network.CurrentTick-conn.LastActiveTick > network.DurationToTicks(network.retransmissionTimeout(conn, rtt)) {
+			delete(network.TCPConnections, key)
+		}
+	}
+}
+
+// GetTCPConnections returns a snapshot of each tracked connection's congestion state
+func (network *NetworkEngine) GetTCPConnections() map[string]TCPConnection {
+	connections := make(map[string]TCPConnection, len(network.TCPConnections))
+	for key, conn := range network.TCPConnections {
+		connections[key] = *conn
+	}
+	return connections
+}
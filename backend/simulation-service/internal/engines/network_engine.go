@@ -3,7 +3,6 @@ package engines
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"time"
 )
 
@@ -21,23 +20,48 @@ type NetworkEngine struct {
 	BandwidthMbps     int     `json:"bandwidth_mbps"`
 	BaseLatencyMs     float64 `json:"base_latency_ms"`
 	MaxConnections    int     `json:"max_connections"`
-	Protocol          string  `json:"protocol"`          // TCP, UDP, HTTP/1.1, HTTP/2, gRPC
+	Protocol          string  `json:"protocol"`          // TCP, UDP, HTTP/1.1, HTTP/2, gRPC, HTTP/3 (QUIC)
 	GeographicDistance float64 `json:"geographic_distance"` // kilometers (legacy)
 	NetworkType       string  `json:"network_type"`      // LAN, WAN, Internet
 
 	// Graph-based topology (NEW)
 	NetworkTopology   *NetworkTopology `json:"network_topology"`
 	CurrentNodeID     string          `json:"current_node_id"`     // This engine's node ID
+	RoutingMetric     string          `json:"routing_metric"`      // latency or hops
+
+	// Per-edge bandwidth sharing between routed transmissions
+	EdgeStates map[string]*NetworkEdgeState `json:"edge_states"`
+	RouteFlows map[string]*NetworkFlow      `json:"route_flows"`
+
+	// Per-connection TCP congestion control from the profile's congestion_behavior
+	PacketLossRate   float64                   `json:"packet_loss_rate"` // Line loss per packet
+	Congestion       TCPCongestionConfig       `json:"congestion"`
+	TCPConnections   map[string]*TCPConnection `json:"tcp_connections"`
+	CurrentTransfers map[string]*TCPTransfer   `json:"current_transfers"`
+
+	// Retry budget and timeouts for recovering lost packets
+	Retransmission RetransmissionConfig `json:"retransmission"`
+
+	// TLS handshakes on new connections from the profile's security_overhead
+	TLS               TLSConfig                `json:"tls"`
+	TLSSessions       map[string]int64         `json:"tls_sessions"` // Session ticket issue tick by client ID
+	CurrentHandshakes map[string]*TLSHandshake `json:"current_handshakes"`
+
+	// Connection pools and stream multiplexing per origin from the profile's http section
+	HTTP            HTTPConfig              `json:"http"`
+	HTTPOrigins     map[string]*HTTPOrigin  `json:"http_origins"`
+	CurrentRequests map[string]*HTTPRequest `json:"current_requests"`
 	
-	// Dynamic bandwidth utilization state (real-time adaptation)
-	BandwidthState struct {
-		CurrentBandwidthMbps int     `json:"current_bandwidth_mbps"`
-		BandwidthUtilization float64 `json:"bandwidth_utilization"`
-		CongestionFactor     float64 `json:"congestion_factor"`
-		PacketLossProbability float64 `json:"packet_loss_probability"`
-		CongestionLatency    time.Duration `json:"congestion_latency"`
-		LastBandwidthUpdate  int64   `json:"last_bandwidth_update"`
-	} `json:"bandwidth_state"`
+	// Which side of the link this engine models. Duplex engines keep ingress and
+	// egress on separate link states; Network-In and Network-Out model one side each.
+	Direction NetworkDirection `json:"direction"`
+
+	// Dynamic bandwidth utilization state (real-time adaptation).
+	// Receive side for duplex and Network-In engines.
+	BandwidthState NetworkLinkState `json:"bandwidth_state"`
+
+	// Transmit side for duplex and Network-Out engines
+	EgressState NetworkLinkState `json:"egress_state"`
 	
 	// Dynamic connection management state
 	ConnectionState struct {
@@ -47,6 +71,7 @@ type NetworkEngine struct {
 		KeepAliveConnections int       `json:"keep_alive_connections"`
 		ConnectionEstablishmentCost time.Duration `json:"connection_establishment_cost"`
 		LastConnectionUpdate int64    `json:"last_connection_update"`
+		LastConnectionReused bool     `json:"last_connection_reused"` // Whether the last operation reused a pooled connection
 	} `json:"connection_state"`
 	
 	// Geographic distance effects (physics-based)
@@ -81,6 +106,64 @@ type NetworkTransmission struct {
 	EstimatedTicks   int64     `json:"estimated_ticks"`
 	Protocol         string    `json:"protocol"`
 	ConnectionReused bool      `json:"connection_reused"`
+	Direction        NetworkDirection `json:"direction"`
+}
+
+// NetworkDirection identifies one side of a full-duplex link
+type NetworkDirection string
+
+const (
+	NetworkDirectionDuplex  NetworkDirection = "duplex"  // Both sides, each with its own link state
+	NetworkDirectionIngress NetworkDirection = "ingress" // Receiving: requests coming in
+	NetworkDirectionEgress  NetworkDirection = "egress"  // Transmitting: responses going out
+)
+
+// NetworkLinkState is the dynamic state of one direction of a link. Ethernet is
+// full duplex, so receiving small requests does not slow down sending large
+// responses: each direction has its own bandwidth, congestion window and NIC offloads.
+type NetworkLinkState struct {
+	Direction             NetworkDirection `json:"direction"`
+	CapacityMbps          int              `json:"capacity_mbps"` // 0 uses the engine's BandwidthMbps
+	CurrentBandwidthMbps  int              `json:"current_bandwidth_mbps"`
+	BandwidthUtilization  float64          `json:"bandwidth_utilization"`
+	CongestionFactor      float64          `json:"congestion_factor"`
+	PacketLossProbability float64          `json:"packet_loss_probability"`
+	CongestionLatency     time.Duration    `json:"congestion_latency"`
+	LastBandwidthUpdate   int64            `json:"last_bandwidth_update"`
+
+	// Congestion window shared by this direction's flows (AIMD)
+	CongestionWindowSegments    int `json:"congestion_window_segments"`
+	MaxCongestionWindowSegments int `json:"max_congestion_window_segments"`
+	MinCongestionWindowSegments int `json:"min_congestion_window_segments"`
+
+	Offload NICOffload `json:"nic_offload"`
+}
+
+// NICOffload describes how much per-packet work the NIC takes off the host
+type NICOffload struct {
+	ChecksumOffload     bool    `json:"checksum_offload"`     // TX/RX checksums computed by the NIC
+	SegmentationOffload bool    `json:"segmentation_offload"` // TSO/GSO: egress hands the NIC up to 64KB at a time
+	ReceiveCoalescing   bool    `json:"receive_coalescing"`   // LRO/GRO: ingress segments merged before the stack
+	PerPacketCostUs     float64 `json:"per_packet_cost_us"`   // Host stack cost per packet without offloads
+	MTUBytes            int     `json:"mtu_bytes"`
+}
+
+// newNetworkLinkState creates an idle link side with Gigabit Ethernet NIC defaults
+func newNetworkLinkState(direction NetworkDirection) NetworkLinkState {
+	return NetworkLinkState{
+		Direction:                   direction,
+		CongestionFactor:            1.0,
+		CongestionWindowSegments:    1000, // Window starts open; it only closes under congestion
+		MaxCongestionWindowSegments: 1000,
+		MinCongestionWindowSegments: 10,
+		Offload: NICOffload{
+			ChecksumOffload:     true,
+			SegmentationOffload: direction == NetworkDirectionEgress,
+			ReceiveCoalescing:   direction == NetworkDirectionIngress,
+			PerPacketCostUs:     1.0, // ~1μs per packet through the kernel stack
+			MTUBytes:            1500,
+		},
+	}
 }
 
 // TransmissionEvent represents a completed transmission event
@@ -122,30 +205,55 @@ type NetworkEdge struct {
 	QualityFactor   float64 `json:"quality_factor"`   // 0.0-1.0, affects reliability and jitter
 }
 
-// NewNetworkEngine creates a new Network engine with Gigabit Ethernet defaults
+// NewNetworkEngine creates a new full-duplex Network engine with Gigabit Ethernet defaults
 func NewNetworkEngine(queueCapacity int) *NetworkEngine {
-	common := NewCommonEngine(NetworkEngineType, queueCapacity)
+	return newNetworkEngine(NetworkEngineType, NetworkDirectionDuplex, queueCapacity)
+}
+
+// NewNetworkInEngine creates a Network-In engine modeling the receive side of the
+// link, with its own queue, bandwidth and congestion window
+func NewNetworkInEngine(queueCapacity int) *NetworkEngine {
+	return newNetworkEngine(NetworkInEngineType, NetworkDirectionIngress, queueCapacity)
+}
+
+// NewNetworkOutEngine creates a Network-Out engine modeling the transmit side of the link
+func NewNetworkOutEngine(queueCapacity int) *NetworkEngine {
+	return newNetworkEngine(NetworkOutEngineType, NetworkDirectionEgress, queueCapacity)
+}
+
+func newNetworkEngine(engineType EngineType, direction NetworkDirection, queueCapacity int) *NetworkEngine {
+	common := NewCommonEngine(engineType, queueCapacity)
 
 	network := &NetworkEngine{
 		CommonEngine:        common,
 		ComplexityInterface: NewNetworkInterface(ComplexityAdvanced), // Default to advanced complexity
+		Direction:           direction,
 		BandwidthMbps:       1000, // 1 Gbps
 		BaseLatencyMs:       1.0,  // 1ms LAN latency
 		MaxConnections:      10000,
 		Protocol:            "TCP",
 		GeographicDistance:  0.1,  // 100m LAN
 		NetworkType:         "LAN",
+		RoutingMetric:       RoutingMetricLatency,
+		EdgeStates:          make(map[string]*NetworkEdgeState),
+		RouteFlows:          make(map[string]*NetworkFlow),
+		Congestion:          defaultTCPCongestionConfig(),
+		Retransmission:      defaultRetransmissionConfig(),
+		TCPConnections:      make(map[string]*TCPConnection),
+		CurrentTransfers:    make(map[string]*TCPTransfer),
+		TLS:                 defaultTLSConfig(),
+		TLSSessions:         make(map[string]int64),
+		CurrentHandshakes:   make(map[string]*TLSHandshake),
+		HTTP:                defaultHTTPConfig(),
+		HTTPOrigins:         make(map[string]*HTTPOrigin),
+		CurrentRequests:     make(map[string]*HTTPRequest),
 		ActiveTransmissions: make(map[string]*NetworkTransmission),
 		TransmissionHistory: make([]TransmissionEvent, 0, 10000),
 	}
 	
-	// Initialize bandwidth state
-	network.BandwidthState.CurrentBandwidthMbps = 0
-	network.BandwidthState.BandwidthUtilization = 0.0
-	network.BandwidthState.CongestionFactor = 1.0
-	network.BandwidthState.PacketLossProbability = 0.0
-	network.BandwidthState.CongestionLatency = 0
-	network.BandwidthState.LastBandwidthUpdate = 0
+	// Initialize bandwidth state for each side of the link
+	network.BandwidthState = newNetworkLinkState(NetworkDirectionIngress)
+	network.EgressState = newNetworkLinkState(NetworkDirectionEgress)
 	
 	// Initialize connection state
 	network.ConnectionState.ActiveConnections = 0
@@ -181,8 +289,11 @@ func NewNetworkEngine(queueCapacity int) *NetworkEngine {
 func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64) *OperationResult {
 	network.CurrentTick = currentTick
 
+	// Ingress and egress are independent resources on a full-duplex link
+	link := network.linkFor(op)
+
 	// Calculate base transmission time from profile (IOPS and latency)
-	baseTime := network.calculateBaseTransmissionTime(op)
+	baseTime := network.calculateBaseTransmissionTime(op, link)
 
 	// Apply graph-based topology effects (if enabled)
 	topologyTime := baseTime
@@ -199,11 +310,33 @@ func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64)
 	// Apply bandwidth saturation effects (if enabled)
 	bandwidthTime := geographicTime
 	if network.ComplexityInterface.ShouldEnableFeature("bandwidth_limits") {
-		bandwidthTime = network.applyBandwidthSaturation(geographicTime)
+		bandwidthTime = network.applyBandwidthSaturation(geographicTime, link)
+	}
+
+	// Assign HTTP requests a pooled connection or a stream (if enabled)
+	if network.ComplexityInterface.ShouldEnableFeature("protocol_overhead") {
+		network.reserveHTTPRequest(op, link)
+	}
+
+	// Apply per-packet host cost after NIC offloads (if enabled)
+	if network.ComplexityInterface.ShouldEnableFeature("nic_offload") {
+		bandwidthTime = network.applyNICOffload(bandwidthTime, op, link)
+	}
+
+	// Apply TCP slow start, congestion window limits and loss recovery (if enabled)
+	if network.ComplexityInterface.ShouldEnableFeature("congestion_control") {
+		bandwidthTime = network.applyCongestionControl(bandwidthTime, op, link)
+	}
+
+	// Retransmit lost packets, failing once the retry budget is spent (if enabled)
+	var networkErr error
+	if network.ComplexityInterface.ShouldEnableFeature("packet_loss") {
+		bandwidthTime, networkErr = network.applyPacketLoss(bandwidthTime, op, link)
 	}
 
 	// Apply connection management effects (if enabled)
 	connectionTime := bandwidthTime
+	network.ConnectionState.LastConnectionReused = false
 	if network.ComplexityInterface.ShouldEnableFeature("connection_pooling") {
 		connectionTime = network.applyConnectionManagement(bandwidthTime, op)
 	}
@@ -239,18 +372,29 @@ func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64)
 	}
 
 	// Apply common performance factors (load, queue, health, variance)
-	utilization := network.calculateCurrentUtilization()
+	utilization := network.linkUtilization(link)
 	finalTime := network.ApplyCommonPerformanceFactors(securityTime, utilization)
 
+	// Queue for an HTTP connection or stream and stall behind other streams' losses (if enabled)
+	if network.ComplexityInterface.ShouldEnableFeature("protocol_overhead") {
+		finalTime = network.applyProtocolBehavior(finalTime, op, link)
+	}
+
 	// Update dynamic state tracking (if enabled)
 	if network.ComplexityInterface.ShouldEnableFeature("dynamic_behavior") {
-		network.updateDynamicState(op, finalTime)
+		network.updateDynamicState(op, finalTime, link)
 	}
 
-	// Determine success based on packet loss (if enabled)
-	success := true
-	if network.ComplexityInterface.ShouldEnableFeature("packet_loss") {
-		success = !network.checkPacketLoss()
+	// Operations fail only when lost packets could not be recovered
+	success := networkErr == nil
+	errorMessage := ""
+	if networkErr != nil {
+		errorMessage = networkErr.Error()
+	}
+
+	// Back off or grow this direction's congestion window (if enabled)
+	if network.ComplexityInterface.ShouldEnableFeature("congestion_control") {
+		network.updateCongestionWindow(link, !success)
 	}
 
 	// Ensure operations take at least 1 tick to complete
@@ -260,15 +404,15 @@ func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64)
 	}
 
 	// Calculate penalty factors for routing decisions
-	loadPenalty := 1.0 + (network.BandwidthState.BandwidthUtilization * 0.5) // Bandwidth utilization
+	loadPenalty := 1.0 + (link.BandwidthUtilization * 0.5) // Bandwidth utilization
 	queuePenalty := 1.0 + (float64(network.ConnectionState.ActiveConnections) / 1000.0 * 0.3) // Connection load
 	thermalPenalty := 1.0 // Network equipment typically doesn't have thermal issues
-	contentionPenalty := network.BandwidthState.CongestionFactor // Direct congestion impact
+	contentionPenalty := link.CongestionFactor // Direct congestion impact
 	healthPenalty := 1.0 + (1.0 - network.GetHealth().Score) * 0.25
 
 	// Network-specific penalties
 	latencyPenalty := 1.0 + (network.GeographicState.PhysicsLatencyMs / 100.0) // Latency impact
-	packetLossPenalty := 1.0 + (network.BandwidthState.PacketLossProbability * 2.0) // Packet loss is critical
+	packetLossPenalty := 1.0 + (link.PacketLossProbability * 2.0) // Packet loss is critical
 	protocolPenalty := 1.0 / network.ProtocolState.ProtocolEfficiency // Protocol inefficiency
 
 	totalPenaltyFactor := loadPenalty * queuePenalty * contentionPenalty * healthPenalty * latencyPenalty * packetLossPenalty * protocolPenalty
@@ -295,8 +439,10 @@ func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64)
 		ProcessingTime: finalTime,
 		CompletedTick:  currentTick + ticksToComplete,
 		Success:        success,
+		ErrorMessage:   errorMessage,
+		Error:          networkErr,
 		PenaltyInfo: &PenaltyInformation{
-			EngineType:           NetworkEngineType,
+			EngineType:           network.GetEngineType(),
 			EngineID:            network.ID,
 			BaseProcessingTime:   baseTime,
 			ActualProcessingTime: finalTime,
@@ -309,25 +455,72 @@ func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64)
 			PerformanceGrade:    performanceGrade,
 			RecommendedAction:   recommendedAction,
 			NetworkPenalties: &NetworkPenaltyDetails{
-				BandwidthUtilization: network.BandwidthState.BandwidthUtilization,
-				CongestionFactor:     network.BandwidthState.CongestionFactor,
-				PacketLossRate:       network.BandwidthState.PacketLossProbability,
+				BandwidthUtilization: link.BandwidthUtilization,
+				CongestionFactor:     link.CongestionFactor,
+				PacketLossRate:       link.PacketLossProbability,
 				LatencyPenalty:       latencyPenalty,
 				ProtocolEfficiency:   network.ProtocolState.ProtocolEfficiency,
+				Direction:            string(link.Direction),
 			},
 		},
 		Metrics: map[string]interface{}{
 			"base_time_ms":         float64(baseTime) / float64(time.Millisecond),
 			"physics_latency_ms":   network.GeographicState.PhysicsLatencyMs,
-			"bandwidth_utilization": network.BandwidthState.BandwidthUtilization,
-			"congestion_factor":    network.BandwidthState.CongestionFactor,
-			"packet_loss_prob":     network.BandwidthState.PacketLossProbability,
+			"bandwidth_utilization": link.BandwidthUtilization,
+			"congestion_factor":    link.CongestionFactor,
+			"packet_loss_prob":     link.PacketLossProbability,
+			"congestion_window":    link.CongestionWindowSegments,
+			"direction":            string(link.Direction),
 			"active_connections":   network.ConnectionState.ActiveConnections,
 			"protocol_efficiency":  network.ProtocolState.ProtocolEfficiency,
 			"geographic_distance":  network.GeographicDistance,
 		},
 	}
 	
+	// Routed operations report their path and its bottleneck
+	if flow, ok := network.RouteFlows[op.ID]; ok && flow.EndTick >= currentTick {
+		result.Metrics["route_edges"] = flow.Route.EdgeIDs
+		result.Metrics["route_hops"] = flow.Route.HopCount
+		result.Metrics["route_latency_ms"] = flow.Route.LatencyMs
+		result.Metrics["bottleneck_edge"] = flow.Route.BottleneckEdgeID
+		result.Metrics["bottleneck_mbps"] = flow.Route.BottleneckMbps
+	}
+
+	// TCP transfers report how their congestion window evolved and what loss cost them
+	if transfer, ok := network.CurrentTransfers[op.ID]; ok {
+		if transfer.RoundTrips > 0 {
+			result.Metrics["congestion_algorithm"] = network.Congestion.Algorithm
+			result.Metrics["tcp_round_trips"] = transfer.RoundTrips
+			result.Metrics["tcp_loss_events"] = transfer.LossEvents
+			result.Metrics["tcp_ack_wait_ms"] = float64(transfer.AckWait) / float64(time.Millisecond)
+			result.Metrics["tcp_start_window"] = transfer.StartWindow
+			result.Metrics["tcp_end_window"] = transfer.EndWindow
+		}
+		result.Metrics["retransmits"] = transfer.Retransmits
+		result.Metrics["recovery_time_ms"] = float64(transfer.RecoveryTime) / float64(time.Millisecond)
+		delete(network.CurrentTransfers, op.ID)
+	}
+
+	// New TLS connections report their handshake and hand its crypto to the CPU engine
+	if handshake, ok := network.CurrentHandshakes[op.ID]; ok {
+		result.Metrics["tls_version"] = handshake.Version
+		result.Metrics["tls_resumed"] = handshake.Resumed
+		result.Metrics["tls_handshake_rtts"] = handshake.RoundTrips
+		result.Metrics["tls_handshake_ms"] = float64(handshake.Latency) / float64(time.Millisecond)
+		result.Metrics["tls_cpu_ms"] = float64(handshake.CPUTime) / float64(time.Millisecond)
+		result.FollowUpOperations = append(result.FollowUpOperations, network.handshakeCPUOperation(op, handshake))
+		delete(network.CurrentHandshakes, op.ID)
+	}
+
+	// HTTP requests report their wait and hold their connection or stream until they complete
+	if request, ok := network.CurrentRequests[op.ID]; ok {
+		result.Metrics["http_queue_wait_ms"] = float64(request.QueueWait) / float64(time.Millisecond)
+		result.Metrics["http_head_of_line_ms"] = float64(request.HeadOfLineDelay) / float64(time.Millisecond)
+		result.Metrics["http_streams_in_flight"] = request.StreamsInFlight
+		network.completeHTTPRequest(op, request, result.CompletedTick)
+		delete(network.CurrentRequests, op.ID)
+	}
+
 	// Update operation history for convergence
 	network.AddOperationToHistory(finalTime)
 	if result.Success {
@@ -339,6 +532,12 @@ func (network *NetworkEngine) ProcessOperation(op *Operation, currentTick int64)
 	return result
 }
 
+// NextEventTick returns the next tick if operations are waiting in the queue.
+// Network operations complete in the tick they are dequeued, so there is no heap.
+func (network *NetworkEngine) NextEventTick() (int64, bool) {
+	return network.nextEventTick(0, false)
+}
+
 // ProcessTick processes one simulation tick
 func (network *NetworkEngine) ProcessTick(currentTick int64) []OperationResult {
 	network.CurrentTick = currentTick
@@ -354,7 +553,7 @@ func (network *NetworkEngine) ProcessTick(currentTick int64) []OperationResult {
 	network.updateProtocolEfficiency()
 	
 	// Process queued operations if bandwidth available
-	for network.BandwidthState.CurrentBandwidthMbps < network.BandwidthMbps && network.GetQueueLength() > 0 {
+	for network.hasSpareBandwidth() && network.GetQueueLength() > 0 {
 		queuedOp := network.DequeueOperation()
 		if queuedOp != nil {
 			result := network.ProcessOperation(queuedOp.Operation, currentTick)
@@ -371,8 +570,8 @@ func (network *NetworkEngine) ProcessTick(currentTick int64) []OperationResult {
 	return results
 }
 
-// calculateBaseTransmissionTime calculates base transmission time
-func (network *NetworkEngine) calculateBaseTransmissionTime(op *Operation) time.Duration {
+// calculateBaseTransmissionTime calculates base transmission time over one side of the link
+func (network *NetworkEngine) calculateBaseTransmissionTime(op *Operation, link *NetworkLinkState) time.Duration {
 	// Base latency from profile
 	baseLatencyMs := network.BaseLatencyMs
 	
@@ -385,7 +584,7 @@ func (network *NetworkEngine) calculateBaseTransmissionTime(op *Operation) time.
 	// Calculate transmission time based on data size and bandwidth
 	if op.DataSize > 0 {
 		// Convert bandwidth from Mbps to bytes per millisecond
-		bandwidthBytesPerMs := float64(network.BandwidthMbps) * 1000000 / 8 / 1000
+		bandwidthBytesPerMs := network.effectiveBandwidthMbps(link) * 1000000 / 8 / 1000
 		transmissionTimeMs := float64(op.DataSize) / bandwidthBytesPerMs
 		baseLatencyMs += transmissionTimeMs
 	}
@@ -393,12 +592,103 @@ func (network *NetworkEngine) calculateBaseTransmissionTime(op *Operation) time.
 	return time.Duration(baseLatencyMs * float64(time.Millisecond))
 }
 
-// calculateCurrentUtilization calculates current network utilization
+// calculateCurrentUtilization calculates current network utilization. Duplex
+// engines report the busier direction, since that is the side that saturates first.
 func (network *NetworkEngine) calculateCurrentUtilization() float64 {
-	if network.BandwidthMbps == 0 {
+	switch network.Direction {
+	case NetworkDirectionIngress:
+		return network.linkUtilization(&network.BandwidthState)
+	case NetworkDirectionEgress:
+		return network.linkUtilization(&network.EgressState)
+	default:
+		return math.Max(network.linkUtilization(&network.BandwidthState), network.linkUtilization(&network.EgressState))
+	}
+}
+
+// linkUtilization calculates utilization of one side of the link
+func (network *NetworkEngine) linkUtilization(link *NetworkLinkState) float64 {
+	capacity := network.linkCapacityMbps(link)
+	if capacity == 0 {
 		return 0.0
 	}
-	return float64(network.BandwidthState.CurrentBandwidthMbps) / float64(network.BandwidthMbps)
+	return float64(link.CurrentBandwidthMbps) / float64(capacity)
+}
+
+// linkCapacityMbps returns a link side's capacity, defaulting to the engine bandwidth
+// for symmetric links
+func (network *NetworkEngine) linkCapacityMbps(link *NetworkLinkState) int {
+	if link.CapacityMbps > 0 {
+		return link.CapacityMbps
+	}
+	return network.BandwidthMbps
+}
+
+// effectiveBandwidthMbps returns the bandwidth a link side delivers with its current
+// congestion window. The window only closes after loss, so an idle link runs at capacity.
+func (network *NetworkEngine) effectiveBandwidthMbps(link *NetworkLinkState) float64 {
+	capacity := float64(network.linkCapacityMbps(link))
+	if !network.ComplexityInterface.ShouldEnableFeature("congestion_control") || link.MaxCongestionWindowSegments <= 0 {
+		return capacity
+	}
+	window := float64(link.CongestionWindowSegments) / float64(link.MaxCongestionWindowSegments)
+	return capacity * math.Max(window, 0.01)
+}
+
+// linkFor returns the side of the link an operation travels on. Sends and
+// responses leave on egress; receives, requests and connects arrive on ingress.
+func (network *NetworkEngine) linkFor(op *Operation) *NetworkLinkState {
+	switch network.Direction {
+	case NetworkDirectionIngress:
+		return &network.BandwidthState
+	case NetworkDirectionEgress:
+		return &network.EgressState
+	}
+
+	if direction, ok := op.Metadata["direction"].(string); ok {
+		switch NetworkDirection(direction) {
+		case NetworkDirectionIngress:
+			return &network.BandwidthState
+		case NetworkDirectionEgress:
+			return &network.EgressState
+		}
+	}
+
+	switch op.Type {
+	case OpNetworkSend, OpNetworkResponse:
+		return &network.EgressState
+	default:
+		return &network.BandwidthState
+	}
+}
+
+// links returns the link sides this engine models
+func (network *NetworkEngine) links() []*NetworkLinkState {
+	switch network.Direction {
+	case NetworkDirectionIngress:
+		return []*NetworkLinkState{&network.BandwidthState}
+	case NetworkDirectionEgress:
+		return []*NetworkLinkState{&network.EgressState}
+	default:
+		return []*NetworkLinkState{&network.BandwidthState, &network.EgressState}
+	}
+}
+
+// primaryLink returns the link side reported in engine-level state
+func (network *NetworkEngine) primaryLink() *NetworkLinkState {
+	if network.Direction == NetworkDirectionEgress {
+		return &network.EgressState
+	}
+	return &network.BandwidthState
+}
+
+// hasSpareBandwidth reports whether any modeled link side is below capacity
+func (network *NetworkEngine) hasSpareBandwidth() bool {
+	for _, link := range network.links() {
+		if link.CurrentBandwidthMbps < network.linkCapacityMbps(link) {
+			return true
+		}
+	}
+	return false
 }
 
 // calculatePhysicsLatency calculates physics-based latency from distance
@@ -420,28 +710,36 @@ func (network *NetworkEngine) calculatePhysicsLatency() {
 	network.GeographicState.PropagationDelay = time.Duration(actualLatencySeconds * float64(time.Second))
 }
 
-// applyGraphTopologyEffects applies graph-based distance modeling (NEW FEATURE)
+// applyGraphTopologyEffects routes the operation through the topology to the node in
+// its "target_node" metadata, from "source_node" or this engine's node
 func (network *NetworkEngine) applyGraphTopologyEffects(baseTime time.Duration, op *Operation) time.Duration {
 	// If no topology is configured, fall back to geographic effects
 	if network.NetworkTopology == nil || network.CurrentNodeID == "" {
 		return network.applyGeographicEffects(baseTime)
 	}
 
-	// For now, use a simple approach - in a real implementation, this would:
-	// 1. Determine target node from operation metadata
-	// 2. Find shortest path using Dijkstra's algorithm
-	// 3. Sum latencies along the path
-	// 4. Apply bandwidth constraints of bottleneck edge
+	targetNodeID, _ := op.Metadata["target_node"].(string)
+	if targetNodeID == "" {
+		return network.applyGeographicEffects(baseTime)
+	}
+	sourceNodeID, _ := op.Metadata["source_node"].(string)
+	if sourceNodeID == "" {
+		sourceNodeID = network.CurrentNodeID
+	}
+
+	// Unreachable targets fall back to distance-based latency
+	route, err := network.FindRoute(sourceNodeID, targetNodeID)
+	if err != nil {
+		return network.applyGeographicEffects(baseTime)
+	}
 
-	// Placeholder: Use geographic effects as baseline
-	// TODO: Implement full graph-based routing when operation metadata includes target node
-	return network.applyGeographicEffects(baseTime)
+	return network.routeTransmission(baseTime, op, route)
 }
 
 // applyJitterEffects applies network jitter modeling
 func (network *NetworkEngine) applyJitterEffects(baseTime time.Duration, op *Operation) time.Duration {
 	// Network jitter is typically 1-5% of base latency
-	jitterFactor := 1.0 + (rand.Float64()-0.5)*0.05 // ±2.5% jitter
+	jitterFactor := 1.0 + (network.randomFloat64()-0.5)*0.05 // ±2.5% jitter
 	return time.Duration(float64(baseTime) * jitterFactor)
 }
 
@@ -449,7 +747,7 @@ func (network *NetworkEngine) applyJitterEffects(baseTime time.Duration, op *Ope
 func (network *NetworkEngine) applyQoSEffects(baseTime time.Duration, op *Operation) time.Duration {
 	// QoS prioritization based on operation type
 	// High priority operations get better treatment during congestion
-	utilization := network.calculateCurrentUtilization()
+	utilization := network.linkUtilization(network.linkFor(op))
 
 	if utilization < 0.7 {
 		return baseTime // No QoS effects under normal load
@@ -488,17 +786,24 @@ func (network *NetworkEngine) applyCompressionEffects(baseTime time.Duration, op
 	return time.Duration(float64(baseTime) * transmissionReduction * processingOverhead)
 }
 
-// applySecurityOverhead applies TLS/encryption processing costs
+// applySecurityOverhead applies TLS handshake latency on new connections and
+// bulk encryption costs on payloads
 func (network *NetworkEngine) applySecurityOverhead(baseTime time.Duration, op *Operation) time.Duration {
-	// TLS handshake and encryption overhead
-	if op.DataSize <= 0 {
-		return baseTime
+	securityTime := baseTime
+
+	// Reused connections already hold session keys; UDP carries no TLS
+	if !network.ConnectionState.LastConnectionReused && network.Protocol != "UDP" {
+		handshake := network.tlsHandshake(op)
+		network.CurrentHandshakes[op.ID] = handshake
+		securityTime += handshake.Latency
 	}
 
-	// Security overhead is typically 5-15% for established connections
-	securityOverhead := 1.08 // 8% overhead typical
+	// Encryption overhead is typically 5-15% for established connections
+	if op.DataSize > 0 {
+		securityTime += time.Duration(float64(baseTime) * network.TLS.EncryptionCPUOverhead)
+	}
 
-	return time.Duration(float64(baseTime) * securityOverhead)
+	return securityTime
 }
 
 // configureProtocol configures protocol-specific settings
@@ -506,9 +811,9 @@ func (network *NetworkEngine) configureProtocol() {
 	switch network.Protocol {
 	case "HTTP/1.1":
 		network.ProtocolState.HeaderOverheadBytes = 200 // HTTP headers
-		network.ProtocolState.ProtocolEfficiency = 0.8  // Connection per request overhead
+		network.ProtocolState.ProtocolEfficiency = 0.8  // Text headers, one request per connection at a time
 		network.ProtocolState.MultiplexingFactor = 1.0  // No multiplexing
-		network.ProtocolState.KeepAliveEnabled = false
+		network.ProtocolState.KeepAliveEnabled = true   // Persistent connections, limited per origin
 		
 	case "HTTP/2":
 		network.ProtocolState.HeaderOverheadBytes = 50  // Compressed headers
@@ -522,6 +827,12 @@ func (network *NetworkEngine) configureProtocol() {
 		network.ProtocolState.MultiplexingFactor = 8.0  // High multiplexing
 		network.ProtocolState.KeepAliveEnabled = true
 		
+	case "QUIC", "HTTP/3":
+		network.ProtocolState.HeaderOverheadBytes = 30  // UDP + QUIC short header, QPACK-compressed fields
+		network.ProtocolState.ProtocolEfficiency = 1.2  // Multiplexing efficiency
+		network.ProtocolState.MultiplexingFactor = 4.0  // Independent streams
+		network.ProtocolState.KeepAliveEnabled = true
+
 	case "UDP":
 		network.ProtocolState.HeaderOverheadBytes = 8   // UDP header only
 		network.ProtocolState.ProtocolEfficiency = 1.1  // No connection overhead
@@ -536,9 +847,9 @@ func (network *NetworkEngine) configureProtocol() {
 	}
 }
 
-// checkPacketLoss checks if packet loss occurred
-func (network *NetworkEngine) checkPacketLoss() bool {
-	return rand.Float64() < network.BandwidthState.PacketLossProbability
+// checkPacketLoss checks if packet loss occurred on one side of the link
+func (network *NetworkEngine) checkPacketLoss(link *NetworkLinkState) bool {
+	return network.randomFloat64() < link.PacketLossProbability
 }
 
 // applyGeographicEffects applies physics-based distance effects (NOT random)
@@ -548,8 +859,8 @@ func (network *NetworkEngine) applyGeographicEffects(baseTime time.Duration) tim
 }
 
 // applyBandwidthSaturation applies bandwidth saturation effects (congestion-based performance)
-func (network *NetworkEngine) applyBandwidthSaturation(baseTime time.Duration) time.Duration {
-	utilization := network.BandwidthState.BandwidthUtilization
+func (network *NetworkEngine) applyBandwidthSaturation(baseTime time.Duration, link *NetworkLinkState) time.Duration {
+	utilization := link.BandwidthUtilization
 
 	// Gigabit Ethernet Congestion Behavior (Based on Network Equipment Documentation)
 	var congestionFactor float64
@@ -568,21 +879,62 @@ func (network *NetworkEngine) applyBandwidthSaturation(baseTime time.Duration) t
 		congestionFactor = 3.0 + excess*140.0 // Up to 10x slower
 
 		// Packet loss probability increases with congestion
-		network.BandwidthState.PacketLossProbability = math.Min(excess*100.0, 0.05) // Up to 5% loss
+		link.PacketLossProbability = math.Min(excess*100.0, 0.05) // Up to 5% loss
 	}
 
-	network.BandwidthState.CongestionFactor = congestionFactor
+	link.CongestionFactor = congestionFactor
 
 	// Apply congestion latency
 	if utilization > 0.70 {
 		congestionLatencyMs := (utilization - 0.70) * 10.0 // Up to 3ms additional latency
-		network.BandwidthState.CongestionLatency = time.Duration(congestionLatencyMs * float64(time.Millisecond))
+		link.CongestionLatency = time.Duration(congestionLatencyMs * float64(time.Millisecond))
 	} else {
-		network.BandwidthState.CongestionLatency = 0
-		network.BandwidthState.PacketLossProbability = 0.0
+		link.CongestionLatency = 0
+		link.PacketLossProbability = 0.0
+	}
+
+	return time.Duration(float64(baseTime)*congestionFactor) + link.CongestionLatency
+}
+
+// applyNICOffload adds the host's per-packet processing cost. Checksum offload
+// trims every packet's cost; TSO/GSO on egress and LRO/GRO on ingress hand the
+// stack 64KB at a time instead of one MTU-sized packet.
+func (network *NetworkEngine) applyNICOffload(baseTime time.Duration, op *Operation, link *NetworkLinkState) time.Duration {
+	offload := link.Offload
+	if op.DataSize <= 0 || offload.PerPacketCostUs <= 0 || offload.MTUBytes <= 0 {
+		return baseTime
+	}
+
+	bytesPerPacket := float64(offload.MTUBytes)
+	if (link.Direction == NetworkDirectionEgress && offload.SegmentationOffload) ||
+		(link.Direction == NetworkDirectionIngress && offload.ReceiveCoalescing) {
+		bytesPerPacket = 64 * 1024
 	}
+	packets := math.Ceil(float64(op.DataSize) / bytesPerPacket)
 
-	return time.Duration(float64(baseTime)*congestionFactor) + network.BandwidthState.CongestionLatency
+	costUs := offload.PerPacketCostUs
+	if offload.ChecksumOffload {
+		costUs *= 0.85 // Checksumming is ~15% of per-packet stack work
+	}
+
+	return baseTime + time.Duration(math.Round(packets*costUs*float64(time.Microsecond)))
+}
+
+// updateCongestionWindow applies additive increase / multiplicative decrease to one
+// side of the link: loss or severe saturation halves the window, other operations
+// grow it by one segment
+func (network *NetworkEngine) updateCongestionWindow(link *NetworkLinkState, lost bool) {
+	if lost || link.BandwidthUtilization >= 0.95 {
+		link.CongestionWindowSegments /= 2
+		if link.CongestionWindowSegments < link.MinCongestionWindowSegments {
+			link.CongestionWindowSegments = link.MinCongestionWindowSegments
+		}
+		return
+	}
+
+	if link.CongestionWindowSegments < link.MaxCongestionWindowSegments {
+		link.CongestionWindowSegments++
+	}
 }
 
 // applyConnectionManagement applies TCP connection lifecycle costs
@@ -592,11 +944,13 @@ func (network *NetworkEngine) applyConnectionManagement(baseTime time.Duration,
 
 	if network.ProtocolState.KeepAliveEnabled && len(network.ConnectionState.ConnectionPool) > 0 {
 		// 80% chance of connection reuse with keep-alive
-		if rand.Float64() < 0.8 {
+		if network.randomFloat64() < 0.8 {
 			connectionReused = true
 		}
 	}
 
+	network.ConnectionState.LastConnectionReused = connectionReused
+
 	if !connectionReused {
 		// New connection required - add establishment cost
 		establishmentCost := network.ConnectionState.ConnectionEstablishmentCost
@@ -605,6 +959,14 @@ func (network *NetworkEngine) applyConnectionManagement(baseTime time.Duration,
 		rtt := network.GeographicState.PropagationDelay * 2 // Round trip
 		tcpHandshakeCost := time.Duration(float64(rtt) * 1.5)
 
+		// QUIC's transport handshake is the TLS 1.3 handshake, paid there when security is modeled
+		if network.usesQUIC() {
+			tcpHandshakeCost = rtt
+			if network.ComplexityInterface.ShouldEnableFeature("security_overhead") {
+				establishmentCost, tcpHandshakeCost = 0, 0
+			}
+		}
+
 		// Use the larger of configured cost or calculated handshake cost
 		if tcpHandshakeCost > establishmentCost {
 			establishmentCost = tcpHandshakeCost
@@ -652,19 +1014,35 @@ func (network *NetworkEngine) applyProtocolOverhead(baseTime time.Duration, op *
 	return time.Duration(float64(baseTime) / efficiencyFactor)
 }
 
-// updateBandwidthUtilization updates bandwidth utilization state
+// updateBandwidthUtilization updates bandwidth utilization state of each link side
 func (network *NetworkEngine) updateBandwidthUtilization() {
-	// Calculate current bandwidth usage from active transmissions
-	currentBandwidth := 0
-	for _, transmission := range network.ActiveTransmissions {
+	// Calculate current bandwidth usage from active transmissions, retiring
+	// transmissions that have finished
+	ingressBandwidth, egressBandwidth := 0, 0
+	for id, transmission := range network.ActiveTransmissions {
+		if transmission.StartTick+transmission.EstimatedTicks < network.CurrentTick {
+			delete(network.ActiveTransmissions, id)
+			continue
+		}
+
 		// Estimate bandwidth per transmission (simplified)
 		transmissionBandwidth := int(float64(transmission.DataSizeBytes) / 1000.0) // Rough estimate
-		currentBandwidth += transmissionBandwidth
+		if transmission.Direction == NetworkDirectionEgress {
+			egressBandwidth += transmissionBandwidth
+		} else {
+			ingressBandwidth += transmissionBandwidth
+		}
 	}
 
-	network.BandwidthState.CurrentBandwidthMbps = currentBandwidth
-	network.BandwidthState.BandwidthUtilization = network.calculateCurrentUtilization()
-	network.BandwidthState.LastBandwidthUpdate = network.CurrentTick
+	network.BandwidthState.CurrentBandwidthMbps = ingressBandwidth
+	network.EgressState.CurrentBandwidthMbps = egressBandwidth
+	for _, link := range []*NetworkLinkState{&network.BandwidthState, &network.EgressState} {
+		link.BandwidthUtilization = network.linkUtilization(link)
+		link.LastBandwidthUpdate = network.CurrentTick
+	}
+
+	// Routed transmissions also hold bandwidth on topology edges
+	network.updateTopologyUtilization()
 }
 
 // updateConnectionState updates connection management state
@@ -686,6 +1064,10 @@ func (network *NetworkEngine) updateConnectionState() {
 		network.ConnectionState.ConnectionPool = network.ConnectionState.ConnectionPool[10:]
 	}
 
+	// Forget TCP connections that have gone idle and expired session tickets
+	network.pruneTCPConnections()
+	network.pruneTLSSessions()
+
 	network.ConnectionState.LastConnectionUpdate = network.CurrentTick
 }
 
@@ -719,7 +1101,7 @@ func (network *NetworkEngine) updateProtocolEfficiency() {
 }
 
 // updateDynamicState updates all dynamic state after processing an operation
-func (network *NetworkEngine) updateDynamicState(op *Operation, processingTime time.Duration) {
+func (network *NetworkEngine) updateDynamicState(op *Operation, processingTime time.Duration, link *NetworkLinkState) {
 	// Add to active transmissions
 	transmission := &NetworkTransmission{
 		OperationID:      op.ID,
@@ -728,7 +1110,8 @@ func (network *NetworkEngine) updateDynamicState(op *Operation, processingTime t
 		StartTick:        network.CurrentTick,
 		EstimatedTicks:   network.DurationToTicks(processingTime),
 		Protocol:         network.Protocol,
-		ConnectionReused: len(network.ConnectionState.ConnectionPool) > 0,
+		ConnectionReused: network.ConnectionState.LastConnectionReused,
+		Direction:        link.Direction,
 	}
 	network.ActiveTransmissions[op.ID] = transmission
 
@@ -739,8 +1122,8 @@ func (network *NetworkEngine) updateDynamicState(op *Operation, processingTime t
 		DataSizeBytes: op.DataSize,
 		Duration:      processingTime,
 		CompletedAt:   network.CurrentTick,
-		PacketLoss:    network.checkPacketLoss(),
-		Congestion:    network.BandwidthState.CongestionFactor > 1.5,
+		PacketLoss:    network.checkPacketLoss(link),
+		Congestion:    link.CongestionFactor > 1.5,
 	}
 	network.TransmissionHistory = append(network.TransmissionHistory, event)
 
@@ -792,7 +1175,7 @@ func (network *NetworkEngine) updateConvergenceModels() {
 	// Update bandwidth behavior model
 	if bandwidthModel, exists := network.ConvergenceState.Models["bandwidth_behavior"]; exists {
 		// Update based on current congestion factor
-		bandwidthModel.CurrentValue = network.BandwidthState.CongestionFactor
+		bandwidthModel.CurrentValue = network.primaryLink().CongestionFactor
 
 		// Check convergence
 		if network.CompletedOps >= bandwidthModel.MinOperations {
@@ -843,10 +1226,23 @@ func (network *NetworkEngine) LoadProfile(profile *EngineProfile) error {
 		network.BandwidthMbps = int(bandwidth)
 	}
 
+	// Asymmetric links (cable, DSL, cloud egress caps) size each direction separately
+	if bandwidth, ok := profile.BaselinePerformance["ingress_bandwidth_mbps"]; ok {
+		network.BandwidthState.CapacityMbps = int(bandwidth)
+	}
+
+	if bandwidth, ok := profile.BaselinePerformance["egress_bandwidth_mbps"]; ok {
+		network.EgressState.CapacityMbps = int(bandwidth)
+	}
+
 	if latency, ok := profile.BaselinePerformance["base_latency_ms"]; ok {
 		network.BaseLatencyMs = latency
 	}
 
+	if lossRate, ok := profile.BaselinePerformance["packet_loss_rate"]; ok {
+		network.PacketLossRate = lossRate
+	}
+
 	if maxConn, ok := profile.BaselinePerformance["max_connections"]; ok {
 		network.MaxConnections = int(maxConn)
 	}
@@ -875,6 +1271,45 @@ func (network *NetworkEngine) LoadProfile(profile *EngineProfile) error {
 		}
 	}
 
+	if engineSpecific, ok := profile.EngineSpecific["routing"]; ok {
+		if routing, ok := engineSpecific.(map[string]interface{}); ok {
+			if metric, ok := routing["metric"].(string); ok {
+				network.RoutingMetric = metric
+			}
+		}
+	}
+
+	if engineSpecific, ok := profile.EngineSpecific["nic_offload"]; ok {
+		if offload, ok := engineSpecific.(map[string]interface{}); ok {
+			network.BandwidthState.Offload.loadProfile(offload)
+			network.EgressState.Offload.loadProfile(offload)
+		}
+	}
+
+	if engineSpecific, ok := profile.EngineSpecific["congestion_behavior"]; ok {
+		if congestion, ok := engineSpecific.(map[string]interface{}); ok {
+			network.Congestion.loadProfile(congestion)
+		}
+	}
+
+	if engineSpecific, ok := profile.EngineSpecific["retransmission"]; ok {
+		if retransmission, ok := engineSpecific.(map[string]interface{}); ok {
+			network.Retransmission.loadProfile(retransmission)
+		}
+	}
+
+	if engineSpecific, ok := profile.EngineSpecific["http"]; ok {
+		if http, ok := engineSpecific.(map[string]interface{}); ok {
+			network.HTTP.loadProfile(http)
+		}
+	}
+
+	if engineSpecific, ok := profile.EngineSpecific["security_overhead"]; ok {
+		if security, ok := engineSpecific.(map[string]interface{}); ok {
+			network.TLS.loadProfile(security)
+		}
+	}
+
 	// Configure protocol-specific settings
 	network.configureProtocol()
 
@@ -884,6 +1319,25 @@ func (network *NetworkEngine) LoadProfile(profile *EngineProfile) error {
 	return nil
 }
 
+// loadProfile overrides NIC offload settings present in a profile's nic_offload section
+func (offload *NICOffload) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["checksum_offload"].(bool); ok {
+		offload.ChecksumOffload = val
+	}
+	if val, ok := settings["segmentation_offload"].(bool); ok {
+		offload.SegmentationOffload = val
+	}
+	if val, ok := settings["receive_coalescing"].(bool); ok {
+		offload.ReceiveCoalescing = val
+	}
+	if val, ok := settings["per_packet_cost_us"].(float64); ok {
+		offload.PerPacketCostUs = val
+	}
+	if val, ok := settings["mtu_bytes"].(float64); ok {
+		offload.MTUBytes = int(val)
+	}
+}
+
 // GetProfile returns the current profile (BaseEngine interface)
 func (network *NetworkEngine) GetProfile() *EngineProfile {
 	return network.Profile
@@ -904,19 +1358,32 @@ func (network *NetworkEngine) GetComplexityLevel() int {
 
 // GetDynamicState returns the current dynamic state with network-specific data (BaseEngine interface)
 func (network *NetworkEngine) GetDynamicState() *DynamicState {
+	link := network.primaryLink()
 	return &DynamicState{
 		CurrentUtilization:  network.calculateCurrentUtilization(),
-		PerformanceFactor:   network.BandwidthState.CongestionFactor,
+		PerformanceFactor:   link.CongestionFactor,
 		ConvergenceProgress: network.CommonEngine.calculateConvergenceProgress(),
 		HardwareSpecific: map[string]interface{}{
-			"bandwidth_utilization":    network.BandwidthState.BandwidthUtilization,
+			"direction":                string(network.Direction),
+			"bandwidth_utilization":    link.BandwidthUtilization,
+			"ingress_utilization":      network.BandwidthState.BandwidthUtilization,
+			"egress_utilization":       network.EgressState.BandwidthUtilization,
+			"ingress_congestion_window": network.BandwidthState.CongestionWindowSegments,
+			"egress_congestion_window": network.EgressState.CongestionWindowSegments,
 			"active_connections":       network.ConnectionState.ActiveConnections,
 			"connection_utilization":   network.ConnectionState.ConnectionUtilization,
-			"packet_loss_probability":  network.BandwidthState.PacketLossProbability,
-			"congestion_factor":        network.BandwidthState.CongestionFactor,
+			"packet_loss_probability":  link.PacketLossProbability,
+			"congestion_factor":        link.CongestionFactor,
 			"protocol_efficiency":      network.ProtocolState.ProtocolEfficiency,
 			"physics_latency_ms":       network.GeographicState.PhysicsLatencyMs,
 			"active_transmissions":     len(network.ActiveTransmissions),
+			"link_utilization":         network.edgeUtilizations(),
+			"bottleneck_link":          network.BottleneckLink(),
+			"congestion_algorithm":     network.Congestion.Algorithm,
+			"tcp_connections":          len(network.TCPConnections),
+			"tls_version":              network.TLS.Version,
+			"tls_sessions":             len(network.TLSSessions),
+			"http_origins":             len(network.HTTPOrigins),
 			"protocol":                 network.Protocol,
 			"network_type":             network.NetworkType,
 			"bandwidth_mbps":           network.BandwidthMbps,
@@ -983,13 +1450,12 @@ func (network *NetworkEngine) GetConvergenceMetrics() *ConvergenceMetrics {
 
 // Reset resets the network engine to initial state (BaseEngine interface)
 func (network *NetworkEngine) Reset() {
-	// Reset bandwidth state
-	network.BandwidthState.CurrentBandwidthMbps = 0
-	network.BandwidthState.BandwidthUtilization = 0.0
-	network.BandwidthState.CongestionFactor = 1.0
-	network.BandwidthState.PacketLossProbability = 0.0
-	network.BandwidthState.CongestionLatency = 0
-	network.BandwidthState.LastBandwidthUpdate = 0
+	// Reset bandwidth state of both sides, keeping profile capacity and NIC offloads
+	for _, link := range []*NetworkLinkState{&network.BandwidthState, &network.EgressState} {
+		capacity, offload := link.CapacityMbps, link.Offload
+		*link = newNetworkLinkState(link.Direction)
+		link.CapacityMbps, link.Offload = capacity, offload
+	}
 
 	// Reset connection state
 	network.ConnectionState.ActiveConnections = 0
@@ -997,6 +1463,7 @@ func (network *NetworkEngine) Reset() {
 	network.ConnectionState.ConnectionPool = make([]string, 0, 1000)
 	network.ConnectionState.KeepAliveConnections = 0
 	network.ConnectionState.LastConnectionUpdate = 0
+	network.ConnectionState.LastConnectionReused = false
 
 	// Reset protocol state
 	network.ProtocolState.ProtocolEfficiency = 1.0
@@ -1005,6 +1472,14 @@ func (network *NetworkEngine) Reset() {
 
 	// Clear active transmissions and history
 	network.ActiveTransmissions = make(map[string]*NetworkTransmission)
+	network.EdgeStates = make(map[string]*NetworkEdgeState)
+	network.RouteFlows = make(map[string]*NetworkFlow)
+	network.TCPConnections = make(map[string]*TCPConnection)
+	network.CurrentTransfers = make(map[string]*TCPTransfer)
+	network.TLSSessions = make(map[string]int64)
+	network.CurrentHandshakes = make(map[string]*TLSHandshake)
+	network.HTTPOrigins = make(map[string]*HTTPOrigin)
+	network.CurrentRequests = make(map[string]*HTTPRequest)
 	network.TransmissionHistory = make([]TransmissionEvent, 0, 10000)
 
 	// Reset convergence models
@@ -1026,13 +1501,18 @@ func (network *NetworkEngine) GetCurrentState() map[string]interface{} {
 		"protocol":                 network.Protocol,
 		"network_type":             network.NetworkType,
 		"geographic_distance_km":   network.GeographicDistance,
+		"direction":                string(network.Direction),
+		"ingress_bandwidth_mbps":   network.linkCapacityMbps(&network.BandwidthState),
+		"egress_bandwidth_mbps":    network.linkCapacityMbps(&network.EgressState),
 
 		// Dynamic state
-		"bandwidth_utilization":    network.BandwidthState.BandwidthUtilization,
+		"bandwidth_utilization":    network.primaryLink().BandwidthUtilization,
+		"ingress_utilization":      network.BandwidthState.BandwidthUtilization,
+		"egress_utilization":       network.EgressState.BandwidthUtilization,
 		"active_connections":       network.ConnectionState.ActiveConnections,
 		"connection_utilization":   network.ConnectionState.ConnectionUtilization,
-		"packet_loss_probability":  network.BandwidthState.PacketLossProbability,
-		"congestion_factor":        network.BandwidthState.CongestionFactor,
+		"packet_loss_probability":  network.primaryLink().PacketLossProbability,
+		"congestion_factor":        network.primaryLink().CongestionFactor,
 		"protocol_efficiency":      network.ProtocolState.ProtocolEfficiency,
 		"physics_latency_ms":       network.GeographicState.PhysicsLatencyMs,
 		"active_transmissions":     len(network.ActiveTransmissions),
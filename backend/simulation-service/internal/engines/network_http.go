@@ -0,0 +1,228 @@
+package engines
+
+import (
+	"fmt"
+	"time"
+)
+
+// Application protocol behaviour per origin. HTTP/1.1 carries one request at a
+// time per connection, so once the client's connection pool is exhausted new
+// requests wait for a response to finish. HTTP/2 and gRPC multiplex streams over
+// one TCP connection: TCP delivers bytes in order, so a segment lost from any
+// stream stalls every stream behind it until it is retransmitted (head-of-line
+// blocking). HTTP/3 runs streams over QUIC, which recovers each stream's losses
+// independently.
+
+// HTTPConfig is loaded from a profile's http section
+type HTTPConfig struct {
+	MaxConnectionsPerOrigin int `json:"max_connections_per_origin"` // HTTP/1.1 client pool limit
+	MaxConcurrentStreams    int `json:"max_concurrent_streams"`     // HTTP/2, gRPC and HTTP/3 streams per connection
+}
+
+// HTTPOrigin tracks the connections and streams a client holds to one origin
+type HTTPOrigin struct {
+	Origin              string                 `json:"origin"`
+	ConnectionBusyUntil []int64                `json:"connection_busy_until"` // HTTP/1.1: tick each pooled connection finishes its response
+	Streams             map[string]*HTTPStream `json:"streams"`               // Multiplexed streams in flight
+	QueuedRequests      int64                  `json:"queued_requests"`       // Requests that waited for a connection or stream
+	HeadOfLineStalls    int64                  `json:"head_of_line_stalls"`   // Streams stalled by another stream's loss
+}
+
+// HTTPStream is a request in flight on a multiplexed connection
+type HTTPStream struct {
+	OperationID string `json:"operation_id"`
+	Segments    int64  `json:"segments"`
+	EndTick     int64  `json:"end_tick"`
+}
+
+// HTTPRequest is the protocol cost paid by one operation
+type HTTPRequest struct {
+	Origin          string        `json:"origin"`
+	Connection      int           `json:"connection"` // HTTP/1.1 pool slot, -1 when multiplexed
+	Segments        int64         `json:"segments"`
+	QueueWait       time.Duration `json:"queue_wait"`         // Waiting for a free connection or stream
+	HeadOfLineDelay time.Duration `json:"head_of_line_delay"` // Stalled behind other streams' lost segments
+	StreamsInFlight int           `json:"streams_in_flight"`
+	OtherSegments   int64         `json:"other_segments"` // Segments of the other streams sharing the connection
+}
+
+// defaultHTTPConfig returns browser defaults: six connections per origin for
+// HTTP/1.1 and the common 100-stream limit for multiplexed protocols
+func defaultHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		MaxConnectionsPerOrigin: 6,
+		MaxConcurrentStreams:    100,
+	}
+}
+
+// loadProfile overrides HTTP settings present in a profile's http section
+func (config *HTTPConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["max_connections_per_origin"].(float64); ok && val >= 1 {
+		config.MaxConnectionsPerOrigin = int(val)
+	}
+	if val, ok := settings["max_concurrent_streams"].(float64); ok && val >= 1 {
+		config.MaxConcurrentStreams = int(val)
+	}
+}
+
+// usesQUIC reports whether the protocol runs over QUIC
+func (network *NetworkEngine) usesQUIC() bool {
+	return network.Protocol == "QUIC" || network.Protocol == "HTTP/3"
+}
+
+// multiplexed reports whether requests share a connection as streams
+func (network *NetworkEngine) multiplexed() bool {
+	switch network.Protocol {
+	case "HTTP/2", "gRPC", "HTTP/3":
+		return true
+	}
+	return false
+}
+
+// httpOrigin returns the tracked state for an operation's origin, named by its
+// origin or target_node metadata
+func (network *NetworkEngine) httpOrigin(op *Operation) *HTTPOrigin {
+	name, _ := op.Metadata["origin"].(string)
+	if name == "" {
+		name, _ = op.Metadata["target_node"].(string)
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	origin, ok := network.HTTPOrigins[name]
+	if !ok {
+		origin = &HTTPOrigin{Origin: name, Streams: make(map[string]*HTTPStream)}
+		network.HTTPOrigins[name] = origin
+	}
+	return origin
+}
+
+// reserveHTTPRequest assigns an HTTP request its connection or stream before it
+// is sent and works out how long it waits for one
+func (network *NetworkEngine) reserveHTTPRequest(op *Operation, link *NetworkLinkState) {
+	if network.Protocol != "HTTP/1.1" && !network.multiplexed() {
+		return
+	}
+
+	origin := network.httpOrigin(op)
+	mss := int64(network.maxSegmentBytes(link))
+	request := &HTTPRequest{Origin: origin.Origin, Connection: -1, Segments: (op.DataSize + mss - 1) / mss}
+	network.CurrentRequests[op.ID] = request
+
+	if network.Protocol == "HTTP/1.1" {
+		request.QueueWait = network.reservePooledConnection(origin, request)
+		return
+	}
+
+	// Finished streams free their slot on the connection
+	earliestEnd, otherSegments := int64(-1), int64(0)
+	for id, stream := range origin.Streams {
+		if stream.EndTick <= network.CurrentTick {
+			delete(origin.Streams, id)
+			continue
+		}
+		if earliestEnd < 0 || stream.EndTick < earliestEnd {
+			earliestEnd = stream.EndTick
+		}
+		otherSegments += stream.Segments
+	}
+	request.StreamsInFlight = len(origin.Streams)
+	request.OtherSegments = otherSegments
+
+	if len(origin.Streams) >= network.HTTP.MaxConcurrentStreams {
+		request.QueueWait = network.TicksToDuration(earliestEnd - network.CurrentTick)
+		origin.QueuedRequests++
+	}
+}
+
+// applyProtocolBehavior adds a request's wait for its connection or stream and,
+// on multiplexed TCP connections, its head-of-line stalls. TCP delivers in order,
+// so each round trip in which another stream loses a segment holds back this
+// stream's bytes until the fast retransmit arrives a round trip later, or a
+// timeout when too few segments follow to trigger one. QUIC streams never wait
+// on each other. The connection or stream is held until the operation completes.
+func (network *NetworkEngine) applyProtocolBehavior(baseTime time.Duration, op *Operation, link *NetworkLinkState) time.Duration {
+	request, ok := network.CurrentRequests[op.ID]
+	if !ok {
+		return baseTime
+	}
+
+	if !network.usesQUIC() && request.OtherSegments > 0 {
+		rounds := int64(1)
+		if transfer, ok := network.CurrentTransfers[op.ID]; ok && transfer.RoundTrips > 1 {
+			rounds = transfer.RoundTrips
+		}
+		perRound := (request.OtherSegments + rounds - 1) / rounds
+
+		rtt := network.roundTripTime(op)
+		stall := rtt
+		if perRound < 4 {
+			stall = network.retransmissionTimeout(nil, rtt)
+		}
+		for i := int64(0); i < rounds; i++ {
+			if network.checkWindowLoss(link, perRound) {
+				request.HeadOfLineDelay += stall
+			}
+		}
+		if request.HeadOfLineDelay > 0 {
+			network.HTTPOrigins[request.Origin].HeadOfLineStalls++
+		}
+	}
+
+	return baseTime + request.QueueWait + request.HeadOfLineDelay
+}
+
+// connectionID names the TCP connection carrying a request: one per pooled
+// HTTP/1.1 connection, or the origin's single multiplexed connection
+func (request *HTTPRequest) connectionID() string {
+	if request.Connection >= 0 {
+		return fmt.Sprintf("%s#%d", request.Origin, request.Connection)
+	}
+	return request.Origin
+}
+
+// reservePooledConnection picks the HTTP/1.1 connection that frees up first,
+// opening another while the pool has room, and returns the wait for it
+func (network *NetworkEngine) reservePooledConnection(origin *HTTPOrigin, request *HTTPRequest) time.Duration {
+	slot := -1
+	for i, busyUntil := range origin.ConnectionBusyUntil {
+		if slot < 0 || busyUntil < origin.ConnectionBusyUntil[slot] {
+			slot = i
+		}
+	}
+
+	if slot < 0 || origin.ConnectionBusyUntil[slot] > network.CurrentTick {
+		if len(origin.ConnectionBusyUntil) < network.HTTP.MaxConnectionsPerOrigin {
+			origin.ConnectionBusyUntil = append(origin.ConnectionBusyUntil, network.CurrentTick)
+			request.Connection = len(origin.ConnectionBusyUntil) - 1
+			return 0
+		}
+	}
+
+	request.Connection = slot
+	if origin.ConnectionBusyUntil[slot] <= network.CurrentTick {
+		return 0
+	}
+	origin.QueuedRequests++
+	return network.TicksToDuration(origin.ConnectionBusyUntil[slot] - network.CurrentTick)
+}
+
+// completeHTTPRequest holds the request's connection or stream until the tick it completes
+func (network *NetworkEngine) completeHTTPRequest(op *Operation, request *HTTPRequest, completedTick int64) {
+	origin := network.HTTPOrigins[request.Origin]
+	if request.Connection >= 0 {
+		origin.ConnectionBusyUntil[request.Connection] = completedTick
+		return
+	}
+	origin.Streams[op.ID] = &HTTPStream{OperationID: op.ID, Segments: request.Segments, EndTick: completedTick}
+}
+
+// GetHTTPOrigins returns a snapshot of per-origin connection and stream state
+func (network *NetworkEngine) GetHTTPOrigins() map[string]HTTPOrigin {
+	origins := make(map[string]HTTPOrigin, len(network.HTTPOrigins))
+	for name, origin := range network.HTTPOrigins {
+		origins[name] = *origin
+	}
+	return origins
+}
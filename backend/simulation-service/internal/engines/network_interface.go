@@ -21,6 +21,7 @@ type NetworkFeatures struct {
 	EnablePacketLoss         bool `json:"enable_packet_loss"`          // Realistic packet loss modeling
 	EnableJitterModeling     bool `json:"enable_jitter_modeling"`      // Network jitter and variance
 	EnableGeographicEffects  bool `json:"enable_geographic_effects"`   // Distance-based latency
+	EnableNICOffload         bool `json:"enable_nic_offload"`          // Checksum, TSO/GSO and LRO/GRO offloads
 	
 	// Advanced network modeling (Advanced+)
 	EnableQoSModeling        bool `json:"enable_qos_modeling"`         // Quality of Service prioritization
@@ -101,6 +102,7 @@ func (ni *NetworkInterface) configureMinimalFeatures() {
 		EnablePacketLoss:         false,
 		EnableJitterModeling:     false,
 		EnableGeographicEffects:  false,
+		EnableNICOffload:         false,
 		EnableQoSModeling:        false,
 		EnableLoadBalancing:      false,
 		EnableSecurityOverhead:   false,
@@ -132,6 +134,7 @@ func (ni *NetworkInterface) configureBasicFeatures() {
 		EnablePacketLoss:         true,  // Packet loss affects real-world performance
 		EnableJitterModeling:     true,  // Jitter is important for application performance
 		EnableGeographicEffects:  true,  // Distance effects are real-world critical
+		EnableNICOffload:         true,  // Per-packet host cost depends on NIC offloads
 		
 		// Some advanced features
 		EnableQoSModeling:        false, // Skip QoS complexity
@@ -163,6 +166,7 @@ func (ni *NetworkInterface) configureAdvancedFeatures() {
 		EnablePacketLoss:         true,
 		EnableJitterModeling:     true,
 		EnableGeographicEffects:  true,
+		EnableNICOffload:         true,
 		
 		// Enhanced real-world features
 		EnableQoSModeling:        true,  // QoS modeling for enhanced accuracy
@@ -194,6 +198,7 @@ func (ni *NetworkInterface) configureMaximumFeatures() {
 		EnablePacketLoss:         true,
 		EnableJitterModeling:     true,
 		EnableGeographicEffects:  true,
+		EnableNICOffload:         true,
 		EnableQoSModeling:        true,
 		EnableLoadBalancing:      true,
 		EnableSecurityOverhead:   true,
@@ -230,6 +235,8 @@ func (ni *NetworkInterface) ShouldEnableFeature(featureName string) bool {
 		return ni.Features.EnableJitterModeling
 	case "geographic_effects":
 		return ni.Features.EnableGeographicEffects
+	case "nic_offload":
+		return ni.Features.EnableNICOffload
 	case "qos_modeling":
 		return ni.Features.EnableQoSModeling
 	case "load_balancing":
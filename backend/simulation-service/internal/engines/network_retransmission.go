@@ -0,0 +1,182 @@
+package engines
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Recovery of lost packets. TCP retransmits a lost segment: the first time
+// after three duplicate ACKs, then on a retransmission timeout (RTO) derived
+// from the smoothed RTT, doubling the timeout each try. QUIC probes on its own
+// timeout (PTO) and gives up after an idle period. UDP never retransmits. Once
+// the retry budget is spent the operation fails with a *NetworkError.
+
+// NetworkErrorCode identifies why a network operation failed
+type NetworkErrorCode string
+
+const (
+	NetworkErrorRetransmitsExhausted NetworkErrorCode = "retransmits_exhausted" // TCP gave up after its retry budget
+	NetworkErrorIdleTimeout          NetworkErrorCode = "idle_timeout"          // QUIC heard no ACK within its idle timeout
+	NetworkErrorDatagramLost         NetworkErrorCode = "datagram_lost"         // UDP does not retransmit
+)
+
+// NetworkError is returned for network operations that lost data they could not recover
+type NetworkError struct {
+	Code        NetworkErrorCode `json:"code"`
+	OperationID string           `json:"operation_id"`
+	Protocol    string           `json:"protocol"`
+	Attempts    int              `json:"attempts"` // Retransmissions tried before giving up
+	Elapsed     time.Duration    `json:"elapsed"`  // Time spent recovering before giving up
+}
+
+// Error implements the error interface
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network %s: operation %s over %s failed after %d retransmissions in %v",
+		e.Code, e.OperationID, e.Protocol, e.Attempts, e.Elapsed)
+}
+
+// RetransmissionConfig is loaded from a profile's retransmission section
+type RetransmissionConfig struct {
+	MaxRetransmits  int           `json:"max_retransmits"`   // TCP retry budget per lost segment
+	MinRTO          time.Duration `json:"min_rto"`           // Lower bound on the retransmission timeout
+	MaxRTO          time.Duration `json:"max_rto"`           // Upper bound after exponential backoff
+	QUICIdleTimeout time.Duration `json:"quic_idle_timeout"` // QUIC closes the connection after this long without ACKs
+}
+
+// defaultRetransmissionConfig returns Linux defaults: 15 retries (tcp_retries2),
+// RTO between 200ms and 120s, and a 30s QUIC idle timeout
+func defaultRetransmissionConfig() RetransmissionConfig {
+	return RetransmissionConfig{
+		MaxRetransmits:  15,
+		MinRTO:          200 * time.Millisecond,
+		MaxRTO:          120 * time.Second,
+		QUICIdleTimeout: 30 * time.Second,
+	}
+}
+
+// loadProfile overrides retransmission settings present in a profile's retransmission section
+func (config *RetransmissionConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["max_retransmits"].(float64); ok && val >= 0 {
+		config.MaxRetransmits = int(val)
+	}
+	if val, ok := settings["min_rto_ms"].(float64); ok && val > 0 {
+		config.MinRTO = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["max_rto_ms"].(float64); ok && val > 0 {
+		config.MaxRTO = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["quic_idle_timeout_ms"].(float64); ok && val > 0 {
+		config.QUICIdleTimeout = time.Duration(val * float64(time.Millisecond))
+	}
+}
+
+// observeRTT folds a round trip sample into the connection's smoothed RTT and
+// RTT variance (RFC 6298). Retransmitted rounds are never sampled (Karn).
+func (conn *TCPConnection) observeRTT(sample time.Duration) {
+	if conn.SmoothedRTT == 0 {
+		conn.SmoothedRTT = sample
+		conn.RTTVariance = sample / 2
+		return
+	}
+	deviation := conn.SmoothedRTT - sample
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	conn.RTTVariance = (3*conn.RTTVariance + deviation) / 4
+	conn.SmoothedRTT = (7*conn.SmoothedRTT + sample) / 8
+}
+
+// retransmissionTimeout returns RTO = SRTT + 4*RTTVAR, clamped to the configured
+// bounds. Without samples from the connection the path RTT is used as the first sample.
+func (network *NetworkEngine) retransmissionTimeout(conn *TCPConnection, rtt time.Duration) time.Duration {
+	srtt, rttvar := rtt, rtt/2
+	if conn != nil && conn.SmoothedRTT > 0 {
+		srtt, rttvar = conn.SmoothedRTT, conn.RTTVariance
+	}
+
+	rto := srtt + 4*rttvar
+	if rto < network.Retransmission.MinRTO {
+		rto = network.Retransmission.MinRTO
+	}
+	if rto > network.Retransmission.MaxRTO {
+		rto = network.Retransmission.MaxRTO
+	}
+	return rto
+}
+
+// probeTimeout returns QUIC's PTO = SRTT + max(4*RTTVAR, 1ms) + max_ack_delay (RFC 9002)
+func (network *NetworkEngine) probeTimeout(conn *TCPConnection, rtt time.Duration) time.Duration {
+	srtt, rttvar := rtt, rtt/2
+	if conn != nil && conn.SmoothedRTT > 0 {
+		srtt, rttvar = conn.SmoothedRTT, conn.RTTVariance
+	}
+	return srtt + time.Duration(math.Max(float64(4*rttvar), float64(time.Millisecond))) + 25*time.Millisecond
+}
+
+// retransmit recovers one lost segment, returning the time spent and the number
+// of retransmissions sent. fastRetransmit is set when enough segments followed
+// the lost one to produce three duplicate ACKs. A retransmission timeout drops
+// the window to one segment; QUIC's probe timeouts do not.
+func (network *NetworkEngine) retransmit(op *Operation, conn *TCPConnection, link *NetworkLinkState, rtt time.Duration, fastRetransmit bool) (time.Duration, int, error) {
+	protocol := network.Protocol
+	if protocol == "UDP" {
+		return 0, 0, &NetworkError{Code: NetworkErrorDatagramLost, OperationID: op.ID, Protocol: protocol}
+	}
+	quic := network.usesQUIC()
+
+	elapsed, attempts := time.Duration(0), 0
+	timeout := network.retransmissionTimeout(conn, rtt)
+	if quic {
+		timeout = network.probeTimeout(conn, rtt)
+	}
+
+	for {
+		// Loss is detected a round trip later from duplicate ACKs (TCP) or
+		// later acknowledged packets (QUIC), otherwise only when the timer fires
+		if attempts == 0 && (fastRetransmit || quic) {
+			elapsed += rtt
+		} else {
+			elapsed += timeout
+			timeout = time.Duration(math.Min(float64(2*timeout), float64(network.Retransmission.MaxRTO)))
+			if conn != nil && !quic && conn.Algorithm != CongestionAlgorithmBBR {
+				conn.CongestionWindow = 1
+				conn.SlowStart = true
+			}
+		}
+
+		if quic && elapsed > network.Retransmission.QUICIdleTimeout {
+			return elapsed, attempts, &NetworkError{Code: NetworkErrorIdleTimeout, OperationID: op.ID, Protocol: protocol, Attempts: attempts, Elapsed: elapsed}
+		}
+		if !quic && attempts >= network.Retransmission.MaxRetransmits {
+			return elapsed, attempts, &NetworkError{Code: NetworkErrorRetransmitsExhausted, OperationID: op.ID, Protocol: protocol, Attempts: attempts, Elapsed: elapsed}
+		}
+
+		attempts++
+		if !network.checkWindowLoss(link, 1) {
+			return elapsed, attempts, nil
+		}
+	}
+}
+
+// applyPacketLoss recovers an operation's lost packets. Transfers already sent
+// segment by segment under congestion control carry their own outcome; other
+// operations check all their packets at once and retransmit on the path RTT.
+func (network *NetworkEngine) applyPacketLoss(baseTime time.Duration, op *Operation, link *NetworkLinkState) (time.Duration, error) {
+	if transfer, ok := network.CurrentTransfers[op.ID]; ok {
+		return baseTime, transfer.Err
+	}
+
+	mss := int64(network.maxSegmentBytes(link))
+	packets := (op.DataSize + mss - 1) / mss
+	if packets < 1 {
+		packets = 1
+	}
+	if !network.checkWindowLoss(link, packets) {
+		return baseTime, nil
+	}
+
+	recovery, attempts, err := network.retransmit(op, nil, link, network.roundTripTime(op), false)
+	network.CurrentTransfers[op.ID] = &TCPTransfer{LossEvents: 1, Retransmits: int64(attempts), RecoveryTime: recovery, Err: err}
+	return baseTime + recovery, err
+}
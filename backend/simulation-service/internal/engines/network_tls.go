@@ -0,0 +1,161 @@
+package engines
+
+import (
+	"time"
+)
+
+// TLS on new connections. Reused keep-alive connections skip the handshake.
+// A full handshake costs two round trips on TLS 1.2 and one on TLS 1.3, plus
+// certificate validation and asymmetric crypto on the server. Clients holding
+// a session ticket resume instead: one round trip (TLS 1.2 or 1.3) or none with
+// TLS 1.3 early data, and only symmetric crypto. The server's crypto time is
+// handed to the CPU engine as a follow-up operation rather than added here.
+
+// TLS protocol versions
+const (
+	TLSVersion12 = "1.2"
+	TLSVersion13 = "1.3"
+)
+
+// TLSConfig is loaded from a profile's security_overhead section
+type TLSConfig struct {
+	Version                 string        `json:"version"`                   // 1.2 or 1.3
+	SessionTickets          bool          `json:"session_tickets"`           // Server issues tickets for resumption
+	EarlyData               bool          `json:"early_data"`                // TLS 1.3 0-RTT on resumption
+	TicketLifetime          time.Duration `json:"ticket_lifetime"`           // How long a client's ticket stays valid
+	SessionReuseProbability float64       `json:"session_reuse_probability"` // Chance an unidentified client holds a ticket
+	CertificateValidation   time.Duration `json:"certificate_validation"`    // Chain verification on full handshakes
+	FullHandshakeCPU        time.Duration `json:"full_handshake_cpu"`        // Server key exchange and signature
+	ResumedHandshakeCPU     time.Duration `json:"resumed_handshake_cpu"`     // Ticket decryption and key schedule
+	EncryptionCPUOverhead   float64       `json:"encryption_cpu_overhead"`   // Bulk encryption cost relative to transfer time
+}
+
+// TLSHandshake is the handshake paid by one operation's new connection
+type TLSHandshake struct {
+	Version    string        `json:"version"`
+	Resumed    bool          `json:"resumed"`
+	RoundTrips int           `json:"round_trips"`
+	Latency    time.Duration `json:"latency"`  // Round trips plus certificate validation
+	CPUTime    time.Duration `json:"cpu_time"` // Server crypto, run on the CPU engine
+}
+
+// defaultTLSConfig returns TLS 1.3 with session tickets, ECDHE-P256 with an
+// RSA-2048 signature for full handshakes, and 8% bulk encryption overhead
+func defaultTLSConfig() TLSConfig {
+	return TLSConfig{
+		Version:                 TLSVersion13,
+		SessionTickets:          true,
+		EarlyData:               false,
+		TicketLifetime:          2 * time.Hour,
+		SessionReuseProbability: 0.8,
+		CertificateValidation:   1 * time.Millisecond,
+		FullHandshakeCPU:        1500 * time.Microsecond,
+		ResumedHandshakeCPU:     100 * time.Microsecond,
+		EncryptionCPUOverhead:   0.08,
+	}
+}
+
+// loadProfile overrides TLS settings present in a profile's security_overhead section
+func (config *TLSConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["version"].(string); ok {
+		config.Version = val
+	}
+	if val, ok := settings["session_tickets"].(bool); ok {
+		config.SessionTickets = val
+	}
+	if val, ok := settings["early_data"].(bool); ok {
+		config.EarlyData = val
+	}
+	if val, ok := settings["ticket_lifetime_s"].(float64); ok && val > 0 {
+		config.TicketLifetime = time.Duration(val * float64(time.Second))
+	}
+	if val, ok := settings["session_reuse_probability"].(float64); ok {
+		config.SessionReuseProbability = val
+	}
+	if val, ok := settings["certificate_validation_ms"].(float64); ok && val >= 0 {
+		config.CertificateValidation = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["handshake_cpu_ms"].(float64); ok && val >= 0 {
+		config.FullHandshakeCPU = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["resumption_cpu_ms"].(float64); ok && val >= 0 {
+		config.ResumedHandshakeCPU = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["encryption_cpu_overhead"].(float64); ok && val >= 0 {
+		config.EncryptionCPUOverhead = val
+	}
+}
+
+// tlsHandshake works out the handshake for an operation opening a new
+// connection. Clients named by a client_id resume when they hold an unexpired
+// ticket; anonymous clients resume with the profile's reuse probability.
+func (network *NetworkEngine) tlsHandshake(op *Operation) *TLSHandshake {
+	config := network.TLS
+	if network.usesQUIC() {
+		config.Version = TLSVersion13 // QUIC carries only TLS 1.3
+	}
+	handshake := &TLSHandshake{Version: config.Version}
+
+	clientID, _ := op.Metadata["client_id"].(string)
+	if config.SessionTickets {
+		if clientID != "" {
+			issued, ok := network.TLSSessions[clientID]
+			handshake.Resumed = ok && network.CurrentTick-issued <= network.DurationToTicks(config.TicketLifetime)
+		} else {
+			handshake.Resumed = network.randomFloat64() < config.SessionReuseProbability
+		}
+	}
+
+	switch {
+	case config.Version == TLSVersion12 && !handshake.Resumed:
+		handshake.RoundTrips = 2
+	case config.Version == TLSVersion13 && handshake.Resumed && config.EarlyData:
+		handshake.RoundTrips = 0 // Request data rides with the ClientHello
+	default:
+		handshake.RoundTrips = 1
+	}
+
+	handshake.Latency = time.Duration(handshake.RoundTrips) * network.roundTripTime(op)
+	if handshake.Resumed {
+		handshake.CPUTime = config.ResumedHandshakeCPU
+	} else {
+		handshake.Latency += config.CertificateValidation
+		handshake.CPUTime = config.FullHandshakeCPU
+	}
+
+	// Every handshake ends with a fresh ticket for the next connection
+	if config.SessionTickets && clientID != "" {
+		network.TLSSessions[clientID] = network.CurrentTick
+	}
+
+	return handshake
+}
+
+// handshakeCPUOperation returns the CPU engine operation for a handshake's server-side crypto
+func (network *NetworkEngine) handshakeCPUOperation(op *Operation, handshake *TLSHandshake) *Operation {
+	return &Operation{
+		ID:         op.ID + "_tls",
+		Type:       OpCPUCompute,
+		Complexity: ComplexityO1,
+		Language:   LangCPP, // OpenSSL/BoringSSL
+		Priority:   op.Priority,
+		StartTick:  network.CurrentTick,
+		Metadata: map[string]interface{}{
+			"source":       "tls_handshake",
+			"tls_version":  handshake.Version,
+			"tls_resumed":  handshake.Resumed,
+			"cpu_time_ms":  float64(handshake.CPUTime) / float64(time.Millisecond),
+			"operation_id": op.ID,
+		},
+	}
+}
+
+// pruneTLSSessions forgets session tickets past their lifetime
+func (network *NetworkEngine) pruneTLSSessions() {
+	lifetime := network.DurationToTicks(network.TLS.TicketLifetime)
+	for clientID, issued := range network.TLSSessions {
+		if network.CurrentTick-issued > lifetime {
+			delete(network.TLSSessions, clientID)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package engines
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Multi-hop routing over the engine's NetworkTopology. Edges are physical links
+// usable in both directions. Routes minimise total latency or total hop count,
+// and concurrent transmissions share each edge's bandwidth equally, so the
+// smallest share along a path is the bottleneck of a transfer.
+
+// Routing metrics for shortest-path selection
+const (
+	RoutingMetricLatency = "latency" // Minimise summed edge latency
+	RoutingMetricHops    = "hops"    // Minimise summed router hops
+)
+
+// NetworkRoute is a path through the topology between two nodes
+type NetworkRoute struct {
+	SourceNodeID     string   `json:"source_node_id"`
+	TargetNodeID     string   `json:"target_node_id"`
+	NodeIDs          []string `json:"node_ids"`
+	EdgeIDs          []string `json:"edge_ids"`
+	LatencyMs        float64  `json:"latency_ms"`         // Summed one-way edge latency
+	HopCount         int      `json:"hop_count"`          // Summed router hops
+	BottleneckEdgeID string   `json:"bottleneck_edge_id"` // Edge giving this route the least bandwidth
+	BottleneckMbps   float64  `json:"bottleneck_mbps"`    // Bandwidth share available on that edge
+}
+
+// NetworkEdgeState tracks the transmissions currently sharing one edge
+type NetworkEdgeState struct {
+	EdgeID               string  `json:"edge_id"`
+	ActiveFlows          int     `json:"active_flows"`
+	CurrentMbps          float64 `json:"current_mbps"`
+	Utilization          float64 `json:"utilization"`
+	PeakUtilization      float64 `json:"peak_utilization"`
+	BytesCarried         int64   `json:"bytes_carried"`
+	TransmissionsCarried int64   `json:"transmissions_carried"`
+}
+
+// NetworkFlow is a routed transmission holding bandwidth on every edge of its path
+type NetworkFlow struct {
+	OperationID string        `json:"operation_id"`
+	Route       *NetworkRoute `json:"route"`
+	RateMbps    float64       `json:"rate_mbps"`
+	EndTick     int64         `json:"end_tick"`
+}
+
+// FindRoute returns the shortest path between two topology nodes using the
+// engine's routing metric. With advanced routing enabled, busy edges weigh more
+// so traffic steers around congestion.
+func (network *NetworkEngine) FindRoute(sourceNodeID, targetNodeID string) (*NetworkRoute, error) {
+	topology := network.NetworkTopology
+	if topology == nil {
+		return nil, fmt.Errorf("no network topology configured")
+	}
+
+	// Adjacency in edge ID order keeps tie-breaking deterministic
+	edgeIDs := make([]string, 0, len(topology.Edges))
+	for id := range topology.Edges {
+		edgeIDs = append(edgeIDs, id)
+	}
+	sort.Strings(edgeIDs)
+
+	adjacency := make(map[string][]*NetworkEdge)
+	for _, id := range edgeIDs {
+		edge := topology.Edges[id]
+		adjacency[edge.SourceNodeID] = append(adjacency[edge.SourceNodeID], edge)
+		adjacency[edge.TargetNodeID] = append(adjacency[edge.TargetNodeID], edge)
+	}
+	if _, ok := adjacency[sourceNodeID]; !ok && sourceNodeID != targetNodeID {
+		return nil, fmt.Errorf("node %s has no links", sourceNodeID)
+	}
+
+	congestionAware := network.ComplexityInterface.ShouldEnableFeature("advanced_routing")
+
+	// Dijkstra over the (small) topology graph
+	distance := map[string]float64{sourceNodeID: 0}
+	previous := make(map[string]*NetworkEdge)
+	visited := make(map[string]bool)
+	for {
+		current, best := "", math.Inf(1)
+		for node, d := range distance {
+			if !visited[node] && (d < best || (d == best && node < current)) {
+				current, best = node, d
+			}
+		}
+		if current == "" || current == targetNodeID {
+			break
+		}
+		visited[current] = true
+
+		for _, edge := range adjacency[current] {
+			next := edge.TargetNodeID
+			if next == current {
+				next = edge.SourceNodeID
+			}
+
+			weight := network.edgeWeight(edge)
+			if congestionAware {
+				weight *= 1.0 + network.edgeState(edge.ID).Utilization
+			}
+			if d, seen := distance[next]; !seen || best+weight < d {
+				distance[next] = best + weight
+				previous[next] = edge
+			}
+		}
+	}
+
+	if _, reached := distance[targetNodeID]; !reached {
+		return nil, fmt.Errorf("no route from %s to %s", sourceNodeID, targetNodeID)
+	}
+
+	// Walk back from the target to build the path
+	route := &NetworkRoute{SourceNodeID: sourceNodeID, TargetNodeID: targetNodeID, BottleneckMbps: math.Inf(1)}
+	nodes := []string{targetNodeID}
+	edges := []string{}
+	for node := targetNodeID; node != sourceNodeID; {
+		edge := previous[node]
+		edges = append(edges, edge.ID)
+		if edge.TargetNodeID == node {
+			node = edge.SourceNodeID
+		} else {
+			node = edge.TargetNodeID
+		}
+		nodes = append(nodes, node)
+
+		route.LatencyMs += network.edgeLatencyMs(edge)
+		route.HopCount += edgeHops(edge)
+
+		// A new flow gets an equal share of the edge with the flows already on it
+		share := float64(edge.BandwidthMbps) / float64(network.edgeState(edge.ID).ActiveFlows+1)
+		if share < route.BottleneckMbps {
+			route.BottleneckEdgeID, route.BottleneckMbps = edge.ID, share
+		}
+	}
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+	route.NodeIDs, route.EdgeIDs = nodes, edges
+	if len(edges) == 0 {
+		route.BottleneckMbps = 0
+	}
+
+	return route, nil
+}
+
+// edgeWeight returns an edge's cost under the engine's routing metric
+func (network *NetworkEngine) edgeWeight(edge *NetworkEdge) float64 {
+	if network.RoutingMetric == RoutingMetricHops {
+		return float64(edgeHops(edge))
+	}
+	return network.edgeLatencyMs(edge)
+}
+
+// edgeLatencyMs returns an edge's measured latency, or its propagation delay
+// through fiber when no measurement is configured
+func (network *NetworkEngine) edgeLatencyMs(edge *NetworkEdge) float64 {
+	if edge.BaseLatencyMs > 0 {
+		return edge.BaseLatencyMs
+	}
+	effectiveSpeed := network.GeographicState.SpeedOfLightMps * network.GeographicState.FiberOpticFactor
+	if effectiveSpeed <= 0 {
+		return 0
+	}
+	return edge.DistanceKm * 1000 / effectiveSpeed * network.GeographicState.RoutingOverhead * 1000
+}
+
+// edgeHops returns the router hops an edge crosses, at least one
+func edgeHops(edge *NetworkEdge) int {
+	if edge.HopCount > 0 {
+		return edge.HopCount
+	}
+	return 1
+}
+
+// edgeState returns the tracked state of an edge, creating it on first use
+func (network *NetworkEngine) edgeState(edgeID string) *NetworkEdgeState {
+	state, ok := network.EdgeStates[edgeID]
+	if !ok {
+		state = &NetworkEdgeState{EdgeID: edgeID}
+		network.EdgeStates[edgeID] = state
+	}
+	return state
+}
+
+// startFlow holds bandwidth for a routed transmission on every edge of its path
+func (network *NetworkEngine) startFlow(op *Operation, route *NetworkRoute, endTick int64) {
+	if previous, ok := network.RouteFlows[op.ID]; ok {
+		network.releaseFlow(previous)
+	}
+
+	flow := &NetworkFlow{OperationID: op.ID, Route: route, RateMbps: route.BottleneckMbps, EndTick: endTick}
+	network.RouteFlows[op.ID] = flow
+
+	for _, edgeID := range route.EdgeIDs {
+		state := network.edgeState(edgeID)
+		state.ActiveFlows++
+		state.CurrentMbps += flow.RateMbps
+		state.BytesCarried += op.DataSize
+		state.TransmissionsCarried++
+	}
+	network.updateEdgeUtilization()
+}
+
+// releaseFlow returns a finished transmission's bandwidth to its edges
+func (network *NetworkEngine) releaseFlow(flow *NetworkFlow) {
+	for _, edgeID := range flow.Route.EdgeIDs {
+		state := network.edgeState(edgeID)
+		state.ActiveFlows--
+		state.CurrentMbps = math.Max(0, state.CurrentMbps-flow.RateMbps)
+	}
+	delete(network.RouteFlows, flow.OperationID)
+}
+
+// updateTopologyUtilization retires finished routed transmissions and refreshes
+// per-edge utilization
+func (network *NetworkEngine) updateTopologyUtilization() {
+	for _, flow := range network.RouteFlows {
+		if flow.EndTick < network.CurrentTick {
+			network.releaseFlow(flow)
+		}
+	}
+	network.updateEdgeUtilization()
+}
+
+func (network *NetworkEngine) updateEdgeUtilization() {
+	if network.NetworkTopology == nil {
+		return
+	}
+	for edgeID, state := range network.EdgeStates {
+		edge, ok := network.NetworkTopology.Edges[edgeID]
+		if !ok || edge.BandwidthMbps <= 0 {
+			continue
+		}
+		state.Utilization = math.Min(state.CurrentMbps/float64(edge.BandwidthMbps), 1.0)
+		state.PeakUtilization = math.Max(state.PeakUtilization, state.Utilization)
+	}
+}
+
+// GetLinkUtilization returns a snapshot of every topology edge that has carried traffic
+func (network *NetworkEngine) GetLinkUtilization() map[string]NetworkEdgeState {
+	links := make(map[string]NetworkEdgeState, len(network.EdgeStates))
+	for edgeID, state := range network.EdgeStates {
+		links[edgeID] = *state
+	}
+	return links
+}
+
+// edgeUtilizations returns current utilization by edge ID for dynamic state reporting
+func (network *NetworkEngine) edgeUtilizations() map[string]float64 {
+	utilization := make(map[string]float64, len(network.EdgeStates))
+	for edgeID, state := range network.EdgeStates {
+		utilization[edgeID] = state.Utilization
+	}
+	return utilization
+}
+
+// BottleneckLink returns the most utilized topology edge, or "" when no edge carries traffic
+func (network *NetworkEngine) BottleneckLink() string {
+	bottleneck, highest := "", 0.0
+	for edgeID, state := range network.EdgeStates {
+		if state.Utilization > highest || (state.Utilization == highest && highest > 0 && edgeID < bottleneck) {
+			bottleneck, highest = edgeID, state.Utilization
+		}
+	}
+	return bottleneck
+}
+
+// routeTransmission adds the routed path's latency and the time lost to its
+// bottleneck edge, compared with sending at the engine's own bandwidth
+func (network *NetworkEngine) routeTransmission(baseTime time.Duration, op *Operation, route *NetworkRoute) time.Duration {
+	pathLatency := time.Duration(route.LatencyMs * float64(time.Millisecond))
+
+	extraTransfer := time.Duration(0)
+	if op.DataSize > 0 && route.BottleneckMbps > 0 && network.BandwidthMbps > 0 {
+		bits := float64(op.DataSize) * 8
+		bottleneckSeconds := bits / (route.BottleneckMbps * 1e6)
+		localSeconds := bits / (float64(network.BandwidthMbps) * 1e6)
+		if bottleneckSeconds > localSeconds {
+			extraTransfer = time.Duration((bottleneckSeconds - localSeconds) * float64(time.Second))
+		}
+	}
+
+	routedTime := baseTime + pathLatency + extraTransfer
+	network.startFlow(op, route, network.CurrentTick+network.DurationToTicks(routedTime))
+	return routedTime
+}
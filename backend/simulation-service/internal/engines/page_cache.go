@@ -0,0 +1,432 @@
+package engines
+
+import (
+	"container/list"
+	"time"
+)
+
+// The OS page cache of the component instance a storage engine belongs to. It
+// lives in the RAM the instance's memory engine leaves free, so it shrinks as
+// the process grows. Reads find their pages in the cache and are copied out at
+// memory latency, or miss and read from the device, leaving the pages cached.
+// Writes are copied into the cache and return at once, leaving the pages dirty;
+// the flusher wakes every writeback interval and writes back pages dirty for
+// longer than the expiry, and once dirty pages pass the dirty ratio writers
+// must wait for the device themselves. storage_sync (fsync) writes back every
+// dirty page before returning, and operations with direct metadata (O_DIRECT)
+// bypass the cache.
+//
+// The cache evicts the least recently used page, or under 2Q keeps pages read
+// once in a probation FIFO and promotes only those touched again (or soon
+// after eviction, remembered as ghosts), so one scan cannot flush the hot set.
+// Evicting a dirty page writes it back first. Pages are addressed by the
+// operation's lba metadata, placed as the HDD model places them when missing.
+
+// Page cache replacement policies
+const (
+	PageCacheLRU = "lru"
+	PageCache2Q  = "2q"
+)
+
+// PageCacheMemory is the RAM a storage engine's page cache lives in
+type PageCacheMemory interface {
+	PageCacheBytes() int64                      // RAM the process leaves free for the cache
+	PageCacheLatency(bytes int64) time.Duration // Time to copy bytes to or from the cache
+}
+
+// PageCacheConfig is loaded from a profile's page_cache section
+type PageCacheConfig struct {
+	Policy            string        `json:"policy"`             // lru or 2q
+	CapacityBytes     int64         `json:"capacity_bytes"`     // 0 sizes the cache from the memory engine's free RAM
+	PageBytes         int64         `json:"page_bytes"`         // Unit of caching and write-back
+	DirtyExpire       time.Duration `json:"dirty_expire"`       // Age at which the flusher writes a dirty page back
+	WritebackInterval time.Duration `json:"writeback_interval"` // How often the flusher wakes
+	DirtyRatio        float64       `json:"dirty_ratio"`        // Dirty share of the cache at which writers are throttled
+	SyscallOverhead   time.Duration `json:"syscall_overhead"`   // Per read or write served from the cache
+}
+
+// PageCacheStats tracks the cache's hits and write-back
+type PageCacheStats struct {
+	Hits             int64   `json:"hits"`
+	Misses           int64   `json:"misses"`
+	HitRatio         float64 `json:"hit_ratio"`
+	CachedPages      int64   `json:"cached_pages"`
+	DirtyPages       int64   `json:"dirty_pages"`
+	Evictions        int64   `json:"evictions"`
+	WrittenBackPages int64   `json:"written_back_pages"` // Dirty pages written to the device
+	SyncedPages      int64   `json:"synced_pages"`       // Of those, by storage_sync
+	ThrottledWrites  int64   `json:"throttled_writes"`   // Writes sent to the device over the dirty ratio
+}
+
+// PageCache holds the pages of a storage engine's device cached in memory
+type PageCache struct {
+	Config PageCacheConfig `json:"config"`
+	Stats  PageCacheStats  `json:"stats"`
+
+	memory        PageCacheMemory
+	pages         map[int64]*list.Element
+	probation     *list.List // 2Q: pages read once, in arrival order
+	protected     *list.List // Pages touched again (every page under LRU), most recent first
+	ghosts        *list.List // 2Q: pages recently evicted from probation
+	ghostPages    map[int64]*list.Element
+	lastWriteback int64
+}
+
+// cachedPage is a page in the cache
+type cachedPage struct {
+	block     int64
+	dirty     bool
+	dirtiedAt int64
+	protected bool
+}
+
+// defaultPageCacheConfig returns Linux's defaults: 30s dirty expiry, a flusher
+// waking every 5s and writers throttled at 20% dirty
+func defaultPageCacheConfig() PageCacheConfig {
+	return PageCacheConfig{
+		Policy:            PageCache2Q,
+		PageBytes:         4096,
+		DirtyExpire:       30 * time.Second,
+		WritebackInterval: 5 * time.Second,
+		DirtyRatio:        0.2,
+		SyscallOverhead:   time.Microsecond,
+	}
+}
+
+// loadProfile overrides settings present in a profile's page_cache section
+func (config *PageCacheConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["policy"].(string); ok && (val == PageCacheLRU || val == PageCache2Q) {
+		config.Policy = val
+	}
+	if val, ok := settings["size_mb"].(float64); ok && val >= 0 {
+		config.CapacityBytes = int64(val * (1 << 20))
+	}
+	if val, ok := settings["page_kb"].(float64); ok && val > 0 {
+		config.PageBytes = int64(val * 1024)
+	}
+	if val, ok := settings["dirty_expire_ms"].(float64); ok && val >= 0 {
+		config.DirtyExpire = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["writeback_interval_ms"].(float64); ok && val > 0 {
+		config.WritebackInterval = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["dirty_ratio"].(float64); ok && val > 0 && val <= 1 {
+		config.DirtyRatio = val
+	}
+	if val, ok := settings["syscall_overhead_us"].(float64); ok && val >= 0 {
+		config.SyscallOverhead = time.Duration(val * float64(time.Microsecond))
+	}
+}
+
+// NewPageCache creates an empty page cache in the given memory
+func NewPageCache(config PageCacheConfig, memory PageCacheMemory) *PageCache {
+	return &PageCache{
+		Config:     config,
+		memory:     memory,
+		pages:      make(map[int64]*list.Element),
+		probation:  list.New(),
+		protected:  list.New(),
+		ghosts:     list.New(),
+		ghostPages: make(map[int64]*list.Element),
+	}
+}
+
+// capacityPages returns the pages the cache may hold
+func (cache *PageCache) capacityPages() int64 {
+	capacity := cache.Config.CapacityBytes
+	if capacity == 0 && cache.memory != nil {
+		capacity = cache.memory.PageCacheBytes()
+	}
+	return max(capacity/cache.Config.PageBytes, 1)
+}
+
+// read looks up pages, caching the missing ones, and reports whether all were
+// cached along with the dirty pages evicted to make room
+func (cache *PageCache) read(first, count int64) (bool, int64) {
+	hit := true
+	var evictedDirty int64
+	for block := first; block < first+count; block++ {
+		if element, ok := cache.pages[block]; ok {
+			cache.touch(element)
+			continue
+		}
+		hit = false
+		evictedDirty += cache.insert(block)
+	}
+	if hit {
+		cache.Stats.Hits++
+	} else {
+		cache.Stats.Misses++
+	}
+	cache.Stats.HitRatio = float64(cache.Stats.Hits) / float64(cache.Stats.Hits+cache.Stats.Misses)
+	return hit, evictedDirty
+}
+
+// write caches pages, dirty or clean, and returns the dirty pages evicted to make room
+func (cache *PageCache) write(first, count int64, dirty bool, tick int64) int64 {
+	var evictedDirty int64
+	for block := first; block < first+count; block++ {
+		element, ok := cache.pages[block]
+		if ok {
+			cache.touch(element)
+		} else {
+			evictedDirty += cache.insert(block)
+			element = cache.pages[block]
+		}
+		page := element.Value.(*cachedPage)
+		if dirty && !page.dirty {
+			page.dirty, page.dirtiedAt = true, tick
+			cache.Stats.DirtyPages++
+		}
+	}
+	return evictedDirty
+}
+
+// touch records an access to a cached page
+func (cache *PageCache) touch(element *list.Element) {
+	page := element.Value.(*cachedPage)
+	if page.protected {
+		cache.protected.MoveToFront(element)
+	}
+	// 2Q: a page on probation stays in arrival order; a second access soon after
+	// it was cached says nothing about its reuse
+}
+
+// insert caches a page, evicting as needed, and returns the dirty pages evicted
+func (cache *PageCache) insert(block int64) int64 {
+	page := &cachedPage{block: block}
+	if ghost, ok := cache.ghostPages[block]; ok || cache.Config.Policy == PageCacheLRU {
+		// Under 2Q a page read again after probation has proven its reuse
+		if ok {
+			cache.ghosts.Remove(ghost)
+			delete(cache.ghostPages, block)
+		}
+		page.protected = true
+		cache.pages[block] = cache.protected.PushFront(page)
+	} else {
+		cache.pages[block] = cache.probation.PushFront(page)
+	}
+	cache.Stats.CachedPages++
+
+	var evictedDirty int64
+	capacity := cache.capacityPages()
+	for cache.Stats.CachedPages > capacity {
+		if cache.evict() {
+			evictedDirty++
+		}
+	}
+	return evictedDirty
+}
+
+// evict drops one page, from probation while it holds more than a quarter of the
+// cache, and reports whether it was dirty
+func (cache *PageCache) evict() bool {
+	victims := cache.protected
+	if cache.probation.Len() > 0 && (int64(cache.probation.Len()) > cache.capacityPages()/4 || cache.protected.Len() == 0) {
+		victims = cache.probation
+	}
+	element := victims.Back()
+	page := victims.Remove(element).(*cachedPage)
+	delete(cache.pages, page.block)
+	cache.Stats.CachedPages--
+	cache.Stats.Evictions++
+
+	if victims == cache.probation {
+		// Remember the page for half the cache's worth of evictions
+		cache.ghostPages[page.block] = cache.ghosts.PushFront(page.block)
+		for int64(cache.ghosts.Len()) > cache.capacityPages()/2 {
+			delete(cache.ghostPages, cache.ghosts.Remove(cache.ghosts.Back()).(int64))
+		}
+	}
+	if page.dirty {
+		cache.Stats.DirtyPages--
+	}
+	return page.dirty
+}
+
+// clean marks dirty pages last dirtied before a tick clean and returns how many
+func (cache *PageCache) clean(before int64) int64 {
+	var cleaned int64
+	for _, element := range cache.pages {
+		if page := element.Value.(*cachedPage); page.dirty && page.dirtiedAt < before {
+			page.dirty = false
+			cleaned++
+		}
+	}
+	cache.Stats.DirtyPages -= cleaned
+	return cleaned
+}
+
+// overDirtyRatio reports whether writers must wait for the device
+func (cache *PageCache) overDirtyRatio() bool {
+	return float64(cache.Stats.DirtyPages) >= cache.Config.DirtyRatio*float64(cache.capacityPages())
+}
+
+// AttachPageCache gives the storage engine a page cache in the given memory
+func (storage *StorageEngine) AttachPageCache(memory PageCacheMemory) {
+	storage.PageCache = NewPageCache(storage.PageCacheConfig, memory)
+}
+
+// pageCacheEnabled reports whether an operation goes through the page cache
+func (storage *StorageEngine) pageCacheEnabled(op *Operation) bool {
+	if storage.PageCache == nil || !storage.ComplexityInterface.ShouldEnableFeature("page_cache") {
+		return false
+	}
+	direct, _ := op.Metadata["direct"].(bool)
+	return !direct
+}
+
+// operationPages returns the first page and the number of pages an operation touches
+func (storage *StorageEngine) operationPages(op *Operation) (int64, int64) {
+	pageBytes := storage.PageCache.Config.PageBytes
+	offset := storage.operationLBA(op) * hddSectorBytes
+	first := offset / pageBytes
+	last := (offset + max(op.DataSize, 1) - 1) / pageBytes
+	return first, last - first + 1
+}
+
+// applyPageCache serves an operation from the page cache, returning its result,
+// or returns nil for the device to serve it along with the time it first spends
+// writing back dirty pages
+func (storage *StorageEngine) applyPageCache(op *Operation, currentTick int64) (*OperationResult, time.Duration) {
+	cache := storage.PageCache
+	switch op.Type {
+	case OpStorageRead:
+		first, count := storage.operationPages(op)
+		hit, evictedDirty := cache.read(first, count)
+		reclaim := storage.writeBack(evictedDirty, currentTick)
+		if hit {
+			return storage.pageCacheResult(op, currentTick, "hit", nil), 0
+		}
+		return nil, reclaim
+
+	case OpStorageWrite:
+		first, count := storage.operationPages(op)
+		if cache.overDirtyRatio() {
+			// Throttled: the write goes to the device and leaves its pages clean
+			cache.Stats.ThrottledWrites++
+			return nil, storage.writeBack(cache.write(first, count, false, currentTick), currentTick)
+		}
+
+		// Space is reserved when the write is buffered, not when it is written back
+		if storage.ComplexityInterface.ShouldEnableFeature("capacity_tracking") {
+			if _, err := storage.applyCapacityEffects(0, op); err != nil {
+				return storage.pageCacheResult(op, currentTick, "buffered", err), 0
+			}
+		}
+		storage.writeBack(cache.write(first, count, true, currentTick), currentTick)
+		return storage.pageCacheResult(op, currentTick, "buffered", nil), 0
+
+	case OpStorageSync:
+		synced := cache.clean(currentTick + 1)
+		cache.Stats.SyncedPages += synced
+		return nil, storage.writeBack(synced, currentTick)
+	}
+	return nil, 0
+}
+
+// writeBack writes dirty pages to the device as one sorted batch and returns the
+// time it takes; a spinning disk is busy with it before later requests
+func (storage *StorageEngine) writeBack(pages int64, currentTick int64) time.Duration {
+	if pages == 0 {
+		return 0
+	}
+	storage.PageCache.Stats.WrittenBackPages += pages
+	writeTime := storage.calculateBaseStorageAccessTime(&Operation{
+		Type:     OpStorageWrite,
+		DataSize: pages * storage.PageCache.Config.PageBytes,
+	})
+	if storage.hddMechanicsEnabled() {
+		storage.reserveDisk(currentTick, writeTime)
+	}
+	return writeTime
+}
+
+// flushExpiredPages wakes the flusher every writeback interval to write back
+// pages dirty for longer than the expiry
+func (storage *StorageEngine) flushExpiredPages(currentTick int64) {
+	cache := storage.PageCache
+	if currentTick-cache.lastWriteback < storage.DurationToTicks(cache.Config.WritebackInterval) {
+		return
+	}
+	cache.lastWriteback = currentTick
+	expired := cache.clean(currentTick - storage.DurationToTicks(cache.Config.DirtyExpire) + 1)
+	storage.writeBack(expired, currentTick)
+}
+
+// pageCacheResult returns the result of an operation the page cache served,
+// copied at the memory engine's latency
+func (storage *StorageEngine) pageCacheResult(op *Operation, currentTick int64, outcome string, err error) *OperationResult {
+	cache := storage.PageCache
+	latency := cache.Config.SyscallOverhead
+	if cache.memory != nil {
+		latency += cache.memory.PageCacheLatency(op.DataSize)
+	}
+	ticksToComplete := max(storage.DurationToTicks(latency), 1)
+
+	return &OperationResult{
+		OperationID:    op.ID,
+		OperationType:  op.Type,
+		ProcessingTime: latency,
+		CompletedTick:  currentTick + ticksToComplete,
+		CompletedAt:    currentTick + ticksToComplete,
+		Success:        err == nil,
+		Error:          err,
+		NextComponent:  op.NextComponent,
+		PenaltyInfo: &PenaltyInformation{
+			EngineType:           StorageEngineType,
+			EngineID:             storage.ID,
+			BaseProcessingTime:   latency,
+			ActualProcessingTime: latency,
+			LoadPenalty:          1.0,
+			QueuePenalty:         1.0,
+			ThermalPenalty:       1.0,
+			ContentionPenalty:    1.0,
+			HealthPenalty:        1.0,
+			TotalPenaltyFactor:   1.0,
+			PerformanceGrade:     "A",
+			RecommendedAction:    "continue",
+			StoragePenalties: &StoragePenaltyDetails{
+				AccessPattern:       storage.determineAccessPattern(op),
+				PowerStateImpact:    1.0,
+				CapacityUtilization: storage.capacityUtilization(),
+				PageCacheHitRatio:   cache.Stats.HitRatio,
+			},
+		},
+		Metrics: map[string]interface{}{
+			"base_time_us":         latency.Microseconds(),
+			"final_time_us":        latency.Microseconds(),
+			"page_cache":           outcome,
+			"page_cache_hit_ratio": cache.Stats.HitRatio,
+			"dirty_pages":          cache.Stats.DirtyPages,
+		},
+	}
+}
+
+// GetPageCacheStats returns the page cache's hits and write-back, or nothing without one
+func (storage *StorageEngine) GetPageCacheStats() PageCacheStats {
+	if storage.PageCache == nil {
+		return PageCacheStats{}
+	}
+	return storage.PageCache.Stats
+}
+
+// PageCacheBytes returns the RAM the process leaves free for the OS page cache
+func (mem *MemoryEngine) PageCacheBytes() int64 {
+	limit := mem.Capacity.LimitBytes
+	if limit <= 0 {
+		limit = mem.CapacityGB << 30
+	}
+	return max(limit-mem.ResidentSet.ResidentBytes, 0)
+}
+
+// PageCacheLatency returns the time to copy bytes to or from the page cache:
+// the profile's access time plus transfer at its bandwidth
+func (mem *MemoryEngine) PageCacheLatency(bytes int64) time.Duration {
+	bandwidth := mem.BandwidthGBps
+	if bandwidth <= 0 {
+		bandwidth = 25.0 // Dual-channel DDR4
+	}
+	transfer := time.Duration(float64(bytes) / (bandwidth * (1 << 30)) * float64(time.Second))
+	return mem.calculateBaseMemoryAccessTime(&Operation{Type: OpMemoryRead, DataSize: bytes}) + transfer
+}
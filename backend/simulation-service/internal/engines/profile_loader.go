@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,7 +50,7 @@ func (pl *ProfileLoader) LoadProfilesFromDirectory() (*ProfileManager, error) {
 		// Load profile from file
 		profile, err := pl.LoadProfileFromFile(path)
 		if err != nil {
-			fmt.Printf("Warning: Failed to load profile from %s: %v\n", path, err)
+			log.Printf("Warning: Failed to load profile from %s: %v\n", path, err)
 			return nil // Continue loading other profiles
 		}
 		
@@ -63,14 +64,16 @@ func (pl *ProfileLoader) LoadProfilesFromDirectory() (*ProfileManager, error) {
 			pm.StorageProfiles[profile.Name] = profile
 		case NetworkEngineType:
 			pm.NetworkProfiles[profile.Name] = profile
+		case CoordinationEngineType:
+			pm.CoordinationProfiles[profile.Name] = profile
 		default:
-			fmt.Printf("Warning: Unknown profile type %v in file %s\n", profile.Type, path)
+			log.Printf("Warning: Unknown profile type %v in file %s\n", profile.Type, path)
 		}
 		
 		// Cache the profile
 		pl.Cache[profile.Name] = profile
 		
-		fmt.Printf("Loaded profile: %s (%s) from %s\n", profile.Name, profile.Type.String(), path)
+		log.Printf("Loaded profile: %s (%s) from %s\n", profile.Name, profile.Type.String(), path)
 		return nil
 	})
 	
@@ -109,7 +112,7 @@ func (pl *ProfileLoader) ValidateProfile(profile *EngineProfile) error {
 		return fmt.Errorf("profile name cannot be empty")
 	}
 	
-	if profile.Type < CPUEngineType || profile.Type > NetworkEngineType {
+	if profile.Type < CPUEngineType || profile.Type > CoordinationEngineType {
 		return fmt.Errorf("invalid profile type: %v", profile.Type)
 	}
 	
@@ -127,6 +130,8 @@ func (pl *ProfileLoader) ValidateProfile(profile *EngineProfile) error {
 		return pl.validateStorageProfile(profile)
 	case NetworkEngineType:
 		return pl.validateNetworkProfile(profile)
+	case CoordinationEngineType:
+		return pl.validateCoordinationProfile(profile)
 	}
 	
 	return nil
@@ -140,6 +145,13 @@ func (pl *ProfileLoader) validateCPUProfile(profile *EngineProfile) error {
 			return fmt.Errorf("CPU profile missing required field: %s", field)
 		}
 	}
+	if settings, ok := profile.EngineSpecific["cpu_scheduler"].(map[string]interface{}); ok {
+		scheduler := defaultCPUSchedulerConfig(int(profile.BaselinePerformance["cores"]))
+		scheduler.loadProfile(settings)
+		if err := scheduler.validate(); err != nil {
+			return fmt.Errorf("CPU profile %v", err)
+		}
+	}
 	return nil
 }
 
@@ -162,6 +174,27 @@ func (pl *ProfileLoader) validateStorageProfile(profile *EngineProfile) error {
 			return fmt.Errorf("Storage profile missing required field: %s", field)
 		}
 	}
+	if volume, ok := profile.EngineSpecific["cloud_volume"].(map[string]interface{}); ok {
+		if volumeType, ok := volume["volume_type"].(string); ok {
+			switch volumeType {
+			case CloudVolumeGP2, CloudVolumeGP3, CloudVolumeIO1, CloudVolumeIO2:
+			default:
+				return fmt.Errorf("Storage profile cloud volume type must be gp2, gp3, io1 or io2, got %s", volumeType)
+			}
+		}
+		for _, field := range []string{"provisioned_iops", "provisioned_throughput_mbps"} {
+			if limit, ok := volume[field].(float64); ok && limit <= 0 {
+				return fmt.Errorf("Storage profile cloud volume %s must be positive", field)
+			}
+		}
+	}
+	if settings, ok := profile.EngineSpecific["storage_array"].(map[string]interface{}); ok {
+		array := defaultStorageArrayConfig()
+		array.loadProfile(settings)
+		if err := array.validate(); err != nil {
+			return fmt.Errorf("Storage profile %v", err)
+		}
+	}
 	return nil
 }
 
@@ -173,6 +206,39 @@ func (pl *ProfileLoader) validateNetworkProfile(profile *EngineProfile) error {
 			return fmt.Errorf("Network profile missing required field: %s", field)
 		}
 	}
+	for _, field := range []string{"ingress_bandwidth_mbps", "egress_bandwidth_mbps"} {
+		if bandwidth, ok := profile.BaselinePerformance[field]; ok && bandwidth <= 0 {
+			return fmt.Errorf("Network profile %s must be positive", field)
+		}
+	}
+	if congestion, ok := profile.EngineSpecific["congestion_behavior"].(map[string]interface{}); ok {
+		if algorithm, ok := congestion["algorithm"].(string); ok {
+			switch algorithm {
+			case CongestionAlgorithmReno, CongestionAlgorithmCubic, CongestionAlgorithmBBR:
+			default:
+				return fmt.Errorf("Network profile congestion algorithm must be reno, cubic or bbr, got %s", algorithm)
+			}
+		}
+	}
+	if security, ok := profile.EngineSpecific["security_overhead"].(map[string]interface{}); ok {
+		if version, ok := security["version"].(string); ok && version != TLSVersion12 && version != TLSVersion13 {
+			return fmt.Errorf("Network profile TLS version must be 1.2 or 1.3, got %s", version)
+		}
+	}
+	return nil
+}
+
+// validateCoordinationProfile validates Coordination-specific profile fields
+func (pl *ProfileLoader) validateCoordinationProfile(profile *EngineProfile) error {
+	required := []string{"cluster_size", "rtt_ms", "fsync_ms"}
+	for _, field := range required {
+		if _, ok := profile.BaselinePerformance[field]; !ok {
+			return fmt.Errorf("Coordination profile missing required field: %s", field)
+		}
+	}
+	if profile.BaselinePerformance["cluster_size"] < 1 {
+		return fmt.Errorf("Coordination profile cluster_size must be at least 1")
+	}
 	return nil
 }
 
@@ -184,7 +250,7 @@ func (pl *ProfileLoader) CreateDefaultProfileFiles() error {
 	}
 	
 	// Create subdirectories for organization
-	subdirs := []string{"cpu", "memory", "storage", "network"}
+	subdirs := []string{"cpu", "memory", "storage", "network", "coordination"}
 	for _, subdir := range subdirs {
 		if err := os.MkdirAll(filepath.Join(pl.ProfilesDir, subdir), 0755); err != nil {
 			return fmt.Errorf("failed to create %s directory: %w", subdir, err)
@@ -222,7 +288,14 @@ func (pl *ProfileLoader) CreateDefaultProfileFiles() error {
 		}
 	}
 	
-	fmt.Printf("Created default profile files in %s\n", pl.ProfilesDir)
+	// Save Coordination profiles
+	for name, profile := range pm.CoordinationProfiles {
+		if err := pl.SaveProfileToFile(profile, filepath.Join(pl.ProfilesDir, "coordination", name+".json")); err != nil {
+			return fmt.Errorf("failed to save Coordination profile %s: %w", name, err)
+		}
+	}
+	
+	log.Printf("Created default profile files in %s\n", pl.ProfilesDir)
 	return nil
 }
 
@@ -255,6 +328,9 @@ func (pl *ProfileLoader) ListAvailableProfiles() (map[string][]string, error) {
 	profiles["Memory"] = []string{}
 	profiles["Storage"] = []string{}
 	profiles["Network"] = []string{}
+	profiles["Coordination"] = []string{}
+	profiles["NetworkIn"] = []string{}
+	profiles["NetworkOut"] = []string{}
 	
 	// Check if profiles directory exists
 	if _, err := os.Stat(pl.ProfilesDir); os.IsNotExist(err) {
@@ -289,6 +365,10 @@ func (pl *ProfileLoader) ListAvailableProfiles() (map[string][]string, error) {
 				profiles["Storage"] = append(profiles["Storage"], fileName)
 			case "network":
 				profiles["Network"] = append(profiles["Network"], fileName)
+				profiles["NetworkIn"] = append(profiles["NetworkIn"], fileName)
+				profiles["NetworkOut"] = append(profiles["NetworkOut"], fileName)
+			case "coordination":
+				profiles["Coordination"] = append(profiles["Coordination"], fileName)
 			}
 		}
 		
@@ -308,8 +388,10 @@ func (pl *ProfileLoader) GetProfilePath(engineType EngineType, profileName strin
 		subdir = "memory"
 	case StorageEngineType:
 		subdir = "storage"
-	case NetworkEngineType:
+	case NetworkEngineType, NetworkInEngineType, NetworkOutEngineType:
 		subdir = "network"
+	case CoordinationEngineType:
+		subdir = "coordination"
 	default:
 		subdir = "unknown"
 	}
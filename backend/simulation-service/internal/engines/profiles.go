@@ -11,6 +11,7 @@ type ProfileManager struct {
 	MemoryProfiles  map[string]*EngineProfile `json:"memory_profiles"`
 	StorageProfiles map[string]*EngineProfile `json:"storage_profiles"`
 	NetworkProfiles map[string]*EngineProfile `json:"network_profiles"`
+	CoordinationProfiles map[string]*EngineProfile `json:"coordination_profiles"`
 }
 
 // NewProfileManager creates a new profile manager with default profiles
@@ -20,6 +21,7 @@ func NewProfileManager() *ProfileManager {
 		MemoryProfiles:  make(map[string]*EngineProfile),
 		StorageProfiles: make(map[string]*EngineProfile),
 		NetworkProfiles: make(map[string]*EngineProfile),
+		CoordinationProfiles: make(map[string]*EngineProfile),
 	}
 	
 	// Load default profiles
@@ -39,8 +41,11 @@ func (pm *ProfileManager) GetProfile(engineType EngineType, name string) (*Engin
 		profiles = pm.MemoryProfiles
 	case StorageEngineType:
 		profiles = pm.StorageProfiles
-	case NetworkEngineType:
+	case NetworkEngineType, NetworkInEngineType, NetworkOutEngineType:
+		// Network-In and Network-Out model one side of the same links
 		profiles = pm.NetworkProfiles
+	case CoordinationEngineType:
+		profiles = pm.CoordinationProfiles
 	default:
 		return nil, fmt.Errorf("unknown engine type: %v", engineType)
 	}
@@ -69,6 +74,8 @@ func (pm *ProfileManager) LoadProfile(profileJSON []byte) error {
 		pm.StorageProfiles[profile.Name] = &profile
 	case NetworkEngineType:
 		pm.NetworkProfiles[profile.Name] = &profile
+	case CoordinationEngineType:
+		pm.CoordinationProfiles[profile.Name] = &profile
 	default:
 		return fmt.Errorf("unknown profile type: %v", profile.Type)
 	}
@@ -598,6 +605,7 @@ func (pm *ProfileManager) loadDefaultProfiles() {
 			"queue_depth":         128,
 			"controller_cache_mb": 1024,
 			"thermal_limit":       70, // Celsius
+			"endurance_tbw":       600.0,
 		},
 		EngineSpecific: map[string]interface{}{
 			"iops_curves": map[string]interface{}{
@@ -653,6 +661,39 @@ func (pm *ProfileManager) loadDefaultProfiles() {
 		},
 	}
 	pm.NetworkProfiles["gigabit_ethernet"] = gigabitProfile
+
+	// etcd Raft Cluster Coordination Profile
+	etcdProfile := &EngineProfile{
+		Name:        "etcd_raft_cluster",
+		Type:        CoordinationEngineType,
+		Description: "etcd v3 - 3-node Raft cluster in one datacenter with NVMe WAL",
+		Version:     "1.0",
+		BaselinePerformance: map[string]float64{
+			"cluster_size":            3,
+			"rtt_ms":                  0.5,
+			"rtt_jitter":              0.2,
+			"fsync_ms":                2.0,
+			"processing_ms":           0.05,
+			"election_timeout_min_ms": 1000, // etcd default election timeout
+			"election_timeout_max_ms": 2000,
+			"lock_hold_ms":            10,
+			"max_inflight":            1000,
+		},
+		TechnologySpecs: map[string]interface{}{
+			"protocol":    "raft",
+			"lease_reads": false, // etcd serves linearizable reads with ReadIndex
+		},
+		EngineSpecific: map[string]interface{}{
+			"leader_failure_rate": 0.00001,
+			"two_phase_commit": map[string]interface{}{
+				"participants":           2,
+				"participant_prepare_ms": 2.0,
+				"participant_commit_ms":  1.0,
+				"prepare_failure_rate":   0.001,
+			},
+		},
+	}
+	pm.CoordinationProfiles["etcd_raft_cluster"] = etcdProfile
 }
 
 // GetDefaultProfile returns the default profile for an engine type
@@ -664,8 +705,10 @@ func (pm *ProfileManager) GetDefaultProfile(engineType EngineType) (*EngineProfi
 		return pm.GetProfile(MemoryEngineType, "ddr4_3200_server")
 	case StorageEngineType:
 		return pm.GetProfile(StorageEngineType, "samsung_980_pro")
-	case NetworkEngineType:
+	case NetworkEngineType, NetworkInEngineType, NetworkOutEngineType:
 		return pm.GetProfile(NetworkEngineType, "gigabit_ethernet")
+	case CoordinationEngineType:
+		return pm.GetProfile(CoordinationEngineType, "etcd_raft_cluster")
 	default:
 		return nil, fmt.Errorf("unknown engine type: %v", engineType)
 	}
@@ -682,8 +725,10 @@ func (pm *ProfileManager) ListProfiles(engineType EngineType) []string {
 		profiles = pm.MemoryProfiles
 	case StorageEngineType:
 		profiles = pm.StorageProfiles
-	case NetworkEngineType:
+	case NetworkEngineType, NetworkInEngineType, NetworkOutEngineType:
 		profiles = pm.NetworkProfiles
+	case CoordinationEngineType:
+		profiles = pm.CoordinationProfiles
 	default:
 		return nil
 	}
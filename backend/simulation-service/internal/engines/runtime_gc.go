@@ -0,0 +1,497 @@
+package engines
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Language runtime garbage collection shared by every engine of a component
+// instance. The memory engine feeds it memory_alloc and memory_free operations;
+// when the heap crosses a runtime's trigger a collection runs and its
+// stop-the-world phase stalls every CPU and memory operation in flight on the
+// instance, not only the operation that allocated. Generational runtimes
+// collect the young generation often and cheaply and the whole heap rarely;
+// Go and ZGC collect the whole heap concurrently with only sub-millisecond
+// pauses. CPython frees most objects by reference counting and only pauses for
+// its cycle collector.
+
+// Garbage-collected runtimes
+const (
+	RuntimeGo     = "go"
+	RuntimeJavaG1 = "java_g1"
+	RuntimeZGC    = "java_zgc"
+	RuntimeDotNet = "dotnet"
+	RuntimePython = "python"
+	RuntimeNode   = "node"
+)
+
+// GC cycle kinds
+const (
+	GCCycleMinor = "minor"
+	GCCycleMajor = "major"
+)
+
+// maxTrackedPauses bounds the pause history engines read stalls from
+const maxTrackedPauses = 256
+
+// GCConfig describes when a runtime collects and how long its pauses last
+type GCConfig struct {
+	Runtime           string        `json:"runtime"`
+	ReferenceCounting bool          `json:"reference_counting"` // Frees reclaim memory immediately
+	YoungGenBytes     int64         `json:"young_gen_bytes"`    // Allocation budget before a minor cycle (0 = not generational by size)
+	YoungAllocations  int64         `json:"young_allocations"`  // Allocation count before a minor cycle (0 = not counted)
+	TriggerRatio      float64       `json:"trigger_ratio"`      // Heap growth over the live heap that starts a major cycle
+	MinHeapBytes      int64         `json:"min_heap_bytes"`     // Heap size below which no major cycle starts
+	MinorPause        time.Duration `json:"minor_pause"`
+	MinorPausePerGB   time.Duration `json:"minor_pause_per_gb"` // Per GB of young survivors copied
+	MajorPause        time.Duration `json:"major_pause"`
+	MajorPausePerGB   time.Duration `json:"major_pause_per_gb"` // Per GB of live heap
+	MaxPause          time.Duration `json:"max_pause"`          // Pause target the collector keeps to (0 = none)
+}
+
+// GCPause is one stop-the-world window on the instance's simulated clock
+type GCPause struct {
+	Seq      int64         `json:"seq"`
+	Kind     string        `json:"kind"`
+	Start    time.Duration `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// RuntimeGCState is the heap and collector state of one runtime
+type RuntimeGCState struct {
+	Config GCConfig `json:"config"`
+
+	LiveBytes        int64         `json:"live_bytes"`        // Reachable objects
+	HeapBytes        int64         `json:"heap_bytes"`        // Live objects plus garbage not yet collected
+	YoungBytes       int64         `json:"young_bytes"`       // Allocated since the last cycle
+	YoungAllocations int64         `json:"young_allocations"` // Allocations since the last cycle
+	NextMajorBytes   int64         `json:"next_major_bytes"`  // Heap size that starts the next major cycle
+	AllocationRate   float64       `json:"allocation_rate"`   // Bytes per second of simulated time
+	MinorCycles      int64         `json:"minor_cycles"`
+	MajorCycles      int64         `json:"major_cycles"`
+	TotalPauseTime   time.Duration `json:"total_pause_time"`
+	LongestPause     time.Duration `json:"longest_pause"`
+	StoppedUntil     time.Duration `json:"stopped_until"` // End of the latest scheduled pause
+	Pauses           []GCPause     `json:"pauses"`        // Recent pauses, oldest first
+}
+
+// RuntimeGC is the collector of one component instance, shared by its CPU and
+// memory engines
+type RuntimeGC struct {
+	RuntimeGCState
+
+	rateWindowStart time.Duration
+	rateWindowBytes int64
+	nextSeq         int64
+	mutex           sync.Mutex
+}
+
+// RuntimeAttacher is implemented by engines whose operations a runtime's pauses stall
+type RuntimeAttacher interface {
+	AttachRuntime(runtime *RuntimeGC)
+}
+
+// RuntimePauseCursor records which of a runtime's pauses an engine has already
+// applied to its in-flight operations. Pauses shorter than a tick accumulate in
+// Carry until they add up to a whole tick.
+type RuntimePauseCursor struct {
+	Seq   int64         `json:"seq"`
+	Carry time.Duration `json:"carry"`
+}
+
+// defaultGCConfig returns collector settings typical of each runtime's default configuration
+func defaultGCConfig(runtime string) GCConfig {
+	const mb = 1 << 20
+	switch runtime {
+	case RuntimeJavaG1:
+		// Young pauses copy eden survivors; mixed collections after concurrent
+		// marking stay within the 200ms MaxGCPauseMillis target
+		return GCConfig{Runtime: runtime, YoungGenBytes: 256 * mb, TriggerRatio: 0.8, MinHeapBytes: 512 * mb,
+			MinorPause: 3 * time.Millisecond, MinorPausePerGB: 500 * time.Millisecond,
+			MajorPause: 10 * time.Millisecond, MajorPausePerGB: 50 * time.Millisecond, MaxPause: 200 * time.Millisecond}
+	case RuntimeZGC:
+		// Three sub-millisecond pauses per cycle whatever the heap size
+		return GCConfig{Runtime: runtime, TriggerRatio: 1.0, MinHeapBytes: 256 * mb,
+			MajorPause: 300 * time.Microsecond, MaxPause: time.Millisecond}
+	case RuntimeDotNet:
+		// Gen0 budget per heap, blocking gen2 compaction under pressure
+		return GCConfig{Runtime: runtime, YoungGenBytes: 64 * mb, TriggerRatio: 1.0, MinHeapBytes: 256 * mb,
+			MinorPause: time.Millisecond, MinorPausePerGB: 500 * time.Millisecond,
+			MajorPause: 10 * time.Millisecond, MajorPausePerGB: 100 * time.Millisecond}
+	case RuntimePython:
+		// Generation 0 every 700 allocations; a full collection traverses every
+		// container object once the long-lived heap has grown by a quarter
+		return GCConfig{Runtime: runtime, ReferenceCounting: true, YoungAllocations: 700, TriggerRatio: 0.25, MinHeapBytes: 16 * mb,
+			MinorPause: 100 * time.Microsecond,
+			MajorPause: time.Millisecond, MajorPausePerGB: time.Second}
+	case RuntimeNode:
+		// V8 scavenges a 16MB new space; incremental marking leaves a final
+		// mark-compact pause
+		return GCConfig{Runtime: runtime, YoungGenBytes: 16 * mb, TriggerRatio: 1.0, MinHeapBytes: 64 * mb,
+			MinorPause: 500 * time.Microsecond, MinorPausePerGB: time.Second,
+			MajorPause: 5 * time.Millisecond, MajorPausePerGB: 100 * time.Millisecond}
+	default:
+		// Go: concurrent mark-sweep paced by GOGC=100 with two short pauses per cycle
+		return GCConfig{Runtime: RuntimeGo, TriggerRatio: 1.0, MinHeapBytes: 4 * mb,
+			MajorPause: 100 * time.Microsecond}
+	}
+}
+
+// loadProfile overrides collector settings present in a profile's gc_behavior entry
+func (config *GCConfig) loadProfile(settings map[string]interface{}) {
+	const mb = 1 << 20
+	if val, ok := settings["young_gen_mb"].(float64); ok && val >= 0 {
+		config.YoungGenBytes = int64(val * mb)
+	}
+	if val, ok := settings["young_allocations"].(float64); ok && val >= 0 {
+		config.YoungAllocations = int64(val)
+	}
+	if val, ok := settings["trigger_ratio"].(float64); ok && val > 0 {
+		config.TriggerRatio = val
+	}
+	if val, ok := settings["min_heap_mb"].(float64); ok && val >= 0 {
+		config.MinHeapBytes = int64(val * mb)
+	}
+	if val, ok := settings["minor_pause_ms"].(float64); ok && val >= 0 {
+		config.MinorPause = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["minor_pause_per_gb_ms"].(float64); ok && val >= 0 {
+		config.MinorPausePerGB = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["major_pause_ms"].(float64); ok && val >= 0 {
+		config.MajorPause = time.Duration(val * float64(time.Millisecond))
+	}
+	// pause_time_per_gb is the older name for the major pause per GB
+	if val, ok := settings["pause_time_per_gb"].(float64); ok && val >= 0 {
+		config.MajorPausePerGB = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["major_pause_per_gb_ms"].(float64); ok && val >= 0 {
+		config.MajorPausePerGB = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["max_pause_ms"].(float64); ok && val >= 0 {
+		config.MaxPause = time.Duration(val * float64(time.Millisecond))
+	}
+}
+
+// NewRuntimeGC creates the collector for a runtime with its default settings
+func NewRuntimeGC(runtime string) *RuntimeGC {
+	rt := &RuntimeGC{RuntimeGCState: RuntimeGCState{Config: defaultGCConfig(runtime)}}
+	rt.NextMajorBytes = rt.Config.MinHeapBytes
+	return rt
+}
+
+// RuntimeForLanguage returns the runtime an operation language runs on, or ""
+// for languages without a garbage collector
+func RuntimeForLanguage(language string) string {
+	switch language {
+	case LangGo:
+		return RuntimeGo
+	case LangJava:
+		return RuntimeJavaG1
+	case "csharp":
+		return RuntimeDotNet
+	case LangPython:
+		return RuntimePython
+	case LangJS:
+		return RuntimeNode
+	}
+	return ""
+}
+
+// LoadProfile applies a memory profile's gc_behavior entry for this runtime,
+// keyed by runtime or, for older profiles, by language
+func (rt *RuntimeGC) LoadProfile(profile *EngineProfile) {
+	if profile == nil {
+		return
+	}
+	behavior, ok := profile.EngineSpecific["gc_behavior"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	keys := []string{rt.Config.Runtime}
+	switch rt.Config.Runtime {
+	case RuntimeJavaG1, RuntimeZGC:
+		keys = append(keys, LangJava)
+	case RuntimeDotNet:
+		keys = append(keys, "csharp")
+	}
+
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	for _, key := range keys {
+		if settings, ok := behavior[key].(map[string]interface{}); ok {
+			rt.Config.loadProfile(settings)
+			break
+		}
+	}
+	rt.NextMajorBytes = int64(math.Max(float64(rt.NextMajorBytes), float64(rt.Config.MinHeapBytes)))
+}
+
+// RecordAllocation adds objects to the heap at the given simulated time and
+// runs any collection the allocation triggers, returning the pauses it scheduled
+func (rt *RuntimeGC) RecordAllocation(now time.Duration, bytes, objects int64) []GCPause {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	if objects < 1 {
+		objects = 1
+	}
+	rt.LiveBytes += bytes
+	rt.HeapBytes += bytes
+	rt.YoungBytes += bytes
+	rt.YoungAllocations += objects
+	rt.updateAllocationRate(now, bytes)
+
+	var pauses []GCPause
+	config := rt.Config
+	if (config.YoungGenBytes > 0 && rt.YoungBytes >= config.YoungGenBytes) ||
+		(config.YoungAllocations > 0 && rt.YoungAllocations >= config.YoungAllocations) {
+		pauses = append(pauses, rt.collectYoung(now))
+	}
+	if rt.HeapBytes >= rt.NextMajorBytes {
+		pauses = append(pauses, rt.collectHeap(now))
+	}
+	return pauses
+}
+
+// restart empties the heap of a process that was killed
+func (rt *RuntimeGC) restart() {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	rt.LiveBytes, rt.HeapBytes, rt.YoungBytes, rt.YoungAllocations = 0, 0, 0, 0
+	rt.NextMajorBytes = rt.Config.MinHeapBytes
+}
+
+// RecordFree drops objects from the live heap. Tracing collectors reclaim them at
+// the next cycle; reference counting reclaims them at once.
+func (rt *RuntimeGC) RecordFree(bytes int64) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	rt.LiveBytes = max(rt.LiveBytes-bytes, 0)
+	if rt.Config.ReferenceCounting {
+		rt.HeapBytes = max(rt.HeapBytes-bytes, rt.LiveBytes)
+	}
+}
+
+// collectYoung runs a minor cycle: young garbage is reclaimed and the pause
+// grows with the survivors copied out of the young generation
+func (rt *RuntimeGC) collectYoung(now time.Duration) GCPause {
+	freed := min(rt.HeapBytes-rt.LiveBytes, rt.YoungBytes)
+	survivors := rt.YoungBytes - freed
+	rt.HeapBytes -= freed
+	rt.YoungBytes = 0
+	rt.YoungAllocations = 0
+	rt.MinorCycles++
+
+	pause := rt.Config.MinorPause + time.Duration(float64(rt.Config.MinorPausePerGB)*float64(survivors)/float64(1<<30))
+	return rt.schedulePause(now, GCCycleMinor, pause)
+}
+
+// collectHeap runs a major cycle: all garbage is reclaimed and the pause grows
+// with the live heap marked. The next cycle starts once the heap has grown by
+// the trigger ratio over what survived.
+func (rt *RuntimeGC) collectHeap(now time.Duration) GCPause {
+	rt.HeapBytes = rt.LiveBytes
+	rt.YoungBytes = 0
+	rt.YoungAllocations = 0
+	rt.NextMajorBytes = max(int64(float64(rt.LiveBytes)*(1+rt.Config.TriggerRatio)), rt.Config.MinHeapBytes)
+	rt.MajorCycles++
+
+	pause := rt.Config.MajorPause + time.Duration(float64(rt.Config.MajorPausePerGB)*float64(rt.LiveBytes)/float64(1<<30))
+	return rt.schedulePause(now, GCCycleMajor, pause)
+}
+
+// schedulePause starts a pause at the given time, or after the pause already under way
+func (rt *RuntimeGC) schedulePause(now time.Duration, kind string, duration time.Duration) GCPause {
+	if rt.Config.MaxPause > 0 && duration > rt.Config.MaxPause {
+		duration = rt.Config.MaxPause
+	}
+
+	rt.nextSeq++
+	pause := GCPause{Seq: rt.nextSeq, Kind: kind, Start: max(now, rt.StoppedUntil), Duration: duration}
+	rt.StoppedUntil = pause.Start + duration
+	rt.TotalPauseTime += duration
+	rt.LongestPause = max(rt.LongestPause, duration)
+
+	rt.Pauses = append(rt.Pauses, pause)
+	if len(rt.Pauses) > maxTrackedPauses {
+		rt.Pauses = rt.Pauses[len(rt.Pauses)-maxTrackedPauses:]
+	}
+	return pause
+}
+
+// updateAllocationRate folds each 100ms of allocations into a moving average
+func (rt *RuntimeGC) updateAllocationRate(now time.Duration, bytes int64) {
+	rt.rateWindowBytes += bytes
+	elapsed := now - rt.rateWindowStart
+	if elapsed < 100*time.Millisecond {
+		return
+	}
+	rate := float64(rt.rateWindowBytes) / elapsed.Seconds()
+	if rt.AllocationRate == 0 {
+		rt.AllocationRate = rate
+	} else {
+		rt.AllocationRate = 0.7*rt.AllocationRate + 0.3*rate
+	}
+	rt.rateWindowStart = now
+	rt.rateWindowBytes = 0
+}
+
+// Stall returns how much longer work started at the given time takes because
+// of pauses up to and including maxSeq: the work stops for every pause that
+// begins before it would have finished
+func (rt *RuntimeGC) Stall(start, work time.Duration, maxSeq int64) time.Duration {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	at, remaining := start, work
+	for _, pause := range rt.Pauses {
+		if pause.Seq > maxSeq || pause.Start >= at+remaining {
+			break
+		}
+		end := pause.Start + pause.Duration
+		if end <= at {
+			continue
+		}
+		if pause.Start > at {
+			remaining -= pause.Start - at
+		}
+		at = end
+	}
+	return at + remaining - start - work
+}
+
+// advance returns the pauses after the cursor as tick shifts, each the tick the
+// pause starts and the whole ticks it adds, and moves the cursor past them
+func (rt *RuntimeGC) advance(cursor *RuntimePauseCursor, tickDuration time.Duration) (starts, ticks []int64) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	for _, pause := range rt.Pauses {
+		if pause.Seq <= cursor.Seq {
+			continue
+		}
+		cursor.Seq = pause.Seq
+		cursor.Carry += pause.Duration
+		if whole := int64(cursor.Carry / tickDuration); whole > 0 {
+			cursor.Carry -= time.Duration(whole) * tickDuration
+			starts = append(starts, int64(pause.Start/tickDuration))
+			ticks = append(ticks, whole)
+		}
+	}
+	return starts, ticks
+}
+
+// Snapshot returns a copy of the collector state
+func (rt *RuntimeGC) Snapshot() RuntimeGCState {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	snapshot := rt.RuntimeGCState
+	snapshot.Pauses = append([]GCPause(nil), rt.Pauses...)
+	return snapshot
+}
+
+// lastPauseSeq returns the sequence number of the latest pause
+func (rt *RuntimeGC) lastPauseSeq() int64 {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	return rt.nextSeq
+}
+
+// applyRuntimePauses delays the in-flight operations of an engine by every
+// pause it has not yet applied. shift moves operations completing after the
+// given tick back by the given number of ticks.
+func applyRuntimePauses(rt *RuntimeGC, cursor *RuntimePauseCursor, tickDuration time.Duration, shift func(afterTick, ticks int64)) {
+	if rt == nil || tickDuration <= 0 {
+		return
+	}
+	starts, ticks := rt.advance(cursor, tickDuration)
+	for i := range starts {
+		shift(starts[i], ticks[i])
+	}
+}
+
+// operationObjects returns the object count of an allocation, one unless its
+// metadata names more
+func operationObjects(op *Operation) int64 {
+	if objects, ok := op.Metadata["objects"].(float64); ok && objects >= 1 {
+		return int64(objects)
+	}
+	if objects, ok := op.Metadata["objects"].(int); ok && objects >= 1 {
+		return int64(objects)
+	}
+	return 1
+}
+
+// AttachRuntime makes the CPU engine's operations stop for the runtime's GC pauses
+func (cpu *CPUEngine) AttachRuntime(runtime *RuntimeGC) {
+	cpu.Runtime = runtime
+	cpu.RuntimeCursor = RuntimePauseCursor{Seq: runtime.lastPauseSeq()}
+}
+
+// applyRuntimePauses delays in-flight CPU operations by the runtime's new pauses
+func (cpu *CPUEngine) applyRuntimePauses() {
+	applyRuntimePauses(cpu.Runtime, &cpu.RuntimeCursor, cpu.TickDuration, func(afterTick, ticks int64) {
+		for i := range *cpu.ActiveOperations {
+			if active := &(*cpu.ActiveOperations)[i]; active.CompletionTick > afterTick {
+				active.CompletionTick += ticks
+				if active.Cores != nil {
+					active.RemainingWork += time.Duration(ticks) * cpu.TickDuration
+				}
+			}
+		}
+	})
+}
+
+// runtimeStall returns the GC pause time work starting this tick sits through
+func (cpu *CPUEngine) runtimeStall(currentTick int64, work time.Duration, maxSeq int64) time.Duration {
+	if cpu.Runtime == nil {
+		return 0
+	}
+	return cpu.Runtime.Stall(cpu.TicksToDuration(currentTick), work, maxSeq)
+}
+
+// AttachRuntime makes the memory engine feed allocations to the runtime and stop
+// for its GC pauses. The memory profile's gc_behavior tunes the collector.
+func (mem *MemoryEngine) AttachRuntime(runtime *RuntimeGC) {
+	runtime.LoadProfile(mem.Profile)
+	mem.Runtime = runtime
+	mem.RuntimeCursor = RuntimePauseCursor{Seq: runtime.lastPauseSeq()}
+}
+
+// applyRuntimePauses delays in-flight memory operations by the runtime's new pauses
+func (mem *MemoryEngine) applyRuntimePauses() {
+	applyRuntimePauses(mem.Runtime, &mem.RuntimeCursor, mem.TickDuration, func(afterTick, ticks int64) {
+		for _, active := range *mem.ActiveOperations {
+			if active.CompletionTick > afterTick {
+				active.CompletionTick += ticks
+			}
+		}
+	})
+}
+
+// runtimeStall returns the GC pause time work starting this tick sits through
+func (mem *MemoryEngine) runtimeStall(currentTick int64, work time.Duration, maxSeq int64) time.Duration {
+	if mem.Runtime == nil {
+		return 0
+	}
+	return mem.Runtime.Stall(mem.TicksToDuration(currentTick), work, maxSeq)
+}
+
+// recordRuntimeOperation passes allocations and frees to the runtime's heap
+func (mem *MemoryEngine) recordRuntimeOperation(op *Operation, currentTick int64) {
+	if mem.Runtime == nil {
+		return
+	}
+	switch op.Type {
+	case OpMemoryAlloc, "memory_allocate":
+		mem.Runtime.RecordAllocation(mem.TicksToDuration(currentTick), op.DataSize, operationObjects(op))
+	case OpMemoryFree, "memory_deallocate":
+		mem.Runtime.RecordFree(op.DataSize)
+	}
+}
@@ -0,0 +1,65 @@
+package engines
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// Deterministic seeding
+//
+// A simulation runs from a single seed. Every engine, instance, load balancer and
+// failure injector gets its own seed derived from it with DeriveSeed, so that two
+// runs of the same design with the same seed make exactly the same decisions.
+// A seed of zero means "unseeded": random sources fall back to the wall clock and
+// hash-based decisions behave as they did before seeding existed.
+
+// DeriveSeed derives an independent child seed from a parent seed and a path of
+// names, e.g. DeriveSeed(seed, componentID, instanceID, "CPU"). A zero parent
+// seed always derives zero.
+func DeriveSeed(seed int64, path ...string) int64 {
+	if seed == 0 {
+		return 0
+	}
+
+	hasher := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(seed))
+	hasher.Write(buf[:])
+	for _, name := range path {
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(name))
+	}
+
+	derived := int64(hasher.Sum64())
+	if derived == 0 {
+		derived = 1 // Keep derived seeds distinguishable from "unseeded"
+	}
+	return derived
+}
+
+// NewSeededRand returns a random source for the given seed, or one seeded from the
+// wall clock when the seed is zero
+func NewSeededRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// seedHashMix folds a seed into a value that is XORed into decision hashes.
+// A zero seed yields zero, leaving hashes unchanged.
+func seedHashMix(seed int64) uint32 {
+	if seed == 0 {
+		return 0
+	}
+
+	mix := uint32(seed) ^ uint32(seed>>32)
+	mix ^= mix >> 16
+	mix *= 0x85ebca6b
+	mix ^= mix >> 13
+	mix *= 0xc2b2ae35
+	mix ^= mix >> 16
+	return mix
+}
@@ -0,0 +1,828 @@
+package engines
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// A storage array composing several identical devices, each a storage engine
+// loaded from the same profile. RAID0 stripes the data across the devices,
+// RAID1 mirrors it on every device, RAID10 stripes it across mirrored pairs and
+// RAID5 stripes it with a parity chunk rotating across the devices, so a write
+// smaller than a stripe reads the old data and parity before writing both. A
+// replicated set writes to every replica and acknowledges once a quorum has.
+// The devices serve their part of an operation in parallel, so it completes
+// with the slowest. After a device fails the array runs degraded: mirrors read
+// the surviving copy and RAID5 reconstructs the lost chunk from every other
+// device. A replacement is rebuilt at the rebuild rate, and the rebuild takes
+// that share of the bandwidth of every device it reads or writes. One rebuild
+// runs at a time: hot spares for devices failing during it wait their turn, in
+// the order the devices failed. Losing more
+// devices than the layout tolerates fails every operation with EIO.
+
+// Storage array layouts
+const (
+	StorageArrayRAID0      = "raid0"
+	StorageArrayRAID1      = "raid1"
+	StorageArrayRAID5      = "raid5"
+	StorageArrayRAID10     = "raid10"
+	StorageArrayReplicated = "replicated"
+)
+
+// maxRebuildShare caps the share of a device's bandwidth a rebuild takes
+const maxRebuildShare = 0.9
+
+// StorageArrayFailure fails a device at a set time in the simulation
+type StorageArrayFailure struct {
+	Device int           `json:"device"`
+	At     time.Duration `json:"at"`
+}
+
+// StorageArrayConfig is loaded from a profile's storage_array section
+type StorageArrayConfig struct {
+	Layout       string                `json:"layout"`
+	Devices      int                   `json:"devices"`
+	StripeBytes  int64                 `json:"stripe_bytes"`  // Chunk placed on one device before moving to the next
+	WriteQuorum  int                   `json:"write_quorum"`  // Replicated: replicas acknowledging a write
+	RebuildMBps  float64               `json:"rebuild_mbps"`  // Rate a replacement device is rebuilt at
+	RebuildBytes int64                 `json:"rebuild_bytes"` // Copied to a replacement; 0 for the whole device
+	HotSpare     bool                  `json:"hot_spare"`     // Rebuild onto a spare as soon as a device fails
+	Failures     []StorageArrayFailure `json:"failures"`      // In the order they happen
+}
+
+// StorageArrayState tracks failed devices, the rebuild and the array's I/O
+type StorageArrayState struct {
+	Failed            []bool        `json:"failed"`
+	FailedDevices     int           `json:"failed_devices"`
+	Lost              bool          `json:"lost"`         // More devices failed than the layout tolerates
+	LostAtTick        int64         `json:"lost_at_tick"` // -1 while the array holds its data
+	Rebuilding        int           `json:"rebuilding"`   // Device being rebuilt, -1 when none
+	RebuildStartTick  int64         `json:"rebuild_start_tick"`
+	RebuildLastTick   int64         `json:"rebuild_last_tick"`
+	RebuildTotalBytes int64         `json:"rebuild_total_bytes"`
+	RebuiltBytes      int64         `json:"rebuilt_bytes"`
+	RebuildsCompleted int           `json:"rebuilds_completed"`
+	LastRebuildTime   time.Duration `json:"last_rebuild_time"`
+	Replacements      int           `json:"replacements"`
+	PendingSpares     []int         `json:"pending_spares"`       // Failed devices waiting for a hot spare, in the order they failed
+	LastError         string        `json:"last_error,omitempty"` // Last scheduled failure or hot spare replacement that could not be applied
+	FailuresApplied   int           `json:"failures_applied"` // Scheduled failures that have happened
+	ReadCursor        int           `json:"read_cursor"`      // Spreads reads across the copies
+	DegradedReads     int64         `json:"degraded_reads"`   // Reads of a chunk on a device out of the array
+	ReadModifyWrites  int64         `json:"read_modify_writes"`
+	FullStripeWrites  int64         `json:"full_stripe_writes"`
+	FailedOperations  int64         `json:"failed_operations"`
+}
+
+// defaultStorageArrayConfig returns a two-way mirror with md's defaults
+func defaultStorageArrayConfig() StorageArrayConfig {
+	return StorageArrayConfig{
+		Layout:      StorageArrayRAID1,
+		Devices:     2,
+		StripeBytes: 64 * 1024,
+		RebuildMBps: 200,
+	}
+}
+
+// loadProfile overrides settings present in a profile's storage_array section
+func (config *StorageArrayConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["layout"].(string); ok {
+		config.Layout = val
+	}
+	if val, ok := settings["devices"].(float64); ok {
+		config.Devices = int(val)
+	}
+	if val, ok := settings["stripe_kb"].(float64); ok && val > 0 {
+		config.StripeBytes = int64(val * 1024)
+	}
+	config.WriteQuorum = config.Devices/2 + 1
+	if val, ok := settings["write_quorum"].(float64); ok {
+		config.WriteQuorum = int(val)
+	}
+	if val, ok := settings["rebuild_mbps"].(float64); ok && val > 0 {
+		config.RebuildMBps = val
+	}
+	if val, ok := settings["rebuild_gb"].(float64); ok && val >= 0 {
+		config.RebuildBytes = int64(val * (1 << 30))
+	}
+	if val, ok := settings["hot_spare"].(bool); ok {
+		config.HotSpare = val
+	}
+	if failures, ok := settings["failures"].([]interface{}); ok {
+		config.Failures = nil
+		for _, entry := range failures {
+			failure, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			device, _ := failure["device"].(float64)
+			atMs, _ := failure["at_ms"].(float64)
+			config.Failures = append(config.Failures, StorageArrayFailure{
+				Device: int(device),
+				At:     time.Duration(atMs * float64(time.Millisecond)),
+			})
+		}
+		sort.SliceStable(config.Failures, func(i, j int) bool { return config.Failures[i].At < config.Failures[j].At })
+	}
+}
+
+// validate checks the layout has the devices it needs
+func (config StorageArrayConfig) validate() error {
+	minDevices := map[string]int{
+		StorageArrayRAID0:      2,
+		StorageArrayRAID1:      2,
+		StorageArrayRAID5:      3,
+		StorageArrayRAID10:     4,
+		StorageArrayReplicated: 2,
+	}
+	required, ok := minDevices[config.Layout]
+	if !ok {
+		return fmt.Errorf("storage array layout must be raid0, raid1, raid5, raid10 or replicated, got %s", config.Layout)
+	}
+	if config.Devices < required {
+		return fmt.Errorf("storage array %s needs at least %d devices, got %d", config.Layout, required, config.Devices)
+	}
+	if config.Layout == StorageArrayRAID10 && config.Devices%2 != 0 {
+		return fmt.Errorf("storage array raid10 needs an even number of devices, got %d", config.Devices)
+	}
+	if config.Layout == StorageArrayReplicated && (config.WriteQuorum < 1 || config.WriteQuorum > config.Devices) {
+		return fmt.Errorf("storage array write quorum must be between 1 and %d, got %d", config.Devices, config.WriteQuorum)
+	}
+	for _, failure := range config.Failures {
+		if failure.Device < 0 || failure.Device >= config.Devices {
+			return fmt.Errorf("storage array failure names device %d of %d", failure.Device, config.Devices)
+		}
+	}
+	return nil
+}
+
+// dataDevices returns how many devices' worth of data the array holds
+func (config StorageArrayConfig) dataDevices() int {
+	switch config.Layout {
+	case StorageArrayRAID0:
+		return config.Devices
+	case StorageArrayRAID5:
+		return config.Devices - 1
+	case StorageArrayRAID10:
+		return config.Devices / 2
+	}
+	return 1
+}
+
+// loadStorageArray composes the devices of the profile's storage array
+func (storage *StorageEngine) loadStorageArray() error {
+	storage.Array, storage.ArrayDevices = StorageArrayConfig{}, nil
+	storage.ArrayState = StorageArrayState{Rebuilding: -1, LostAtTick: -1}
+	settings, ok := storage.Profile.EngineSpecific["storage_array"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	storage.Array = defaultStorageArrayConfig()
+	storage.Array.loadProfile(settings)
+	if err := storage.Array.validate(); err != nil {
+		return err
+	}
+	storage.ArrayDevices = make([]*StorageEngine, storage.Array.Devices)
+	for device := range storage.ArrayDevices {
+		member, err := storage.newArrayDevice(device)
+		if err != nil {
+			return fmt.Errorf("storage array device %d: %w", device, err)
+		}
+		storage.ArrayDevices[device] = member
+	}
+	storage.ArrayState.Failed = make([]bool, storage.Array.Devices)
+	return nil
+}
+
+// newArrayDevice creates a device of the array from the engine's profile,
+// sharing the engine's complexity settings
+func (storage *StorageEngine) newArrayDevice(device int) (*StorageEngine, error) {
+	profile := *storage.Profile
+	profile.EngineSpecific = make(map[string]interface{}, len(storage.Profile.EngineSpecific))
+	for key, value := range storage.Profile.EngineSpecific {
+		if key != "storage_array" && key != "page_cache" {
+			profile.EngineSpecific[key] = value
+		}
+	}
+
+	member := NewStorageEngine(storage.GetQueueCapacity())
+	member.ComplexityInterface = storage.ComplexityInterface
+	member.SetTickDuration(storage.TickDuration)
+	if err := member.LoadProfile(&profile); err != nil {
+		return nil, err
+	}
+	member.ID = fmt.Sprintf("%s-dev%d", storage.ID, device)
+	member.SetSeed(storage.arrayDeviceSeed(storage.GetSeed(), device))
+	return member, nil
+}
+
+// arrayDeviceSeed derives a device's seed, giving each replacement its own
+func (storage *StorageEngine) arrayDeviceSeed(seed int64, device int) int64 {
+	return DeriveSeed(seed, "array", strconv.Itoa(device), strconv.Itoa(storage.ArrayState.Replacements))
+}
+
+// SetSeed seeds the engine and the devices of its array
+func (storage *StorageEngine) SetSeed(seed int64) {
+	storage.CommonEngine.SetSeed(seed)
+	for device, member := range storage.ArrayDevices {
+		member.SetSeed(storage.arrayDeviceSeed(seed, device))
+	}
+}
+
+// SetTickDuration sets the tick duration of the engine and its array's devices
+func (storage *StorageEngine) SetTickDuration(duration time.Duration) {
+	storage.CommonEngine.SetTickDuration(duration)
+	for _, member := range storage.ArrayDevices {
+		member.SetTickDuration(duration)
+	}
+}
+
+// arrayEnabled reports whether the engine spreads its operations over an array
+func (storage *StorageEngine) arrayEnabled() bool {
+	return len(storage.ArrayDevices) > 0 && storage.ComplexityInterface.ShouldEnableFeature("storage_array")
+}
+
+// deviceInSync reports whether a device holds its share of the array's data:
+// it has not failed and is not a replacement still being rebuilt
+func (storage *StorageEngine) deviceInSync(device int) bool {
+	return !storage.ArrayState.Failed[device] && storage.ArrayState.Rebuilding != device
+}
+
+// arrayDataLost reports whether the devices out of sync hold data the layout
+// cannot recover
+func (storage *StorageEngine) arrayDataLost() bool {
+	outOfSync := 0
+	for device := range storage.ArrayDevices {
+		if !storage.deviceInSync(device) {
+			outOfSync++
+		}
+	}
+
+	switch storage.Array.Layout {
+	case StorageArrayRAID0:
+		return outOfSync > 0
+	case StorageArrayRAID5:
+		return outOfSync > 1
+	case StorageArrayRAID10:
+		for pair := 0; pair < storage.Array.Devices; pair += 2 {
+			if !storage.deviceInSync(pair) && !storage.deviceInSync(pair+1) {
+				return true
+			}
+		}
+		return false
+	}
+	return outOfSync == storage.Array.Devices
+}
+
+// FailDevice takes a device out of the array, leaving it degraded or, past
+// what the layout tolerates, lost. With a hot spare the rebuild starts at once.
+func (storage *StorageEngine) FailDevice(device int, currentTick int64) error {
+	state := &storage.ArrayState
+	if device < 0 || device >= len(storage.ArrayDevices) {
+		return fmt.Errorf("storage array has no device %d", device)
+	}
+	if state.Failed[device] {
+		return fmt.Errorf("storage array device %d has already failed", device)
+	}
+
+	state.Failed[device] = true
+	state.FailedDevices++
+	if state.Rebuilding == device {
+		state.Rebuilding = -1
+	}
+	if !state.Lost && storage.arrayDataLost() {
+		state.Lost = true
+		state.LostAtTick = currentTick
+		state.Rebuilding = -1
+		state.PendingSpares = nil
+	}
+	if storage.Array.HotSpare && !state.Lost {
+		state.PendingSpares = append(state.PendingSpares, device)
+		if state.Rebuilding < 0 {
+			return storage.replaceNextSpare(currentTick)
+		}
+	}
+	return nil
+}
+
+// replaceNextSpare starts rebuilding a hot spare in place of the device that
+// has waited longest for one
+func (storage *StorageEngine) replaceNextSpare(currentTick int64) error {
+	state := &storage.ArrayState
+	if len(state.PendingSpares) == 0 {
+		return nil
+	}
+	device := state.PendingSpares[0]
+	state.PendingSpares = state.PendingSpares[1:]
+	return storage.ReplaceDevice(device, currentTick)
+}
+
+// ReplaceDevice puts a new device in place of a failed one and starts
+// rebuilding it
+func (storage *StorageEngine) ReplaceDevice(device int, currentTick int64) error {
+	state := &storage.ArrayState
+	if device < 0 || device >= len(storage.ArrayDevices) {
+		return fmt.Errorf("storage array has no device %d", device)
+	}
+	if !state.Failed[device] {
+		return fmt.Errorf("storage array device %d has not failed", device)
+	}
+	if state.Lost {
+		return fmt.Errorf("storage array lost its data at tick %d", state.LostAtTick)
+	}
+	if state.Rebuilding >= 0 {
+		return fmt.Errorf("storage array is still rebuilding device %d", state.Rebuilding)
+	}
+
+	state.PendingSpares = slices.DeleteFunc(state.PendingSpares, func(pending int) bool { return pending == device })
+	state.Replacements++
+	member, err := storage.newArrayDevice(device)
+	if err != nil {
+		return err
+	}
+	storage.ArrayDevices[device] = member
+	state.Failed[device] = false
+	state.FailedDevices--
+	state.Rebuilding = device
+	state.RebuildStartTick, state.RebuildLastTick = currentTick, currentTick
+	state.RebuiltBytes = 0
+	state.RebuildTotalBytes = storage.Array.RebuildBytes
+	if state.RebuildTotalBytes <= 0 {
+		state.RebuildTotalBytes = member.CapacityGB * (1 << 30)
+	}
+	return nil
+}
+
+// rebuildShare returns the share of a device's bandwidth the rebuild takes:
+// the replacement is written, and read from are its mirror or, under RAID5,
+// every other device
+func (storage *StorageEngine) rebuildShare(device int) float64 {
+	target := storage.ArrayState.Rebuilding
+	if target < 0 {
+		return 0.0
+	}
+	switch storage.Array.Layout {
+	case StorageArrayRAID10:
+		if device != target && device != target^1 {
+			return 0.0
+		}
+	case StorageArrayRAID1, StorageArrayReplicated:
+		if device != target && device != storage.rebuildSource() {
+			return 0.0
+		}
+	}
+	bandwidth := storage.ArrayDevices[device].BandwidthMBps
+	if bandwidth <= 0 {
+		return 0.0
+	}
+	return math.Min(storage.Array.RebuildMBps/bandwidth, maxRebuildShare)
+}
+
+// rebuildSource returns the mirror a replacement is copied from
+func (storage *StorageEngine) rebuildSource() int {
+	for device := range storage.ArrayDevices {
+		if storage.deviceInSync(device) {
+			return device
+		}
+	}
+	return -1
+}
+
+// rebuildProgress returns the share of the replacement rebuilt, 1 when none is
+func (storage *StorageEngine) rebuildProgress() float64 {
+	state := storage.ArrayState
+	if state.Rebuilding < 0 || state.RebuildTotalBytes <= 0 {
+		return 1.0
+	}
+	return float64(state.RebuiltBytes) / float64(state.RebuildTotalBytes)
+}
+
+// advanceStorageArray fails the devices scheduled to fail by now and copies
+// the rebuild's share of the time since it last advanced, starting the next
+// hot spare once it completes. A failure or replacement that cannot be applied
+// is recorded in the state's LastError.
+func (storage *StorageEngine) advanceStorageArray(currentTick int64) {
+	state := &storage.ArrayState
+	now := storage.TicksToDuration(currentTick)
+	for state.FailuresApplied < len(storage.Array.Failures) && storage.Array.Failures[state.FailuresApplied].At <= now {
+		if err := storage.FailDevice(storage.Array.Failures[state.FailuresApplied].Device, currentTick); err != nil {
+			state.LastError = fmt.Sprintf("tick %d: %v", currentTick, err)
+		}
+		state.FailuresApplied++
+	}
+
+	if state.Rebuilding < 0 || currentTick <= state.RebuildLastTick {
+		return
+	}
+	rate := storage.rebuildShare(state.Rebuilding) * storage.ArrayDevices[state.Rebuilding].BandwidthMBps * 1024 * 1024
+	state.RebuiltBytes += int64(rate * storage.TicksToDuration(currentTick-state.RebuildLastTick).Seconds())
+	state.RebuildLastTick = currentTick
+	if state.RebuiltBytes >= state.RebuildTotalBytes {
+		state.RebuiltBytes = state.RebuildTotalBytes
+		state.Rebuilding = -1
+		state.RebuildsCompleted++
+		state.LastRebuildTime = storage.TicksToDuration(currentTick - state.RebuildStartTick)
+		if err := storage.replaceNextSpare(currentTick); err != nil {
+			state.LastError = fmt.Sprintf("tick %d: %v", currentTick, err)
+		}
+	}
+}
+
+// nextArrayEventTick returns the tick of the next scheduled failure or of the
+// rebuild finishing
+func (storage *StorageEngine) nextArrayEventTick() (int64, bool) {
+	state := storage.ArrayState
+	next, pending := int64(math.MaxInt64), false
+	if state.FailuresApplied < len(storage.Array.Failures) {
+		next, pending = storage.DurationToTicks(storage.Array.Failures[state.FailuresApplied].At), true
+	}
+	if state.Rebuilding >= 0 {
+		rate := storage.rebuildShare(state.Rebuilding) * storage.ArrayDevices[state.Rebuilding].BandwidthMBps * 1024 * 1024
+		if rate > 0 {
+			remaining := time.Duration(float64(state.RebuildTotalBytes-state.RebuiltBytes) / rate * float64(time.Second))
+			next, pending = min(next, state.RebuildLastTick+max(storage.DurationToTicks(remaining), 1)), true
+		}
+	}
+	return next, pending
+}
+
+// arrayExtent is the part of an operation one device serves
+type arrayExtent struct {
+	device int
+	offset int64 // Bytes into the device
+	bytes  int64
+}
+
+// arrayPhase is a set of device I/Os issued together
+type arrayPhase struct {
+	opType  string
+	extents []arrayExtent
+	quorum  int // I/Os the phase waits for; 0 for all of them
+}
+
+// add appends an extent, merging it into one it continues on the same device
+func (phase *arrayPhase) add(device int, offset, bytes int64) {
+	for i := range phase.extents {
+		extent := &phase.extents[i]
+		if extent.device == device && extent.offset+extent.bytes == offset {
+			extent.bytes += bytes
+			return
+		}
+	}
+	phase.extents = append(phase.extents, arrayExtent{device: device, offset: offset, bytes: bytes})
+}
+
+// arrayChunk is the part of an operation falling in one chunk of a stripe
+type arrayChunk struct {
+	stripe int64 // Row of chunks across the devices
+	column int   // Data column within the stripe
+	offset int64 // Bytes into the device
+	bytes  int64
+}
+
+// arrayChunks splits an operation's byte range into the stripe chunks holding it
+func (storage *StorageEngine) arrayChunks(offset, bytes int64) []arrayChunk {
+	columns, chunkBytes := int64(storage.Array.dataDevices()), storage.Array.StripeBytes
+	var chunks []arrayChunk
+	for end := offset + bytes; offset < end; {
+		chunk, within := offset/chunkBytes, offset%chunkBytes
+		length := min(chunkBytes-within, end-offset)
+		stripe := chunk / columns
+		chunks = append(chunks, arrayChunk{
+			stripe: stripe,
+			column: int(chunk % columns),
+			offset: stripe*chunkBytes + within,
+			bytes:  length,
+		})
+		offset += length
+	}
+	return chunks
+}
+
+// raid5Devices returns the device holding a data column of a stripe and the
+// stripe's parity device, rotating the parity left-symmetrically as md does
+func (storage *StorageEngine) raid5Devices(stripe int64, column int) (int, int) {
+	devices := storage.Array.Devices
+	parity := devices - 1 - int(stripe%int64(devices))
+	return (parity + 1 + column) % devices, parity
+}
+
+// pickCopy returns the next in-sync device holding a copy, or -1 if none does
+func (storage *StorageEngine) pickCopy(devices ...int) int {
+	for i := range devices {
+		device := devices[(storage.ArrayState.ReadCursor+i)%len(devices)]
+		if storage.deviceInSync(device) {
+			storage.ArrayState.ReadCursor++
+			return device
+		}
+	}
+	return -1
+}
+
+// planArrayRead places a read on the devices holding its data, reconstructing
+// chunks on a device out of sync from the rest of their stripe
+func (storage *StorageEngine) planArrayRead(reads *arrayPhase, offset, bytes int64) bool {
+	all := make([]int, storage.Array.Devices)
+	for device := range all {
+		all[device] = device
+	}
+
+	degraded := false
+	switch storage.Array.Layout {
+	case StorageArrayRAID1, StorageArrayReplicated:
+		reads.add(storage.pickCopy(all...), offset, bytes)
+	case StorageArrayRAID0:
+		for _, chunk := range storage.arrayChunks(offset, bytes) {
+			reads.add(chunk.column, chunk.offset, chunk.bytes)
+		}
+	case StorageArrayRAID10:
+		for _, chunk := range storage.arrayChunks(offset, bytes) {
+			first := 2 * chunk.column
+			if !storage.deviceInSync(first) || !storage.deviceInSync(first+1) {
+				degraded = true
+			}
+			reads.add(storage.pickCopy(first, first+1), chunk.offset, chunk.bytes)
+		}
+	case StorageArrayRAID5:
+		for _, chunk := range storage.arrayChunks(offset, bytes) {
+			device, _ := storage.raid5Devices(chunk.stripe, chunk.column)
+			if storage.deviceInSync(device) {
+				reads.add(device, chunk.offset, chunk.bytes)
+				continue
+			}
+			degraded = true
+			for other := range all {
+				if other != device {
+					reads.add(other, chunk.offset, chunk.bytes)
+				}
+			}
+		}
+	}
+	return degraded
+}
+
+// planArrayWrite places a write on every device holding a copy of its data.
+// RAID5 writes a whole stripe with its parity, and otherwise reads the old
+// data and parity to compute the new parity, or for a chunk on a device out
+// of sync the rest of its stripe.
+func (storage *StorageEngine) planArrayWrite(reads, writes *arrayPhase, offset, bytes int64) {
+	failed := storage.ArrayState.Failed
+	switch storage.Array.Layout {
+	case StorageArrayRAID1, StorageArrayReplicated:
+		for device := range storage.ArrayDevices {
+			if !failed[device] {
+				writes.add(device, offset, bytes)
+			}
+		}
+	case StorageArrayRAID0:
+		for _, chunk := range storage.arrayChunks(offset, bytes) {
+			writes.add(chunk.column, chunk.offset, chunk.bytes)
+		}
+	case StorageArrayRAID10:
+		for _, chunk := range storage.arrayChunks(offset, bytes) {
+			for device := 2 * chunk.column; device <= 2*chunk.column+1; device++ {
+				if !failed[device] {
+					writes.add(device, chunk.offset, chunk.bytes)
+				}
+			}
+		}
+	case StorageArrayRAID5:
+		stripes := make(map[int64][]arrayChunk)
+		var order []int64
+		for _, chunk := range storage.arrayChunks(offset, bytes) {
+			if _, ok := stripes[chunk.stripe]; !ok {
+				order = append(order, chunk.stripe)
+			}
+			stripes[chunk.stripe] = append(stripes[chunk.stripe], chunk)
+		}
+		for _, stripe := range order {
+			storage.planRAID5StripeWrite(reads, writes, stripes[stripe])
+		}
+	}
+}
+
+// planRAID5StripeWrite places the writes to one stripe with its parity
+func (storage *StorageEngine) planRAID5StripeWrite(reads, writes *arrayPhase, chunks []arrayChunk) {
+	state := &storage.ArrayState
+	_, parity := storage.raid5Devices(chunks[0].stripe, 0)
+
+	// The parity covers the range of the stripe's chunks being written
+	stripeStart := chunks[0].stripe * storage.Array.StripeBytes
+	low, high, written := stripeStart+storage.Array.StripeBytes, stripeStart, int64(0)
+	for _, chunk := range chunks {
+		low, high, written = min(low, chunk.offset), max(high, chunk.offset+chunk.bytes), written+chunk.bytes
+	}
+	fullStripe := written == storage.Array.StripeBytes*int64(storage.Array.dataDevices())
+	if fullStripe {
+		state.FullStripeWrites++
+	} else {
+		state.ReadModifyWrites++
+	}
+
+	readParity := false
+	for _, chunk := range chunks {
+		device, _ := storage.raid5Devices(chunk.stripe, chunk.column)
+		if !state.Failed[device] {
+			writes.add(device, chunk.offset, chunk.bytes)
+		}
+		switch {
+		case fullStripe:
+		case !storage.deviceInSync(device):
+			// Reconstruct-write: the new parity comes from the stripe's other data
+			for column := 0; column < storage.Array.dataDevices(); column++ {
+				if other, _ := storage.raid5Devices(chunk.stripe, column); other != device {
+					reads.add(other, chunk.offset, chunk.bytes)
+				}
+			}
+		case storage.deviceInSync(parity):
+			reads.add(device, chunk.offset, chunk.bytes)
+			readParity = true
+		}
+	}
+	if readParity {
+		reads.add(parity, low, high-low)
+	}
+	if !state.Failed[parity] {
+		writes.add(parity, low, high-low)
+	}
+}
+
+// runArrayPhase issues a phase's device I/Os in parallel and returns when the
+// quorum of them completes with the I/O that completed last
+func (storage *StorageEngine) runArrayPhase(phase *arrayPhase, op *Operation, pattern string, currentTick int64) (time.Duration, *OperationResult, error) {
+	var results []*OperationResult
+	var err error
+	for _, extent := range phase.extents {
+		result := storage.ArrayDevices[extent.device].ProcessOperation(&Operation{
+			ID:       op.ID,
+			Type:     phase.opType,
+			DataSize: extent.bytes,
+			Priority: op.Priority,
+			Metadata: map[string]interface{}{"lba": extent.offset / hddSectorBytes, "access_pattern": pattern},
+		}, currentTick)
+
+		// The rebuild's traffic leaves the I/O the rest of the device's bandwidth
+		if share := storage.rebuildShare(extent.device); share > 0 {
+			result.ProcessingTime = time.Duration(float64(result.ProcessingTime) / (1 - share))
+		}
+		if err == nil {
+			err = result.Error
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return 0, nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].ProcessingTime < results[j].ProcessingTime })
+	completing := results[len(results)-1]
+	if phase.quorum > 0 && phase.quorum < len(results) {
+		completing = results[phase.quorum-1]
+	}
+	return completing.ProcessingTime, completing, err
+}
+
+// processArrayOperation serves an operation from the array's devices, reading
+// before it writes when the layout needs the old data
+func (storage *StorageEngine) processArrayOperation(op *Operation, currentTick int64, writebackTime time.Duration) *OperationResult {
+	state := &storage.ArrayState
+	offset := storage.operationLBA(op) * hddSectorBytes
+	bytes := max(op.DataSize, hddSectorBytes)
+	pattern := storage.determineAccessPattern(op)
+
+	reads, writes := &arrayPhase{opType: OpStorageRead}, &arrayPhase{opType: op.Type}
+	degraded := state.FailedDevices > 0 || state.Rebuilding >= 0
+	var err error
+	live := 0
+	for device := range storage.ArrayDevices {
+		if !state.Failed[device] {
+			live++
+		}
+	}
+	switch {
+	case state.Lost:
+		err = storage.arrayError(op)
+	case op.Type == OpStorageWrite || op.Type == OpStorageTrim:
+		if storage.Array.Layout == StorageArrayReplicated {
+			if live < storage.Array.WriteQuorum {
+				err = storage.arrayError(op)
+				break
+			}
+			writes.quorum = storage.Array.WriteQuorum
+		}
+		if op.Type == OpStorageTrim && storage.Array.Layout == StorageArrayRAID5 {
+			// A trim releases the data chunks and leaves the parity stale
+			for _, chunk := range storage.arrayChunks(offset, bytes) {
+				if device, _ := storage.raid5Devices(chunk.stripe, chunk.column); !state.Failed[device] {
+					writes.add(device, chunk.offset, chunk.bytes)
+				}
+			}
+			break
+		}
+		storage.planArrayWrite(reads, writes, offset, bytes)
+	case op.Type == OpStorageSync:
+		for device := range storage.ArrayDevices {
+			if !state.Failed[device] {
+				writes.add(device, 0, bytes)
+			}
+		}
+	default:
+		reads.opType = op.Type
+		if storage.planArrayRead(reads, offset, bytes) {
+			state.DegradedReads++
+		}
+	}
+
+	// Writes go out once the reads they depend on are back
+	readTime, readResult, readErr := storage.runArrayPhase(reads, op, pattern, currentTick)
+	writeTime, writeResult, writeErr := storage.runArrayPhase(writes, op, pattern, currentTick+storage.DurationToTicks(readTime))
+	for _, phaseErr := range []error{readErr, writeErr} {
+		if err == nil {
+			err = phaseErr
+		}
+	}
+	if err != nil {
+		state.FailedOperations++
+	}
+
+	// The result follows the device I/O the operation completed with
+	basis := writeResult
+	if basis == nil {
+		basis = readResult
+	}
+	if basis == nil {
+		basis = &OperationResult{
+			PenaltyInfo: &PenaltyInformation{
+				EngineType:         StorageEngineType,
+				TotalPenaltyFactor: 1.0,
+				PerformanceGrade:   "F",
+				RecommendedAction:  "redirect",
+				StoragePenalties:   &StoragePenaltyDetails{},
+			},
+			Metrics: map[string]interface{}{},
+		}
+	}
+	finalTime := readTime + writeTime + writebackTime
+	if storage.ComplexityInterface.ShouldEnableFeature("dynamic_behavior") {
+		storage.updateStorageState(op, finalTime)
+	}
+	ticksToComplete := max(storage.DurationToTicks(finalTime), 1)
+
+	penalties := *basis.PenaltyInfo
+	penalties.EngineID = storage.ID
+	penalties.ActualProcessingTime = finalTime
+	details := *basis.PenaltyInfo.StoragePenalties
+	details.PageCacheHitRatio = storage.GetPageCacheStats().HitRatio
+	details.ArrayDegraded = degraded
+	details.RebuildProgress = storage.rebuildProgress()
+	penalties.StoragePenalties = &details
+
+	result := &OperationResult{
+		OperationID:    op.ID,
+		OperationType:  op.Type,
+		ProcessingTime: finalTime,
+		CompletedTick:  currentTick + ticksToComplete,
+		CompletedAt:    currentTick + ticksToComplete,
+		Success:        err == nil,
+		Error:          err,
+		NextComponent:  op.NextComponent,
+		PenaltyInfo:    &penalties,
+		Metrics:        make(map[string]interface{}, len(basis.Metrics)+6),
+	}
+	for key, value := range basis.Metrics {
+		result.Metrics[key] = value
+	}
+	result.Metrics["final_time_us"] = finalTime.Microseconds()
+	result.Metrics["array_layout"] = storage.Array.Layout
+	result.Metrics["array_device_ios"] = len(reads.extents) + len(writes.extents)
+	result.Metrics["array_degraded"] = degraded
+	result.Metrics["failed_devices"] = state.FailedDevices
+	result.Metrics["rebuild_progress"] = details.RebuildProgress
+	if storage.pageCacheEnabled(op) {
+		result.Metrics["page_cache"] = "miss"
+		result.Metrics["page_cache_hit_ratio"] = storage.PageCache.Stats.HitRatio
+		result.Metrics["dirty_pages"] = storage.PageCache.Stats.DirtyPages
+	}
+	return result
+}
+
+// arrayError returns the EIO for an operation the array cannot serve
+func (storage *StorageEngine) arrayError(op *Operation) error {
+	return &StorageError{
+		Code:           StorageErrorArrayFailed,
+		OperationID:    op.ID,
+		RequestedBytes: op.DataSize,
+		FailedDevices:  storage.ArrayState.FailedDevices,
+		Devices:        storage.Array.Devices,
+	}
+}
+
+// GetStorageArrayState returns the array's failed devices, rebuild and I/O
+func (storage *StorageEngine) GetStorageArrayState() StorageArrayState {
+	return storage.ArrayState
+}
@@ -0,0 +1,214 @@
+package engines
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Space and endurance of the device a storage engine models. Writes allocate
+// space until the device is full, after which they fail with ENOSPC, and
+// storage_trim releases it; writes marked as overwrites reuse space they
+// already hold. Flash cannot overwrite in place, so garbage collection copies
+// the still-valid pages out of a block before erasing it: random writes to a
+// nearly full drive rewrite several NAND pages per host page, while sequential
+// writes invalidate whole blocks. Free space and the over-provisioned spare
+// area keep the copying down. The NAND bytes written wear the drive against
+// its rated TBW (terabytes written).
+
+// StorageErrorCode identifies why a storage operation failed
+type StorageErrorCode string
+
+const (
+	StorageErrorNoSpace     StorageErrorCode = "storage_full" // ENOSPC: the write did not fit in the free space
+	StorageErrorArrayFailed StorageErrorCode = "array_failed" // EIO: too many of the array's devices have failed
+)
+
+// StorageError is returned for storage operations the device refused
+type StorageError struct {
+	Code           StorageErrorCode `json:"code"`
+	OperationID    string           `json:"operation_id"`
+	RequestedBytes int64            `json:"requested_bytes"`
+	UsedBytes      int64            `json:"used_bytes"`
+	CapacityBytes  int64            `json:"capacity_bytes"`
+	FailedDevices  int              `json:"failed_devices,omitempty"` // Array failures: devices out of the array
+	Devices        int              `json:"devices,omitempty"`
+}
+
+// Error implements the error interface
+func (e *StorageError) Error() string {
+	if e.Code == StorageErrorArrayFailed {
+		return fmt.Sprintf("storage %s: operation %s with %d of %d devices failed",
+			e.Code, e.OperationID, e.FailedDevices, e.Devices)
+	}
+	return fmt.Sprintf("storage %s: operation %s wrote %d bytes with %d of %d used",
+		e.Code, e.OperationID, e.RequestedBytes, e.UsedBytes, e.CapacityBytes)
+}
+
+// StorageCapacityConfig is loaded from the profile's capacity, over-provisioning
+// and endurance, and from a profile's capacity section
+type StorageCapacityConfig struct {
+	CapacityBytes         int64 `json:"capacity_bytes"`          // User-addressable space
+	OverProvisioningBytes int64 `json:"over_provisioning_bytes"` // Spare flash hidden from the host
+	EnduranceBytes        int64 `json:"endurance_bytes"`         // Rated TBW; 0 for devices without one
+	InitialUsedBytes      int64 `json:"initial_used_bytes"`      // Data on the device when the simulation starts
+}
+
+// StorageUsage tracks space and wear on the device
+type StorageUsage struct {
+	UsedBytes          int64   `json:"used_bytes"`
+	HostBytesWritten   int64   `json:"host_bytes_written"`
+	NANDBytesWritten   int64   `json:"nand_bytes_written"` // Host writes plus garbage collection copies
+	TrimmedBytes       int64   `json:"trimmed_bytes"`
+	FailedWrites       int64   `json:"failed_writes"` // Writes refused with ENOSPC
+	WriteAmplification float64 `json:"write_amplification"`
+	FirstWriteTick     int64   `json:"first_write_tick"`
+}
+
+// StorageEnduranceReport projects the drive's lifetime under the simulated workload
+type StorageEnduranceReport struct {
+	CapacityBytes         int64   `json:"capacity_bytes"`
+	UsedBytes             int64   `json:"used_bytes"`
+	Utilization           float64 `json:"utilization"`
+	HostBytesWritten      int64   `json:"host_bytes_written"`
+	NANDBytesWritten      int64   `json:"nand_bytes_written"`
+	WriteAmplification    float64 `json:"write_amplification"` // NAND bytes per host byte so far
+	EnduranceTBW          float64 `json:"endurance_tbw"`
+	EnduranceUsed         float64 `json:"endurance_used"`           // Fraction of the rating consumed
+	RemainingTBW          float64 `json:"remaining_tbw"`            // Host terabytes left at the observed write amplification
+	HostWriteBytesPerSec  float64 `json:"host_write_bytes_per_sec"` // Since the first write
+	ProjectedLifetimeDays float64 `json:"projected_lifetime_days"`  // Until the rating is used up; 0 when unknown
+}
+
+// defaultStorageCapacityConfig sizes the device from the profile's capacity,
+// over-provisioning and technology_specs endurance_tbw
+func (storage *StorageEngine) defaultStorageCapacityConfig() StorageCapacityConfig {
+	const gb = int64(1 << 30)
+	config := StorageCapacityConfig{CapacityBytes: storage.CapacityGB * gb}
+	if storage.StorageType != "HDD" {
+		config.OverProvisioningBytes = int64(storage.WearLevelingState.OverProvisioningGB) * gb
+		switch tbw := storage.Profile.TechnologySpecs["endurance_tbw"].(type) {
+		case float64:
+			config.EnduranceBytes = int64(tbw * 1e12)
+		case int:
+			config.EnduranceBytes = int64(tbw) * 1e12
+		}
+	}
+	return config
+}
+
+// loadProfile overrides capacity settings present in a profile's capacity section
+func (config *StorageCapacityConfig) loadProfile(settings map[string]interface{}) {
+	const gb = 1 << 30
+	if val, ok := settings["over_provisioning_gb"].(float64); ok && val >= 0 {
+		config.OverProvisioningBytes = int64(val * gb)
+	}
+	if val, ok := settings["endurance_tbw"].(float64); ok && val >= 0 {
+		config.EnduranceBytes = int64(val * 1e12)
+	}
+	if val, ok := settings["used_gb"].(float64); ok && val >= 0 {
+		config.InitialUsedBytes = min(int64(val*gb), config.CapacityBytes)
+	}
+}
+
+// applyCapacityEffects allocates or releases space for an operation and slows
+// writes by their write amplification, or returns a *StorageError for a write
+// that does not fit
+func (storage *StorageEngine) applyCapacityEffects(baseTime time.Duration, op *Operation) (time.Duration, error) {
+	config, usage := storage.Capacity, &storage.Usage
+	if config.CapacityBytes <= 0 {
+		return baseTime, nil
+	}
+
+	switch op.Type {
+	case OpStorageWrite:
+		growth := op.DataSize
+		if overwrite, _ := op.Metadata["overwrite"].(bool); overwrite {
+			growth = 0
+		}
+		if usage.UsedBytes+growth > config.CapacityBytes {
+			usage.FailedWrites++
+			return baseTime, &StorageError{
+				Code:           StorageErrorNoSpace,
+				OperationID:    op.ID,
+				RequestedBytes: op.DataSize,
+				UsedBytes:      usage.UsedBytes,
+				CapacityBytes:  config.CapacityBytes,
+			}
+		}
+		usage.UsedBytes += growth
+
+		if usage.HostBytesWritten == 0 {
+			usage.FirstWriteTick = storage.CurrentTick
+		}
+		amplification := storage.writeAmplification(op)
+		usage.WriteAmplification = amplification
+		usage.HostBytesWritten += op.DataSize
+		usage.NANDBytesWritten += int64(float64(op.DataSize) * amplification)
+		if config.EnduranceBytes > 0 {
+			storage.WearLevelingState.WearLevel = math.Min(float64(usage.NANDBytesWritten)/float64(config.EnduranceBytes), 1.0)
+		}
+
+		// Garbage collection copies share the flash with the host's write
+		return time.Duration(float64(baseTime) * amplification), nil
+
+	case OpStorageTrim:
+		trimmed := min(op.DataSize, usage.UsedBytes)
+		usage.UsedBytes -= trimmed
+		usage.TrimmedBytes += trimmed
+	}
+
+	return baseTime, nil
+}
+
+// writeAmplification returns the NAND bytes written per host byte for a write.
+// Greedy garbage collection under uniform random writes amplifies them by
+// (1+ρ)/(2ρ), where ρ is the spare space (free plus over-provisioned) over the
+// valid data; sequential writes, disks and cloud volumes (whose flash the
+// provider manages) are not amplified.
+func (storage *StorageEngine) writeAmplification(op *Operation) float64 {
+	if storage.StorageType == "HDD" || storage.CloudVolume.VolumeType != "" || storage.determineAccessPattern(op) == "sequential" {
+		return 1.0
+	}
+
+	used := storage.Usage.UsedBytes
+	if used <= 0 {
+		return 1.0
+	}
+	spare := float64(storage.Capacity.CapacityBytes+storage.Capacity.OverProvisioningBytes-used) / float64(used)
+	spare = math.Max(spare, 0.01) // A full drive without over-provisioning still finds some invalid pages
+	return math.Max((1+spare)/(2*spare), 1.0)
+}
+
+// GetEnduranceReport returns space and wear so far with the drive's projected lifetime
+func (storage *StorageEngine) GetEnduranceReport() StorageEnduranceReport {
+	config, usage := storage.Capacity, storage.Usage
+	report := StorageEnduranceReport{
+		CapacityBytes:      config.CapacityBytes,
+		UsedBytes:          usage.UsedBytes,
+		HostBytesWritten:   usage.HostBytesWritten,
+		NANDBytesWritten:   usage.NANDBytesWritten,
+		WriteAmplification: 1.0,
+		EnduranceTBW:       float64(config.EnduranceBytes) / 1e12,
+	}
+	if config.CapacityBytes > 0 {
+		report.Utilization = float64(usage.UsedBytes) / float64(config.CapacityBytes)
+	}
+	if usage.HostBytesWritten == 0 {
+		report.RemainingTBW = report.EnduranceTBW
+		return report
+	}
+
+	report.WriteAmplification = float64(usage.NANDBytesWritten) / float64(usage.HostBytesWritten)
+	elapsed := storage.TicksToDuration(storage.CurrentTick - usage.FirstWriteTick + 1)
+	report.HostWriteBytesPerSec = float64(usage.HostBytesWritten) / elapsed.Seconds()
+
+	if config.EnduranceBytes > 0 {
+		remaining := math.Max(float64(config.EnduranceBytes-usage.NANDBytesWritten), 0)
+		report.EnduranceUsed = math.Min(float64(usage.NANDBytesWritten)/float64(config.EnduranceBytes), 1.0)
+		report.RemainingTBW = remaining / report.WriteAmplification / 1e12
+		nandBytesPerSec := report.HostWriteBytesPerSec * report.WriteAmplification
+		report.ProjectedLifetimeDays = remaining / nandBytesPerSec / (24 * 3600)
+	}
+	return report
+}
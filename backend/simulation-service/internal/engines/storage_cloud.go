@@ -0,0 +1,206 @@
+package engines
+
+import (
+	"math"
+	"time"
+)
+
+// A network-attached cloud block volume (EBS/PD-style). Every I/O crosses the
+// network to the volume and back, and the volume admits I/Os no faster than
+// its IOPS and throughput limits, queueing the rest; I/Os up to the I/O unit
+// count once and larger ones once per unit. gp3, io1 and io2 volumes run at
+// their provisioned limits. gp2 volumes get a baseline of 3 IOPS per GB and may
+// burst to 3000 IOPS while they hold I/O credits: the bucket fills at the
+// baseline rate up to 5.4 million credits and every I/O spends one, so a
+// volume bursting above its baseline drains it and drops back to the baseline
+// once it is empty.
+
+// cloudVolumeBurstWindow is how far ahead of its slot the volume admits an I/O,
+// so I/Os arriving together within the limits are not spaced out
+const cloudVolumeBurstWindow = time.Millisecond
+
+// Cloud volume types
+const (
+	CloudVolumeGP2 = "gp2"
+	CloudVolumeGP3 = "gp3"
+	CloudVolumeIO1 = "io1"
+	CloudVolumeIO2 = "io2"
+)
+
+// CloudVolumeConfig is loaded from a profile's cloud_volume section, with limits
+// the profile leaves out derived from the volume type and size
+type CloudVolumeConfig struct {
+	VolumeType       string        `json:"volume_type"`
+	SizeGB           float64       `json:"size_gb"`
+	BaselineIOPS     float64       `json:"baseline_iops"`      // Provisioned, or gp2's 3 per GB
+	BaselineMBps     float64       `json:"baseline_mbps"`      // Provisioned throughput
+	BurstIOPS        float64       `json:"burst_iops"`         // gp2: rate while credits last; 0 without bursting
+	BurstMBps        float64       `json:"burst_mbps"`         // gp2: throughput while credits last
+	CreditCapacity   float64       `json:"credit_capacity"`    // I/O credits the bucket holds
+	InitialCredits   float64       `json:"initial_credits"`    // Credits when the simulation starts
+	IOUnitBytes      int64         `json:"io_unit_bytes"`      // Largest I/O counted once against the IOPS limit
+	NetworkRoundTrip time.Duration `json:"network_round_trip"` // Between the instance and the volume
+}
+
+// CloudVolumeState tracks the volume's credits and its rate limiting
+type CloudVolumeState struct {
+	CreditBalance    float64       `json:"credit_balance"`
+	CreditsExhausted bool          `json:"credits_exhausted"`
+	ExhaustedAtTick  int64         `json:"exhausted_at_tick"` // -1 until the credits first run out
+	LastRefill       time.Duration `json:"last_refill"`
+	NextIOSlot       time.Duration `json:"next_io_slot"`   // Simulated time the IOPS limit admits the next I/O
+	NextByteSlot     time.Duration `json:"next_byte_slot"` // Simulated time the throughput limit admits the next byte
+	ThrottledIOs     int64         `json:"throttled_ios"`
+	ThrottleTime     time.Duration `json:"throttle_time"`
+	CurrentIOPSLimit float64       `json:"current_iops_limit"`
+	CurrentMBpsLimit float64       `json:"current_mbps_limit"`
+	IOUnitsAdmitted  int64         `json:"io_units_admitted"`
+	BytesAdmitted    int64         `json:"bytes_admitted"`
+}
+
+// loadProfile overrides settings present in a profile's cloud_volume section
+// and derives the rest from the volume type and size
+func (config *CloudVolumeConfig) loadProfile(settings map[string]interface{}, capacityGB int64) {
+	config.VolumeType = CloudVolumeGP3
+	if val, ok := settings["volume_type"].(string); ok {
+		config.VolumeType = val
+	}
+	config.SizeGB = float64(capacityGB)
+	if val, ok := settings["size_gb"].(float64); ok && val > 0 {
+		config.SizeGB = val
+	}
+
+	// AWS's published limits for each volume type
+	config.IOUnitBytes = 256 * 1024
+	config.NetworkRoundTrip = 500 * time.Microsecond
+	switch config.VolumeType {
+	case CloudVolumeGP2:
+		config.BaselineIOPS = math.Min(math.Max(3*config.SizeGB, 100), 16000)
+		config.BaselineMBps = 250
+		if config.SizeGB <= 170 {
+			config.BaselineMBps = 128
+		}
+		if config.BaselineIOPS < 3000 {
+			config.BurstIOPS = 3000
+			config.BurstMBps = 250
+			config.CreditCapacity = 5.4e6
+		}
+	case CloudVolumeGP3:
+		config.BaselineIOPS = 3000
+		config.BaselineMBps = 125
+	case CloudVolumeIO1, CloudVolumeIO2:
+		config.BaselineIOPS = 100
+		config.IOUnitBytes = 16 * 1024
+		config.NetworkRoundTrip = 250 * time.Microsecond
+	}
+
+	if val, ok := settings["provisioned_iops"].(float64); ok && val > 0 {
+		config.BaselineIOPS = val
+	}
+	if val, ok := settings["provisioned_throughput_mbps"].(float64); ok && val > 0 {
+		config.BaselineMBps = val
+	}
+	if config.BaselineMBps == 0 {
+		// io1/io2: 256KB of throughput per provisioned IOPS, up to 4000MB/s
+		config.BaselineMBps = math.Min(config.BaselineIOPS*0.25, 4000)
+	}
+	if val, ok := settings["burst_iops"].(float64); ok && val >= 0 {
+		config.BurstIOPS = val
+	}
+	if val, ok := settings["burst_throughput_mbps"].(float64); ok && val >= 0 {
+		config.BurstMBps = val
+	}
+	if val, ok := settings["burst_credit_capacity"].(float64); ok && val >= 0 {
+		config.CreditCapacity = val
+	}
+	config.InitialCredits = config.CreditCapacity
+	if val, ok := settings["initial_burst_credits"].(float64); ok && val >= 0 {
+		config.InitialCredits = math.Min(val, config.CreditCapacity)
+	}
+	if val, ok := settings["io_unit_kb"].(float64); ok && val > 0 {
+		config.IOUnitBytes = int64(val * 1024)
+	}
+	if val, ok := settings["network_rtt_us"].(float64); ok && val >= 0 {
+		config.NetworkRoundTrip = time.Duration(val * float64(time.Microsecond))
+	}
+}
+
+// cloudVolumeEnabled reports whether the engine models a cloud volume
+func (storage *StorageEngine) cloudVolumeEnabled() bool {
+	return storage.CloudVolume.VolumeType != "" && storage.ComplexityInterface.ShouldEnableFeature("cloud_volume")
+}
+
+// resetCloudVolume fills the credit bucket to its initial balance
+func (storage *StorageEngine) resetCloudVolume() {
+	storage.CloudVolumeState = CloudVolumeState{
+		CreditBalance:    storage.CloudVolume.InitialCredits,
+		ExhaustedAtTick:  -1,
+		CurrentIOPSLimit: storage.CloudVolume.BaselineIOPS,
+		CurrentMBpsLimit: storage.CloudVolume.BaselineMBps,
+	}
+}
+
+// bursting reports whether the volume can run above its baseline
+func (storage *StorageEngine) bursting() bool {
+	return storage.CloudVolume.BurstIOPS > storage.CloudVolume.BaselineIOPS && storage.CloudVolumeState.CreditBalance >= 1
+}
+
+// applyCloudVolumeLimits admits an operation under the volume's IOPS and
+// throughput limits and returns how long it queues behind earlier I/Os
+func (storage *StorageEngine) applyCloudVolumeLimits(op *Operation, currentTick int64) time.Duration {
+	config, state := storage.CloudVolume, &storage.CloudVolumeState
+	now := storage.TicksToDuration(currentTick)
+
+	// The bucket refills at the baseline rate
+	if config.CreditCapacity > 0 && now > state.LastRefill {
+		state.CreditBalance = math.Min(state.CreditBalance+config.BaselineIOPS*(now-state.LastRefill).Seconds(), config.CreditCapacity)
+		if state.CreditBalance >= 1 {
+			state.CreditsExhausted = false
+		}
+	}
+	state.LastRefill = max(state.LastRefill, now)
+
+	units := max((op.DataSize+config.IOUnitBytes-1)/config.IOUnitBytes, 1)
+	state.CurrentIOPSLimit, state.CurrentMBpsLimit = config.BaselineIOPS, config.BaselineMBps
+	if storage.bursting() {
+		state.CurrentIOPSLimit, state.CurrentMBpsLimit = config.BurstIOPS, math.Max(config.BurstMBps, config.BaselineMBps)
+	}
+	if config.CreditCapacity > 0 {
+		state.CreditBalance = math.Max(state.CreditBalance-float64(units), 0)
+		if state.CreditBalance < 1 && !state.CreditsExhausted {
+			state.CreditsExhausted = true
+			if state.ExhaustedAtTick < 0 {
+				state.ExhaustedAtTick = currentTick
+			}
+		}
+	}
+
+	// Each limit schedules I/Os at its rate in arrival order, admitting those
+	// within the burst window of their slot at once
+	ioSlot := max(now, state.NextIOSlot)
+	state.NextIOSlot = ioSlot + time.Duration(float64(units)/state.CurrentIOPSLimit*float64(time.Second))
+	byteSlot := max(now, state.NextByteSlot)
+	state.NextByteSlot = byteSlot + time.Duration(float64(op.DataSize)/(state.CurrentMBpsLimit*1024*1024)*float64(time.Second))
+	state.IOUnitsAdmitted += units
+	state.BytesAdmitted += op.DataSize
+
+	wait := max(max(ioSlot, byteSlot)-now-cloudVolumeBurstWindow, 0)
+	if wait > 0 {
+		state.ThrottledIOs++
+		state.ThrottleTime += wait
+	}
+	return wait
+}
+
+// burstCreditFraction returns the share of the credit bucket left, 1 without one
+func (storage *StorageEngine) burstCreditFraction() float64 {
+	if storage.CloudVolume.CreditCapacity <= 0 {
+		return 1.0
+	}
+	return storage.CloudVolumeState.CreditBalance / storage.CloudVolume.CreditCapacity
+}
+
+// GetCloudVolumeState returns the volume's credits and rate limiting
+func (storage *StorageEngine) GetCloudVolumeState() CloudVolumeState {
+	return storage.CloudVolumeState
+}
@@ -15,6 +15,7 @@ type StorageProcessingOperation struct {
 	IOPSUsed       int              `json:"iops_used"`
 	AccessPattern  string           `json:"access_pattern"` // "sequential", "random", "mixed"
 	QueuePosition  int              `json:"queue_position"` // For NCQ/TCQ modeling
+	Error          error            `json:"-"`              // Set when the device refused the operation
 }
 
 // StorageProcessingHeap implements heap.Interface for storage operations
@@ -123,6 +124,28 @@ type StorageEngine struct {
 		SeekPenaltyFactor  float64 `json:"seek_penalty_factor"`
 		DefragBenefit      float64 `json:"defrag_benefit"`
 	} `json:"fragmentation_state"`
+
+	// Space used on the device and its wear against the rated endurance
+	Capacity StorageCapacityConfig `json:"capacity"`
+	Usage    StorageUsage          `json:"usage"`
+
+	// Head mechanics of a spinning disk and the scheduler ordering its queue
+	HDD         HDDConfig         `json:"hdd"`
+	IOScheduler IOSchedulerConfig `json:"io_scheduler"`
+	HDDState    HDDState          `json:"hdd_state"`
+
+	// OS page cache in the instance's free memory, when attached
+	PageCacheConfig PageCacheConfig `json:"page_cache_config"`
+	PageCache       *PageCache      `json:"page_cache"`
+
+	// Limits and burst credits of a network-attached cloud volume
+	CloudVolume      CloudVolumeConfig `json:"cloud_volume"`
+	CloudVolumeState CloudVolumeState  `json:"cloud_volume_state"`
+
+	// Devices the engine stripes, mirrors or replicates its data across, when an array
+	Array        StorageArrayConfig `json:"array"`
+	ArrayDevices []*StorageEngine   `json:"-"`
+	ArrayState   StorageArrayState  `json:"array_state"`
 }
 
 // NewStorageEngine creates a new Storage engine with profile-driven configuration (NO HARDCODED VALUES)
@@ -149,6 +172,8 @@ func NewStorageEngine(queueCapacity int) *StorageEngine {
 		BandwidthMBps:   0.0,
 		QueueDepth:      0,
 		BlockSizeBytes:  0,
+
+		PageCacheConfig: defaultPageCacheConfig(),
 	}
 	
 	// Initialize state structures with minimal defaults (profile will set proper values)
@@ -206,24 +231,51 @@ func NewStorageEngine(queueCapacity int) *StorageEngine {
 func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64) *OperationResult {
 	storage.CurrentTick = currentTick
 
-	// Calculate base storage access time from profile (IOPS and latency)
-	baseTime := storage.calculateBaseStorageAccessTime(op)
+	// Serve reads from and buffer writes in the OS page cache (if enabled); the
+	// device serves the rest after any write-back they wait for
+	var writebackTime time.Duration
+	if storage.pageCacheEnabled(op) {
+		var cached *OperationResult
+		if cached, writebackTime = storage.applyPageCache(op, currentTick); cached != nil {
+			return cached
+		}
+	}
+
+	// Serve the operation from the devices of the array (if an array), which
+	// take their parts of it in parallel
+	if storage.arrayEnabled() {
+		return storage.processArrayOperation(op, currentTick, writebackTime)
+	}
+
+	// Calculate base storage access time from profile (IOPS and latency), or from
+	// the seek and rotation to reach the operation on a spinning disk (if enabled)
+	mechanical, cloud := storage.hddMechanicsEnabled(), storage.cloudVolumeEnabled()
+	var baseTime time.Duration
+	if mechanical {
+		baseTime = storage.calculateMechanicalAccessTime(op)
+	} else {
+		baseTime = storage.calculateBaseStorageAccessTime(op)
+	}
 
-	// Apply access pattern optimization (if enabled)
+	// Apply access pattern optimization (if enabled); the head's travel already
+	// separates sequential from random access on a spinning disk
 	patternAdjustedTime := baseTime
-	if storage.ComplexityInterface.ShouldEnableFeature("sequential_optimization") {
+	if storage.ComplexityInterface.ShouldEnableFeature("sequential_optimization") && !mechanical {
 		patternAdjustedTime = storage.applyAccessPatternOptimization(baseTime, op)
 	}
 
-	// Apply queue depth management effects (if enabled)
+	// Apply queue depth management effects (if enabled); a spinning disk serves
+	// one request at a time, gaining from its queue only through the scheduler
 	queueAdjustedTime := patternAdjustedTime
-	if storage.ComplexityInterface.ShouldEnableFeature("queue_depth_management") {
+	if storage.ComplexityInterface.ShouldEnableFeature("queue_depth_management") && !mechanical {
 		queueAdjustedTime = storage.applyQueueDepthEffects(patternAdjustedTime, op)
 	}
 
-	// Apply controller cache effects (if enabled)
+	// Apply controller cache effects (if enabled); a spinning disk's read-ahead
+	// is the streaming of sequential reads from under the head, and a cloud
+	// volume's caching is part of its profile latency
 	cacheAdjustedTime := queueAdjustedTime
-	if storage.ComplexityInterface.ShouldEnableFeature("controller_cache") {
+	if storage.ComplexityInterface.ShouldEnableFeature("controller_cache") && !(mechanical && op.Type == OpStorageRead) && !cloud {
 		cacheAdjustedTime = storage.applyControllerCacheEffects(queueAdjustedTime, op)
 	}
 
@@ -239,11 +291,19 @@ func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64)
 		fragmentationAdjustedTime = storage.applyFragmentationEffects(filesystemAdjustedTime, op)
 	}
 
+	// Apply capacity and write amplification (if enabled): writes allocate space and wait
+	// for garbage collection copies, and fail with ENOSPC once the device is full
+	capacityAdjustedTime := fragmentationAdjustedTime
+	var capacityErr error
+	if storage.ComplexityInterface.ShouldEnableFeature("capacity_tracking") {
+		capacityAdjustedTime, capacityErr = storage.applyCapacityEffects(fragmentationAdjustedTime, op)
+	}
+
 	// Apply wear leveling overhead (if enabled - SSD specific)
-	wearAdjustedTime := fragmentationAdjustedTime
+	wearAdjustedTime := capacityAdjustedTime
 	if storage.ComplexityInterface.ShouldEnableFeature("basic_wear_leveling") ||
 	   storage.ComplexityInterface.ShouldEnableFeature("advanced_wear_leveling") {
-		wearAdjustedTime = storage.applyWearLevelingEffects(fragmentationAdjustedTime, op)
+		wearAdjustedTime = storage.applyWearLevelingEffects(capacityAdjustedTime, op)
 	}
 
 	// Apply power state effects (if enabled - HDD specific)
@@ -304,6 +364,22 @@ func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64)
 	utilization := storage.calculateCurrentUtilization()
 	finalTime := storage.ApplyCommonPerformanceFactors(eccAdjustedTime, utilization)
 
+	// Wait for the disk to finish the request it is serving (if a spinning disk),
+	// which includes any write-back queued ahead of it
+	if mechanical {
+		finalTime += storage.reserveDisk(currentTick, finalTime)
+	} else {
+		finalTime += writebackTime
+	}
+
+	// Cross the network to the volume, queueing behind I/Os its IOPS and
+	// throughput limits have not yet admitted (if a cloud volume)
+	var throttleTime time.Duration
+	if cloud {
+		throttleTime = storage.applyCloudVolumeLimits(op, currentTick)
+		finalTime += storage.CloudVolume.NetworkRoundTrip + throttleTime
+	}
+
 	// Update dynamic state tracking (if enabled)
 	if storage.ComplexityInterface.ShouldEnableFeature("dynamic_behavior") {
 		storage.updateStorageState(op, finalTime)
@@ -338,7 +414,13 @@ func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64)
 		powerPenalty = 2.0 // Significant penalty for full wake-up
 	}
 
-	totalPenaltyFactor := loadPenalty * queuePenalty * thermalPenalty * contentionPenalty * healthPenalty * powerPenalty
+	// Volume throttling impact: time queued behind the volume's limits
+	throttlePenalty := 1.0
+	if throttleTime > 0 {
+		throttlePenalty = float64(finalTime) / float64(finalTime-throttleTime)
+	}
+
+	totalPenaltyFactor := loadPenalty * queuePenalty * thermalPenalty * contentionPenalty * healthPenalty * powerPenalty * throttlePenalty
 
 	// Ensure total penalty factor is valid
 	if totalPenaltyFactor != totalPenaltyFactor || totalPenaltyFactor <= 0 { // Check for NaN or invalid values
@@ -368,7 +450,8 @@ func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64)
 		ProcessingTime: finalTime,
 		CompletedTick:  currentTick + ticksToComplete,
 		CompletedAt:    currentTick + ticksToComplete,
-		Success:        true,
+		Success:        capacityErr == nil,
+		Error:          capacityErr,
 		NextComponent:  op.NextComponent,
 		PenaltyInfo: &PenaltyInformation{
 			EngineType:           StorageEngineType,
@@ -389,6 +472,12 @@ func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64)
 				AccessPattern:     storage.determineAccessPattern(op),
 				ThermalThrottling: func() float64 { if storage.ThermalState.ThrottlingActive { return 1.0 } else { return 0.0 } }(),
 				PowerStateImpact:  powerPenalty,
+				CapacityUtilization: storage.capacityUtilization(),
+				WriteAmplification:  storage.Usage.WriteAmplification,
+				PageCacheHitRatio:   storage.GetPageCacheStats().HitRatio,
+				BurstCreditBalance:  storage.burstCreditFraction(),
+				CreditsExhausted:    storage.CloudVolumeState.CreditsExhausted,
+				VolumeThrottleMs:    float64(throttleTime) / float64(time.Millisecond),
 			},
 		},
 		Metrics: map[string]interface{}{
@@ -401,10 +490,50 @@ func (storage *StorageEngine) ProcessOperation(op *Operation, currentTick int64)
 			"power_state":           storage.PowerState.CurrentState,
 		},
 	}
+	if storage.ComplexityInterface.ShouldEnableFeature("capacity_tracking") && storage.Capacity.CapacityBytes > 0 {
+		result.Metrics["used_gb"] = float64(storage.Usage.UsedBytes) / float64(1<<30)
+		if op.Type == OpStorageWrite && capacityErr == nil {
+			result.Metrics["write_amplification"] = storage.Usage.WriteAmplification
+		}
+	}
+	if mechanical {
+		result.Metrics["seek_ms"] = float64(storage.HDDState.SeekTime) / float64(time.Millisecond)
+		result.Metrics["rotational_ms"] = float64(storage.HDDState.RotationalTime) / float64(time.Millisecond)
+		result.Metrics["head_lba"] = storage.HDDState.HeadLBA
+		result.Metrics["scheduler"] = storage.IOScheduler.Scheduler
+	}
+	if cloud {
+		result.Metrics["burst_credits"] = storage.CloudVolumeState.CreditBalance
+		result.Metrics["credits_exhausted"] = storage.CloudVolumeState.CreditsExhausted
+		result.Metrics["iops_limit"] = storage.CloudVolumeState.CurrentIOPSLimit
+		result.Metrics["volume_throttle_ms"] = float64(throttleTime) / float64(time.Millisecond)
+	}
+	if storage.pageCacheEnabled(op) {
+		result.Metrics["page_cache"] = "miss"
+		result.Metrics["page_cache_hit_ratio"] = storage.PageCache.Stats.HitRatio
+		result.Metrics["dirty_pages"] = storage.PageCache.Stats.DirtyPages
+	}
 
 	return result
 }
 
+// NextEventTick returns the earliest completion tick in the processing heap, or the
+// next tick if operations are waiting in the queue
+func (storage *StorageEngine) NextEventTick() (int64, bool) {
+	next, pending := storage.nextEventTick(0, false)
+	if storage.ActiveOperations != nil && storage.ActiveOperations.Len() > 0 {
+		next, pending = storage.nextEventTick((*storage.ActiveOperations)[0].CompletionTick, true)
+	}
+
+	// A device failing or a rebuild finishing changes how the array serves I/O
+	if storage.arrayEnabled() {
+		if arrayTick, ok := storage.nextArrayEventTick(); ok && (!pending || arrayTick < next) {
+			return arrayTick, true
+		}
+	}
+	return next, pending
+}
+
 // ProcessTick processes one simulation tick following CPU/Memory engine pattern
 func (storage *StorageEngine) ProcessTick(currentTick int64) []OperationResult {
 	storage.CurrentTick = currentTick
@@ -427,7 +556,8 @@ func (storage *StorageEngine) ProcessTick(currentTick int64) []OperationResult {
 				ProcessingTime: time.Duration(completedOp.CompletionTick-completedOp.StartTick) * storage.TickDuration,
 				CompletedTick:  completedOp.CompletionTick,
 				CompletedAt:    completedOp.CompletionTick,
-				Success:        true,
+				Success:        completedOp.Error == nil,
+				Error:          completedOp.Error,
 				NextComponent:  completedOp.Operation.Operation.NextComponent,
 				Metrics: map[string]interface{}{
 					"access_pattern":     completedOp.AccessPattern,
@@ -446,17 +576,31 @@ func (storage *StorageEngine) ProcessTick(currentTick int64) []OperationResult {
 		}
 	}
 
-	// Step 2: Start new operations from queue (max 3 per tick like CPU/Memory)
+	// Fail the array's devices scheduled to fail and advance its rebuild (if an array)
+	if storage.arrayEnabled() {
+		storage.advanceStorageArray(currentTick)
+	}
+
+	// Step 2: Start new operations from queue (max 3 per tick like CPU/Memory), in
+	// the order the I/O scheduler picks. A spinning disk takes a request whenever
+	// it finishes the last, so the scheduler chooses each from a full queue.
 	operationsStarted := 0
 	maxOperationsPerTick := 3 // Same limit as CPU/Memory engines
+	mechanical := storage.hddMechanicsEnabled()
+	if mechanical {
+		maxOperationsPerTick = max(storage.QueueDepth, 1)
+	}
 
 	for operationsStarted < maxOperationsPerTick && storage.GetQueueLength() > 0 {
 		// Check if we have available IOPS capacity
 		if storage.BusyIOPS >= storage.getMaxConcurrentIOPS() {
 			break // No IOPS capacity available
 		}
+		if mechanical && !storage.diskFreeBefore(currentTick+1) {
+			break // The disk is busy through this tick
+		}
 
-		queuedOp := storage.DequeueOperation()
+		queuedOp := storage.dequeueScheduledOperation()
 		if queuedOp == nil {
 			break
 		}
@@ -472,6 +616,7 @@ func (storage *StorageEngine) ProcessTick(currentTick int64) []OperationResult {
 			IOPSUsed:       storage.calculateIOPSUsage(queuedOp.Operation),
 			AccessPattern:  storage.determineAccessPattern(queuedOp.Operation),
 			QueuePosition:  storage.QueueState.ActiveCommands,
+			Error:          result.Error,
 		}
 
 		// Add to active operations heap
@@ -486,6 +631,11 @@ func (storage *StorageEngine) ProcessTick(currentTick int64) []OperationResult {
 	// Step 3: Update storage state
 	storage.updateStorageStatePerTick()
 
+	// Write back expired dirty pages (if a page cache is attached)
+	if storage.PageCache != nil && storage.ComplexityInterface.ShouldEnableFeature("page_cache") {
+		storage.flushExpiredPages(currentTick)
+	}
+
 	// Update health metrics
 	storage.UpdateHealth()
 
@@ -538,6 +688,14 @@ func (storage *StorageEngine) calculateBaseStorageAccessTime(op *Operation) time
 	return time.Duration(baseLatencyUs * float64(time.Microsecond))
 }
 
+// capacityUtilization returns the fraction of the device's space in use
+func (storage *StorageEngine) capacityUtilization() float64 {
+	if storage.Capacity.CapacityBytes <= 0 {
+		return 0.0
+	}
+	return float64(storage.Usage.UsedBytes) / float64(storage.Capacity.CapacityBytes)
+}
+
 // calculateCurrentUtilization calculates current storage utilization
 func (storage *StorageEngine) calculateCurrentUtilization() float64 {
 	maxIOPS := storage.getMaxConcurrentIOPS()
@@ -551,6 +709,13 @@ func (storage *StorageEngine) calculateCurrentUtilization() float64 {
 func (storage *StorageEngine) getMaxConcurrentIOPS() int {
 	// Use the lower of IOPS limits and queue depth
 	maxReadWrite := storage.IOPSRead + storage.IOPSWrite
+	if maxReadWrite <= 0 {
+		// Fallback when the profile sets no IOPS limits, so the queue still drains
+		if storage.QueueDepth > 0 {
+			return storage.QueueDepth
+		}
+		return 32
+	}
 	if storage.QueueDepth > 0 && storage.QueueDepth < maxReadWrite {
 		return storage.QueueDepth
 	}
@@ -568,6 +733,11 @@ func (storage *StorageEngine) calculateIOPSUsage(op *Operation) int {
 
 // determineAccessPattern determines the access pattern for an operation
 func (storage *StorageEngine) determineAccessPattern(op *Operation) string {
+	// An operation's access_pattern metadata overrides the estimate
+	if pattern, ok := op.Metadata["access_pattern"].(string); ok && (pattern == "sequential" || pattern == "random") {
+		return pattern
+	}
+
 	// Use deterministic pattern based on operation characteristics
 	hash := uint32(storage.CompletedOps + int64(op.DataSize))
 
@@ -971,6 +1141,9 @@ func (storage *StorageEngine) loadStorageSpecificProfile() error {
 		if blockSize, ok := storage.Profile.BaselinePerformance["block_size_bytes"]; ok {
 			storage.BlockSizeBytes = int(blockSize)
 		}
+		if overProvisioning, ok := storage.Profile.BaselinePerformance["over_provisioning_gb"]; ok {
+			storage.WearLevelingState.OverProvisioningGB = int(overProvisioning)
+		}
 	}
 
 	// Load technology specs
@@ -994,6 +1167,49 @@ func (storage *StorageEngine) loadStorageSpecificProfile() error {
 	// Reinitialize with profile data (like CPU engine)
 	storage.initializeFromProfile()
 
+	// Compose the devices of an array, which then addresses the space the
+	// layout leaves for data
+	if err := storage.loadStorageArray(); err != nil {
+		return err
+	}
+	if storage.Array.Devices > 0 {
+		storage.CapacityGB *= int64(storage.Array.dataDevices())
+	}
+
+	// Size the device, then fill it with any data the profile starts it with
+	storage.Capacity = storage.defaultStorageCapacityConfig()
+	if capacity, ok := storage.Profile.EngineSpecific["capacity"].(map[string]interface{}); ok {
+		storage.Capacity.loadProfile(capacity)
+	}
+	storage.Usage = StorageUsage{UsedBytes: storage.Capacity.InitialUsedBytes}
+
+	// Head mechanics and the I/O scheduler
+	storage.HDD = storage.defaultHDDConfig()
+	if mechanics, ok := storage.Profile.EngineSpecific["hdd_mechanics"].(map[string]interface{}); ok {
+		storage.HDD.loadProfile(mechanics)
+	}
+	storage.IOScheduler = defaultIOSchedulerConfig(storage.StorageType)
+	if scheduler, ok := storage.Profile.EngineSpecific["io_scheduler"].(map[string]interface{}); ok {
+		storage.IOScheduler.loadProfile(scheduler)
+	}
+	storage.HDDState = HDDState{}
+
+	// Page cache settings, for a cache attached now or later
+	storage.PageCacheConfig = defaultPageCacheConfig()
+	if pageCache, ok := storage.Profile.EngineSpecific["page_cache"].(map[string]interface{}); ok {
+		storage.PageCacheConfig.loadProfile(pageCache)
+	}
+	if storage.PageCache != nil {
+		storage.PageCache.Config = storage.PageCacheConfig
+	}
+
+	// Cloud volume limits, with a full credit bucket
+	storage.CloudVolume = CloudVolumeConfig{}
+	if volume, ok := storage.Profile.EngineSpecific["cloud_volume"].(map[string]interface{}); ok {
+		storage.CloudVolume.loadProfile(volume, storage.CapacityGB)
+	}
+	storage.resetCloudVolume()
+
 	return nil
 }
 
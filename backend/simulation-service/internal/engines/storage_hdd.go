@@ -0,0 +1,338 @@
+package engines
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Mechanics of a spinning disk. The head sits over one LBA at a time: reaching
+// another costs a seek that grows with the square root of the distance (the arm
+// accelerates, then coasts), then a wait of up to one rotation for the sector
+// to come round. A request starting where the previous one ended streams on
+// without either. The disk serves one request at a time, so the order in which
+// the I/O scheduler hands requests over decides how far the head travels:
+//   - noop passes them through in arrival order
+//   - deadline sweeps the head across the disk in LBA order (a one-way
+//     elevator), first serving any request that waited past its expiry, reads
+//     before writes
+//   - cfq and bfq give each process a turn of quantum requests, sweeping
+//     through that process's requests in LBA order
+//
+// An operation's lba metadata places it on the disk, in 512-byte sectors.
+// Without one, sequential operations follow on from the previous operation
+// and random operations land anywhere.
+
+// I/O schedulers
+const (
+	IOSchedulerNoop     = "noop"
+	IOSchedulerDeadline = "deadline"
+	IOSchedulerCFQ      = "cfq"
+	IOSchedulerBFQ      = "bfq"
+)
+
+const hddSectorBytes = 512
+
+// averageSeekDistanceFactor is E[sqrt(|U-V|)] for two uniform positions: the
+// share of the full-stroke seek an average random seek travels
+const averageSeekDistanceFactor = 8.0 / 15.0
+
+// HDDConfig is loaded from the profile's rpm and a profile's hdd_mechanics section
+type HDDConfig struct {
+	RPM              float64       `json:"rpm"`
+	TrackToTrackSeek time.Duration `json:"track_to_track_seek"`
+	AverageSeek      time.Duration `json:"average_seek"`
+	FullStrokeSeek   time.Duration `json:"full_stroke_seek"` // Derived from the average seek when not set
+	CommandOverhead  time.Duration `json:"command_overhead"`
+}
+
+// IOSchedulerConfig is loaded from a profile's io_scheduler section
+type IOSchedulerConfig struct {
+	Scheduler   string        `json:"scheduler"`
+	ReadExpire  time.Duration `json:"read_expire"`  // deadline: reads waiting this long are served first
+	WriteExpire time.Duration `json:"write_expire"` // deadline: writes waiting this long are served next
+	Quantum     int           `json:"quantum"`      // cfq/bfq: requests per process turn
+}
+
+// HDDState tracks the head and the requests the disk has served
+type HDDState struct {
+	HeadLBA           int64         `json:"head_lba"`
+	NextSequentialLBA int64         `json:"next_sequential_lba"`
+	BusyUntil         time.Duration `json:"busy_until"` // Simulated time the disk finishes its current request
+	Seeks             int64         `json:"seeks"`
+	SeekTime          time.Duration `json:"seek_time"`
+	RotationalTime    time.Duration `json:"rotational_time"`
+	CurrentProcess    string        `json:"current_process"` // cfq/bfq: process whose turn it is
+	ProcessServed     int           `json:"process_served"`
+}
+
+// defaultHDDConfig returns a desktop drive's seeks at the profile's technology_specs
+// rpm, 7200 when not given
+func (storage *StorageEngine) defaultHDDConfig() HDDConfig {
+	config := HDDConfig{
+		RPM:              7200,
+		TrackToTrackSeek: time.Millisecond,
+		AverageSeek:      8500 * time.Microsecond,
+		CommandOverhead:  100 * time.Microsecond,
+	}
+	if storage.Profile != nil {
+		switch rpm := storage.Profile.TechnologySpecs["rpm"].(type) {
+		case float64:
+			config.RPM = rpm
+		case int:
+			config.RPM = float64(rpm)
+		}
+	}
+	return config
+}
+
+// defaultIOSchedulerConfig returns Linux's defaults: deadline for spinning
+// disks and noop for flash, with deadline's 500ms read and 5s write expiry
+func defaultIOSchedulerConfig(storageType string) IOSchedulerConfig {
+	config := IOSchedulerConfig{
+		Scheduler:   IOSchedulerNoop,
+		ReadExpire:  500 * time.Millisecond,
+		WriteExpire: 5 * time.Second,
+		Quantum:     8,
+	}
+	if storageType == "HDD" {
+		config.Scheduler = IOSchedulerDeadline
+	}
+	return config
+}
+
+// loadProfile overrides mechanics present in a profile's hdd_mechanics section
+func (config *HDDConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["rpm"].(float64); ok && val > 0 {
+		config.RPM = val
+	}
+	if val, ok := settings["track_to_track_seek_ms"].(float64); ok && val >= 0 {
+		config.TrackToTrackSeek = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["average_seek_ms"].(float64); ok && val > 0 {
+		config.AverageSeek = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["full_stroke_seek_ms"].(float64); ok && val > 0 {
+		config.FullStrokeSeek = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["command_overhead_ms"].(float64); ok && val >= 0 {
+		config.CommandOverhead = time.Duration(val * float64(time.Millisecond))
+	}
+}
+
+// loadProfile overrides settings present in a profile's io_scheduler section
+func (config *IOSchedulerConfig) loadProfile(settings map[string]interface{}) {
+	if val, ok := settings["scheduler"].(string); ok {
+		switch val {
+		case IOSchedulerNoop, IOSchedulerDeadline, IOSchedulerCFQ, IOSchedulerBFQ:
+			config.Scheduler = val
+		}
+	}
+	if val, ok := settings["read_expire_ms"].(float64); ok && val > 0 {
+		config.ReadExpire = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["write_expire_ms"].(float64); ok && val > 0 {
+		config.WriteExpire = time.Duration(val * float64(time.Millisecond))
+	}
+	if val, ok := settings["quantum"].(float64); ok && val >= 1 {
+		config.Quantum = int(val)
+	}
+}
+
+// SetIOScheduler selects the scheduler that orders the storage queue
+func (storage *StorageEngine) SetIOScheduler(scheduler string) {
+	storage.IOScheduler.loadProfile(map[string]interface{}{"scheduler": scheduler})
+}
+
+// hddMechanicsEnabled reports whether operations pay seeks and rotation
+func (storage *StorageEngine) hddMechanicsEnabled() bool {
+	return storage.StorageType == "HDD" && storage.HDD.RPM > 0 &&
+		storage.ComplexityInterface.ShouldEnableFeature("hdd_mechanics")
+}
+
+// totalSectors returns the number of LBAs on the disk
+func (storage *StorageEngine) totalSectors() int64 {
+	return max(storage.CapacityGB*(1<<30)/hddSectorBytes, 1)
+}
+
+// operationLBA returns where an operation starts on the disk, placing it on
+// first use when its metadata does not say
+func (storage *StorageEngine) operationLBA(op *Operation) int64 {
+	switch lba := op.Metadata["lba"].(type) {
+	case int64:
+		return lba
+	case int:
+		return int64(lba)
+	case float64:
+		return int64(lba)
+	}
+
+	lba := storage.HDDState.NextSequentialLBA
+	if storage.determineAccessPattern(op) == "random" {
+		lba = int64(storage.randomFloat64() * float64(storage.totalSectors()))
+	}
+	storage.HDDState.NextSequentialLBA = lba + operationSectors(op)
+
+	if op.Metadata == nil {
+		op.Metadata = make(map[string]interface{})
+	}
+	op.Metadata["lba"] = lba
+	return lba
+}
+
+// operationSectors returns the number of sectors an operation transfers
+func operationSectors(op *Operation) int64 {
+	return max((op.DataSize+hddSectorBytes-1)/hddSectorBytes, 1)
+}
+
+// seekTime returns the time to move the head a distance in sectors
+func (storage *StorageEngine) seekTime(distance int64) time.Duration {
+	if distance == 0 {
+		return 0
+	}
+	config := storage.HDD
+	fullStroke := config.FullStrokeSeek
+	if fullStroke == 0 {
+		fullStroke = config.TrackToTrackSeek + time.Duration(float64(config.AverageSeek-config.TrackToTrackSeek)/averageSeekDistanceFactor)
+	}
+	fraction := math.Min(float64(distance)/float64(storage.totalSectors()), 1.0)
+	return config.TrackToTrackSeek + time.Duration(float64(fullStroke-config.TrackToTrackSeek)*math.Sqrt(fraction))
+}
+
+// calculateMechanicalAccessTime moves the head to an operation and returns the
+// time to seek, wait for the sector and transfer the data
+func (storage *StorageEngine) calculateMechanicalAccessTime(op *Operation) time.Duration {
+	lba := storage.operationLBA(op)
+	distance := lba - storage.HDDState.HeadLBA
+	if distance < 0 {
+		distance = -distance
+	}
+
+	var seek, rotation time.Duration
+	if distance > 0 {
+		seek = storage.seekTime(distance)
+		rotationPeriod := time.Duration(float64(time.Minute) / storage.HDD.RPM)
+		rotation = time.Duration(storage.randomFloat64() * float64(rotationPeriod))
+		storage.HDDState.Seeks++
+		storage.HDDState.SeekTime += seek
+		storage.HDDState.RotationalTime += rotation
+	}
+	storage.HDDState.HeadLBA = lba + operationSectors(op)
+
+	var transfer time.Duration
+	if storage.BandwidthMBps > 0 {
+		transfer = time.Duration(float64(op.DataSize) / (storage.BandwidthMBps * 1024 * 1024) * float64(time.Second))
+	}
+	return storage.HDD.CommandOverhead + seek + rotation + transfer
+}
+
+// reserveDisk queues a request of the given service time behind the one the disk
+// is serving and returns how long it waits
+func (storage *StorageEngine) reserveDisk(currentTick int64, service time.Duration) time.Duration {
+	now := storage.TicksToDuration(currentTick)
+	start := max(now, storage.HDDState.BusyUntil)
+	storage.HDDState.BusyUntil = start + service
+	return start - now
+}
+
+// diskFreeBefore reports whether the disk finishes its current request before a tick
+func (storage *StorageEngine) diskFreeBefore(tick int64) bool {
+	return storage.HDDState.BusyUntil < storage.TicksToDuration(tick)
+}
+
+// dequeueScheduledOperation removes the next operation the I/O scheduler dispatches
+func (storage *StorageEngine) dequeueScheduledOperation() *QueuedOperation {
+	if storage.IOScheduler.Scheduler == IOSchedulerNoop || storage.IOScheduler.Scheduler == "" {
+		return storage.DequeueOperation()
+	}
+
+	queue := storage.PeekQueue()
+	if len(queue) == 0 {
+		return nil
+	}
+
+	var next *QueuedOperation
+	switch storage.IOScheduler.Scheduler {
+	case IOSchedulerDeadline:
+		next = storage.selectDeadline(queue)
+	case IOSchedulerCFQ, IOSchedulerBFQ:
+		next = storage.selectFairQueue(queue)
+	}
+	if next == nil || !storage.DequeueQueuedOperation(next) {
+		return storage.DequeueOperation()
+	}
+	return next
+}
+
+// selectDeadline serves the oldest expired read, then the oldest expired write,
+// then the next request along the elevator's sweep
+func (storage *StorageEngine) selectDeadline(queue []*QueuedOperation) *QueuedOperation {
+	for _, opType := range []string{OpStorageRead, OpStorageWrite} {
+		expire := storage.IOScheduler.ReadExpire
+		if opType == OpStorageWrite {
+			expire = storage.IOScheduler.WriteExpire
+		}
+		for _, queuedOp := range queue {
+			if queuedOp.Operation.Type == opType && storage.TicksToDuration(storage.CurrentTick-queuedOp.QueuedAt) >= expire {
+				return queuedOp
+			}
+		}
+	}
+	return storage.selectElevator(queue)
+}
+
+// selectElevator returns the request nearest ahead of the head, wrapping round to
+// the lowest LBA once the sweep passes the last request
+func (storage *StorageEngine) selectElevator(queue []*QueuedOperation) *QueuedOperation {
+	var ahead, lowest *QueuedOperation
+	var aheadLBA, lowestLBA int64
+	for _, queuedOp := range queue {
+		lba := storage.operationLBA(queuedOp.Operation)
+		if lba >= storage.HDDState.HeadLBA && (ahead == nil || lba < aheadLBA) {
+			ahead, aheadLBA = queuedOp, lba
+		}
+		if lowest == nil || lba < lowestLBA {
+			lowest, lowestLBA = queuedOp, lba
+		}
+	}
+	if ahead != nil {
+		return ahead
+	}
+	return lowest
+}
+
+// selectFairQueue gives each process, named by the operation's process metadata,
+// a turn of quantum requests in elevator order
+func (storage *StorageEngine) selectFairQueue(queue []*QueuedOperation) *QueuedOperation {
+	byProcess := make(map[string][]*QueuedOperation)
+	for _, queuedOp := range queue {
+		process, _ := queuedOp.Operation.Metadata["process"].(string)
+		byProcess[process] = append(byProcess[process], queuedOp)
+	}
+
+	state := &storage.HDDState
+	if len(byProcess[state.CurrentProcess]) == 0 || state.ProcessServed >= storage.IOScheduler.Quantum {
+		// Next process round the ring after the current one
+		processes := make([]string, 0, len(byProcess))
+		for process := range byProcess {
+			processes = append(processes, process)
+		}
+		sort.Strings(processes)
+		next := processes[0]
+		for _, process := range processes {
+			if process > state.CurrentProcess {
+				next = process
+				break
+			}
+		}
+		state.CurrentProcess, state.ProcessServed = next, 0
+	}
+
+	state.ProcessServed++
+	return storage.selectElevator(byProcess[state.CurrentProcess])
+}
+
+// GetHDDState returns the head position and the seeks the disk has made
+func (storage *StorageEngine) GetHDDState() HDDState {
+	return storage.HDDState
+}
@@ -15,6 +15,11 @@ type StorageFeatures struct {
 	EnableSequentialOptimization bool `json:"enable_sequential_optimization"` // Sequential vs random I/O patterns
 	EnableQueueDepthManagement bool `json:"enable_queue_depth_management"` // NCQ/TCQ modeling
 	EnableBasicWearLeveling   bool `json:"enable_basic_wear_leveling"`   // SSD wear leveling basics
+	EnableCapacityTracking    bool `json:"enable_capacity_tracking"`     // Space used, ENOSPC, write amplification and endurance
+	EnableHDDMechanics        bool `json:"enable_hdd_mechanics"`         // Head seeks, rotational latency and I/O scheduling
+	EnablePageCache           bool `json:"enable_page_cache"`            // OS page cache with dirty-page write-back
+	EnableCloudVolume         bool `json:"enable_cloud_volume"`          // Cloud volume limits, burst credits and network latency
+	EnableStorageArray        bool `json:"enable_storage_array"`         // RAID and replicated arrays with failures and rebuilds
 	
 	// Advanced storage modeling (Advanced+)
 	EnableFileSystemOverhead  bool `json:"enable_filesystem_overhead"`   // Metadata operation costs
@@ -94,6 +99,11 @@ func (si *StorageInterface) configureMinimalFeatures() {
 		EnableSequentialOptimization: true,  // Pattern optimization is essential for real-world accuracy
 		EnableQueueDepthManagement: true,  // Queue management is critical for modern storage
 		EnableBasicWearLeveling:   true,  // Wear leveling affects real-world performance
+		EnableCapacityTracking:    true,
+		EnableHDDMechanics:        true,
+		EnablePageCache:           true,
+		EnableCloudVolume:         true,
+		EnableStorageArray:        true,
 		
 		// Skip all advanced features
 		EnableFileSystemOverhead:  false,
@@ -128,6 +138,11 @@ func (si *StorageInterface) configureBasicFeatures() {
 		EnableSequentialOptimization: true,  // Sequential vs random patterns
 		EnableQueueDepthManagement: true,  // Queue management is essential
 		EnableBasicWearLeveling:   true,  // SSD wear leveling
+		EnableCapacityTracking:    true,
+		EnableHDDMechanics:        true,
+		EnablePageCache:           true,
+		EnableCloudVolume:         true,
+		EnableStorageArray:        true,
 
 		// Important real-world features
 		EnableFileSystemOverhead:  true,  // Filesystem metadata costs
@@ -162,6 +177,11 @@ func (si *StorageInterface) configureAdvancedFeatures() {
 		EnableSequentialOptimization: true,
 		EnableQueueDepthManagement: true,
 		EnableBasicWearLeveling:   true,
+		EnableCapacityTracking:    true,
+		EnableHDDMechanics:        true,
+		EnablePageCache:           true,
+		EnableCloudVolume:         true,
+		EnableStorageArray:        true,
 
 		// Enhanced real-world features
 		EnableFileSystemOverhead:  true,
@@ -196,6 +216,11 @@ func (si *StorageInterface) configureMaximumFeatures() {
 		EnableSequentialOptimization: true,
 		EnableQueueDepthManagement: true,
 		EnableBasicWearLeveling:   true,
+		EnableCapacityTracking:    true,
+		EnableHDDMechanics:        true,
+		EnablePageCache:           true,
+		EnableCloudVolume:         true,
+		EnableStorageArray:        true,
 		EnableFileSystemOverhead:  true,
 		EnableFragmentationEffects: true,
 		EnableControllerCache:     true,
@@ -229,6 +254,16 @@ func (si *StorageInterface) ShouldEnableFeature(featureName string) bool {
 		return si.Features.EnableQueueDepthManagement
 	case "basic_wear_leveling":
 		return si.Features.EnableBasicWearLeveling
+	case "capacity_tracking":
+		return si.Features.EnableCapacityTracking
+	case "hdd_mechanics":
+		return si.Features.EnableHDDMechanics
+	case "page_cache":
+		return si.Features.EnablePageCache
+	case "cloud_volume":
+		return si.Features.EnableCloudVolume
+	case "storage_array":
+		return si.Features.EnableStorageArray
 	case "filesystem_overhead":
 		return si.Features.EnableFileSystemOverhead
 	case "fragmentation_effects":
@@ -300,6 +335,21 @@ func (si *StorageInterface) GetEnabledFeatures() []string {
 	if si.Features.EnableBasicWearLeveling {
 		features = append(features, "Basic Wear Leveling")
 	}
+	if si.Features.EnableCapacityTracking {
+		features = append(features, "Capacity Tracking")
+	}
+	if si.Features.EnableHDDMechanics {
+		features = append(features, "HDD Mechanics")
+	}
+	if si.Features.EnablePageCache {
+		features = append(features, "Page Cache")
+	}
+	if si.Features.EnableCloudVolume {
+		features = append(features, "Cloud Volume")
+	}
+	if si.Features.EnableStorageArray {
+		features = append(features, "Storage Array")
+	}
 	if si.Features.EnableFileSystemOverhead {
 		features = append(features, "Filesystem Overhead")
 	}
@@ -12,6 +12,9 @@ const (
 	MemoryEngineType
 	StorageEngineType
 	NetworkEngineType
+	CoordinationEngineType
+	NetworkInEngineType
+	NetworkOutEngineType
 )
 
 func (et EngineType) String() string {
@@ -24,6 +27,12 @@ func (et EngineType) String() string {
 		return "Storage"
 	case NetworkEngineType:
 		return "Network"
+	case CoordinationEngineType:
+		return "Coordination"
+	case NetworkInEngineType:
+		return "NetworkIn"
+	case NetworkOutEngineType:
+		return "NetworkOut"
 	default:
 		return "Unknown"
 	}
@@ -59,6 +68,13 @@ type BaseEngine interface {
 	SetComplexityLevel(level int) error  // 0=Minimal, 1=Basic, 2=Advanced, 3=Maximum
 	GetComplexityLevel() int             // Returns current complexity level
 
+	// Next-event scheduling: earliest tick with work to do, false when idle
+	NextEventTick() (int64, bool)
+
+	// Deterministic randomness (0 = unseeded)
+	SetSeed(seed int64)
+	GetSeed() int64
+
 	// Dynamic behavior methods
 	GetDynamicState() *DynamicState
 	UpdateDynamicBehavior()
@@ -92,6 +108,7 @@ type OperationResult struct {
 	CompletedAt    int64                  `json:"completed_at"`    // Tick when completed
 	Success        bool                   `json:"success"`
 	ErrorMessage   string                 `json:"error_message"`
+	Error          error                  `json:"-"`               // Typed cause when Success is false
 	NextComponent  string                 `json:"next_component"`  // Where to route next
 
 	// Performance penalty information for routing decisions
@@ -99,6 +116,9 @@ type OperationResult struct {
 
 	// Detailed metrics (legacy field, use PenaltyInfo for routing decisions)
 	Metrics        map[string]interface{} `json:"metrics"`
+
+	// Work this operation hands to other engines, e.g. TLS handshake crypto for the CPU
+	FollowUpOperations []*Operation       `json:"follow_up_operations,omitempty"`
 }
 
 // PenaltyInformation contains structured penalty data for performance-aware routing
@@ -123,6 +143,7 @@ type PenaltyInformation struct {
 	MemoryPenalties    *MemoryPenaltyDetails    `json:"memory_penalties,omitempty"`
 	StoragePenalties   *StoragePenaltyDetails   `json:"storage_penalties,omitempty"`
 	NetworkPenalties   *NetworkPenaltyDetails   `json:"network_penalties,omitempty"`
+	CoordinationPenalties *CoordinationPenaltyDetails `json:"coordination_penalties,omitempty"`
 
 	// Overall performance assessment
 	TotalPenaltyFactor float64 `json:"total_penalty_factor"` // Combined penalty multiplier
@@ -137,6 +158,8 @@ type CPUPenaltyDetails struct {
 	ThermalThrottling  float64 `json:"thermal_throttling"`   // Thermal throttling factor
 	CoreUtilization    float64 `json:"core_utilization"`     // CPU core usage
 	MemoryContention   float64 `json:"memory_contention"`    // Memory bandwidth contention
+	RunQueueLength     float64 `json:"run_queue_length"`     // Runnable threads sharing the operation's busiest core
+	QuotaThrottledMs   float64 `json:"quota_throttled_ms"`   // Held by the cgroup CPU quota
 }
 
 // MemoryPenaltyDetails contains memory-specific penalty information
@@ -155,6 +178,14 @@ type StoragePenaltyDetails struct {
 	AccessPattern      string  `json:"access_pattern"`       // "sequential", "random"
 	ThermalThrottling  float64 `json:"thermal_throttling"`   // Storage thermal throttling
 	PowerStateImpact   float64 `json:"power_state_impact"`   // Power management impact
+	CapacityUtilization float64 `json:"capacity_utilization"` // Fraction of the device's space in use
+	WriteAmplification  float64 `json:"write_amplification"`  // NAND bytes per host byte of the last write
+	PageCacheHitRatio   float64 `json:"page_cache_hit_ratio"` // Reads served from the OS page cache
+	BurstCreditBalance  float64 `json:"burst_credit_balance"` // Share of a cloud volume's credit bucket left
+	CreditsExhausted    bool    `json:"credits_exhausted"`    // Cloud volume held to its baseline
+	VolumeThrottleMs    float64 `json:"volume_throttle_ms"`   // Queued behind the cloud volume's limits
+	ArrayDegraded       bool    `json:"array_degraded"`       // A device of the storage array has failed
+	RebuildProgress     float64 `json:"rebuild_progress"`     // Share of the replacement device rebuilt
 }
 
 // NetworkPenaltyDetails contains network-specific penalty information
@@ -164,6 +195,16 @@ type NetworkPenaltyDetails struct {
 	PacketLossRate       float64 `json:"packet_loss_rate"`      // Packet loss percentage
 	LatencyPenalty       float64 `json:"latency_penalty"`       // Geographic/routing latency
 	ProtocolEfficiency   float64 `json:"protocol_efficiency"`   // Protocol overhead impact
+	Direction            string  `json:"direction"`             // Link side the operation used: ingress or egress
+}
+
+// CoordinationPenaltyDetails contains coordination-specific penalty information
+type CoordinationPenaltyDetails struct {
+	LockWaitMs       float64 `json:"lock_wait_ms"`       // Time spent behind the lock holder
+	ContentionRatio  float64 `json:"contention_ratio"`   // Fraction of lock acquisitions that waited
+	QuorumRoundTrips int     `json:"quorum_round_trips"` // Round trips to reach a quorum
+	QuorumLatencyMs  float64 `json:"quorum_latency_ms"`  // Time for a majority to acknowledge
+	ElectionDelayMs  float64 `json:"election_delay_ms"`  // Time spent waiting for a leader
 }
 
 // QueuedOperation represents an operation in the queue
@@ -274,6 +315,17 @@ const (
 	OpNetworkSend   = "network_send"
 	OpNetworkRecv   = "network_recv"
 	OpNetworkConn   = "network_connect"
+	OpNetworkResponse = "network_response"
+
+	// Coordination Operations
+	OpCoordinationLockAcquire    = "coordination_lock_acquire"
+	OpCoordinationLockRelease    = "coordination_lock_release"
+	OpCoordinationConsensus      = "coordination_consensus"
+	OpCoordinationRead           = "coordination_read"
+	OpCoordinationLeaderElection = "coordination_leader_election"
+	OpCoordination2PCPrepare     = "coordination_2pc_prepare"
+	OpCoordination2PCCommit      = "coordination_2pc_commit"
+	OpCoordination2PCAbort       = "coordination_2pc_abort"
 )
 
 // Constants for complexity levels
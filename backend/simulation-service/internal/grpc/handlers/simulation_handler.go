@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	simulationpb "github.com/systemsim/simulation-service/api/proto"
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/simulation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default and minimum interval between StreamMetrics snapshots
+const (
+	defaultStreamInterval = time.Second
+	minStreamInterval     = 100 * time.Millisecond
+)
+
+// SimulationGRPCHandler implements the gRPC SimulationService interface
+type SimulationGRPCHandler struct {
+	simulationpb.UnimplementedSimulationServiceServer
+	simManager *simulation.Manager
+
+	// Closed on shutdown to end open metric streams
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewSimulationGRPCHandler creates a new gRPC simulation handler
+func NewSimulationGRPCHandler(simManager *simulation.Manager) *SimulationGRPCHandler {
+	return &SimulationGRPCHandler{
+		simManager: simManager,
+		done:       make(chan struct{}),
+	}
+}
+
+// Shutdown ends every open StreamMetrics call so the server can stop gracefully
+func (h *SimulationGRPCHandler) Shutdown() {
+	h.doneOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+// CreateSimulation creates a new simulation
+func (h *SimulationGRPCHandler) CreateSimulation(ctx context.Context, req *simulationpb.CreateSimulationRequest) (*simulationpb.CreateSimulationResponse, error) {
+	log.Printf("gRPC CreateSimulation called by user %s (request_id: %s)", req.UserId, req.RequestId)
+
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	specs := make([]simulation.ComponentSpec, 0, len(req.Components))
+	for _, c := range req.Components {
+		specs = append(specs, simulation.ComponentSpec{
+			ID:   c.Id,
+			Type: components.ComponentType(c.Type),
+		})
+	}
+
+	sim, err := h.simManager.CreateSimulation(&simulation.CreateSimulationRequest{
+		Name:          req.Name,
+		Description:   req.Description,
+		Components:    specs,
+		ScalingFactor: req.ScalingFactor,
+		MaxRuntime:    time.Duration(req.MaxRuntimeMs) * time.Millisecond,
+		LearningMode:  req.LearningMode,
+		Seed:          req.Seed,
+		OwnerID:       req.UserId,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create simulation: %v", err)
+	}
+
+	return &simulationpb.CreateSimulationResponse{
+		Simulation: toProtoSimulation(sim),
+	}, nil
+}
+
+// ListSimulations lists the requesting user's simulations
+func (h *SimulationGRPCHandler) ListSimulations(ctx context.Context, req *simulationpb.ListSimulationsRequest) (*simulationpb.ListSimulationsResponse, error) {
+	sims := h.simManager.ListSimulationsByOwner(req.UserId)
+
+	resp := &simulationpb.ListSimulationsResponse{
+		Simulations: make([]*simulationpb.Simulation, 0, len(sims)),
+		Total:       int32(len(sims)),
+	}
+	for _, sim := range sims {
+		resp.Simulations = append(resp.Simulations, toProtoSimulation(sim))
+	}
+
+	return resp, nil
+}
+
+// StartSimulation starts a simulation
+func (h *SimulationGRPCHandler) StartSimulation(ctx context.Context, req *simulationpb.StartSimulationRequest) (*simulationpb.StartSimulationResponse, error) {
+	log.Printf("gRPC StartSimulation called for %s by user %s (request_id: %s)", req.SimulationId, req.UserId, req.RequestId)
+
+	id, err := h.ownedSimulationID(req.SimulationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.simManager.StartSimulation(id); err != nil {
+		if errors.Is(err, simulation.ErrSimulationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return &simulationpb.StartSimulationResponse{
+			Success:      false,
+			Status:       h.currentStatus(id),
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &simulationpb.StartSimulationResponse{
+		Success: true,
+		Status:  h.currentStatus(id),
+	}, nil
+}
+
+// StopSimulation stops a simulation
+func (h *SimulationGRPCHandler) StopSimulation(ctx context.Context, req *simulationpb.StopSimulationRequest) (*simulationpb.StopSimulationResponse, error) {
+	log.Printf("gRPC StopSimulation called for %s by user %s (request_id: %s)", req.SimulationId, req.UserId, req.RequestId)
+
+	id, err := h.ownedSimulationID(req.SimulationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.simManager.StopSimulation(id); err != nil {
+		if errors.Is(err, simulation.ErrSimulationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return &simulationpb.StopSimulationResponse{
+			Success:      false,
+			Status:       h.currentStatus(id),
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &simulationpb.StopSimulationResponse{
+		Success: true,
+		Status:  h.currentStatus(id),
+	}, nil
+}
+
+// GetStatus returns the live status of a simulation
+func (h *SimulationGRPCHandler) GetStatus(ctx context.Context, req *simulationpb.GetStatusRequest) (*simulationpb.GetStatusResponse, error) {
+	id, err := h.ownedSimulationID(req.SimulationId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	simStatus, err := h.simManager.GetSimulationStatus(id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &simulationpb.GetStatusResponse{
+		SimulationId:      simStatus.ID.String(),
+		Status:            string(simStatus.Status),
+		CurrentTick:       simStatus.CurrentTick,
+		SimulationTimeMs:  durationToMs(simStatus.SimulationTime),
+		RealTimeElapsedMs: durationToMs(simStatus.RealTimeElapsed),
+		ComponentCount:    int32(simStatus.ComponentCount),
+		HealthyComponents: int32(simStatus.HealthyComponents),
+		ControllerStatus:  string(simStatus.ControllerStatus),
+	}, nil
+}
+
+// GetResults returns the simulation record together with its aggregated metrics
+func (h *SimulationGRPCHandler) GetResults(ctx context.Context, req *simulationpb.GetResultsRequest) (*simulationpb.GetResultsResponse, error) {
+	id, err := parseSimulationID(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+
+	sim, err := h.simManager.GetSimulationForOwner(id, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	metrics, err := h.simManager.GetSimulationMetrics(id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &simulationpb.GetResultsResponse{
+		Simulation: toProtoSimulation(sim),
+		Metrics:    toProtoMetrics(metrics),
+	}, nil
+}
+
+// StreamMetrics sends a metrics snapshot every interval until the client cancels,
+// the simulation is deleted or the server shuts down
+func (h *SimulationGRPCHandler) StreamMetrics(req *simulationpb.StreamMetricsRequest, stream simulationpb.SimulationService_StreamMetricsServer) error {
+	id, err := h.ownedSimulationID(req.SimulationId, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	interval := time.Duration(req.IntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultStreamInterval
+	} else if interval < minStreamInterval {
+		interval = minStreamInterval
+	}
+
+	log.Printf("gRPC StreamMetrics started for %s every %v (request_id: %s)", id, interval, req.RequestId)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		metrics, err := h.simManager.GetSimulationMetrics(id)
+		if err != nil {
+			return status.Error(codes.NotFound, err.Error())
+		}
+
+		if err := stream.Send(toProtoMetrics(metrics)); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			log.Printf("gRPC StreamMetrics closed for %s", id)
+			return nil
+		case <-h.done:
+			log.Printf("gRPC StreamMetrics ended for %s: server shutting down", id)
+			return status.Error(codes.Unavailable, "simulation service is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+// currentStatus returns the simulation status string, or empty if unknown
+func (h *SimulationGRPCHandler) currentStatus(id uuid.UUID) string {
+	sim, err := h.simManager.GetSimulation(id)
+	if err != nil {
+		return ""
+	}
+	return string(sim.Status)
+}
+
+// ownedSimulationID parses a request's simulation ID and checks the requesting
+// user owns it; other users' simulations are reported as not found
+func (h *SimulationGRPCHandler) ownedSimulationID(raw, userID string) (uuid.UUID, error) {
+	id, err := parseSimulationID(raw)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err := h.simManager.GetSimulationForOwner(id, userID); err != nil {
+		return uuid.Nil, status.Error(codes.NotFound, err.Error())
+	}
+	return id, nil
+}
+
+// parseSimulationID converts a request ID into a UUID
+func parseSimulationID(raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "simulation_id is required")
+	}
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid simulation_id: %v", err)
+	}
+
+	return id, nil
+}
+
+// toProtoSimulation converts a simulation record into its protobuf form
+func toProtoSimulation(sim *simulation.Simulation) *simulationpb.Simulation {
+	pb := &simulationpb.Simulation{
+		Id:            sim.ID.String(),
+		Name:          sim.Name,
+		Description:   sim.Description,
+		Status:        string(sim.Status),
+		Components:    make([]*simulationpb.ComponentSpec, 0, len(sim.Components)),
+		ScalingFactor: sim.Settings.ScalingFactor,
+		MaxRuntimeMs:  sim.Settings.MaxRuntime.Milliseconds(),
+		LearningMode:  sim.Settings.LearningMode,
+		CreatedAt:     sim.CreatedAt.Unix(),
+		UpdatedAt:     sim.UpdatedAt.Unix(),
+		LastError:     sim.LastError,
+		Seed:          sim.Settings.Seed,
+	}
+
+	for _, c := range sim.Components {
+		pb.Components = append(pb.Components, &simulationpb.ComponentSpec{
+			Id:   c.ID,
+			Type: string(c.Type),
+		})
+	}
+
+	if sim.StartedAt != nil {
+		pb.StartedAt = sim.StartedAt.Unix()
+	}
+	if sim.StoppedAt != nil {
+		pb.StoppedAt = sim.StoppedAt.Unix()
+	}
+
+	return pb
+}
+
+// toProtoMetrics converts aggregated simulation metrics into their protobuf form
+func toProtoMetrics(metrics *simulation.MetricsResponse) *simulationpb.SimulationMetrics {
+	pb := &simulationpb.SimulationMetrics{
+		SimulationId:        metrics.ID.String(),
+		CurrentTick:         metrics.CurrentTick,
+		TicksPerSecond:      metrics.TicksPerSecond,
+		AverageTickTimeUs:   float64(metrics.AverageTickTime) / float64(time.Microsecond),
+		MaxTickTimeUs:       float64(metrics.MaxTickTime) / float64(time.Microsecond),
+		TotalOperations:     metrics.TotalOperations,
+		CompletedOperations: metrics.CompletedOps,
+		FailedOperations:    metrics.FailedOps,
+		Components:          make([]*simulationpb.ComponentMetrics, 0, len(metrics.ComponentMetrics)),
+		Timestamp:           metrics.Timestamp.Unix(),
+	}
+
+	for componentID, cm := range metrics.ComponentMetrics {
+		pb.Components = append(pb.Components, &simulationpb.ComponentMetrics{
+			ComponentId:         componentID,
+			ComponentType:       string(cm.ComponentType),
+			State:               string(cm.State),
+			TotalOperations:     cm.TotalOperations,
+			CompletedOperations: cm.CompletedOps,
+			FailedOperations:    cm.FailedOps,
+			AverageLatencyMs:    durationToMs(cm.AverageLatency),
+			CurrentUtilization:  cm.CurrentUtilization,
+		})
+	}
+
+	return pb
+}
+
+// durationToMs converts a duration into fractional milliseconds
+func durationToMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
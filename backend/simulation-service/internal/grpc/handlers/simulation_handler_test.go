@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	simulationpb "github.com/systemsim/simulation-service/api/proto"
+	"github.com/systemsim/simulation-service/internal/config"
+	"github.com/systemsim/simulation-service/internal/simulation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// metricsStream collects the snapshots StreamMetrics sends
+type metricsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*simulationpb.SimulationMetrics
+}
+
+func (s *metricsStream) Context() context.Context { return s.ctx }
+
+func (s *metricsStream) Send(m *simulationpb.SimulationMetrics) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestSimulationGRPCHandler_RejectsOtherUsers(t *testing.T) {
+	manager := simulation.NewManager(config.SimulationConfig{
+		TickDuration:        10 * time.Microsecond,
+		MaxSimulations:      2,
+		MaxComponentsPerSim: 3,
+		ProfilesPath:        "../../../profiles",
+	})
+	defer manager.Shutdown()
+	handler := NewSimulationGRPCHandler(manager)
+	defer handler.Shutdown()
+	ctx := context.Background()
+
+	created, err := handler.CreateSimulation(ctx, &simulationpb.CreateSimulationRequest{Name: "alice-sim", UserId: "alice"})
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+	id := created.Simulation.Id
+
+	notFound := func(rpc string, err error) {
+		t.Helper()
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected %s by another user to be not found, got %v", rpc, err)
+		}
+	}
+	_, err = handler.StartSimulation(ctx, &simulationpb.StartSimulationRequest{SimulationId: id, UserId: "bob"})
+	notFound("StartSimulation", err)
+	_, err = handler.StopSimulation(ctx, &simulationpb.StopSimulationRequest{SimulationId: id, UserId: "bob"})
+	notFound("StopSimulation", err)
+	_, err = handler.GetStatus(ctx, &simulationpb.GetStatusRequest{SimulationId: id, UserId: "bob"})
+	notFound("GetStatus", err)
+	_, err = handler.GetResults(ctx, &simulationpb.GetResultsRequest{SimulationId: id, UserId: "bob"})
+	notFound("GetResults", err)
+	stream := &metricsStream{ctx: ctx}
+	notFound("StreamMetrics", handler.StreamMetrics(&simulationpb.StreamMetricsRequest{SimulationId: id, UserId: "bob"}, stream))
+	if len(stream.sent) != 0 {
+		t.Errorf("Expected no metrics streamed to another user, got %d snapshots", len(stream.sent))
+	}
+	if sim, _ := manager.GetSimulation(uuid.MustParse(id)); sim.Status != simulation.StatusCreated {
+		t.Errorf("Expected another user's start to leave the simulation created, got %s", sim.Status)
+	}
+
+	// The owner is served as before
+	if resp, err := handler.GetStatus(ctx, &simulationpb.GetStatusRequest{SimulationId: id, UserId: "alice"}); err != nil || resp.SimulationId != id {
+		t.Errorf("Expected the owner to get the status, got %v", err)
+	}
+	if resp, err := handler.GetResults(ctx, &simulationpb.GetResultsRequest{SimulationId: id, UserId: "alice"}); err != nil || resp.Simulation.Name != "alice-sim" {
+		t.Errorf("Expected the owner to get the results, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	simulationpb "github.com/systemsim/simulation-service/api/proto"
+	"github.com/systemsim/simulation-service/internal/config"
+	"github.com/systemsim/simulation-service/internal/grpc/handlers"
+	"github.com/systemsim/simulation-service/internal/simulation"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// How long Stop waits for in-flight calls before closing their connections
+const gracefulStopTimeout = 10 * time.Second
+
+// Server represents the gRPC server
+type Server struct {
+	server            *grpc.Server
+	listener          net.Listener
+	simulationHandler *handlers.SimulationGRPCHandler
+	config            *config.Config
+	healthServer      *grpchealth.Server
+}
+
+// NewServer creates a new gRPC server listening on the configured gRPC address
+func NewServer(cfg *config.Config, simManager *simulation.Manager) (*Server, error) {
+	// Create listener
+	listener, err := net.Listen("tcp", cfg.GRPC.GetGRPCAddr())
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", cfg.GRPC.GetGRPCAddr(), err)
+	}
+
+	// Configure gRPC server options
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+
+	// Create gRPC server
+	server := grpc.NewServer(opts...)
+
+	// Create simulation handler
+	simulationHandler := handlers.NewSimulationGRPCHandler(simManager)
+
+	// Register services
+	simulationpb.RegisterSimulationServiceServer(server, simulationHandler)
+
+	// Register standard gRPC health service
+	healthServer := grpchealth.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	// Set initial health status for the simulation service
+	healthServer.SetServingStatus("simulation", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING) // Overall service health
+
+	// Enable reflection for development
+	if cfg.Server.Environment == "development" {
+		reflection.Register(server)
+	}
+
+	return &Server{
+		server:            server,
+		listener:          listener,
+		simulationHandler: simulationHandler,
+		config:            cfg,
+		healthServer:      healthServer,
+	}, nil
+}
+
+// Start starts the gRPC server
+func (s *Server) Start() error {
+	log.Printf("Starting gRPC server on %s", s.config.GRPC.GetGRPCAddr())
+
+	if err := s.server.Serve(s.listener); err != nil {
+		return fmt.Errorf("failed to serve gRPC: %w", err)
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the gRPC server. Open metric streams are ended first;
+// calls still running after gracefulStopTimeout are cut off.
+func (s *Server) Stop() {
+	log.Println("Stopping gRPC server...")
+	s.healthServer.Shutdown()
+	s.simulationHandler.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(gracefulStopTimeout):
+		log.Printf("gRPC server did not stop within %v, forcing stop", gracefulStopTimeout)
+		s.server.Stop()
+		<-stopped
+	}
+}
+
+// GetListener returns the server listener
+func (s *Server) GetListener() net.Listener {
+	return s.listener
+}
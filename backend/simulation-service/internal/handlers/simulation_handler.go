@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"bytes"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/systemsim/simulation-service/internal/openmetrics"
 	"github.com/systemsim/simulation-service/internal/simulation"
 )
 
@@ -44,6 +46,16 @@ func (h *SimulationHandler) CreateSimulation(c *gin.Context) {
 	c.JSON(http.StatusCreated, sim)
 }
 
+// ListSimulations lists all simulations
+func (h *SimulationHandler) ListSimulations(c *gin.Context) {
+	sims := h.simManager.ListSimulations()
+
+	c.JSON(http.StatusOK, gin.H{
+		"simulations": sims,
+		"count":       len(sims),
+	})
+}
+
 // GetSimulation retrieves a simulation by ID
 func (h *SimulationHandler) GetSimulation(c *gin.Context) {
 	idStr := c.Param("id")
@@ -260,6 +272,23 @@ func (h *SimulationHandler) GetSimulationMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, metrics)
 }
 
+// ExportMetrics serves the metrics of all simulations for Prometheus scrapers, in
+// the OpenMetrics format when the scraper accepts it
+func (h *SimulationHandler) ExportMetrics(c *gin.Context) {
+	format := openmetrics.Negotiate(c.GetHeader("Accept"))
+
+	var body bytes.Buffer
+	if err := h.simManager.CollectMetrics().Write(&body, format); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to export metrics",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, format.ContentType(), body.Bytes())
+}
+
 // Component-related handlers (placeholder implementations)
 func (h *SimulationHandler) CreateComponent(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{
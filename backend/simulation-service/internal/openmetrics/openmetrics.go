@@ -0,0 +1,209 @@
+package openmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Exposition of simulation metrics in the OpenMetrics text format, and in the
+// older Prometheus text format for scrapers that do not ask for OpenMetrics.
+// The service does not depend on a Prometheus client library: metrics are
+// snapshots taken at scrape time, so a family is built fresh for every scrape.
+
+// Format selects the text encoding of an exposition
+type Format int
+
+const (
+	FormatOpenMetrics Format = iota // application/openmetrics-text; version=1.0.0
+	FormatPrometheus                // text/plain; version=0.0.4
+)
+
+// Content types of the supported formats
+const (
+	ContentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	ContentTypePrometheus  = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// MetricType is the type of a metric family
+type MetricType string
+
+const (
+	TypeGauge   MetricType = "gauge"
+	TypeCounter MetricType = "counter"
+)
+
+// Label is a name/value pair identifying a sample within its family
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is one labeled value of a metric family
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Family is a named group of samples sharing a type and help text. Counter
+// names leave out the _total suffix, which is added on exposition.
+type Family struct {
+	Name    string
+	Help    string
+	Type    MetricType
+	Samples []Sample
+}
+
+// Add appends a sample to the family
+func (f *Family) Add(value float64, labels ...Label) {
+	f.Samples = append(f.Samples, Sample{Labels: labels, Value: value})
+}
+
+// Registry collects the metric families of one scrape in registration order
+type Registry struct {
+	families map[string]*Family
+	order    []string
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*Family)}
+}
+
+// Gauge returns the gauge family with the given name, registering it on first use
+func (r *Registry) Gauge(name, help string) *Family {
+	return r.family(name, help, TypeGauge)
+}
+
+// Counter returns the counter family with the given name, registering it on first use
+func (r *Registry) Counter(name, help string) *Family {
+	return r.family(name, help, TypeCounter)
+}
+
+// Families returns the registered families in registration order
+func (r *Registry) Families() []*Family {
+	families := make([]*Family, 0, len(r.order))
+	for _, name := range r.order {
+		families = append(families, r.families[name])
+	}
+	return families
+}
+
+func (r *Registry) family(name, help string, metricType MetricType) *Family {
+	if family, exists := r.families[name]; exists {
+		if family.Type != metricType {
+			panic(fmt.Sprintf("metric %s registered as %s and %s", name, family.Type, metricType))
+		}
+		return family
+	}
+
+	family := &Family{Name: name, Help: help, Type: metricType}
+	r.families[name] = family
+	r.order = append(r.order, name)
+	return family
+}
+
+// Negotiate picks the exposition format for an HTTP Accept header. OpenMetrics is
+// served only when the scraper asks for it, as Prometheus does by default.
+func Negotiate(accept string) Format {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(mediaRange, ";", 2)[0])
+		if strings.EqualFold(mediaType, "application/openmetrics-text") {
+			return FormatOpenMetrics
+		}
+	}
+	return FormatPrometheus
+}
+
+// ContentType returns the HTTP content type of a format
+func (f Format) ContentType() string {
+	if f == FormatOpenMetrics {
+		return ContentTypeOpenMetrics
+	}
+	return ContentTypePrometheus
+}
+
+// Write encodes the registry's families in the given format. Families without
+// samples are left out.
+func (r *Registry) Write(w io.Writer, format Format) error {
+	buf := bufio.NewWriter(w)
+
+	for _, family := range r.Families() {
+		if len(family.Samples) == 0 {
+			continue
+		}
+
+		sampleName := family.Name
+		if family.Type == TypeCounter {
+			sampleName += "_total"
+		}
+		metadataName := family.Name
+		if format == FormatPrometheus {
+			metadataName = sampleName
+		}
+
+		fmt.Fprintf(buf, "# TYPE %s %s\n", metadataName, family.Type)
+		if family.Help != "" {
+			fmt.Fprintf(buf, "# HELP %s %s\n", metadataName, escapeHelp(family.Help, format))
+		}
+		for _, sample := range family.Samples {
+			buf.WriteString(sampleName)
+			writeLabels(buf, sample.Labels)
+			buf.WriteByte(' ')
+			buf.WriteString(formatValue(sample.Value))
+			buf.WriteByte('\n')
+		}
+	}
+
+	if format == FormatOpenMetrics {
+		buf.WriteString("# EOF\n")
+	}
+	return buf.Flush()
+}
+
+func writeLabels(buf *bufio.Writer, labels []Label) {
+	if len(labels) == 0 {
+		return
+	}
+
+	buf.WriteByte('{')
+	for i, label := range labels {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(label.Name)
+		buf.WriteString(`="`)
+		buf.WriteString(labelValueEscaper.Replace(label.Value))
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('}')
+}
+
+var (
+	labelValueEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	prometheusHelpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+// escapeHelp escapes help text; only OpenMetrics escapes double quotes in it
+func escapeHelp(help string, format Format) string {
+	if format == FormatOpenMetrics {
+		return labelValueEscaper.Replace(help)
+	}
+	return prometheusHelpEscaper.Replace(help)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
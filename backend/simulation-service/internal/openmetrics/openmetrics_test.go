@@ -0,0 +1,84 @@
+package openmetrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func testRegistry() *Registry {
+	registry := NewRegistry()
+
+	ops := registry.Counter("simsim_engine_operations", "Operations processed by an engine")
+	ops.Add(42, Label{"component", "db"}, Label{"engine_type", "storage"})
+
+	score := registry.Gauge("simsim_engine_health_score", `Health score, "1" is fully healthy`)
+	score.Add(0.75, Label{"component", `web "edge"`})
+	score.Add(math.NaN(), Label{"component", "cache\nhot"})
+
+	registry.Gauge("simsim_unused", "Never sampled")
+	return registry
+}
+
+func TestWrite_OpenMetrics(t *testing.T) {
+	var out strings.Builder
+	if err := testRegistry().Write(&out, FormatOpenMetrics); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	want := `# TYPE simsim_engine_operations counter
+# HELP simsim_engine_operations Operations processed by an engine
+simsim_engine_operations_total{component="db",engine_type="storage"} 42
+# TYPE simsim_engine_health_score gauge
+# HELP simsim_engine_health_score Health score, \"1\" is fully healthy
+simsim_engine_health_score{component="web \"edge\""} 0.75
+simsim_engine_health_score{component="cache\nhot"} NaN
+# EOF
+`
+	if out.String() != want {
+		t.Errorf("Unexpected exposition:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWrite_Prometheus(t *testing.T) {
+	var out strings.Builder
+	if err := testRegistry().Write(&out, FormatPrometheus); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	text := out.String()
+	if !strings.Contains(text, "# TYPE simsim_engine_operations_total counter\n") {
+		t.Errorf("Expected counter metadata to carry the _total suffix:\n%s", text)
+	}
+	if !strings.Contains(text, `# HELP simsim_engine_health_score Health score, "1" is fully healthy`) {
+		t.Errorf("Expected unescaped quotes in help text:\n%s", text)
+	}
+	if strings.Contains(text, "# EOF") || strings.Contains(text, "simsim_unused") {
+		t.Errorf("Unexpected EOF marker or empty family:\n%s", text)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]Format{
+		"": FormatPrometheus,
+		"text/plain;version=0.0.4;q=0.5,*/*;q=0.1":                    FormatPrometheus,
+		"application/openmetrics-text;version=1.0.0,text/plain;q=0.5": FormatOpenMetrics,
+		"text/plain, Application/OpenMetrics-Text; version=0.0.1":     FormatOpenMetrics,
+	}
+	for accept, want := range cases {
+		if got := Negotiate(accept); got != want {
+			t.Errorf("Negotiate(%q) = %v, want %v", accept, got, want)
+		}
+	}
+}
+
+func TestRegistry_ReusesFamilies(t *testing.T) {
+	registry := NewRegistry()
+	registry.Gauge("simsim_queue_depth", "Queued operations").Add(1)
+	registry.Gauge("simsim_queue_depth", "Queued operations").Add(2)
+
+	families := registry.Families()
+	if len(families) != 1 || len(families[0].Samples) != 2 {
+		t.Errorf("Expected one family with two samples, got %+v", families)
+	}
+}
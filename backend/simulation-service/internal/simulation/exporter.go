@@ -0,0 +1,250 @@
+package simulation
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+	"github.com/systemsim/simulation-service/internal/openmetrics"
+)
+
+// CollectMetrics snapshots every running simulation as OpenMetrics families.
+// Series are labeled with simulation_id and, where they apply, component,
+// instance and engine_type, so long runs can be followed from Prometheus.
+func (m *Manager) CollectMetrics() *openmetrics.Registry {
+	m.mutex.RLock()
+	managed := make([]*managedSimulation, 0, len(m.simulations))
+	for _, ms := range m.simulations {
+		managed = append(managed, ms)
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(managed, func(i, j int) bool {
+		return managed[i].info.ID.String() < managed[j].info.ID.String()
+	})
+
+	exporter := newMetricsExporter()
+	for _, ms := range managed {
+		ms.mutex.Lock()
+		exporter.simulation(ms.info, ms.runtime)
+		ms.mutex.Unlock()
+	}
+
+	return exporter.registry
+}
+
+// metricsExporter registers every family up front so series appear in the same
+// order on every scrape
+type metricsExporter struct {
+	registry *openmetrics.Registry
+
+	simulationRunning *openmetrics.Family
+	simulationTick    *openmetrics.Family
+	ticksPerSecond    *openmetrics.Family
+
+	instances          *openmetrics.Family
+	healthyInstances   *openmetrics.Family
+	unhealthyInstances *openmetrics.Family
+	requests           *openmetrics.Family
+	successfulRequests *openmetrics.Family
+	failedRequests     *openmetrics.Family
+	responseTime       *openmetrics.Family
+	queueDepth         *openmetrics.Family
+
+	scaleUps          *openmetrics.Family
+	scaleDowns        *openmetrics.Family
+	scalingEfficiency *openmetrics.Family
+	scalingSavings    *openmetrics.Family
+
+	circuitBreakerState *openmetrics.Family
+
+	engineHealthScore      *openmetrics.Family
+	engineUtilization      *openmetrics.Family
+	engineQueueUtilization *openmetrics.Family
+	engineErrorRatio       *openmetrics.Family
+	engineLatency          *openmetrics.Family
+	engineThroughput       *openmetrics.Family
+	engineCurrentLoad      *openmetrics.Family
+	enginePerformance      *openmetrics.Family
+	engineProgress         *openmetrics.Family
+	engineOperations       *openmetrics.Family
+	engineConvergencePoint *openmetrics.Family
+	engineVariance         *openmetrics.Family
+	engineConverged        *openmetrics.Family
+}
+
+// circuitBreakerStates are exported for every breaker so each state is a series
+var circuitBreakerStates = []components.CircuitBreakerState{
+	components.CircuitBreakerClosed,
+	components.CircuitBreakerOpen,
+	components.CircuitBreakerHalfOpen,
+}
+
+func newMetricsExporter() *metricsExporter {
+	r := openmetrics.NewRegistry()
+	return &metricsExporter{
+		registry: r,
+
+		simulationRunning: r.Gauge("simsim_simulation_running", "Whether the simulation is running (1) or not (0)"),
+		simulationTick:    r.Gauge("simsim_simulation_tick", "Current tick of the simulation clock"),
+		ticksPerSecond:    r.Gauge("simsim_simulation_ticks_per_second", "Simulation clock rate in ticks per wall-clock second"),
+
+		instances:          r.Gauge("simsim_component_instances", "Instances behind the component's load balancer"),
+		healthyInstances:   r.Gauge("simsim_component_healthy_instances", "Healthy instances behind the component's load balancer"),
+		unhealthyInstances: r.Gauge("simsim_component_unhealthy_instances", "Unhealthy instances behind the component's load balancer"),
+		requests:           r.Counter("simsim_component_requests", "Operations routed to the component's instances"),
+		successfulRequests: r.Counter("simsim_component_successful_requests", "Operations the component's instances completed"),
+		failedRequests:     r.Counter("simsim_component_failed_requests", "Operations the component's instances failed"),
+		responseTime:       r.Gauge("simsim_component_response_time_seconds", "Average response time across the component's instances"),
+		queueDepth:         r.Gauge("simsim_component_queue_depth", "Operations queued at the load balancer and its instances"),
+
+		scaleUps:          r.Counter("simsim_autoscaling_scale_ups", "Instances added by the auto-scaler"),
+		scaleDowns:        r.Counter("simsim_autoscaling_scale_downs", "Instances removed by the auto-scaler"),
+		scalingEfficiency: r.Gauge("simsim_autoscaling_efficiency_score", "Auto-scaling stability score, 1 when no scaling was needed"),
+		scalingSavings:    r.Gauge("simsim_autoscaling_cost_savings_dollars", "Estimated cost saved by scaling down"),
+
+		circuitBreakerState: r.Gauge("simsim_circuit_breaker_state", "Circuit breaker from an instance to a target component, 1 for its current state"),
+
+		engineHealthScore:      r.Gauge("simsim_engine_health_score", "Engine health score from 0 to 1"),
+		engineUtilization:      r.Gauge("simsim_engine_utilization_ratio", "Engine utilization from 0 to 1"),
+		engineQueueUtilization: r.Gauge("simsim_engine_queue_utilization_ratio", "Engine queue fill from 0 to 1"),
+		engineErrorRatio:       r.Gauge("simsim_engine_error_ratio", "Fraction of engine operations that failed"),
+		engineLatency:          r.Gauge("simsim_engine_latency_seconds", "Average engine operation latency"),
+		engineThroughput:       r.Gauge("simsim_engine_throughput_ops_per_second", "Engine throughput in operations per second"),
+		engineCurrentLoad:      r.Gauge("simsim_engine_current_utilization_ratio", "Utilization driving the engine's dynamic behavior"),
+		enginePerformance:      r.Gauge("simsim_engine_performance_factor", "Dynamic performance factor applied to engine latencies"),
+		engineProgress:         r.Gauge("simsim_engine_convergence_progress_ratio", "Progress towards statistical convergence from 0 to 1"),
+		engineOperations:       r.Counter("simsim_engine_operations", "Operations counted towards convergence"),
+		engineConvergencePoint: r.Gauge("simsim_engine_convergence_point", "Operation count at which the engine is expected to converge"),
+		engineVariance:         r.Gauge("simsim_engine_variance", "Current variance of the engine's performance"),
+		engineConverged:        r.Gauge("simsim_engine_converged", "Whether the engine has converged (1) or not (0)"),
+	}
+}
+
+func (e *metricsExporter) simulation(info *Simulation, runtime *simulationRuntime) {
+	simulationID := openmetrics.Label{Name: "simulation_id", Value: info.ID.String()}
+
+	e.simulationRunning.Add(boolValue(runtime != nil), simulationID)
+	if runtime == nil {
+		return
+	}
+
+	clockMetrics := runtime.coordinator.GetPerformanceMetrics()
+	e.simulationTick.Add(float64(clockMetrics.CurrentTick), simulationID)
+	e.ticksPerSecond.Add(clockMetrics.TicksPerSecond, simulationID)
+
+	scaling := runtime.controller.GetAutoScalingMetrics()
+
+	componentIDs := make([]string, 0, len(runtime.components))
+	for componentID := range runtime.components {
+		componentIDs = append(componentIDs, componentID)
+	}
+	sort.Strings(componentIDs)
+
+	for _, componentID := range componentIDs {
+		lb := runtime.components[componentID]
+		labels := []openmetrics.Label{simulationID, {Name: "component", Value: componentID}}
+
+		e.loadBalancer(lb, labels)
+
+		if metrics, ok := scaling[componentID]; ok {
+			e.autoScaling(metrics, labels)
+		} else if lb.Config != nil && lb.Config.AutoScaling {
+			e.autoScaling(lb.GetAutoScalingMetrics(), labels)
+		}
+
+		for _, instance := range lb.GetInstances() {
+			e.instance(instance, withLabel(labels, "instance", instance.ID))
+		}
+	}
+}
+
+func (e *metricsExporter) loadBalancer(lb *components.LoadBalancer, labels []openmetrics.Label) {
+	metrics := lb.GetLoadBalancerMetrics()
+
+	e.instances.Add(float64(metrics.InstanceCount), labels...)
+	e.healthyInstances.Add(float64(metrics.HealthyInstances), labels...)
+	e.unhealthyInstances.Add(float64(metrics.UnhealthyInstances), labels...)
+	e.requests.Add(float64(metrics.TotalRequests), labels...)
+	e.successfulRequests.Add(float64(metrics.SuccessfulRequests), labels...)
+	e.failedRequests.Add(float64(metrics.FailedRequests), labels...)
+	e.responseTime.Add(metrics.AverageResponseTime/1000, labels...) // milliseconds
+	e.queueDepth.Add(float64(lb.GetQueueDepth()), labels...)
+}
+
+func (e *metricsExporter) autoScaling(metrics *components.AutoScalingMetrics, labels []openmetrics.Label) {
+	e.scaleUps.Add(float64(metrics.TotalScaleUps), labels...)
+	e.scaleDowns.Add(float64(metrics.TotalScaleDowns), labels...)
+	e.scalingEfficiency.Add(metrics.EfficiencyScore, labels...)
+	e.scalingSavings.Add(metrics.CostSavings, labels...)
+}
+
+func (e *metricsExporter) instance(instance *components.ComponentInstance, labels []openmetrics.Label) {
+	if output := instance.CentralizedOutput; output != nil && output.CircuitBreakerManager != nil {
+		states := output.CircuitBreakerManager.GetAllStates()
+		targets := make([]string, 0, len(states))
+		for target := range states {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+
+		for _, target := range targets {
+			for _, state := range circuitBreakerStates {
+				stateLabels := withLabel(withLabel(labels, "target", target), "state", state.String())
+				e.circuitBreakerState.Add(boolValue(states[target] == state), stateLabels...)
+			}
+		}
+	}
+
+	engineTypes := make([]engines.EngineType, 0, len(instance.Engines))
+	for engineType, wrapper := range instance.Engines {
+		if wrapper != nil {
+			engineTypes = append(engineTypes, engineType)
+		}
+	}
+	sort.Slice(engineTypes, func(i, j int) bool { return engineTypes[i] < engineTypes[j] })
+
+	for _, engineType := range engineTypes {
+		snapshot := instance.Engines[engineType].GetSnapshot()
+		e.engine(snapshot, withLabel(labels, "engine_type", strings.ToLower(engineType.String())))
+	}
+}
+
+func (e *metricsExporter) engine(snapshot engines.EngineSnapshot, labels []openmetrics.Label) {
+	if health := snapshot.Health; health != nil {
+		e.engineHealthScore.Add(health.Score, labels...)
+		e.engineUtilization.Add(health.Utilization, labels...)
+		e.engineQueueUtilization.Add(health.QueueUtilization, labels...)
+		e.engineErrorRatio.Add(health.ErrorRate, labels...)
+		e.engineLatency.Add(health.AverageLatency/1000, labels...) // milliseconds
+		e.engineThroughput.Add(health.ThroughputOps, labels...)
+	}
+
+	if state := snapshot.State; state != nil {
+		e.engineCurrentLoad.Add(state.CurrentUtilization, labels...)
+		e.enginePerformance.Add(state.PerformanceFactor, labels...)
+		e.engineProgress.Add(state.ConvergenceProgress, labels...)
+	}
+
+	if convergence := snapshot.Convergence; convergence != nil {
+		e.engineOperations.Add(float64(convergence.OperationCount), labels...)
+		e.engineConvergencePoint.Add(convergence.ConvergencePoint, labels...)
+		e.engineVariance.Add(convergence.CurrentVariance, labels...)
+		e.engineConverged.Add(boolValue(convergence.IsConverged), labels...)
+	}
+}
+
+// withLabel returns a copy of labels with one more label appended
+func withLabel(labels []openmetrics.Label, name, value string) []openmetrics.Label {
+	extended := make([]openmetrics.Label, len(labels), len(labels)+1)
+	copy(extended, labels)
+	return append(extended, openmetrics.Label{Name: name, Value: value})
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
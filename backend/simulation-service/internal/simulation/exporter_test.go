@@ -0,0 +1,51 @@
+package simulation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/openmetrics"
+)
+
+// TestManager_CollectMetricsWhileRunning scrapes a running simulation repeatedly;
+// run with -race to check engine state is only read between ticks
+func TestManager_CollectMetricsWhileRunning(t *testing.T) {
+	manager := newTestManager()
+	defer manager.Shutdown()
+
+	sim, err := manager.CreateSimulation(&CreateSimulationRequest{
+		Name: "scraped",
+		Components: []ComponentSpec{
+			{ID: "web-1", Type: components.ComponentTypeWebServer},
+			{ID: "db-1", Type: components.ComponentTypeDatabase},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+	if err := manager.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Failed to start simulation: %v", err)
+	}
+
+	var body bytes.Buffer
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for scrapes := 0; scrapes < 5 || time.Now().Before(deadline); scrapes++ {
+		body.Reset()
+		if err := manager.CollectMetrics().Write(&body, openmetrics.FormatOpenMetrics); err != nil {
+			t.Fatalf("Failed to write metrics: %v", err)
+		}
+	}
+
+	output := body.String()
+	for _, want := range []string{
+		`simsim_simulation_running{simulation_id="` + sim.ID.String() + `"} 1`,
+		`simsim_engine_health_score{simulation_id="` + sim.ID.String() + `",component="db-1"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in scrape:\n%s", want, output)
+		}
+	}
+}
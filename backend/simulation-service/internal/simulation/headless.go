@@ -0,0 +1,160 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/clock"
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/design"
+	"github.com/systemsim/simulation-service/internal/traffic"
+)
+
+// HeadlessOptions configures a headless run
+type HeadlessOptions struct {
+	Duration     time.Duration // Simulated time to run for
+	Seed         int64         // Seed for traffic generation; defaults to the design's seed
+	TickDuration time.Duration // Defaults to clock.TICK_DURATION
+	FastForward  bool          // Skip idle ticks with next-event scheduling
+}
+
+// RunHeadless runs an instantiated design as fast as possible rather than paced to
+// the wall clock, and returns a report of what every component did. Traffic is
+// generated from the design's traffic section and replayed from its traces.
+// Components run tick-driven, so all engine work happens on the clock and
+// operations still in flight after the last tick are left unfinished.
+// Components are stopped on return.
+func RunHeadless(ctx context.Context, system *design.System, opts HeadlessOptions) (*Report, error) {
+	if opts.TickDuration <= 0 {
+		opts.TickDuration = clock.TICK_DURATION
+	}
+	if opts.Seed == 0 {
+		opts.Seed = system.Design.Seed
+	}
+	ticks := int64(opts.Duration / opts.TickDuration)
+	if ticks <= 0 {
+		return nil, fmt.Errorf("duration %v is shorter than one tick (%v)", opts.Duration, opts.TickDuration)
+	}
+
+	componentIDs := make([]string, 0, len(system.Components))
+	for id := range system.Components {
+		componentIDs = append(componentIDs, id)
+	}
+	sort.Strings(componentIDs)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	collector := newReportCollector(componentIDs, opts.TickDuration)
+	defer collector.stop()
+	coordinator := clock.NewGlobalTickCoordinator()
+	coordinator.TickDuration = opts.TickDuration
+	if opts.FastForward {
+		if err := coordinator.SetSchedulingMode(clock.SchedulingNextEvent); err != nil {
+			return nil, err
+		}
+	}
+
+	if system.Registry == nil {
+		return nil, fmt.Errorf("design %s was built without a registry to inject traffic through", system.Design.Name)
+	}
+	generator := traffic.NewGenerator(system.Registry, system.FlowManager, opts.TickDuration, opts.Seed)
+	for i, spec := range system.Design.Traffic {
+		if err := generator.AddStream(spec.Stream()); err != nil {
+			return nil, fmt.Errorf("traffic %d: %w", i, err)
+		}
+	}
+	for i, spec := range system.Design.Traces {
+		imported, err := spec.Import(system.FlowManager)
+		if err != nil {
+			return nil, fmt.Errorf("trace %d: %w", i, err)
+		}
+		if err := generator.AddTrace(imported, spec.Speed); err != nil {
+			return nil, fmt.Errorf("trace %d: %w", i, err)
+		}
+		log.Printf("Headless: Replaying %d of %d requests spanning %v from %s",
+			imported.Records-imported.Unmatched, imported.Records, imported.Span, spec.File)
+	}
+	if err := coordinator.RegisterComponentSimple(newEventTickFunc("traffic", generator.ProcessTick, generator.NextEventTick)); err != nil {
+		return nil, err
+	}
+
+	started := make([]*components.LoadBalancer, 0, len(componentIDs))
+	defer func() {
+		for _, lb := range started {
+			if err := lb.Stop(); err != nil {
+				log.Printf("Headless: Warning - failed to stop component %s: %v", lb.GetID(), err)
+			}
+		}
+	}()
+
+	for _, id := range componentIDs {
+		lb := system.Components[id]
+		lb.SetObserver(collector.observe)
+		lb.SetTickDriven(opts.TickDuration)
+		if err := lb.Start(runCtx); err != nil {
+			return nil, fmt.Errorf("failed to start component %s: %w", id, err)
+		}
+		started = append(started, lb)
+
+		if err := coordinator.RegisterComponentSimple(newComponentTicker(lb)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each sample stands for every tick since the previous one, so queue depth
+	// averages stay correct when idle ticks are skipped
+	lastSampled := int64(0)
+	sampleQueues := func(currentTick int64) error {
+		for _, id := range componentIDs {
+			collector.sampleQueue(id, system.Components[id].GetQueueDepth(), currentTick-lastSampled)
+		}
+		lastSampled = currentTick
+		return nil
+	}
+	if err := coordinator.RegisterComponentSimple(newTickFunc("queue-sampler", sampleQueues)); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Headless: Running %s for %v (%d ticks, seed %d, fast-forward %t)",
+		system.Design.Name, opts.Duration, ticks, opts.Seed, opts.FastForward)
+
+	wallStart := time.Now()
+	if err := coordinator.RunTicks(runCtx, ticks); err != nil {
+		return nil, fmt.Errorf("simulation interrupted: %w", err)
+	}
+	wallTime := time.Since(wallStart)
+
+	performance := coordinator.GetPerformanceMetrics()
+	report := &Report{
+		Design:        system.Design.Name,
+		Seed:          opts.Seed,
+		SimulatedTime: performance.SimulationTime,
+		TickDuration:  opts.TickDuration,
+		Ticks:         performance.TotalTicks,
+		SkippedTicks:  performance.SkippedTicks,
+		WallTime:      wallTime,
+		Flows:         make(map[string]*FlowReport),
+		Components:    make(map[string]*ComponentReport, len(componentIDs)),
+	}
+	if wallTime > 0 {
+		report.EfficiencyRatio = float64(report.SimulatedTime) / float64(wallTime)
+	}
+	report.Latency = collector.requestLatency(components.LatencyScopeSystem, components.SystemLatencyName)
+
+	for flow, stats := range generator.Stats() {
+		report.Flows[flow] = &FlowReport{
+			Injected: stats.Injected,
+			Rejected: stats.Rejected,
+			Latency:  collector.requestLatency(components.LatencyScopeFlow, flow),
+		}
+	}
+	for _, id := range componentIDs {
+		report.Components[id] = collector.componentReport(id, system.Components[id].GetMetrics(), report.SimulatedTime)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,92 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/design"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// runExampleDesign builds the example design with a fresh registry and runs it headless
+func runExampleDesign(t *testing.T, seed int64) *Report {
+	const profilesPath = "../../profiles"
+
+	registry := components.NewGlobalRegistry()
+	if err := registry.Start(); err != nil {
+		t.Fatalf("Failed to start registry: %v", err)
+	}
+	defer registry.Stop()
+
+	factory := components.NewComponentFactory(profilesPath, engines.NewEngineFactoryWithPaths(profilesPath))
+	factory.SetRegistry(registry)
+
+	d, err := design.ReadFile("../../designs/web_app.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read design: %v", err)
+	}
+	d.Seed = seed
+	system, err := design.NewLoader(factory, registry, profilesPath).Build(d)
+	if err != nil {
+		t.Fatalf("Failed to build design: %v", err)
+	}
+
+	report, err := RunHeadless(context.Background(), system, HeadlessOptions{Duration: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Headless run failed: %v", err)
+	}
+	return report
+}
+
+func TestRunHeadless_SameSeedSameReport(t *testing.T) {
+	first := runExampleDesign(t, 42)
+	second := runExampleDesign(t, 42)
+
+	if first.Flows["browse"] == nil || first.Flows["browse"].Injected == 0 {
+		t.Fatalf("Expected traffic to be injected, got %+v", first.Flows)
+	}
+
+	// Wall-clock timing is the only thing allowed to differ between runs
+	for _, report := range []*Report{first, second} {
+		report.WallTime = 0
+		report.EfficiencyRatio = 0
+	}
+	firstJSON, err := json.Marshal(first)
+	if err != nil {
+		t.Fatalf("Failed to encode report: %v", err)
+	}
+	secondJSON, err := json.Marshal(second)
+	if err != nil {
+		t.Fatalf("Failed to encode report: %v", err)
+	}
+	if string(firstJSON) != string(secondJSON) {
+		t.Errorf("Expected identical reports for the same seed:\n%s\n%s", firstJSON, secondJSON)
+	}
+}
+
+func TestRunHeadless_ReportsSimulatedLatencies(t *testing.T) {
+	report := runExampleDesign(t, 42)
+
+	if report.Latency.Samples == 0 || report.Latency.MaxMs <= 0 {
+		t.Fatalf("Expected end-to-end latencies of finished requests, got %+v", report.Latency)
+	}
+	if browse := report.Flows["browse"]; browse.Latency.Samples == 0 {
+		t.Errorf("Expected the browse flow to record request latencies, got %+v", browse.Latency)
+	}
+	// Requests finish within the run, so none can have taken longer than it
+	if report.Latency.MaxMs > durationToMs(report.SimulatedTime) {
+		t.Errorf("Request latency %vms exceeds the simulated time %v", report.Latency.MaxMs, report.SimulatedTime)
+	}
+
+	for id, component := range report.Components {
+		if component.Completed == 0 {
+			continue
+		}
+		if component.Latency.Samples == 0 || len(component.Engines) == 0 {
+			t.Errorf("Expected component and engine latencies for %s, got %+v / %+v", id, component.Latency, component.Engines)
+		}
+	}
+}
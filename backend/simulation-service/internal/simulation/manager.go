@@ -0,0 +1,604 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/systemsim/simulation-service/internal/clock"
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/config"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// ErrSimulationNotFound is returned when a simulation ID is unknown
+var ErrSimulationNotFound = errors.New("simulation not found")
+
+// Manager owns the lifecycle of all simulations hosted by this service
+type Manager struct {
+	config        config.SimulationConfig
+	engineFactory *engines.EngineFactory
+
+	simulations map[uuid.UUID]*managedSimulation
+	mutex       sync.RWMutex
+}
+
+// managedSimulation couples the public simulation record with its runtime
+type managedSimulation struct {
+	info    *Simulation
+	runtime *simulationRuntime // nil while the simulation is not running
+	mutex   sync.Mutex
+}
+
+// simulationRuntime holds everything that exists only while a simulation runs
+type simulationRuntime struct {
+	controller  *components.SimulationController
+	coordinator *clock.GlobalTickCoordinator
+	registry    *components.GlobalRegistry // Routes between this simulation's components only
+	components  map[string]*components.LoadBalancer
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+// NewManager creates a new simulation manager
+func NewManager(cfg config.SimulationConfig) *Manager {
+	return &Manager{
+		config:        cfg,
+		engineFactory: engines.NewEngineFactoryWithPaths(cfg.ProfilesPath),
+		simulations:   make(map[uuid.UUID]*managedSimulation),
+	}
+}
+
+// CreateSimulation registers a new simulation in the created state
+func (m *Manager) CreateSimulation(req *CreateSimulationRequest) (*Simulation, error) {
+	if req == nil {
+		return nil, fmt.Errorf("create request cannot be nil")
+	}
+	if err := m.validateComponents(req.Components); err != nil {
+		return nil, err
+	}
+	if req.ScalingFactor < 0 {
+		return nil, fmt.Errorf("scaling factor must be positive, got: %f", req.ScalingFactor)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(m.simulations) >= m.config.MaxSimulations {
+		return nil, fmt.Errorf("maximum number of simulations reached (%d)", m.config.MaxSimulations)
+	}
+
+	scalingFactor := req.ScalingFactor
+	if scalingFactor == 0 {
+		scalingFactor = 1.0 // Default to real-time
+	}
+
+	now := time.Now()
+	sim := &Simulation{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      StatusCreated,
+		OwnerID:     req.OwnerID,
+		Settings: Settings{
+			ScalingFactor: scalingFactor,
+			MaxRuntime:    req.MaxRuntime,
+			LearningMode:  req.LearningMode,
+			Seed:          req.Seed,
+		},
+		Components: append([]ComponentSpec(nil), req.Components...),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	m.simulations[sim.ID] = &managedSimulation{info: sim}
+	log.Printf("SimulationManager: Created simulation %s (%s) with %d components", sim.ID, sim.Name, len(sim.Components))
+
+	return sim.copy(), nil
+}
+
+// GetSimulation returns a snapshot of a simulation
+func (m *Manager) GetSimulation(id uuid.UUID) (*Simulation, error) {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	return ms.info.copy(), nil
+}
+
+// GetSimulationForOwner returns a snapshot of a simulation the user created.
+// Another user's simulation is reported as not found, so its ID is not disclosed.
+func (m *Manager) GetSimulationForOwner(id uuid.UUID, ownerID string) (*Simulation, error) {
+	sim, err := m.GetSimulation(id)
+	if err != nil {
+		return nil, err
+	}
+	if sim.OwnerID != ownerID {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+	return sim, nil
+}
+
+// ListSimulations returns snapshots of all simulations
+func (m *Manager) ListSimulations() []*Simulation {
+	m.mutex.RLock()
+	managed := make([]*managedSimulation, 0, len(m.simulations))
+	for _, ms := range m.simulations {
+		managed = append(managed, ms)
+	}
+	m.mutex.RUnlock()
+
+	sims := make([]*Simulation, 0, len(managed))
+	for _, ms := range managed {
+		ms.mutex.Lock()
+		sims = append(sims, ms.info.copy())
+		ms.mutex.Unlock()
+	}
+
+	return sims
+}
+
+// ListSimulationsByOwner returns snapshots of the simulations a user created
+func (m *Manager) ListSimulationsByOwner(ownerID string) []*Simulation {
+	sims := m.ListSimulations()
+	owned := sims[:0]
+	for _, sim := range sims {
+		if sim.OwnerID == ownerID {
+			owned = append(owned, sim)
+		}
+	}
+	return owned
+}
+
+// UpdateSimulation applies the non-nil fields of the request to a simulation
+func (m *Manager) UpdateSimulation(id uuid.UUID, req *UpdateSimulationRequest) (*Simulation, error) {
+	if req == nil {
+		return nil, fmt.Errorf("update request cannot be nil")
+	}
+
+	ms, err := m.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if req.Components != nil {
+		if ms.runtime != nil {
+			return nil, fmt.Errorf("cannot change components of simulation %s while it is %s", id, ms.info.Status)
+		}
+		if err := m.validateComponents(req.Components); err != nil {
+			return nil, err
+		}
+		ms.info.Components = append([]ComponentSpec(nil), req.Components...)
+	}
+
+	if req.ScalingFactor != nil {
+		if *req.ScalingFactor <= 0 {
+			return nil, fmt.Errorf("scaling factor must be positive, got: %f", *req.ScalingFactor)
+		}
+		if ms.runtime != nil {
+			if err := ms.runtime.coordinator.SetScalingFactor(*req.ScalingFactor); err != nil {
+				return nil, fmt.Errorf("failed to update scaling factor: %w", err)
+			}
+		}
+		ms.info.Settings.ScalingFactor = *req.ScalingFactor
+	}
+
+	if req.Name != nil {
+		ms.info.Name = *req.Name
+	}
+	if req.Description != nil {
+		ms.info.Description = *req.Description
+	}
+	if req.MaxRuntime != nil {
+		ms.info.Settings.MaxRuntime = *req.MaxRuntime
+	}
+	if req.LearningMode != nil {
+		ms.info.Settings.LearningMode = *req.LearningMode
+	}
+
+	ms.info.UpdatedAt = time.Now()
+	return ms.info.copy(), nil
+}
+
+// DeleteSimulation stops a simulation if needed and removes it
+func (m *Manager) DeleteSimulation(id uuid.UUID) error {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return err
+	}
+
+	ms.mutex.Lock()
+	if ms.runtime != nil {
+		m.shutdownRuntime(ms)
+	}
+	ms.mutex.Unlock()
+
+	m.mutex.Lock()
+	delete(m.simulations, id)
+	m.mutex.Unlock()
+
+	log.Printf("SimulationManager: Deleted simulation %s", id)
+	return nil
+}
+
+// StartSimulation builds the runtime of a simulation and starts the clock
+func (m *Manager) StartSimulation(id uuid.UUID) error {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if ms.runtime != nil {
+		return fmt.Errorf("simulation %s is already %s", id, ms.info.Status)
+	}
+
+	runtime, err := m.buildRuntime(ms.info)
+	if err != nil {
+		ms.info.Status = StatusError
+		ms.info.LastError = err.Error()
+		return fmt.Errorf("failed to build simulation %s: %w", id, err)
+	}
+
+	if err := runtime.start(); err != nil {
+		runtime.stop()
+		ms.info.Status = StatusError
+		ms.info.LastError = err.Error()
+		return fmt.Errorf("failed to start simulation %s: %w", id, err)
+	}
+
+	now := time.Now()
+	ms.runtime = runtime
+	ms.info.Status = StatusRunning
+	ms.info.StartedAt = &now
+	ms.info.StoppedAt = nil
+	ms.info.LastError = ""
+	ms.info.UpdatedAt = now
+
+	log.Printf("SimulationManager: Started simulation %s", id)
+	return nil
+}
+
+// StopSimulation stops a running or paused simulation
+func (m *Manager) StopSimulation(id uuid.UUID) error {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if ms.runtime == nil {
+		return fmt.Errorf("simulation %s is not running (current: %s)", id, ms.info.Status)
+	}
+
+	m.shutdownRuntime(ms)
+	log.Printf("SimulationManager: Stopped simulation %s", id)
+	return nil
+}
+
+// PauseSimulation pauses the clock and components of a running simulation
+func (m *Manager) PauseSimulation(id uuid.UUID) error {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if ms.runtime == nil || ms.info.Status != StatusRunning {
+		return fmt.Errorf("simulation %s is not running (current: %s)", id, ms.info.Status)
+	}
+
+	if err := ms.runtime.coordinator.Pause(); err != nil {
+		return fmt.Errorf("failed to pause clock: %w", err)
+	}
+	for componentID, lb := range ms.runtime.components {
+		if err := lb.Pause(); err != nil {
+			log.Printf("SimulationManager: Warning - failed to pause component %s: %v", componentID, err)
+		}
+	}
+	if err := ms.runtime.controller.Pause(); err != nil {
+		log.Printf("SimulationManager: Warning - controller pause failed: %v", err)
+	}
+
+	ms.info.Status = StatusPaused
+	ms.info.UpdatedAt = time.Now()
+	return nil
+}
+
+// ResumeSimulation resumes a paused simulation
+func (m *Manager) ResumeSimulation(id uuid.UUID) error {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	if ms.runtime == nil || ms.info.Status != StatusPaused {
+		return fmt.Errorf("simulation %s is not paused (current: %s)", id, ms.info.Status)
+	}
+
+	if err := ms.runtime.controller.Resume(); err != nil {
+		log.Printf("SimulationManager: Warning - controller resume failed: %v", err)
+	}
+	for componentID, lb := range ms.runtime.components {
+		if err := lb.Resume(); err != nil {
+			log.Printf("SimulationManager: Warning - failed to resume component %s: %v", componentID, err)
+		}
+	}
+	if err := ms.runtime.coordinator.Resume(); err != nil {
+		return fmt.Errorf("failed to resume clock: %w", err)
+	}
+
+	ms.info.Status = StatusRunning
+	ms.info.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetSimulationStatus returns the live status of a simulation
+func (m *Manager) GetSimulationStatus(id uuid.UUID) (*StatusResponse, error) {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	status := &StatusResponse{
+		ID:               id,
+		Status:           ms.info.Status,
+		ComponentCount:   len(ms.info.Components),
+		ControllerStatus: components.SimulationStatusStopped,
+	}
+
+	if ms.runtime == nil {
+		return status, nil
+	}
+
+	clockMetrics := ms.runtime.coordinator.GetPerformanceMetrics()
+	delivery := ms.runtime.coordinator.GetTickDeliveryStatus()
+	status.CurrentTick = clockMetrics.CurrentTick
+	status.SimulationTime = clockMetrics.SimulationTime
+	status.RealTimeElapsed = clockMetrics.RealTimeElapsed
+	status.TickDelivery = &delivery
+	status.ControllerStatus = ms.runtime.controller.GetStatus()
+
+	for _, lb := range ms.runtime.components {
+		if lb.IsHealthy() {
+			status.HealthyComponents++
+		}
+	}
+
+	return status, nil
+}
+
+// GetSimulationMetrics aggregates clock and component metrics of a simulation
+func (m *Manager) GetSimulationMetrics(id uuid.UUID) (*MetricsResponse, error) {
+	ms, err := m.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	metrics := &MetricsResponse{
+		ID:               id,
+		ComponentMetrics: make(map[string]*components.ComponentMetrics),
+		Timestamp:        time.Now(),
+	}
+
+	if ms.runtime == nil {
+		return metrics, nil
+	}
+
+	clockMetrics := ms.runtime.coordinator.GetPerformanceMetrics()
+	metrics.CurrentTick = clockMetrics.CurrentTick
+	metrics.TicksPerSecond = clockMetrics.TicksPerSecond
+	metrics.AverageTickTime = clockMetrics.AverageTickTime
+	metrics.MaxTickTime = clockMetrics.MaxTickTime
+
+	for componentID, lb := range ms.runtime.components {
+		componentMetrics := lb.GetMetrics()
+		if componentMetrics == nil {
+			continue
+		}
+		metrics.ComponentMetrics[componentID] = componentMetrics
+		metrics.TotalOperations += componentMetrics.TotalOperations
+		metrics.CompletedOps += componentMetrics.CompletedOps
+		metrics.FailedOps += componentMetrics.FailedOps
+	}
+
+	return metrics, nil
+}
+
+// Shutdown stops every running simulation (used on service shutdown)
+func (m *Manager) Shutdown() {
+	m.mutex.RLock()
+	managed := make([]*managedSimulation, 0, len(m.simulations))
+	for _, ms := range m.simulations {
+		managed = append(managed, ms)
+	}
+	m.mutex.RUnlock()
+
+	for _, ms := range managed {
+		ms.mutex.Lock()
+		if ms.runtime != nil {
+			m.shutdownRuntime(ms)
+		}
+		ms.mutex.Unlock()
+	}
+}
+
+// lookup finds a managed simulation by ID
+func (m *Manager) lookup(id uuid.UUID) (*managedSimulation, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ms, exists := m.simulations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+	return ms, nil
+}
+
+// validateComponents checks component specs against the configured limits
+func (m *Manager) validateComponents(specs []ComponentSpec) error {
+	if len(specs) > m.config.MaxComponentsPerSim {
+		return fmt.Errorf("too many components: %d (max %d)", len(specs), m.config.MaxComponentsPerSim)
+	}
+
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		if spec.ID == "" {
+			return fmt.Errorf("component ID cannot be empty")
+		}
+		if spec.Type == "" {
+			return fmt.Errorf("component %s has no type", spec.ID)
+		}
+		if seen[spec.ID] {
+			return fmt.Errorf("duplicate component ID: %s", spec.ID)
+		}
+		seen[spec.ID] = true
+	}
+
+	return nil
+}
+
+// buildRuntime creates the controller, clock and components for a simulation
+// A fresh runtime is built on every start since the clock cannot be restarted
+func (m *Manager) buildRuntime(sim *Simulation) (*simulationRuntime, error) {
+	controller := components.NewSimulationController(&components.SimulationControllerConfig{
+		SimulationName:     sim.Name,
+		MaxRuntime:         sim.Settings.MaxRuntime,
+		AutoSaveInterval:   5 * time.Minute,
+		LearningMode:       sim.Settings.LearningMode,
+		CheckpointInterval: time.Minute,
+		MetricsEnabled:     true,
+	})
+
+	coordinator := clock.NewGlobalTickCoordinator()
+	coordinator.TickDuration = m.config.TickDuration
+	if err := coordinator.SetScalingFactor(sim.Settings.ScalingFactor); err != nil {
+		return nil, err
+	}
+
+	// Each simulation routes through its own registry, which is keyed by
+	// component ID, so simulations may reuse each other's IDs
+	registry := components.NewGlobalRegistry()
+	factory := components.NewComponentFactory(m.config.ProfilesPath, m.engineFactory)
+	factory.SetRegistry(registry)
+
+	runtime := &simulationRuntime{
+		controller:  controller,
+		coordinator: coordinator,
+		registry:    registry,
+		components:  make(map[string]*components.LoadBalancer, len(sim.Components)),
+	}
+
+	for _, spec := range sim.Components {
+		config, err := factory.LoadComponentConfig(spec.Type, spec.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create component %s: %w", spec.ID, err)
+		}
+		config.Seed = engines.DeriveSeed(sim.Settings.Seed, "component", spec.ID)
+
+		lb, err := factory.CreateComponentFromConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create component %s: %w", spec.ID, err)
+		}
+		registry.Register(spec.ID, lb.GetInputChannel())
+		runtime.components[spec.ID] = lb
+	}
+
+	return runtime, nil
+}
+
+// shutdownRuntime tears down the runtime of a simulation; caller holds ms.mutex
+func (m *Manager) shutdownRuntime(ms *managedSimulation) {
+	ms.runtime.stop()
+	ms.runtime = nil
+
+	now := time.Now()
+	ms.info.Status = StatusStopped
+	ms.info.StoppedAt = &now
+	ms.info.UpdatedAt = now
+}
+
+// start starts the registry and components, registers them with the clock and starts the clock
+func (r *simulationRuntime) start() error {
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+
+	if err := r.registry.Start(); err != nil {
+		return fmt.Errorf("failed to start registry: %w", err)
+	}
+
+	for componentID, lb := range r.components {
+		if err := lb.Start(r.ctx); err != nil {
+			return fmt.Errorf("failed to start component %s: %w", componentID, err)
+		}
+		if err := r.coordinator.RegisterComponent(newComponentTicker(lb), r.ctx); err != nil {
+			return fmt.Errorf("failed to register component %s with clock: %w", componentID, err)
+		}
+	}
+
+	if err := r.controller.Start(); err != nil {
+		return fmt.Errorf("failed to start controller: %w", err)
+	}
+
+	return r.coordinator.Start(r.ctx)
+}
+
+// stop stops the clock, the controller, all components and the registry
+func (r *simulationRuntime) stop() {
+	if r.coordinator.IsRunning() {
+		if err := r.coordinator.Stop(); err != nil {
+			log.Printf("SimulationManager: Warning - failed to stop clock: %v", err)
+		}
+	}
+
+	if err := r.controller.Stop(); err != nil {
+		log.Printf("SimulationManager: Warning - failed to stop controller: %v", err)
+	}
+
+	for componentID, lb := range r.components {
+		if err := lb.Stop(); err != nil {
+			log.Printf("SimulationManager: Warning - failed to stop component %s: %v", componentID, err)
+		}
+	}
+
+	if err := r.registry.Stop(); err != nil {
+		log.Printf("SimulationManager: Warning - failed to stop registry: %v", err)
+	}
+
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// copy returns a deep copy of the simulation record
+func (s *Simulation) copy() *Simulation {
+	c := *s
+	c.Components = append([]ComponentSpec(nil), s.Components...)
+	return &c
+}
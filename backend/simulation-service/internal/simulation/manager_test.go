@@ -0,0 +1,248 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/config"
+)
+
+func newTestManager() *Manager {
+	return NewManager(config.SimulationConfig{
+		TickDuration:        10 * time.Microsecond,
+		MaxSimulations:      2,
+		MaxComponentsPerSim: 3,
+		ProfilesPath:        "../../profiles",
+	})
+}
+
+func TestManager_CreateGetUpdateDelete(t *testing.T) {
+	manager := newTestManager()
+	defer manager.Shutdown()
+
+	sim, err := manager.CreateSimulation(&CreateSimulationRequest{
+		Name: "web-app",
+		Components: []ComponentSpec{
+			{ID: "web-1", Type: components.ComponentTypeWebServer},
+			{ID: "db-1", Type: components.ComponentTypeDatabase},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+
+	if sim.Status != StatusCreated {
+		t.Errorf("Expected status %s, got %s", StatusCreated, sim.Status)
+	}
+	if sim.Settings.ScalingFactor != 1.0 {
+		t.Errorf("Expected default scaling factor 1.0, got %f", sim.Settings.ScalingFactor)
+	}
+
+	retrieved, err := manager.GetSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Failed to get simulation: %v", err)
+	}
+	if retrieved.Name != "web-app" || len(retrieved.Components) != 2 {
+		t.Errorf("Unexpected simulation returned: %+v", retrieved)
+	}
+
+	name := "web-app-v2"
+	factor := 10.0
+	updated, err := manager.UpdateSimulation(sim.ID, &UpdateSimulationRequest{
+		Name:          &name,
+		ScalingFactor: &factor,
+	})
+	if err != nil {
+		t.Fatalf("Failed to update simulation: %v", err)
+	}
+	if updated.Name != name || updated.Settings.ScalingFactor != factor {
+		t.Errorf("Update not applied: %+v", updated)
+	}
+
+	if err := manager.DeleteSimulation(sim.ID); err != nil {
+		t.Fatalf("Failed to delete simulation: %v", err)
+	}
+	if _, err := manager.GetSimulation(sim.ID); err == nil {
+		t.Error("Expected error getting deleted simulation")
+	}
+}
+
+func TestManager_Validation(t *testing.T) {
+	manager := newTestManager()
+	defer manager.Shutdown()
+
+	// Duplicate component IDs
+	_, err := manager.CreateSimulation(&CreateSimulationRequest{
+		Name: "dup",
+		Components: []ComponentSpec{
+			{ID: "web-1", Type: components.ComponentTypeWebServer},
+			{ID: "web-1", Type: components.ComponentTypeWebServer},
+		},
+	})
+	if err == nil {
+		t.Error("Expected error for duplicate component IDs")
+	}
+
+	// Too many components
+	_, err = manager.CreateSimulation(&CreateSimulationRequest{
+		Name: "big",
+		Components: []ComponentSpec{
+			{ID: "a", Type: components.ComponentTypeCache},
+			{ID: "b", Type: components.ComponentTypeCache},
+			{ID: "c", Type: components.ComponentTypeCache},
+			{ID: "d", Type: components.ComponentTypeCache},
+		},
+	})
+	if err == nil {
+		t.Error("Expected error for too many components")
+	}
+
+	// Simulation limit
+	for i := 0; i < 2; i++ {
+		if _, err := manager.CreateSimulation(&CreateSimulationRequest{Name: "sim"}); err != nil {
+			t.Fatalf("Failed to create simulation %d: %v", i, err)
+		}
+	}
+	if _, err := manager.CreateSimulation(&CreateSimulationRequest{Name: "overflow"}); err == nil {
+		t.Error("Expected error when exceeding max simulations")
+	}
+
+	// Lifecycle operations on unknown simulations
+	if err := manager.StartSimulation(uuid.New()); err == nil {
+		t.Error("Expected error starting unknown simulation")
+	}
+	if err := manager.StopSimulation(uuid.New()); err == nil {
+		t.Error("Expected error stopping unknown simulation")
+	}
+}
+
+func TestManager_ListSimulationsByOwner(t *testing.T) {
+	manager := newTestManager()
+	defer manager.Shutdown()
+
+	ids := make(map[string]uuid.UUID)
+	for _, owner := range []string{"alice", "bob"} {
+		sim, err := manager.CreateSimulation(&CreateSimulationRequest{Name: owner + "-sim", OwnerID: owner})
+		if err != nil {
+			t.Fatalf("Failed to create simulation for %s: %v", owner, err)
+		}
+		ids[owner] = sim.ID
+	}
+
+	owned := manager.ListSimulationsByOwner("alice")
+	if len(owned) != 1 || owned[0].OwnerID != "alice" || owned[0].Name != "alice-sim" {
+		t.Errorf("Expected only alice's simulation, got %+v", owned)
+	}
+	if others := manager.ListSimulationsByOwner("carol"); len(others) != 0 {
+		t.Errorf("Expected no simulations for a user without any, got %d", len(others))
+	}
+	if all := manager.ListSimulations(); len(all) != 2 {
+		t.Errorf("Expected ListSimulations to return every simulation, got %d", len(all))
+	}
+
+	if sim, err := manager.GetSimulationForOwner(ids["alice"], "alice"); err != nil || sim.Name != "alice-sim" {
+		t.Errorf("Expected alice to get her simulation, got %v", err)
+	}
+	if _, err := manager.GetSimulationForOwner(ids["alice"], "bob"); !errors.Is(err, ErrSimulationNotFound) {
+		t.Errorf("Expected alice's simulation to be not found for bob, got %v", err)
+	}
+}
+
+func TestManager_StartPauseResumeStop(t *testing.T) {
+	manager := newTestManager()
+	defer manager.Shutdown()
+
+	sim, err := manager.CreateSimulation(&CreateSimulationRequest{
+		Name:       "lifecycle",
+		Components: []ComponentSpec{{ID: "web-1", Type: components.ComponentTypeWebServer}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+
+	steps := []struct {
+		name     string
+		action   func(uuid.UUID) error
+		expected Status
+	}{
+		{"start", manager.StartSimulation, StatusRunning},
+		{"pause", manager.PauseSimulation, StatusPaused},
+		{"resume", manager.ResumeSimulation, StatusRunning},
+		{"stop", manager.StopSimulation, StatusStopped},
+		{"restart", manager.StartSimulation, StatusRunning},
+		{"stop again", manager.StopSimulation, StatusStopped},
+	}
+	for _, step := range steps {
+		if err := step.action(sim.ID); err != nil {
+			t.Fatalf("Failed to %s simulation: %v", step.name, err)
+		}
+		current, err := manager.GetSimulation(sim.ID)
+		if err != nil {
+			t.Fatalf("Failed to get simulation after %s: %v", step.name, err)
+		}
+		if current.Status != step.expected {
+			t.Errorf("Expected status %s after %s, got %s", step.expected, step.name, current.Status)
+		}
+	}
+
+	// Transitions from the wrong state are refused
+	if err := manager.PauseSimulation(sim.ID); err == nil {
+		t.Error("Expected pausing a stopped simulation to fail")
+	}
+	if err := manager.StopSimulation(sim.ID); err == nil {
+		t.Error("Expected stopping a stopped simulation to fail")
+	}
+}
+
+func TestManager_SimulationsWithSameComponentIDs(t *testing.T) {
+	manager := newTestManager()
+	defer manager.Shutdown()
+
+	specs := []ComponentSpec{
+		{ID: "web-1", Type: components.ComponentTypeWebServer},
+		{ID: "db-1", Type: components.ComponentTypeDatabase},
+	}
+	ids := make([]uuid.UUID, 0, 2)
+	for _, name := range []string{"first", "second"} {
+		sim, err := manager.CreateSimulation(&CreateSimulationRequest{Name: name, Components: specs})
+		if err != nil {
+			t.Fatalf("Failed to create simulation %s: %v", name, err)
+		}
+		if err := manager.StartSimulation(sim.ID); err != nil {
+			t.Fatalf("Failed to start simulation %s: %v", name, err)
+		}
+		ids = append(ids, sim.ID)
+	}
+
+	// Each simulation's registry routes its component IDs to its own components
+	runtimes := make([]*simulationRuntime, 0, len(ids))
+	for _, id := range ids {
+		ms, err := manager.lookup(id)
+		if err != nil {
+			t.Fatalf("Failed to look up simulation: %v", err)
+		}
+		runtimes = append(runtimes, ms.runtime)
+		for _, spec := range specs {
+			if channel := ms.runtime.registry.GetChannel(spec.ID); channel == nil || channel != ms.runtime.components[spec.ID].GetInputChannel() {
+				t.Errorf("Expected simulation %s to route %s to its own component", id, spec.ID)
+			}
+		}
+	}
+	if runtimes[0].registry == runtimes[1].registry {
+		t.Fatal("Expected each simulation to have its own registry")
+	}
+
+	// Stopping one simulation leaves the other's routes in place
+	if err := manager.StopSimulation(ids[0]); err != nil {
+		t.Fatalf("Failed to stop the first simulation: %v", err)
+	}
+	for _, spec := range specs {
+		if runtimes[1].registry.GetChannel(spec.ID) != runtimes[1].components[spec.ID].GetInputChannel() {
+			t.Errorf("Expected the second simulation to keep routing %s after the first stopped", spec.ID)
+		}
+	}
+}
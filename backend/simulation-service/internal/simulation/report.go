@@ -0,0 +1,252 @@
+package simulation
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// Report is the machine-readable result of a headless run
+type Report struct {
+	Design          string                      `json:"design"`
+	Seed            int64                       `json:"seed"`
+	SimulatedTime   time.Duration               `json:"simulated_time_ns"`
+	TickDuration    time.Duration               `json:"tick_duration_ns"`
+	Ticks           int64                       `json:"ticks"`         // Ticks actually processed
+	SkippedTicks    int64                       `json:"skipped_ticks"` // Idle ticks jumped over by next-event scheduling
+	WallTime        time.Duration               `json:"wall_time_ns"`
+	EfficiencyRatio float64                     `json:"efficiency_ratio"` // Simulated time / wall time
+	Latency         LatencySummary              `json:"latency"`          // End-to-end latency of finished requests
+	Flows           map[string]*FlowReport      `json:"flows"`
+	Components      map[string]*ComponentReport `json:"components"`
+}
+
+// FlowReport summarizes the traffic offered to one user flow
+type FlowReport struct {
+	Injected int64          `json:"injected"`
+	Rejected int64          `json:"rejected"` // Entry component not registered or its input channel full
+	Latency  LatencySummary `json:"latency"`  // End-to-end latency of the flow's finished requests
+}
+
+// ComponentReport summarizes one component over a headless run
+type ComponentReport struct {
+	Type       components.ComponentType  `json:"type"`
+	Operations int64                     `json:"operations"`
+	Completed  int64                     `json:"completed"`
+	Failed     int64                     `json:"failed"`
+	ErrorRate  float64                   `json:"error_rate"`
+	Throughput float64                   `json:"throughput"` // Completed operations per simulated second
+	Latency    LatencySummary            `json:"latency"`
+	Engines    map[string]LatencySummary `json:"engines"` // Latency of each engine's share of the work
+	QueueDepth QueueDepthSummary         `json:"queue_depth"`
+	Penalties  PenaltySummary            `json:"penalties"`
+}
+
+// LatencySummary holds latency percentiles in simulated milliseconds
+type LatencySummary struct {
+	Samples int64   `json:"samples"`
+	MeanMs  float64 `json:"mean_ms"`
+	P50Ms   float64 `json:"p50_ms"`
+	P90Ms   float64 `json:"p90_ms"`
+	P99Ms   float64 `json:"p99_ms"`
+	P999Ms  float64 `json:"p999_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// QueueDepthSummary holds queue depth statistics, weighted by simulated time
+type QueueDepthSummary struct {
+	Mean float64 `json:"mean"`
+	Max  int     `json:"max"`
+}
+
+// PenaltySummary aggregates the PenaltyInformation attached to engine results
+type PenaltySummary struct {
+	Samples           int64            `json:"samples"`
+	AvgTotalFactor    float64          `json:"avg_total_factor"`
+	MaxTotalFactor    float64          `json:"max_total_factor"`
+	AvgLoadPenalty    float64          `json:"avg_load_penalty"`
+	AvgQueuePenalty   float64          `json:"avg_queue_penalty"`
+	AvgThermalPenalty float64          `json:"avg_thermal_penalty"`
+	AvgContention     float64          `json:"avg_contention_penalty"`
+	AvgHealthPenalty  float64          `json:"avg_health_penalty"`
+	Grades            map[string]int64 `json:"grades"`
+}
+
+// reportCollector gathers per-component data during a headless run. Engine results
+// arrive from component goroutines, queue samples from the clock. Latencies go to
+// the monitoring system's histograms, by system, flow, component and engine.
+type reportCollector struct {
+	components   map[string]*componentCollector
+	monitoring   *components.PerformanceMonitoringSystem
+	tickDuration time.Duration
+	mutex        sync.Mutex
+}
+
+type componentCollector struct {
+	queueSum   int64
+	queueMax   int
+	queueTicks int64
+	penalties  PenaltySummary
+}
+
+func newReportCollector(componentIDs []string, tickDuration time.Duration) *reportCollector {
+	rc := &reportCollector{
+		components: make(map[string]*componentCollector, len(componentIDs)),
+		// Only the histograms are used; the tracker's periodic updates never start
+		monitoring:   components.NewPerformanceMonitoringSystem(&components.PerformanceMonitoringConfig{MetricsInterval: time.Second}),
+		tickDuration: tickDuration,
+	}
+	for _, id := range componentIDs {
+		rc.components[id] = &componentCollector{
+			penalties: PenaltySummary{Grades: make(map[string]int64)},
+		}
+	}
+	return rc
+}
+
+// stop releases the monitoring system
+func (rc *reportCollector) stop() {
+	rc.monitoring.Stop()
+}
+
+// observe implements components.OperationObserver
+func (rc *reportCollector) observe(componentID string, result *engines.OperationResult) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	cc, ok := rc.components[componentID]
+	if !ok {
+		return
+	}
+
+	// A component's final result carries the operation's latency through the whole
+	// instance, an engine result that engine's share of it
+	rc.monitoring.RecordOperationResult(componentID, result)
+	if complete, _ := result.Metrics["request_complete"].(bool); complete {
+		rc.recordRequest(result)
+	}
+
+	if p := result.PenaltyInfo; p != nil {
+		s := &cc.penalties
+		s.Samples++
+		s.AvgTotalFactor += p.TotalPenaltyFactor
+		s.AvgLoadPenalty += p.LoadPenalty
+		s.AvgQueuePenalty += p.QueuePenalty
+		s.AvgThermalPenalty += p.ThermalPenalty
+		s.AvgContention += p.ContentionPenalty
+		s.AvgHealthPenalty += p.HealthPenalty
+		s.MaxTotalFactor = math.Max(s.MaxTotalFactor, p.TotalPenaltyFactor)
+		if p.PerformanceGrade != "" {
+			s.Grades[p.PerformanceGrade]++
+		}
+	}
+}
+
+// recordRequest records the end-to-end latency, in simulated time, of a request
+// that ended with this result
+func (rc *reportCollector) recordRequest(result *engines.OperationResult) {
+	startTick, ok := result.Metrics["request_start_tick"].(int64)
+	if !ok {
+		return
+	}
+	latency := time.Duration(result.CompletedTick-startTick) * rc.tickDuration
+
+	if request, ok := result.Metrics["request"].(*components.Request); ok {
+		rc.monitoring.TrackSimulatedRequest(request, latency)
+		return
+	}
+	rc.monitoring.RecordLatency(components.LatencyScopeSystem, components.SystemLatencyName, latency)
+	if flow, _ := result.Metrics["flow"].(string); flow != "" {
+		rc.monitoring.RecordLatency(components.LatencyScopeFlow, flow, latency)
+	}
+}
+
+// requestLatency summarizes the end-to-end latency of finished requests, across
+// the system or for one flow
+func (rc *reportCollector) requestLatency(scope components.LatencyScope, name string) LatencySummary {
+	return summarizeLatency(rc.monitoring.GetLatencySnapshot(scope, name).Percentiles())
+}
+
+// sampleQueue records a queue depth sample for a component that held for the
+// given number of ticks
+func (rc *reportCollector) sampleQueue(componentID string, depth int, ticks int64) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	if cc, ok := rc.components[componentID]; ok {
+		cc.queueSum += int64(depth) * ticks
+		cc.queueTicks += ticks
+		if depth > cc.queueMax {
+			cc.queueMax = depth
+		}
+	}
+}
+
+// componentReport finalizes the collected data for one component
+func (rc *reportCollector) componentReport(componentID string, metrics *components.ComponentMetrics, simulated time.Duration) *ComponentReport {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	cc := rc.components[componentID]
+	report := &ComponentReport{
+		Type:       metrics.ComponentType,
+		Operations: metrics.TotalOperations,
+		Completed:  metrics.CompletedOps,
+		Failed:     metrics.FailedOps,
+		Latency:    summarizeLatency(rc.monitoring.GetLatencySnapshot(components.LatencyScopeComponent, componentID).Percentiles()),
+		Engines:    make(map[string]LatencySummary),
+		Penalties:  cc.penalties,
+	}
+
+	prefix := componentID + "/"
+	for name, snapshot := range rc.monitoring.GetLatencySnapshots(components.LatencyScopeEngine) {
+		if engine, ok := strings.CutPrefix(name, prefix); ok {
+			report.Engines[engine] = summarizeLatency(snapshot.Percentiles())
+		}
+	}
+
+	if report.Operations > 0 {
+		report.ErrorRate = float64(report.Failed) / float64(report.Operations)
+	}
+	if simulated > 0 {
+		report.Throughput = float64(report.Completed) / simulated.Seconds()
+	}
+	if cc.queueTicks > 0 {
+		report.QueueDepth = QueueDepthSummary{
+			Mean: float64(cc.queueSum) / float64(cc.queueTicks),
+			Max:  cc.queueMax,
+		}
+	}
+
+	if n := float64(report.Penalties.Samples); n > 0 {
+		report.Penalties.AvgTotalFactor /= n
+		report.Penalties.AvgLoadPenalty /= n
+		report.Penalties.AvgQueuePenalty /= n
+		report.Penalties.AvgThermalPenalty /= n
+		report.Penalties.AvgContention /= n
+		report.Penalties.AvgHealthPenalty /= n
+	}
+
+	return report
+}
+
+// summarizeLatency converts histogram percentiles to simulated milliseconds
+func summarizeLatency(p components.LatencyPercentiles) LatencySummary {
+	return LatencySummary{
+		Samples: p.Count,
+		MeanMs:  durationToMs(p.Mean),
+		P50Ms:   durationToMs(p.P50),
+		P90Ms:   durationToMs(p.P90),
+		P99Ms:   durationToMs(p.P99),
+		P999Ms:  durationToMs(p.P999),
+		MaxMs:   durationToMs(p.Max),
+	}
+}
+
+func durationToMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
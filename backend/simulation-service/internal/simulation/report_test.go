@@ -0,0 +1,35 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+)
+
+func TestSummarizeLatency(t *testing.T) {
+	histogram := components.NewLatencyHistogram()
+	for i := 1000; i >= 1; i-- {
+		histogram.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	summary := summarizeLatency(histogram.Percentiles())
+
+	if summary.Samples != 1000 {
+		t.Errorf("Expected 1000 samples, got %d", summary.Samples)
+	}
+
+	// Histogram buckets are accurate to within 1%
+	within := func(got, want float64) bool { return math.Abs(got-want) <= want*0.01 }
+	if !within(summary.P50Ms, 500) || !within(summary.P90Ms, 900) || !within(summary.P99Ms, 990) || !within(summary.P999Ms, 999) {
+		t.Errorf("Unexpected percentiles: %+v", summary)
+	}
+	if summary.MaxMs != 1000 || !within(summary.MeanMs, 500.5) {
+		t.Errorf("Unexpected max/mean: %+v", summary)
+	}
+
+	if empty := summarizeLatency(components.NewLatencyHistogram().Percentiles()); empty.Samples != 0 || empty.MaxMs != 0 {
+		t.Errorf("Expected empty summary, got %+v", empty)
+	}
+}
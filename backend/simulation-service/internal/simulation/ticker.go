@@ -0,0 +1,159 @@
+package simulation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/systemsim/simulation-service/internal/components"
+)
+
+// componentTicker adapts a component load balancer to the clock.Component interface
+// so the global tick coordinator can drive it
+type componentTicker struct {
+	lb          *components.LoadBalancer
+	tickChannel chan int64
+	stopChannel chan struct{}
+	running     bool
+	mutex       sync.Mutex
+}
+
+// newComponentTicker creates a clock adapter for a load balancer
+func newComponentTicker(lb *components.LoadBalancer) *componentTicker {
+	return &componentTicker{
+		lb:          lb,
+		tickChannel: make(chan int64, 100), // Buffer ticks so a slow component doesn't stall the clock
+		stopChannel: make(chan struct{}),
+	}
+}
+
+// ProcessTick forwards a tick to the load balancer
+func (ct *componentTicker) ProcessTick(currentTick int64) error {
+	return ct.lb.ProcessTick(currentTick)
+}
+
+// NextEventTick reports the load balancer's next event for next-event scheduling
+func (ct *componentTicker) NextEventTick(currentTick int64) (int64, bool) {
+	return ct.lb.NextEventTick(currentTick)
+}
+
+// GetID returns the wrapped component's ID
+func (ct *componentTicker) GetID() string {
+	return ct.lb.GetID()
+}
+
+// IsHealthy returns whether the wrapped component is healthy
+func (ct *componentTicker) IsHealthy() bool {
+	return ct.lb.IsHealthy()
+}
+
+// GetTickChannel returns the channel the coordinator delivers ticks on
+func (ct *componentTicker) GetTickChannel() chan int64 {
+	return ct.tickChannel
+}
+
+// Start launches the goroutine that consumes ticks
+// The load balancer itself is started by the simulation runtime
+func (ct *componentTicker) Start(ctx context.Context) error {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if ct.running {
+		return nil
+	}
+	ct.running = true
+
+	go func() {
+		for {
+			select {
+			case tick := <-ct.tickChannel:
+				ct.ProcessTick(tick)
+			case <-ct.stopChannel:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops consuming ticks
+func (ct *componentTicker) Stop() error {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if !ct.running {
+		return nil
+	}
+	ct.running = false
+	close(ct.stopChannel)
+
+	return nil
+}
+
+// tickFunc adapts a plain function to the clock.Component interface. It has no
+// goroutine of its own, so it only runs when the coordinator calls ProcessTick
+// directly, as it does in headless runs.
+type tickFunc struct {
+	id          string
+	fn          func(currentTick int64) error
+	next        func(currentTick int64) (int64, bool) // Optional; nil never schedules a tick
+	tickChannel chan int64
+}
+
+// newTickFunc creates a clock hook that calls fn on every tick
+func newTickFunc(id string, fn func(currentTick int64) error) *tickFunc {
+	return &tickFunc{
+		id:          id,
+		fn:          fn,
+		tickChannel: make(chan int64, 1),
+	}
+}
+
+// newEventTickFunc creates a clock hook that also tells next-event scheduling when
+// it next needs to run
+func newEventTickFunc(id string, fn func(currentTick int64) error, next func(currentTick int64) (int64, bool)) *tickFunc {
+	tf := newTickFunc(id, fn)
+	tf.next = next
+	return tf
+}
+
+// NextEventTick implements clock.EventSource. Hooks without a next function only
+// run on ticks scheduled by other components.
+func (tf *tickFunc) NextEventTick(currentTick int64) (int64, bool) {
+	if tf.next == nil {
+		return 0, false
+	}
+	return tf.next(currentTick)
+}
+
+// ProcessTick calls the wrapped function
+func (tf *tickFunc) ProcessTick(currentTick int64) error {
+	return tf.fn(currentTick)
+}
+
+// GetID returns the hook's ID
+func (tf *tickFunc) GetID() string {
+	return tf.id
+}
+
+// IsHealthy always reports healthy
+func (tf *tickFunc) IsHealthy() bool {
+	return true
+}
+
+// GetTickChannel returns an unused tick channel
+func (tf *tickFunc) GetTickChannel() chan int64 {
+	return tf.tickChannel
+}
+
+// Start is a no-op
+func (tf *tickFunc) Start(ctx context.Context) error {
+	return nil
+}
+
+// Stop is a no-op
+func (tf *tickFunc) Stop() error {
+	return nil
+}
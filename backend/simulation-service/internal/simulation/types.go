@@ -0,0 +1,101 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/systemsim/simulation-service/internal/clock"
+	"github.com/systemsim/simulation-service/internal/components"
+)
+
+// Status represents the lifecycle state of a managed simulation
+type Status string
+
+const (
+	StatusCreated Status = "created"
+	StatusRunning Status = "running"
+	StatusPaused  Status = "paused"
+	StatusStopped Status = "stopped"
+	StatusError   Status = "error"
+)
+
+// ComponentSpec describes a component that should be created for a simulation
+type ComponentSpec struct {
+	ID   string                   `json:"id" binding:"required"`
+	Type components.ComponentType `json:"type" binding:"required"`
+}
+
+// Settings holds the runtime settings of a simulation
+type Settings struct {
+	ScalingFactor float64       `json:"scaling_factor"` // Real-time to simulation time ratio
+	MaxRuntime    time.Duration `json:"max_runtime"`    // 0 = unlimited
+	LearningMode  bool          `json:"learning_mode"`
+	Seed          int64         `json:"seed"` // Seeds every component and engine; 0 = unseeded
+}
+
+// Simulation is the externally visible description of a managed simulation
+type Simulation struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Status      Status          `json:"status"`
+	OwnerID     string          `json:"owner_id,omitempty"` // User who created it through the gateway
+	Settings    Settings        `json:"settings"`
+	Components  []ComponentSpec `json:"components"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	StoppedAt   *time.Time      `json:"stopped_at,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+}
+
+// CreateSimulationRequest is the payload for creating a simulation
+type CreateSimulationRequest struct {
+	Name          string          `json:"name" binding:"required"`
+	Description   string          `json:"description"`
+	Components    []ComponentSpec `json:"components"`
+	ScalingFactor float64         `json:"scaling_factor"`
+	MaxRuntime    time.Duration   `json:"max_runtime"`
+	LearningMode  bool            `json:"learning_mode"`
+	Seed          int64           `json:"seed"`
+	OwnerID       string          `json:"-"` // Set by the gRPC gateway from its authenticated user
+}
+
+// UpdateSimulationRequest is the payload for updating a simulation
+// Only non-nil fields are applied; components can only change while stopped
+type UpdateSimulationRequest struct {
+	Name          *string         `json:"name"`
+	Description   *string         `json:"description"`
+	Components    []ComponentSpec `json:"components"`
+	ScalingFactor *float64        `json:"scaling_factor"`
+	MaxRuntime    *time.Duration  `json:"max_runtime"`
+	LearningMode  *bool           `json:"learning_mode"`
+}
+
+// StatusResponse reports the live status of a simulation
+type StatusResponse struct {
+	ID                uuid.UUID                   `json:"id"`
+	Status            Status                      `json:"status"`
+	CurrentTick       int64                       `json:"current_tick"`
+	SimulationTime    time.Duration               `json:"simulation_time"`
+	RealTimeElapsed   time.Duration               `json:"real_time_elapsed"`
+	ComponentCount    int                         `json:"component_count"`
+	HealthyComponents int                         `json:"healthy_components"`
+	TickDelivery      *clock.TickDeliveryStatus   `json:"tick_delivery,omitempty"`
+	ControllerStatus  components.SimulationStatus `json:"controller_status"`
+}
+
+// MetricsResponse aggregates the metrics of a simulation and its components
+type MetricsResponse struct {
+	ID               uuid.UUID                               `json:"id"`
+	CurrentTick      int64                                   `json:"current_tick"`
+	TicksPerSecond   float64                                 `json:"ticks_per_second"`
+	AverageTickTime  time.Duration                           `json:"average_tick_time"`
+	MaxTickTime      time.Duration                           `json:"max_tick_time"`
+	TotalOperations  int64                                   `json:"total_operations"`
+	CompletedOps     int64                                   `json:"completed_operations"`
+	FailedOps        int64                                   `json:"failed_operations"`
+	ComponentMetrics map[string]*components.ComponentMetrics `json:"component_metrics"`
+	Timestamp        time.Time                               `json:"timestamp"`
+}
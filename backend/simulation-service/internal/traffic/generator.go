@@ -0,0 +1,238 @@
+package traffic
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// DefaultDataSize is the payload size of generated operations when neither the
+// arrival nor the stream sets one
+const DefaultDataSize = 1024
+
+// StreamConfig describes the traffic offered to one user flow
+type StreamConfig struct {
+	Flow     string             // User flow the requests execute
+	Model    ArrivalModel       // When requests arrive
+	Mix      map[string]float64 // Operation type -> relative weight; empty uses the flow's first step
+	DataSize int64              // Payload size in bytes; defaults to DefaultDataSize
+	Priority int                // Operation priority; defaults to 1
+}
+
+// FlowStats counts the requests a generator offered to one user flow
+type FlowStats struct {
+	Injected int64 `json:"injected"`
+	Rejected int64 `json:"rejected"` // Entry component missing or its input channel full
+}
+
+// Generator injects requests into the entry components of user flows as the
+// simulation clock advances. It is driven by the tick coordinator: every tick it
+// sends the arrivals due by the end of that tick to the entry component's input
+// channel in the global registry.
+type Generator struct {
+	registry     components.GlobalRegistryInterface
+	flows        *components.UserFlowManager
+	tickDuration time.Duration
+	seed         int64
+	streams      []*stream
+	sequence     int64 // Shared by all streams so operation IDs stay unique per generator
+	mutex        sync.Mutex
+}
+
+// stream is the runtime state of one StreamConfig
+type stream struct {
+	config     StreamConfig
+	entry      string   // Entry component ID
+	operations []string // Operation types, sorted for deterministic selection
+	weights    []float64
+	rng        *rand.Rand
+	next       Arrival
+	pending    bool
+	stats      FlowStats
+}
+
+// NewGenerator creates a generator that resolves flows against the given flow
+// manager and sends requests to the channels in the given registry. Every stream
+// derives its own random source from seed.
+func NewGenerator(registry components.GlobalRegistryInterface, flows *components.UserFlowManager, tickDuration time.Duration, seed int64) *Generator {
+	return &Generator{
+		registry:     registry,
+		flows:        flows,
+		tickDuration: tickDuration,
+		seed:         seed,
+	}
+}
+
+// AddStream adds a traffic stream. The flow must exist and have at least one
+// step; its first step's component is where requests enter the system.
+func (g *Generator) AddStream(config StreamConfig) error {
+	if config.Model == nil {
+		return fmt.Errorf("traffic for flow %s has no arrival model", config.Flow)
+	}
+	flow, err := g.flows.GetFlow(config.Flow)
+	if err != nil {
+		return err
+	}
+	if len(flow.Steps) == 0 {
+		return fmt.Errorf("flow %s has no steps", config.Flow)
+	}
+	mixTotal := 0.0
+	for operation, weight := range config.Mix {
+		if weight < 0 {
+			return fmt.Errorf("traffic for flow %s: mix weight for %q cannot be negative", config.Flow, operation)
+		}
+		mixTotal += weight
+	}
+	if len(config.Mix) > 0 && mixTotal == 0 {
+		return fmt.Errorf("traffic for flow %s: mix needs at least one positive weight", config.Flow)
+	}
+
+	if config.DataSize <= 0 {
+		config.DataSize = DefaultDataSize
+	}
+	if config.Priority <= 0 {
+		config.Priority = 1
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	s := &stream{
+		config: config,
+		entry:  flow.Steps[0].ComponentID,
+		rng:    engines.NewSeededRand(engines.DeriveSeed(g.seed, "traffic", config.Flow, strconv.Itoa(len(g.streams)))),
+	}
+	if len(config.Mix) == 0 {
+		s.operations = []string{flow.Steps[0].Operation}
+		s.weights = []float64{1}
+	} else {
+		for operation := range config.Mix {
+			s.operations = append(s.operations, operation)
+		}
+		sort.Strings(s.operations)
+		total := 0.0
+		for _, operation := range s.operations {
+			total += config.Mix[operation]
+			s.weights = append(s.weights, total)
+		}
+	}
+
+	s.next, s.pending = config.Model.Next(0, s.rng)
+	g.streams = append(g.streams, s)
+	return nil
+}
+
+// ProcessTick injects every arrival due by the end of the current tick
+func (g *Generator) ProcessTick(currentTick int64) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := time.Duration(currentTick) * g.tickDuration
+	for _, s := range g.streams {
+		for s.pending && s.next.At <= now {
+			g.inject(s, s.next, currentTick)
+			s.next, s.pending = s.config.Model.Next(s.next.At, s.rng)
+		}
+	}
+
+	return nil
+}
+
+// NextEventTick returns the tick in which the next arrival of any stream is due,
+// so that idle ticks between arrivals can be skipped
+func (g *Generator) NextEventTick(currentTick int64) (int64, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	next, pending := int64(0), false
+	for _, s := range g.streams {
+		if !s.pending {
+			continue
+		}
+		tick := int64((s.next.At + g.tickDuration - 1) / g.tickDuration)
+		if tick <= currentTick {
+			tick = currentTick + 1
+		}
+		if !pending || tick < next {
+			next, pending = tick, true
+		}
+	}
+	return next, pending
+}
+
+// Stats returns the requests offered so far, keyed by user flow
+func (g *Generator) Stats() map[string]*FlowStats {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	stats := make(map[string]*FlowStats)
+	for _, s := range g.streams {
+		flow, ok := stats[s.config.Flow]
+		if !ok {
+			flow = &FlowStats{}
+			stats[s.config.Flow] = flow
+		}
+		flow.Injected += s.stats.Injected
+		flow.Rejected += s.stats.Rejected
+	}
+	return stats
+}
+
+// inject sends one arrival to the stream's entry component without blocking
+func (g *Generator) inject(s *stream, arrival Arrival, currentTick int64) {
+	g.sequence++
+	id := fmt.Sprintf("%s-%d", s.config.Flow, g.sequence)
+
+	operation := arrival.Operation
+	if operation == "" {
+		operation = s.pickOperation()
+	}
+	dataSize := arrival.DataSize
+	if dataSize <= 0 {
+		dataSize = s.config.DataSize
+	}
+
+	op := &engines.Operation{
+		ID:       id,
+		Type:     operation,
+		DataSize: dataSize,
+		Priority: s.config.Priority,
+		Metadata: map[string]interface{}{
+			"flow":    s.config.Flow,
+			"request": components.NewRequestWithFlowChain(id, "", operation, []string{s.config.Flow}, false),
+		},
+		StartTick: currentTick,
+	}
+
+	channel := g.registry.GetChannel(s.entry)
+	if channel == nil {
+		s.stats.Rejected++
+		return
+	}
+	select {
+	case channel <- op:
+		s.stats.Injected++
+	default:
+		s.stats.Rejected++
+	}
+}
+
+func (s *stream) pickOperation() string {
+	if len(s.operations) == 1 {
+		return s.operations[0]
+	}
+
+	r := s.rng.Float64() * s.weights[len(s.weights)-1]
+	for i, cumulative := range s.weights {
+		if r < cumulative {
+			return s.operations[i]
+		}
+	}
+	return s.operations[len(s.operations)-1]
+}
@@ -0,0 +1,75 @@
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+	"github.com/systemsim/simulation-service/internal/engines"
+)
+
+// newTestGenerator creates a generator whose "browse" flow enters at a buffered "web" channel
+func newTestGenerator(t *testing.T) (*Generator, chan *engines.Operation) {
+	t.Helper()
+	registry := components.NewGlobalRegistry()
+	web := make(chan *engines.Operation, 100)
+	registry.Register("web", web)
+
+	flows := components.NewUserFlowManager()
+	err := flows.AddFlow("browse", &components.UserFlow{
+		Name:  "browse",
+		Steps: []*components.UserFlowStep{{ComponentID: "web", Operation: "http_get"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to add flow: %v", err)
+	}
+	return NewGenerator(registry, flows, time.Millisecond, 1), web
+}
+
+// TestGeneratorOperationIDsUniqueAcrossStreams tests that streams sharing a flow never reuse an operation ID
+func TestGeneratorOperationIDsUniqueAcrossStreams(t *testing.T) {
+	generator, web := newTestGenerator(t)
+	for i := 0; i < 2; i++ {
+		if err := generator.AddStream(StreamConfig{Flow: "browse", Model: &ConstantModel{Rate: 1000}}); err != nil {
+			t.Fatalf("Failed to add stream %d: %v", i, err)
+		}
+	}
+
+	for tick := int64(0); tick < 10; tick++ {
+		generator.ProcessTick(tick)
+	}
+	close(web)
+
+	seen := make(map[string]bool)
+	for op := range web {
+		if seen[op.ID] {
+			t.Errorf("Operation ID %s was issued twice", op.ID)
+		}
+		seen[op.ID] = true
+	}
+	if injected := generator.Stats()["browse"].Injected; injected < 10 || int(injected) != len(seen) {
+		t.Errorf("Expected every injected operation to have its own ID, got %d IDs for %d operations", len(seen), injected)
+	}
+}
+
+// TestGeneratorRejectsZeroMix tests that a mix must give some operation a positive weight
+func TestGeneratorRejectsZeroMix(t *testing.T) {
+	generator, _ := newTestGenerator(t)
+	err := generator.AddStream(StreamConfig{
+		Flow:  "browse",
+		Model: &ConstantModel{Rate: 10},
+		Mix:   map[string]float64{"http_get": 0, "http_post": 0},
+	})
+	if err == nil {
+		t.Error("Expected a mix with only zero weights to be rejected")
+	}
+
+	err = generator.AddStream(StreamConfig{
+		Flow:  "browse",
+		Model: &ConstantModel{Rate: 10},
+		Mix:   map[string]float64{"http_get": 0, "http_post": 1},
+	})
+	if err != nil {
+		t.Errorf("Expected a mix with one positive weight to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package traffic
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Arrival is a single request arriving at a user flow
+type Arrival struct {
+	At        time.Duration // Simulated time since the start of the run
+	Operation string        // Operation type; empty picks one from the stream's mix
+	DataSize  int64         // Payload size in bytes; 0 uses the stream's default
+}
+
+// ArrivalModel decides when the requests of one stream arrive. Models are
+// open-loop: arrivals do not wait for earlier requests to complete.
+type ArrivalModel interface {
+	// Next returns the arrival that follows one at the given simulated time, or
+	// false when the stream has no more arrivals. The first call passes zero.
+	Next(after time.Duration, rng *rand.Rand) (Arrival, bool)
+}
+
+// maxThinningAttempts bounds the candidates drawn for a single arrival of a
+// time-varying model, so a rate that decays to nothing ends the stream
+const maxThinningAttempts = 1_000_000
+
+// ConstantModel produces evenly spaced arrivals
+type ConstantModel struct {
+	Rate float64 // Requests per simulated second
+}
+
+// Next implements ArrivalModel
+func (m *ConstantModel) Next(after time.Duration, rng *rand.Rand) (Arrival, bool) {
+	if m.Rate <= 0 {
+		return Arrival{}, false
+	}
+	return Arrival{At: after + secondsToDuration(1/m.Rate)}, true
+}
+
+// PoissonModel produces arrivals with exponentially distributed gaps
+type PoissonModel struct {
+	Rate float64 // Mean requests per simulated second
+}
+
+// Next implements ArrivalModel
+func (m *PoissonModel) Next(after time.Duration, rng *rand.Rand) (Arrival, bool) {
+	if m.Rate <= 0 {
+		return Arrival{}, false
+	}
+	return Arrival{At: after + exponentialGap(m.Rate, rng)}, true
+}
+
+// DiurnalModel follows a daily cycle: the rate swings along a cosine between
+// BaseRate at the quietest point and PeakRate at PeakAt
+type DiurnalModel struct {
+	BaseRate float64
+	PeakRate float64
+	Period   time.Duration // Length of one cycle; defaults to 24h
+	PeakAt   time.Duration // Offset of the peak within the cycle
+}
+
+// Rate returns the arrival rate at the given simulated time
+func (m *DiurnalModel) Rate(at time.Duration) float64 {
+	period := m.Period
+	if period <= 0 {
+		period = 24 * time.Hour
+	}
+	phase := 2 * math.Pi * float64(at-m.PeakAt) / float64(period)
+	return m.BaseRate + (m.PeakRate-m.BaseRate)*(1+math.Cos(phase))/2
+}
+
+// Next implements ArrivalModel
+func (m *DiurnalModel) Next(after time.Duration, rng *rand.Rand) (Arrival, bool) {
+	return thinning(after, math.Max(m.BaseRate, m.PeakRate), m.Rate, rng)
+}
+
+// Step is one level of a StepModel
+type Step struct {
+	At   time.Duration // When this rate takes effect
+	Rate float64
+}
+
+// StepModel holds a Poisson rate that changes abruptly at fixed times, e.g. a
+// load test ramping through fixed levels. The rate is zero before the first step.
+type StepModel struct {
+	Steps []Step
+}
+
+// NewStepModel creates a step model, ordering the steps by time
+func NewStepModel(steps []Step) *StepModel {
+	sorted := append([]Step(nil), steps...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At < sorted[j].At })
+	return &StepModel{Steps: sorted}
+}
+
+// Rate returns the arrival rate at the given simulated time
+func (m *StepModel) Rate(at time.Duration) float64 {
+	rate := 0.0
+	for _, step := range m.Steps {
+		if step.At > at {
+			break
+		}
+		rate = step.Rate
+	}
+	return rate
+}
+
+// Next implements ArrivalModel. Arrivals are memoryless, so a gap that crosses
+// a step boundary is simply redrawn at the new rate from the boundary.
+func (m *StepModel) Next(after time.Duration, rng *rand.Rand) (Arrival, bool) {
+	now := after
+	for {
+		rate := m.Rate(now)
+		end, hasEnd := m.nextBoundary(now)
+
+		if rate > 0 {
+			next := now + exponentialGap(rate, rng)
+			if !hasEnd || next < end {
+				return Arrival{At: next}, true
+			}
+		}
+		if !hasEnd {
+			return Arrival{}, false
+		}
+		now = end
+	}
+}
+
+// nextBoundary returns the time of the first step after the given time
+func (m *StepModel) nextBoundary(after time.Duration) (time.Duration, bool) {
+	for _, step := range m.Steps {
+		if step.At > after {
+			return step.At, true
+		}
+	}
+	return 0, false
+}
+
+// SpikeModel is a "viral event": a steady BaseRate that climbs linearly to
+// PeakRate over RampUp starting at Start, holds for Hold, then decays back
+// towards BaseRate exponentially with time constant Decay
+type SpikeModel struct {
+	BaseRate float64
+	PeakRate float64
+	Start    time.Duration
+	RampUp   time.Duration
+	Hold     time.Duration
+	Decay    time.Duration // Zero drops straight back to BaseRate
+}
+
+// Rate returns the arrival rate at the given simulated time
+func (m *SpikeModel) Rate(at time.Duration) float64 {
+	switch {
+	case at < m.Start:
+		return m.BaseRate
+	case at < m.Start+m.RampUp:
+		progress := float64(at-m.Start) / float64(m.RampUp)
+		return m.BaseRate + (m.PeakRate-m.BaseRate)*progress
+	case at < m.Start+m.RampUp+m.Hold:
+		return m.PeakRate
+	case m.Decay <= 0:
+		return m.BaseRate
+	default:
+		since := float64(at-m.Start-m.RampUp-m.Hold) / float64(m.Decay)
+		return m.BaseRate + (m.PeakRate-m.BaseRate)*math.Exp(-since)
+	}
+}
+
+// Next implements ArrivalModel
+func (m *SpikeModel) Next(after time.Duration, rng *rand.Rand) (Arrival, bool) {
+	return thinning(after, math.Max(m.BaseRate, m.PeakRate), m.Rate, rng)
+}
+
+// TraceModel replays recorded arrivals, optionally compressed or stretched in time
+type TraceModel struct {
+	Arrivals []Arrival // Ordered by time
+	Speed    float64   // Replay speed; 2 replays twice as fast. Defaults to 1.
+	next     int
+}
+
+// NewTraceModel creates a trace model, ordering the arrivals by time
+func NewTraceModel(arrivals []Arrival, speed float64) *TraceModel {
+	sorted := append([]Arrival(nil), arrivals...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At < sorted[j].At })
+	return &TraceModel{Arrivals: sorted, Speed: speed}
+}
+
+// Next implements ArrivalModel. The trace is consumed as it is replayed, so a
+// model instance belongs to a single stream; arrivals recorded before the given
+// time are skipped, while simultaneous arrivals are all kept.
+func (m *TraceModel) Next(after time.Duration, rng *rand.Rand) (Arrival, bool) {
+	speed := m.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	for m.next < len(m.Arrivals) {
+		arrival := m.Arrivals[m.next]
+		m.next++
+
+		arrival.At = time.Duration(float64(arrival.At) / speed)
+		if arrival.At >= after {
+			return arrival, true
+		}
+	}
+	return Arrival{}, false
+}
+
+// thinning draws the next arrival of a non-homogeneous Poisson process
+// (Lewis-Shedler): candidates arrive at maxRate and each is kept with
+// probability rate(t)/maxRate
+func thinning(after time.Duration, maxRate float64, rate func(time.Duration) float64, rng *rand.Rand) (Arrival, bool) {
+	if maxRate <= 0 {
+		return Arrival{}, false
+	}
+
+	candidate := after
+	for attempt := 0; attempt < maxThinningAttempts; attempt++ {
+		candidate += exponentialGap(maxRate, rng)
+		if rng.Float64()*maxRate < rate(candidate) {
+			return Arrival{At: candidate}, true
+		}
+	}
+	return Arrival{}, false
+}
+
+// exponentialGap draws the gap to the next arrival of a Poisson process, never
+// less than a nanosecond so that time always moves forward
+func exponentialGap(rate float64, rng *rand.Rand) time.Duration {
+	gap := secondsToDuration(rng.ExpFloat64() / rate)
+	if gap < 1 {
+		gap = 1
+	}
+	return gap
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
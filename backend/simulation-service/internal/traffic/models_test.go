@@ -0,0 +1,158 @@
+package traffic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// countArrivals counts the arrivals a model produces within the given window
+func countArrivals(model ArrivalModel, from, to time.Duration, seed int64) int {
+	rng := rand.New(rand.NewSource(seed))
+	count := 0
+	for arrival, ok := model.Next(0, rng); ok && arrival.At < to; arrival, ok = model.Next(arrival.At, rng) {
+		if arrival.At >= from {
+			count++
+		}
+	}
+	return count
+}
+
+// TestConstantAndPoissonRates tests that homogeneous models produce their configured rate
+func TestConstantAndPoissonRates(t *testing.T) {
+	if got := countArrivals(&ConstantModel{Rate: 100}, 0, 10*time.Second, 1); got != 999 && got != 1000 {
+		t.Errorf("Expected 1000 constant arrivals in 10s, got %d", got)
+	}
+
+	got := countArrivals(&PoissonModel{Rate: 100}, 0, 100*time.Second, 1)
+	if math.Abs(float64(got)-10000) > 400 {
+		t.Errorf("Expected about 10000 poisson arrivals in 100s, got %d", got)
+	}
+
+	if _, ok := (&PoissonModel{}).Next(0, rand.New(rand.NewSource(1))); ok {
+		t.Error("Expected a zero rate to produce no arrivals")
+	}
+}
+
+// TestDiurnalModelFollowsCycle tests that the diurnal model is busiest at its peak
+func TestDiurnalModelFollowsCycle(t *testing.T) {
+	model := &DiurnalModel{BaseRate: 10, PeakRate: 100, Period: 100 * time.Second, PeakAt: 25 * time.Second}
+
+	if rate := model.Rate(25 * time.Second); rate != 100 {
+		t.Errorf("Expected the peak rate at the peak, got %g", rate)
+	}
+	if rate := model.Rate(75 * time.Second); math.Abs(rate-10) > 1e-9 {
+		t.Errorf("Expected the base rate half a period from the peak, got %g", rate)
+	}
+
+	peak := countArrivals(model, 20*time.Second, 30*time.Second, 2)
+	trough := countArrivals(model, 70*time.Second, 80*time.Second, 2)
+	if peak < 3*trough {
+		t.Errorf("Expected far more arrivals around the peak (%d) than the trough (%d)", peak, trough)
+	}
+}
+
+// TestStepModelChangesRate tests that the step model switches rate at each step
+func TestStepModelChangesRate(t *testing.T) {
+	model := NewStepModel([]Step{
+		{At: 20 * time.Second, Rate: 0},
+		{At: 10 * time.Second, Rate: 200},
+		{At: 5 * time.Second, Rate: 20},
+	})
+
+	if got := countArrivals(model, 0, 5*time.Second, 3); got != 0 {
+		t.Errorf("Expected no arrivals before the first step, got %d", got)
+	}
+
+	low := countArrivals(model, 5*time.Second, 10*time.Second, 3)
+	high := countArrivals(model, 10*time.Second, 20*time.Second, 3)
+	if math.Abs(float64(low)-100) > 40 || math.Abs(float64(high)-2000) > 200 {
+		t.Errorf("Expected about 100 then 2000 arrivals, got %d then %d", low, high)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	if _, ok := model.Next(20*time.Second, rng); ok {
+		t.Error("Expected the stream to end once the rate drops to zero for good")
+	}
+}
+
+// TestSpikeModelShape tests the ramp, hold and decay of a viral spike
+func TestSpikeModelShape(t *testing.T) {
+	model := &SpikeModel{
+		BaseRate: 10,
+		PeakRate: 1000,
+		Start:    10 * time.Second,
+		RampUp:   10 * time.Second,
+		Hold:     10 * time.Second,
+		Decay:    5 * time.Second,
+	}
+
+	cases := map[time.Duration]float64{
+		5 * time.Second:  10,
+		15 * time.Second: 505,
+		25 * time.Second: 1000,
+		35 * time.Second: 10 + 990*math.Exp(-1),
+	}
+	for at, want := range cases {
+		if got := model.Rate(at); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Rate at %v: expected %g, got %g", at, want, got)
+		}
+	}
+
+	before := countArrivals(model, 0, 10*time.Second, 4)
+	during := countArrivals(model, 20*time.Second, 30*time.Second, 4)
+	if during < 50*before {
+		t.Errorf("Expected the hold to dwarf the baseline, got %d vs %d", during, before)
+	}
+}
+
+// TestTraceModelReplay tests that a trace replays in order, at the configured speed
+func TestTraceModelReplay(t *testing.T) {
+	model := NewTraceModel([]Arrival{
+		{At: 4 * time.Second, Operation: "checkout"},
+		{At: 2 * time.Second, Operation: "browse"},
+		{At: 2 * time.Second, Operation: "search"},
+	}, 2)
+
+	rng := rand.New(rand.NewSource(5))
+	var replayed []Arrival
+	for arrival, ok := model.Next(0, rng); ok; arrival, ok = model.Next(arrival.At, rng) {
+		replayed = append(replayed, arrival)
+	}
+
+	if len(replayed) != 3 {
+		t.Fatalf("Expected 3 arrivals, got %d", len(replayed))
+	}
+	want := []Arrival{
+		{At: time.Second, Operation: "browse"},
+		{At: time.Second, Operation: "search"},
+		{At: 2 * time.Second, Operation: "checkout"},
+	}
+	for i := range want {
+		if replayed[i] != want[i] {
+			t.Errorf("Arrival %d: expected %+v, got %+v", i, want[i], replayed[i])
+		}
+	}
+}
+
+// TestModelsReproducible tests that a model draws identical arrivals from identical seeds
+func TestModelsReproducible(t *testing.T) {
+	model := &DiurnalModel{BaseRate: 5, PeakRate: 50, Period: time.Minute}
+
+	draw := func() []time.Duration {
+		rng := rand.New(rand.NewSource(42))
+		var times []time.Duration
+		for arrival, ok := model.Next(0, rng); ok && len(times) < 100; arrival, ok = model.Next(arrival.At, rng) {
+			times = append(times, arrival.At)
+		}
+		return times
+	}
+
+	first, second := draw(), draw()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Arrival %d diverged: %v vs %v", i, first[i], second[i])
+		}
+	}
+}
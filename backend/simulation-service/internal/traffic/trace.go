@@ -0,0 +1,356 @@
+package traffic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+)
+
+// TraceFormat identifies the encoding of a recorded trace
+type TraceFormat string
+
+const (
+	TraceFormatCSV  TraceFormat = "csv"  // Access log with timestamp, endpoint and payload size columns
+	TraceFormatOTLP TraceFormat = "otlp" // OpenTelemetry spans in OTLP JSON encoding
+)
+
+// TraceRecord is one request recorded in production
+type TraceRecord struct {
+	At        time.Duration // Offset from the first request in the trace
+	Endpoint  string        // Route or path the request hit
+	DataSize  int64         // Request payload size in bytes, 0 if unknown
+	Operation string        // Operation type, if the trace records one
+}
+
+// Route maps the endpoints of a trace to a user flow
+type Route struct {
+	Endpoint  string // Glob matched against record endpoints, e.g. "/checkout/*"
+	Flow      string
+	Operation string // Operation type for records that carry none; empty uses the stream's mix
+}
+
+// TraceImport is a trace split into timed arrivals per user flow
+type TraceImport struct {
+	Flows     map[string][]Arrival
+	Records   int           // Records read from the trace
+	Unmatched int           // Records no route matched
+	Span      time.Duration // Time between the first and last record
+}
+
+// Header names accepted for each CSV column, compared case-insensitively
+var (
+	csvTimestampColumns = []string{"timestamp", "time", "ts", "start_time"}
+	csvEndpointColumns  = []string{"endpoint", "path", "route", "url"}
+	csvSizeColumns      = []string{"payload_size", "size", "bytes", "data_size"}
+	csvOperationColumns = []string{"operation", "operation_type"}
+)
+
+// TraceFormatFromPath infers the trace format from a file extension
+func TraceFormatFromPath(filename string) (TraceFormat, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return TraceFormatCSV, nil
+	case ".json":
+		return TraceFormatOTLP, nil
+	default:
+		return "", fmt.Errorf("unsupported trace file extension: %s", filepath.Ext(filename))
+	}
+}
+
+// ReadTraceFile reads a trace from disk. An empty format is inferred from the extension.
+func ReadTraceFile(filename string, format TraceFormat) ([]TraceRecord, error) {
+	if format == "" {
+		var err error
+		if format, err = TraceFormatFromPath(filename); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace: %w", err)
+	}
+	defer file.Close()
+
+	return ReadTrace(file, format)
+}
+
+// ReadTrace decodes a trace and returns its records ordered by time, with
+// timestamps made relative to the first record
+func ReadTrace(r io.Reader, format TraceFormat) ([]TraceRecord, error) {
+	var records []TraceRecord
+	var err error
+
+	switch format {
+	case TraceFormatCSV:
+		records, err = readCSVTrace(r)
+	case TraceFormatOTLP:
+		records, err = readOTLPTrace(r)
+	default:
+		return nil, fmt.Errorf("unsupported trace format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].At < records[j].At })
+	if len(records) > 0 {
+		start := records[0].At
+		for i := range records {
+			records[i].At -= start
+		}
+	}
+	return records, nil
+}
+
+// ImportTrace assigns every record to the user flow of the first route whose
+// endpoint glob matches it. Every routed flow must exist in the flow manager.
+func ImportTrace(records []TraceRecord, routes []Route, flows *components.UserFlowManager) (*TraceImport, error) {
+	for _, route := range routes {
+		if _, err := flows.GetFlow(route.Flow); err != nil {
+			return nil, fmt.Errorf("route %q: %w", route.Endpoint, err)
+		}
+		if _, err := path.Match(route.Endpoint, ""); err != nil {
+			return nil, fmt.Errorf("route %q: invalid endpoint pattern: %w", route.Endpoint, err)
+		}
+	}
+
+	imported := &TraceImport{
+		Flows:   make(map[string][]Arrival),
+		Records: len(records),
+	}
+	if len(records) > 0 {
+		imported.Span = records[len(records)-1].At - records[0].At
+	}
+
+	for _, record := range records {
+		route, ok := matchRoute(routes, record.Endpoint)
+		if !ok {
+			imported.Unmatched++
+			continue
+		}
+
+		operation := record.Operation
+		if operation == "" {
+			operation = route.Operation
+		}
+		imported.Flows[route.Flow] = append(imported.Flows[route.Flow], Arrival{
+			At:        record.At,
+			Operation: operation,
+			DataSize:  record.DataSize,
+		})
+	}
+
+	return imported, nil
+}
+
+// AddTrace adds one trace replay stream per user flow of an imported trace.
+// Speed compresses or stretches the trace in time; zero replays it in real time.
+func (g *Generator) AddTrace(imported *TraceImport, speed float64) error {
+	flows := make([]string, 0, len(imported.Flows))
+	for flow := range imported.Flows {
+		flows = append(flows, flow)
+	}
+	sort.Strings(flows)
+
+	for _, flow := range flows {
+		config := StreamConfig{Flow: flow, Model: NewTraceModel(imported.Flows[flow], speed)}
+		if err := g.AddStream(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchRoute(routes []Route, endpoint string) (Route, bool) {
+	for _, route := range routes {
+		if matched, _ := path.Match(route.Endpoint, endpoint); matched {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+// readCSVTrace reads an access log with a header row. Timestamps are RFC 3339 or
+// a number of seconds, either since the epoch or since the start of the trace.
+func readCSVTrace(r io.Reader) ([]TraceRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace header: %w", err)
+	}
+	timestampColumn := csvColumn(header, csvTimestampColumns)
+	endpointColumn := csvColumn(header, csvEndpointColumns)
+	sizeColumn := csvColumn(header, csvSizeColumns)
+	operationColumn := csvColumn(header, csvOperationColumns)
+	if timestampColumn < 0 || endpointColumn < 0 {
+		return nil, fmt.Errorf("trace header needs timestamp and endpoint columns, got %v", header)
+	}
+
+	var records []TraceRecord
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trace: %w", err)
+		}
+
+		at, err := parseTraceTimestamp(row[timestampColumn])
+		if err != nil {
+			return nil, fmt.Errorf("trace line %d: %w", line, err)
+		}
+		record := TraceRecord{At: at, Endpoint: row[endpointColumn]}
+		if sizeColumn >= 0 && row[sizeColumn] != "" {
+			if record.DataSize, err = strconv.ParseInt(row[sizeColumn], 10, 64); err != nil {
+				return nil, fmt.Errorf("trace line %d: invalid payload size %q", line, row[sizeColumn])
+			}
+		}
+		if operationColumn >= 0 {
+			record.Operation = row[operationColumn]
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func csvColumn(header []string, names []string) int {
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		for _, name := range names {
+			if column == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseTraceTimestamp(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return secondsToDuration(seconds), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return time.Duration(t.UnixNano()), nil
+	}
+	return 0, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// OTLP JSON encodes 64-bit integers as strings and enums as either numbers or names
+type otlpTrace struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []otlpSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type otlpSpan struct {
+	Name              string          `json:"name"`
+	ParentSpanID      string          `json:"parentSpanId"`
+	Kind              json.RawMessage `json:"kind"`
+	StartTimeUnixNano otlpInt         `json:"startTimeUnixNano"`
+	Attributes        []struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue *string  `json:"stringValue"`
+			IntValue    *otlpInt `json:"intValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+}
+
+type otlpInt int64
+
+func (i *otlpInt) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+	*i = otlpInt(value)
+	return nil
+}
+
+// Span attributes that identify the endpoint and payload size, in order of preference
+var (
+	otlpEndpointAttributes = []string{"http.route", "url.path", "http.target", "rpc.method"}
+	otlpSizeAttributes     = []string{"http.request.body.size", "http.request_content_length"}
+)
+
+// readOTLPTrace reads the spans of requests entering the system: server spans,
+// and root spans when the exporter leaves the kind out
+func readOTLPTrace(r io.Reader) ([]TraceRecord, error) {
+	var trace otlpTrace
+	if err := json.NewDecoder(r).Decode(&trace); err != nil {
+		return nil, fmt.Errorf("failed to parse OTLP trace: %w", err)
+	}
+
+	var records []TraceRecord
+	for _, resource := range trace.ResourceSpans {
+		for _, scope := range resource.ScopeSpans {
+			for _, span := range scope.Spans {
+				if !span.isEntry() {
+					continue
+				}
+
+				record := TraceRecord{At: time.Duration(span.StartTimeUnixNano), Endpoint: span.Name}
+				if endpoint, ok := span.stringAttribute(otlpEndpointAttributes); ok {
+					record.Endpoint = endpoint
+				}
+				if size, ok := span.intAttribute(otlpSizeAttributes); ok {
+					record.DataSize = size
+				}
+				records = append(records, record)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+func (s *otlpSpan) isEntry() bool {
+	kind := strings.Trim(string(s.Kind), `"`)
+	switch kind {
+	case "2", "SPAN_KIND_SERVER":
+		return true
+	case "", "0", "SPAN_KIND_UNSPECIFIED":
+		return s.ParentSpanID == ""
+	default:
+		return false
+	}
+}
+
+func (s *otlpSpan) stringAttribute(keys []string) (string, bool) {
+	for _, key := range keys {
+		for _, attribute := range s.Attributes {
+			if attribute.Key == key && attribute.Value.StringValue != nil {
+				return *attribute.Value.StringValue, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s *otlpSpan) intAttribute(keys []string) (int64, bool) {
+	for _, key := range keys {
+		for _, attribute := range s.Attributes {
+			if attribute.Key == key && attribute.Value.IntValue != nil {
+				return int64(*attribute.Value.IntValue), true
+			}
+		}
+	}
+	return 0, false
+}
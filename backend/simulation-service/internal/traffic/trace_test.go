@@ -0,0 +1,132 @@
+package traffic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/systemsim/simulation-service/internal/components"
+)
+
+const csvAccessLog = `timestamp,endpoint,payload_size
+2024-11-29T09:00:01.5Z,/checkout/pay,2048
+2024-11-29T09:00:00Z,/products/42,512
+2024-11-29T09:00:00.25Z,/products/7,
+2024-11-29T09:00:03Z,/admin/reindex,100
+`
+
+const otlpSpans = `{
+  "resourceSpans": [{
+    "scopeSpans": [{
+      "spans": [
+        {
+          "name": "GET /products/{id}",
+          "kind": 2,
+          "startTimeUnixNano": "1732870800000000000",
+          "attributes": [{"key": "http.route", "value": {"stringValue": "/products/{id}"}}]
+        },
+        {
+          "name": "SELECT products",
+          "kind": 3,
+          "parentSpanId": "a1b2",
+          "startTimeUnixNano": "1732870800001000000"
+        },
+        {
+          "name": "POST /checkout",
+          "kind": "SPAN_KIND_SERVER",
+          "startTimeUnixNano": "1732870802000000000",
+          "attributes": [{"key": "http.request.body.size", "value": {"intValue": "4096"}}]
+        }
+      ]
+    }]
+  }]
+}`
+
+// TestReadCSVTrace tests that access log rows become ordered, relative records
+func TestReadCSVTrace(t *testing.T) {
+	records, err := ReadTrace(strings.NewReader(csvAccessLog), TraceFormatCSV)
+	if err != nil {
+		t.Fatalf("Failed to read CSV trace: %v", err)
+	}
+
+	want := []TraceRecord{
+		{At: 0, Endpoint: "/products/42", DataSize: 512},
+		{At: 250 * time.Millisecond, Endpoint: "/products/7"},
+		{At: 1500 * time.Millisecond, Endpoint: "/checkout/pay", DataSize: 2048},
+		{At: 3 * time.Second, Endpoint: "/admin/reindex", DataSize: 100},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("Expected %d records, got %d", len(want), len(records))
+	}
+	for i := range want {
+		if records[i] != want[i] {
+			t.Errorf("Record %d: expected %+v, got %+v", i, want[i], records[i])
+		}
+	}
+
+	if _, err := ReadTrace(strings.NewReader("when,where\n1,/\n"), TraceFormatCSV); err == nil {
+		t.Error("Expected an error for a header without timestamp and endpoint columns")
+	}
+}
+
+// TestReadOTLPTrace tests that only spans of requests entering the system are replayed
+func TestReadOTLPTrace(t *testing.T) {
+	records, err := ReadTrace(strings.NewReader(otlpSpans), TraceFormatOTLP)
+	if err != nil {
+		t.Fatalf("Failed to read OTLP trace: %v", err)
+	}
+
+	want := []TraceRecord{
+		{At: 0, Endpoint: "/products/{id}"},
+		{At: 2 * time.Second, Endpoint: "POST /checkout", DataSize: 4096},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("Expected %d server spans, got %+v", len(want), records)
+	}
+	for i := range want {
+		if records[i] != want[i] {
+			t.Errorf("Record %d: expected %+v, got %+v", i, want[i], records[i])
+		}
+	}
+}
+
+// TestImportTraceRoutesToFlows tests that records are split across user flows by endpoint
+func TestImportTraceRoutesToFlows(t *testing.T) {
+	flows := components.NewUserFlowManager()
+	for _, name := range []string{"browse", "checkout"} {
+		flow := &components.UserFlow{
+			Name:  name,
+			Steps: []*components.UserFlowStep{{ComponentID: "web", Operation: "http_get"}},
+		}
+		if err := flows.AddFlow(name, flow); err != nil {
+			t.Fatalf("Failed to add flow %s: %v", name, err)
+		}
+	}
+
+	records, err := ReadTrace(strings.NewReader(csvAccessLog), TraceFormatCSV)
+	if err != nil {
+		t.Fatalf("Failed to read CSV trace: %v", err)
+	}
+
+	imported, err := ImportTrace(records, []Route{
+		{Endpoint: "/products/*", Flow: "browse"},
+		{Endpoint: "/checkout/*", Flow: "checkout", Operation: "http_post"},
+	}, flows)
+	if err != nil {
+		t.Fatalf("Failed to import trace: %v", err)
+	}
+
+	if len(imported.Flows["browse"]) != 2 || len(imported.Flows["checkout"]) != 1 {
+		t.Errorf("Unexpected split across flows: %+v", imported.Flows)
+	}
+	if imported.Unmatched != 1 || imported.Span != 3*time.Second {
+		t.Errorf("Expected 1 unmatched record over 3s, got %d over %v", imported.Unmatched, imported.Span)
+	}
+	if op := imported.Flows["checkout"][0].Operation; op != "http_post" {
+		t.Errorf("Expected the route's operation, got %q", op)
+	}
+
+	if _, err := ImportTrace(records, []Route{{Endpoint: "/*", Flow: "missing"}}, flows); err == nil {
+		t.Error("Expected an error for a route to an unknown flow")
+	}
+}